@@ -3,26 +3,47 @@ package main
 import (
 	"fmt"
 	"log"
+	"net"
 	"os"
+	"strings"
+	"time"
 
 	_ "github.com/alimikegami/compnouron/cmd/app/docs"
 	"github.com/alimikegami/compnouron/db/migration"
 	competitionController "github.com/alimikegami/compnouron/internal/competition/controller"
 	competitionRepository "github.com/alimikegami/compnouron/internal/competition/repository"
 	competitionUseCase "github.com/alimikegami/compnouron/internal/competition/usecase"
+	discussionController "github.com/alimikegami/compnouron/internal/discussion/controller"
+	discussionRepository "github.com/alimikegami/compnouron/internal/discussion/repository"
+	discussionUseCase "github.com/alimikegami/compnouron/internal/discussion/usecase"
+	institutionController "github.com/alimikegami/compnouron/internal/institution/controller"
+	institutionRepository "github.com/alimikegami/compnouron/internal/institution/repository"
+	institutionUseCase "github.com/alimikegami/compnouron/internal/institution/usecase"
+	"github.com/alimikegami/compnouron/internal/media"
+	"github.com/alimikegami/compnouron/internal/policy"
+	"github.com/alimikegami/compnouron/internal/privacy"
 	recruitmentController "github.com/alimikegami/compnouron/internal/recruitment/controller"
 	recruitmentRepository "github.com/alimikegami/compnouron/internal/recruitment/repository"
 	recruitmentUseCase "github.com/alimikegami/compnouron/internal/recruitment/usecase"
+	skillController "github.com/alimikegami/compnouron/internal/skill/controller"
+	skillRepository "github.com/alimikegami/compnouron/internal/skill/repository"
+	skillUseCase "github.com/alimikegami/compnouron/internal/skill/usecase"
 	teamController "github.com/alimikegami/compnouron/internal/team/controller"
 	teamRepository "github.com/alimikegami/compnouron/internal/team/repository"
 	teamUseCase "github.com/alimikegami/compnouron/internal/team/usecase"
+	"github.com/alimikegami/compnouron/internal/unitofwork"
 	"github.com/alimikegami/compnouron/internal/user/controller"
 	"github.com/alimikegami/compnouron/internal/user/repository"
 	"github.com/alimikegami/compnouron/internal/user/usecase"
+	"github.com/alimikegami/compnouron/pkg/keyring"
+	"github.com/alimikegami/compnouron/pkg/loginguard"
+	"github.com/alimikegami/compnouron/pkg/mailer"
+	"github.com/alimikegami/compnouron/pkg/oidc"
+	"github.com/alimikegami/compnouron/pkg/password"
+	"github.com/alimikegami/compnouron/pkg/storage"
 	"github.com/alimikegami/compnouron/pkg/utils"
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
 	echoSwagger "github.com/swaggo/echo-swagger"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -60,37 +81,156 @@ func main() {
 	if err != nil {
 		log.Fatal("Error loading .env file")
 	}
+
+	// ENCRYPTION_KEY seals the TOTP secrets and the state of OIDC logins, an
+	// empty one would seal them with a key anyone can derive
+	err = utils.CheckEncryptionKey(os.Getenv("ENCRYPTION_KEY"))
+	if err != nil {
+		log.Fatal("ENCRYPTION_KEY: ", err)
+	}
+
+	// TRUSTED_PROXIES lists the CIDR ranges of the load balancers in front of
+	// the API, e.g. "10.0.0.0/16". Only then is the client address taken from
+	// X-Forwarded-For, otherwise anyone could pick the address the login
+	// limits count against
+	e.IPExtractor = echo.ExtractIPDirect()
+	if trustedProxies := os.Getenv("TRUSTED_PROXIES"); trustedProxies != "" {
+		trustOptions := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+		for _, cidr := range strings.Split(trustedProxies, ",") {
+			_, ipRange, err := net.ParseCIDR(strings.TrimSpace(cidr))
+			if err != nil {
+				log.Fatal(err)
+			}
+			trustOptions = append(trustOptions, echo.TrustIPRange(ipRange))
+		}
+		e.IPExtractor = echo.ExtractIPFromXFFHeader(trustOptions...)
+	}
 	db, err := initializeDatabaseConnection()
 	if err != nil {
 		fmt.Println("Connection to the database has not been established")
 	}
 
-	config := middleware.JWTConfig{
-		Claims:     &utils.JwtCustomClaims{},
-		SigningKey: []byte(os.Getenv("SIGNING_KEY")),
+	migration.Migrate(db)
+
+	m := mailer.CreateNewLogMailer(os.Getenv("MAIL_LOG_PATH"))
+
+	// STORAGE_BACKEND is "s3" for an S3-compatible bucket (S3, MinIO, R2...),
+	// otherwise uploads are kept on disk and served by the app
+	var st storage.Storage
+	if os.Getenv("STORAGE_BACKEND") == "s3" {
+		st = storage.CreateNewS3Storage(storage.S3ConfigFromEnv(), nil)
+	} else {
+		storageDir := os.Getenv("STORAGE_LOCAL_DIR")
+		if storageDir == "" {
+			storageDir = "uploads"
+		}
+		publicURL := os.Getenv("STORAGE_PUBLIC_URL")
+		if publicURL == "" {
+			publicURL = "/uploads"
+		}
+		st = storage.CreateNewLocalStorage(storageDir, publicURL)
+		e.Static("/uploads", storageDir)
 	}
+	mu := media.CreateNewUploader(st)
 
-	migration.Migrate(db)
+	userRepository := repository.CreateNewUserRepository(db)
 
 	tr := teamRepository.CreateNewTeamRepository(db)
-	tuc := teamUseCase.CreateNewTeamUseCase(tr)
+	cr := competitionRepository.CreateNewCompetitionRepository(db)
+	p := policy.CreateNewPolicy(userRepository, tr)
+	s := privacy.CreateNewShaper(userRepository, tr, cr)
+	uow := unitofwork.CreateNewUnitOfWork(db)
+	tuc := teamUseCase.CreateNewTeamUseCase(tr, userRepository, p, s, mu, m, uow)
 	tc := teamController.CreateNewTeamController(e, tuc)
-	tc.InitializeTeamRoute(config)
 
-	cr := competitionRepository.CreateNewCompetitionRepository(db)
-	cuc := competitionUseCase.CreateNewCompetitionUseCase(cr, tr)
+	dr := discussionRepository.CreateNewDiscussionRepository(db)
+	duc := discussionUseCase.CreateNewDiscussionUseCase(dr, tr, userRepository, p, m)
+	dc := discussionController.CreateNewDiscussionController(e, duc)
+
+	cuc := competitionUseCase.CreateNewCompetitionUseCase(cr, tr, p, mu)
 	cc := competitionController.CreateNewCompetitionController(e, cuc)
-	cc.InitializeCompetitionRoute(config)
 
 	rr := recruitmentRepository.CreateNewRecruitmentRepository(db)
-	ruc := recruitmentUseCase.CreateNewRecruitmentUseCase(rr, tr)
+	ruc := recruitmentUseCase.CreateNewRecruitmentUseCase(rr, tr, p, uow)
 	rc := recruitmentController.CreateNewRecruitmentController(e, ruc)
 
-	userRepository := repository.CreateNewUserRepository(db)
-	userUseCase := usecase.CreateNewUserUseCase(userRepository, cr, rr)
+	sr := skillRepository.CreateNewSkillRepository(db)
+	suc := skillUseCase.CreateNewSkillUseCase(sr, p)
+	sc := skillController.CreateNewSkillController(e, suc)
+
+	ir := institutionRepository.CreateNewInstitutionRepository(db)
+	iuc := institutionUseCase.CreateNewInstitutionUseCase(ir, p)
+	ic := institutionController.CreateNewInstitutionController(e, iuc)
+
+	// both limits lock out for the same duration, keep the attempts that long
+	loginStore := loginguard.CreateNewMemoryStore(loginguard.DefaultIPLimits.LockoutDuration)
+	if os.Getenv("LOGIN_GUARD_STORE") == "database" {
+		loginStore = loginguard.CreateNewGormStore(db, loginguard.DefaultIPLimits.LockoutDuration)
+	}
+	lg := loginguard.CreateNewGuard(loginStore, loginguard.DefaultAccountLimits, loginguard.DefaultIPLimits)
+
+	// OIDC_PROVIDERS lists the identity providers users can sign in with, e.g.
+	// "google,microsoft"
+	oidcProviders := map[string]oidc.Provider{}
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			oidcProviders[name] = oidc.CreateNewProvider(oidc.ConfigFromEnv(name), nil)
+		}
+	}
+
+	// JWT_SIGNING_ALGORITHM is RS256 or EdDSA, JWT_KEY_ROTATION_INTERVAL a
+	// duration such as "720h"
+	keyOptions := keyring.DefaultOptions
+	if algorithm := os.Getenv("JWT_SIGNING_ALGORITHM"); algorithm != "" {
+		keyOptions.Algorithm = algorithm
+	}
+	if rotationInterval, err := time.ParseDuration(os.Getenv("JWT_KEY_ROTATION_INTERVAL")); err == nil {
+		keyOptions.RotationInterval = rotationInterval
+	}
+	// KEYRING_ENCRYPTION_KEY seals the private signing keys. It is kept apart
+	// from ENCRYPTION_KEY, whoever holds it can forge a token for any user
+	keyringKey := os.Getenv("KEYRING_ENCRYPTION_KEY")
+	err = utils.CheckEncryptionKey(keyringKey)
+	if err != nil {
+		log.Fatal("KEYRING_ENCRYPTION_KEY: ", err)
+	}
+	encryptSigningKey := func(plaintext string) (string, error) {
+		return utils.EncryptSecretWithKey(keyringKey, plaintext)
+	}
+	decryptSigningKey := func(ciphertext string) (string, error) {
+		return utils.DecryptSecretWithKey(keyringKey, ciphertext)
+	}
+	kr, err := keyring.CreateNewRing(keyring.CreateNewGormStore(db, encryptSigningKey, decryptSigningKey), keyOptions)
+	if err != nil {
+		log.Fatal(err)
+	}
+	go keyring.RunRotation(kr, time.Minute)
+	e.GET("/.well-known/jwks.json", keyring.JWKSHandler(kr))
+
+	// PASSWORD_BREACH_LIST_DIR points to a copy of the Pwned Passwords range
+	// files, without it passwords are only checked against the policy
+	var breaches password.BreachList
+	if dir := os.Getenv("PASSWORD_BREACH_LIST_DIR"); dir != "" {
+		breaches = password.CreateNewRangeDirectory(dir)
+	}
+	pc := password.CreateNewChecker(password.PolicyFromEnv(), breaches)
+
+	userUseCase := usecase.CreateNewUserUseCase(userRepository, cr, rr, tr, sr, ir, dr, m, p, s, lg, oidcProviders, kr, mu, pc)
 	userController := controller.CreateNewUserController(e, userUseCase)
+
+	// access tokens are only accepted while the login session they belong to
+	// is active, personal access tokens until they are revoked or expire
+	config := utils.CreateJWTConfig(kr, userUseCase)
+	e.Use(utils.ImpersonationAudit(userUseCase))
+
 	userController.InitializeUserRoute(config)
+	tc.InitializeTeamRoute(config)
+	dc.InitializeDiscussionRoute(config)
+	cc.InitializeCompetitionRoute(config)
 	rc.InitializeRecruitmentRoute(config)
+	sc.InitializeSkillRoute(config)
+	ic.InitializeInstitutionRoute(config)
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 	e.Logger.Fatal(e.Start(":1323"))
 }
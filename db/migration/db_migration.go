@@ -2,38 +2,202 @@ package migration
 
 import (
 	compEntity "github.com/alimikegami/compnouron/internal/competition/entity"
+	discussionEntity "github.com/alimikegami/compnouron/internal/discussion/entity"
+	institutionEntity "github.com/alimikegami/compnouron/internal/institution/entity"
 	recruitmentEntity "github.com/alimikegami/compnouron/internal/recruitment/entity"
 	teamEntity "github.com/alimikegami/compnouron/internal/team/entity"
 	"github.com/alimikegami/compnouron/internal/user/entity"
+	"github.com/alimikegami/compnouron/pkg/keyring"
+	"github.com/alimikegami/compnouron/pkg/loginguard"
 
 	"gorm.io/gorm"
 )
 
 func Migrate(db *gorm.DB) {
+	// users reference institutions, so the institutions go first
+	if !db.Migrator().HasTable(&institutionEntity.Institution{}) {
+		db.Migrator().CreateTable(&institutionEntity.Institution{})
+	}
+
+	if !db.Migrator().HasTable(&institutionEntity.InstitutionDomain{}) {
+		db.Migrator().CreateTable(&institutionEntity.InstitutionDomain{})
+	}
+
 	if (!db.Migrator().HasTable(&entity.User{})) {
 		db.Migrator().CreateTable(&entity.User{})
 	}
 
-	if (!db.Migrator().HasTable(&entity.Skill{})) {
-		db.Migrator().CreateTable(&entity.Skill{})
+	// accounts made before email verification existed are treated as
+	// verified, otherwise every one of them would be locked out at once
+	if !db.Migrator().HasColumn(&entity.User{}, "VerifiedAt") {
+		db.Migrator().AddColumn(&entity.User{}, "VerifiedAt")
+		db.Model(&entity.User{}).Where("verified_at IS NULL").UpdateColumn("verified_at", gorm.Expr("created_at"))
+	}
+
+	if !db.Migrator().HasColumn(&entity.User{}, "Role") {
+		db.Migrator().AddColumn(&entity.User{}, "Role")
+	}
+
+	if !db.Migrator().HasColumn(&entity.User{}, "AnonymizedAt") {
+		db.Migrator().AddColumn(&entity.User{}, "AnonymizedAt")
+	}
+
+	if !db.Migrator().HasColumn(&entity.User{}, "EmailVisibility") {
+		db.Migrator().AddColumn(&entity.User{}, "EmailVisibility")
+	}
+
+	if !db.Migrator().HasColumn(&entity.User{}, "PhoneNumberVisibility") {
+		db.Migrator().AddColumn(&entity.User{}, "PhoneNumberVisibility")
+	}
+
+	if !db.Migrator().HasColumn(&entity.User{}, "Searchable") {
+		db.Migrator().AddColumn(&entity.User{}, "Searchable")
+	}
+
+	if !db.Migrator().HasColumn(&entity.User{}, "AvailableForTeams") {
+		db.Migrator().AddColumn(&entity.User{}, "AvailableForTeams")
+	}
+
+	if !db.Migrator().HasColumn(&entity.User{}, "InstitutionID") {
+		db.Migrator().AddColumn(&entity.User{}, "InstitutionID")
+	}
+
+	if !db.Migrator().HasConstraint(&entity.User{}, "Institution") {
+		db.Migrator().CreateConstraint(&entity.User{}, "Institution")
+	}
+
+	if !db.Migrator().HasColumn(&entity.User{}, "TwoFactorSecret") {
+		db.Migrator().AddColumn(&entity.User{}, "TwoFactorSecret")
+	}
+
+	if !db.Migrator().HasColumn(&entity.User{}, "TwoFactorEnabledAt") {
+		db.Migrator().AddColumn(&entity.User{}, "TwoFactorEnabledAt")
+	}
+
+	if !db.Migrator().HasColumn(&entity.User{}, "TwoFactorLastStep") {
+		db.Migrator().AddColumn(&entity.User{}, "TwoFactorLastStep")
+	}
+
+	if !db.Migrator().HasColumn(&entity.User{}, "AvatarKey") {
+		db.Migrator().AddColumn(&entity.User{}, "AvatarKey")
+	}
+
+	if !db.Migrator().HasTable(&entity.RefreshToken{}) {
+		db.Migrator().CreateTable(&entity.RefreshToken{})
+	}
+
+	if !db.Migrator().HasTable(&entity.Session{}) {
+		db.Migrator().CreateTable(&entity.Session{})
+	}
+
+	if !db.Migrator().HasTable(&entity.PersonalAccessToken{}) {
+		db.Migrator().CreateTable(&entity.PersonalAccessToken{})
+	}
+
+	if !db.Migrator().HasTable(&entity.PasswordResetToken{}) {
+		db.Migrator().CreateTable(&entity.PasswordResetToken{})
+	}
+
+	if !db.Migrator().HasTable(&entity.RecoveryCode{}) {
+		db.Migrator().CreateTable(&entity.RecoveryCode{})
 	}
 
+	if !db.Migrator().HasTable(&entity.UserIdentity{}) {
+		db.Migrator().CreateTable(&entity.UserIdentity{})
+	}
+
+	if !db.Migrator().HasTable(&entity.LockoutEvent{}) {
+		db.Migrator().CreateTable(&entity.LockoutEvent{})
+	}
+
+	if !db.Migrator().HasTable(&entity.Impersonation{}) {
+		db.Migrator().CreateTable(&entity.Impersonation{})
+	}
+
+	if !db.Migrator().HasTable(&entity.ImpersonationAction{}) {
+		db.Migrator().CreateTable(&entity.ImpersonationAction{})
+	}
+
+	// actions recorded before they carried the admin and the user take them
+	// from their impersonation
+	if !db.Migrator().HasColumn(&entity.ImpersonationAction{}, "ActorID") {
+		db.Migrator().AddColumn(&entity.ImpersonationAction{}, "ActorID")
+		db.Migrator().AddColumn(&entity.ImpersonationAction{}, "UserID")
+		db.Exec("UPDATE impersonation_actions JOIN impersonations ON impersonations.id = impersonation_actions.impersonation_id SET impersonation_actions.actor_id = impersonations.actor_id, impersonation_actions.user_id = impersonations.user_id")
+	}
+
+	if !db.Migrator().HasTable(&loginguard.LoginAttempt{}) {
+		db.Migrator().CreateTable(&loginguard.LoginAttempt{})
+	}
+
+	if !db.Migrator().HasTable(&keyring.SigningKey{}) {
+		db.Migrator().CreateTable(&keyring.SigningKey{})
+	}
+
+	migrateSkills(db)
+
 	if !db.Migrator().HasTable(&compEntity.Competition{}) {
 		db.Migrator().CreateTable(&compEntity.Competition{})
 	}
 
+	if !db.Migrator().HasColumn(&compEntity.Competition{}, "BannerKey") {
+		db.Migrator().AddColumn(&compEntity.Competition{}, "BannerKey")
+	}
+
+	if !db.Migrator().HasColumn(&compEntity.Competition{}, "ResultsPublishAt") {
+		db.Migrator().AddColumn(&compEntity.Competition{}, "ResultsPublishAt")
+	}
+
 	if !db.Migrator().HasTable(&teamEntity.Team{}) {
 		db.Migrator().CreateTable(&teamEntity.Team{})
 	}
 
+	if !db.Migrator().HasColumn(&teamEntity.Team{}, "LogoKey") {
+		db.Migrator().AddColumn(&teamEntity.Team{}, "LogoKey")
+	}
+
 	if !db.Migrator().HasTable(&teamEntity.TeamMember{}) {
 		db.Migrator().CreateTable(&teamEntity.TeamMember{})
 	}
 
+	if !db.Migrator().HasColumn(&teamEntity.TeamMember{}, "Role") {
+		db.Migrator().AddColumn(&teamEntity.TeamMember{}, "Role")
+	}
+
+	// the leaders flagged by is_leader become the owners of their teams
+	if db.Migrator().HasColumn(&teamEntity.TeamMember{}, "is_leader") {
+		db.Model(&teamEntity.TeamMember{}).Where("is_leader = 1").Update("role", teamEntity.TeamRoleOwner)
+		db.Migrator().DropColumn(&teamEntity.TeamMember{}, "is_leader")
+	}
+
+	if !db.Migrator().HasTable(&teamEntity.TeamInvitation{}) {
+		db.Migrator().CreateTable(&teamEntity.TeamInvitation{})
+	}
+
+	if !db.Migrator().HasTable(&discussionEntity.Thread{}) {
+		db.Migrator().CreateTable(&discussionEntity.Thread{})
+	}
+
+	if !db.Migrator().HasTable(&discussionEntity.Reply{}) {
+		db.Migrator().CreateTable(&discussionEntity.Reply{})
+	}
+
+	if !db.Migrator().HasTable(&discussionEntity.Mention{}) {
+		db.Migrator().CreateTable(&discussionEntity.Mention{})
+	}
+
 	if !db.Migrator().HasTable(&compEntity.CompetitionRegistration{}) {
 		db.Migrator().CreateTable(&compEntity.CompetitionRegistration{})
 	}
 
+	if !db.Migrator().HasTable(&compEntity.CompetitionResult{}) {
+		db.Migrator().CreateTable(&compEntity.CompetitionResult{})
+	}
+
+	if !db.Migrator().HasTable(&compEntity.Achievement{}) {
+		db.Migrator().CreateTable(&compEntity.Achievement{})
+	}
+
 	if !db.Migrator().HasTable(&recruitmentEntity.Recruitment{}) {
 		db.Migrator().CreateTable(&recruitmentEntity.Recruitment{})
 	}
@@ -0,0 +1,99 @@
+package migration
+
+import (
+	"fmt"
+
+	skillEntity "github.com/alimikegami/compnouron/internal/skill/entity"
+	skillRepo "github.com/alimikegami/compnouron/internal/skill/repository"
+	"github.com/alimikegami/compnouron/internal/user/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	legacySkillsTable = "legacy_skills"
+	// the legacy table's foreign key to users has the same name as the one
+	// user_skills gets, and MySQL wants constraint names unique per database
+	legacySkillsUserConstraint = "fk_users_skills"
+)
+
+// legacySkill is a row of the free-text skills table that existed before the
+// skill catalog, where every user had their own copy of a skill name.
+type legacySkill struct {
+	ID     uint
+	Name   string
+	UserID uint
+}
+
+func (legacySkill) TableName() string {
+	return legacySkillsTable
+}
+
+// migrateSkills moves the free-text skills table out of the way of the skill
+// catalog, then maps every legacy row onto a catalog entry. The legacy table is
+// dropped once all of its rows have been mapped, so a failed run can be retried.
+func migrateSkills(db *gorm.DB) {
+	if db.Migrator().HasTable(&skillEntity.Skill{}) && db.Migrator().HasColumn(&skillEntity.Skill{}, "user_id") {
+		db.Migrator().RenameTable(&skillEntity.Skill{}, legacySkillsTable)
+	}
+
+	if db.Migrator().HasConstraint(legacySkillsTable, legacySkillsUserConstraint) {
+		db.Migrator().DropConstraint(legacySkillsTable, legacySkillsUserConstraint)
+	}
+
+	if !db.Migrator().HasTable(&skillEntity.Skill{}) {
+		db.Migrator().CreateTable(&skillEntity.Skill{})
+	}
+
+	if !db.Migrator().HasTable(&skillEntity.SkillAlias{}) {
+		db.Migrator().CreateTable(&skillEntity.SkillAlias{})
+	}
+
+	if !db.Migrator().HasTable(&entity.UserSkill{}) {
+		db.Migrator().CreateTable(&entity.UserSkill{})
+	}
+
+	if !db.Migrator().HasTable(legacySkillsTable) {
+		return
+	}
+
+	err := mapLegacySkills(db)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	db.Migrator().DropTable(legacySkillsTable)
+}
+
+func mapLegacySkills(db *gorm.DB) error {
+	var legacySkills []legacySkill
+	result := db.Find(&legacySkills)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	sr := skillRepo.CreateNewSkillRepository(db)
+	for _, legacy := range legacySkills {
+		if skillRepo.NormalizeSkillName(legacy.Name) == "" {
+			continue
+		}
+
+		skill, err := sr.FindOrCreateSkill(legacy.Name)
+		if err != nil {
+			return err
+		}
+
+		result = db.Omit("Skill").Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.UserSkill{
+			UserID:      legacy.UserID,
+			SkillID:     skill.ID,
+			Proficiency: entity.MinSkillProficiency,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+	}
+
+	return nil
+}
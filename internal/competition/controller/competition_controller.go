@@ -7,6 +7,7 @@ import (
 
 	"github.com/alimikegami/compnouron/internal/competition/dto"
 	"github.com/alimikegami/compnouron/internal/competition/usecase"
+	"github.com/alimikegami/compnouron/internal/media"
 	"github.com/alimikegami/compnouron/pkg/response"
 	"github.com/alimikegami/compnouron/pkg/utils"
 	"github.com/labstack/echo/v4"
@@ -25,17 +26,23 @@ func CreateNewCompetitionController(e *echo.Echo, CompetitionUC usecase.Competit
 func (cc *CompetitionController) InitializeCompetitionRoute(config middleware.JWTConfig) {
 	r := cc.router.Group("/competitions")
 	{
-		r.POST("", cc.CreateCompetition, middleware.JWTWithConfig(config))
+		r.POST("", cc.CreateCompetition, utils.JWTWithScope(config, utils.ScopeCompetitionsWrite), utils.RequireRole(utils.RoleOrganizer, utils.RoleAdmin))
+		// the listing and the details are public, personal access tokens only
+		// need competitions:read for the results, which show more to organizers
 		r.GET("", cc.GetCompetitions)
-		r.POST("/registrations", cc.Register, middleware.JWTWithConfig(config))
-		r.DELETE("/:id", cc.DeleteCompetition, middleware.JWTWithConfig(config))
-		r.PUT("/:id", cc.UpdateCompetition, middleware.JWTWithConfig(config))
-		r.PUT("/registrations/:id/accept", cc.AcceptCompetitionRegistration, middleware.JWTWithConfig(config))
-		r.PUT("/registrations/:id/reject", cc.RejectCompetitionRegistration, middleware.JWTWithConfig(config))
-		r.PUT("/:id/open", cc.OpenCompetitionRegistrationPeriod, middleware.JWTWithConfig(config))
-		r.PUT("/:id/close", cc.CloseCompetitionRegistrationPeriod, middleware.JWTWithConfig(config))
+		r.POST("/registrations", cc.Register, utils.JWTWithScope(config, utils.ScopeRegistrationsWrite))
+		r.DELETE("/:id", cc.DeleteCompetition, utils.JWTWithScope(config, utils.ScopeCompetitionsWrite))
+		r.PUT("/:id", cc.UpdateCompetition, utils.JWTWithScope(config, utils.ScopeCompetitionsWrite))
+		r.PUT("/registrations/:id/accept", cc.AcceptCompetitionRegistration, utils.JWTWithScope(config, utils.ScopeRegistrationsWrite))
+		r.PUT("/registrations/:id/reject", cc.RejectCompetitionRegistration, utils.JWTWithScope(config, utils.ScopeRegistrationsWrite))
+		r.PUT("/:id/open", cc.OpenCompetitionRegistrationPeriod, utils.JWTWithScope(config, utils.ScopeCompetitionsWrite))
+		r.PUT("/:id/close", cc.CloseCompetitionRegistrationPeriod, utils.JWTWithScope(config, utils.ScopeCompetitionsWrite))
 		r.GET("/:id", cc.GetCompetitionByID)
-		r.GET("/:id/registrations", cc.GetCompetitionRegistration, middleware.JWTWithConfig(config))
+		r.GET("/:id/registrations", cc.GetCompetitionRegistration, utils.JWTWithScope(config, utils.ScopeRegistrationsRead))
+		r.PUT("/:id/results", cc.PublishResults, utils.JWTWithScope(config, utils.ScopeCompetitionsWrite))
+		r.GET("/:id/results", cc.GetCompetitionResults, utils.OptionalJWTWithScope(config, utils.ScopeCompetitionsRead))
+		r.PUT("/:id/banner", cc.UploadCompetitionBanner, utils.JWTWithScope(config, utils.ScopeCompetitionsWrite))
+		r.DELETE("/:id/banner", cc.DeleteCompetitionBanner, utils.JWTWithScope(config, utils.ScopeCompetitionsWrite))
 	}
 }
 
@@ -50,6 +57,7 @@ func (cc *CompetitionController) InitializeCompetitionRoute(config middleware.JW
 // @Param data body dto.CompetitionRequest true "Request Body"
 // @Success      200  {object}   response.Response{data=string,status=string,message=string}
 // @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
 // @Failure      500  {object}  response.Response
 // @Router       /competitions [post]
 func (cc *CompetitionController) CreateCompetition(c echo.Context) error {
@@ -66,6 +74,13 @@ func (cc *CompetitionController) CreateCompetition(c echo.Context) error {
 	err := cc.CompetitionUC.CreateCompetition(*competition, userID)
 	if err != nil {
 		fmt.Println(err)
+		if err.Error() == "email is not verified" || err.Error() == "only organizers can create competitions" {
+			return c.JSON(http.StatusForbidden, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, response.Response{
 			Status:  "error",
 			Message: err.Error(),
@@ -296,6 +311,8 @@ func (cc *CompetitionController) GetCompetitions(c echo.Context) error {
 // @Param data body dto.CompetitionRegistrationRequest true "Request Body"
 // @Success      200  {object}   response.Response{data=string,status=string,message=string}
 // @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      403  {object}  response.Response
 // @Failure      500  {object}  response.Response
 // @Router       /competitions/registrations [post]
 func (cc *CompetitionController) Register(c echo.Context) error {
@@ -313,6 +330,20 @@ func (cc *CompetitionController) Register(c echo.Context) error {
 
 	if err != nil {
 		fmt.Println(err)
+		if err.Error() == "action unauthorized" {
+			return c.JSON(http.StatusUnauthorized, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		if err.Error() == "every team member must have a verified institution" || err.Error() == "team members must come from the same institution" {
+			return c.JSON(http.StatusForbidden, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, response.Response{
 			Status:  "error",
 			Message: err.Error(),
@@ -569,3 +600,233 @@ func (cc *CompetitionController) GetCompetitionRegistration(c echo.Context) erro
 		Data:    res,
 	})
 }
+
+// PublishResults godoc
+// @Summary      Publish competition results
+// @Description  Given the ID path parameters and the request body, this endpoint will replace the results of the competition. Only accepted registrations can be ranked, and every member of a ranked team gets the achievement. The results stay hidden until publishAt, or are visible right away when it is left empty
+// @Tags         Competitions
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Competition ID"
+// @Param data body dto.CompetitionResultsRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /competitions/{id}/results [put]
+func (cc *CompetitionController) PublishResults(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	competitionID := c.Param("id")
+	competitionIDUint, err := strconv.ParseUint(competitionID, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	resultsRequest := new(dto.CompetitionResultsRequest)
+	if err := c.Bind(resultsRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	err = cc.CompetitionUC.PublishResults(uint(competitionIDUint), userID, *resultsRequest)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "fill the results" || err.Error() == "invalid rank" || err.Error() == "fill the award title" || err.Error() == "registration not accepted" || err.Error() == "duplicate registration" {
+			statusCode = http.StatusBadRequest
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// GetCompetitionResults godoc
+// @Summary      Get competition results
+// @Description  Given the ID path parameters, this endpoint will retrieve the results of the competition ordered by rank. Results under embargo are only visible to the organizer and admins
+// @Tags         Competitions
+// @Produce      json
+// @Param id path int true "Competition ID"
+// @Success      200  {object}   response.Response{data=[]dto.CompetitionResultResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /competitions/{id}/results [get]
+func (cc *CompetitionController) GetCompetitionResults(c echo.Context) error {
+	competitionID := c.Param("id")
+	competitionIDUint, err := strconv.ParseUint(competitionID, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	res, err := cc.CompetitionUC.GetCompetitionResults(uint(competitionIDUint), utils.GetOptionalUserID(c))
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "results not published" {
+			statusCode = http.StatusNotFound
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    res,
+	})
+}
+
+// UploadCompetitionBanner godoc
+// @Summary      Upload competition banner
+// @Description  Replaces the competition banner with the uploaded image and returns its URLs, a thumbnail is generated as well. Only the organizer can change the banner
+// @Tags         Competitions
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Competition ID"
+// @Param image formData file true "JPEG, PNG or GIF image"
+// @Success      200  {object}   response.Response{data=media.Image,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      413  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /competitions/{id}/banner [put]
+func (cc *CompetitionController) UploadCompetitionBanner(c echo.Context) error {
+	competitionID := c.Param("id")
+	competitionIDUint, err := strconv.ParseUint(competitionID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+	data, err := utils.ReadUploadedFile(c, "image", media.MaxUploadBytes)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "file too large" {
+			statusCode = http.StatusRequestEntityTooLarge
+		} else {
+			statusCode = http.StatusBadRequest
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	result, err := cc.CompetitionUC.UploadCompetitionBanner(uint(competitionIDUint), userID, data)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "file too large" {
+			statusCode = http.StatusRequestEntityTooLarge
+		} else if err.Error() == "unsupported image type" || err.Error() == "invalid image" || err.Error() == "image dimensions too large" {
+			statusCode = http.StatusBadRequest
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    result,
+	})
+}
+
+// DeleteCompetitionBanner godoc
+// @Summary      Delete competition banner
+// @Description  Removes the competition banner. Only the organizer can remove the banner
+// @Tags         Competitions
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Competition ID"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /competitions/{id}/banner [delete]
+func (cc *CompetitionController) DeleteCompetitionBanner(c echo.Context) error {
+	competitionID := c.Param("id")
+	competitionIDUint, err := strconv.ParseUint(competitionID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+
+	err = cc.CompetitionUC.DeleteCompetitionBanner(uint(competitionIDUint), userID)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "banner not found" {
+			statusCode = http.StatusNotFound
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/alimikegami/compnouron/internal/competition/dto"
+	"github.com/alimikegami/compnouron/internal/media"
 	mocks "github.com/alimikegami/compnouron/internal/mocks/competition/usecase"
 	"github.com/alimikegami/compnouron/pkg/utils"
 	"github.com/labstack/echo/v4"
@@ -43,7 +45,7 @@ func TestCreateCompetition(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		// setup controller/handler
 		compController := CompetitionController{
@@ -67,7 +69,7 @@ func TestCreateCompetition(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		// setup controller/handler
 		compController := CompetitionController{
@@ -95,7 +97,7 @@ func TestDeleteCompetition(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id")
 		c.SetParamNames("id")
@@ -121,7 +123,7 @@ func TestDeleteCompetition(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id")
 		c.SetParamNames("id")
@@ -163,7 +165,7 @@ func TestUpdateCompetition(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id")
 		c.SetParamNames("id")
@@ -189,7 +191,7 @@ func TestUpdateCompetition(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id")
 		c.SetParamNames("id")
@@ -236,7 +238,7 @@ func TestRegister(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		// setup controller/handler
 		compController := CompetitionController{
@@ -260,7 +262,7 @@ func TestRegister(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		// setup controller/handler
 		compController := CompetitionController{
@@ -284,7 +286,7 @@ func TestRegister(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		// setup controller/handler
 		compController := CompetitionController{
@@ -308,7 +310,7 @@ func TestRegister(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		// setup controller/handler
 		compController := CompetitionController{
@@ -335,7 +337,7 @@ func TestAcceptCompetitionRegistration(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id/accept")
 		c.SetParamNames("id")
@@ -360,7 +362,7 @@ func TestAcceptCompetitionRegistration(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id/accept")
 		c.SetParamNames("id")
@@ -390,7 +392,7 @@ func TestRejectCompetitionRegistration(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id/reject")
 		c.SetParamNames("id")
@@ -415,7 +417,7 @@ func TestRejectCompetitionRegistration(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id/reject")
 		c.SetParamNames("id")
@@ -444,7 +446,7 @@ func TestOpenCompetitionRegistrationPeriod(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id/open")
 		c.SetParamNames("id")
@@ -469,7 +471,7 @@ func TestOpenCompetitionRegistrationPeriod(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id/open")
 		c.SetParamNames("id")
@@ -498,7 +500,7 @@ func TestCloseCompetitionRegistrationPeriod(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id/close")
 		c.SetParamNames("id")
@@ -523,7 +525,7 @@ func TestCloseCompetitionRegistrationPeriod(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id/close")
 		c.SetParamNames("id")
@@ -660,3 +662,202 @@ func TestGetCompetitionByID(t *testing.T) {
 // 		mockUseCase.AssertExpectations(t)
 // 	})
 // }
+
+func TestPublishResults(t *testing.T) {
+	mockUseCase := mocks.NewCompetitionUseCase(t)
+	resultsRequest := dto.CompetitionResultsRequest{
+		Results: []dto.CompetitionResultRequest{{RegistrationID: 4, Rank: 1, AwardTitle: "Champion"}},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("PublishResults", uint(1), uint(3), resultsRequest).Return(nil).Once()
+		body, _ := json.Marshal(resultsRequest)
+		req, err := http.NewRequest(http.MethodPut, "/competitions/1/results", bytes.NewBuffer(body))
+		assert.NoError(t, err, "No request error")
+		req.Header.Set("Content-Type", "application/json")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		token := utils.CreateJWTToken(uint(3), "gmail@gmail.com", utils.RoleOrganizer, 1)
+		c.Set("user", token)
+		c.SetPath("/:id/results")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		compController := CompetitionController{
+			router:        e,
+			CompetitionUC: mockUseCase,
+		}
+
+		compController.PublishResults(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("registration-not-accepted", func(t *testing.T) {
+		mockUseCase.On("PublishResults", uint(1), uint(3), resultsRequest).Return(errors.New("registration not accepted")).Once()
+		body, _ := json.Marshal(resultsRequest)
+		req, err := http.NewRequest(http.MethodPut, "/competitions/1/results", bytes.NewBuffer(body))
+		assert.NoError(t, err, "No request error")
+		req.Header.Set("Content-Type", "application/json")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		token := utils.CreateJWTToken(uint(3), "gmail@gmail.com", utils.RoleOrganizer, 1)
+		c.Set("user", token)
+		c.SetPath("/:id/results")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		compController := CompetitionController{
+			router:        e,
+			CompetitionUC: mockUseCase,
+		}
+
+		compController.PublishResults(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestGetCompetitionResults(t *testing.T) {
+	mockUseCase := mocks.NewCompetitionUseCase(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("GetCompetitionResults", uint(1), uint(0)).Return([]dto.CompetitionResultResponse{
+			{RegistrationID: 4, Rank: 1, AwardTitle: "Champion", TeamID: 6, TeamName: "Ikegami"},
+		}, nil).Once()
+		req, err := http.NewRequest(http.MethodGet, "/competitions/1/results", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/:id/results")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		compController := CompetitionController{
+			router:        e,
+			CompetitionUC: mockUseCase,
+		}
+
+		compController.GetCompetitionResults(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("embargoed", func(t *testing.T) {
+		mockUseCase.On("GetCompetitionResults", uint(1), uint(0)).Return(nil, errors.New("results not published")).Once()
+		req, err := http.NewRequest(http.MethodGet, "/competitions/1/results", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/:id/results")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		compController := CompetitionController{
+			router:        e,
+			CompetitionUC: mockUseCase,
+		}
+
+		compController.GetCompetitionResults(c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestUploadCompetitionBanner(t *testing.T) {
+	mockUseCase := mocks.NewCompetitionUseCase(t)
+	data := []byte("image")
+	newRequest := func() *http.Request {
+		body := new(bytes.Buffer)
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("image", "banner.png")
+		assert.NoError(t, err)
+		part.Write(data)
+		writer.Close()
+		req := httptest.NewRequest(http.MethodPut, "/competitions/1/banner", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("UploadCompetitionBanner", uint(1), uint(1), data).Return(&media.Image{URL: "/uploads/banners/1/a.png"}, nil).Once()
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(), rec)
+		c.Set("user", utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleOrganizer, 1))
+		c.SetPath("/competitions/:id/banner")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		compController := CompetitionController{
+			router:        e,
+			CompetitionUC: mockUseCase,
+		}
+
+		compController.UploadCompetitionBanner(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("action-unauthorized", func(t *testing.T) {
+		mockUseCase.On("UploadCompetitionBanner", uint(1), uint(1), data).Return(nil, errors.New("action unauthorized")).Once()
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(), rec)
+		c.Set("user", utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleOrganizer, 1))
+		c.SetPath("/competitions/:id/banner")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		compController := CompetitionController{
+			router:        e,
+			CompetitionUC: mockUseCase,
+		}
+
+		compController.UploadCompetitionBanner(c)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestDeleteCompetitionBanner(t *testing.T) {
+	mockUseCase := mocks.NewCompetitionUseCase(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("DeleteCompetitionBanner", uint(1), uint(1)).Return(nil).Once()
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodDelete, "/competitions/1/banner", nil), rec)
+		c.Set("user", utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleOrganizer, 1))
+		c.SetPath("/competitions/:id/banner")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		compController := CompetitionController{
+			router:        e,
+			CompetitionUC: mockUseCase,
+		}
+
+		compController.DeleteCompetitionBanner(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("banner-not-found", func(t *testing.T) {
+		mockUseCase.On("DeleteCompetitionBanner", uint(1), uint(1)).Return(errors.New("banner not found")).Once()
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodDelete, "/competitions/1/banner", nil), rec)
+		c.Set("user", utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleOrganizer, 1))
+		c.SetPath("/competitions/:id/banner")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		compController := CompetitionController{
+			router:        e,
+			CompetitionUC: mockUseCase,
+		}
+
+		compController.DeleteCompetitionBanner(c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	mockUseCase.AssertExpectations(t)
+}
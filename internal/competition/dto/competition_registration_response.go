@@ -12,8 +12,8 @@ type IndividualCompetitionRegistrationResponse struct {
 	ID                uint   `json:"id"`
 	UserID            uint   `json:"userID"`
 	UserName          string `json:"userName"`
-	Email             string `json:"email"`
-	PhoneNumber       string `json:"phoneNumber"`
+	Email             string `json:"email,omitempty"`
+	PhoneNumber       string `json:"phoneNumber,omitempty"`
 	SchoolInstitution string `json:"schoolInstitution"`
 	CompetitionID     uint   `json:"competitionID"`
 	AcceptanceStatus  uint   `json:"AcceptanceStatus"`
@@ -1,5 +1,7 @@
 package dto
 
+import "github.com/alimikegami/compnouron/internal/media"
+
 type CompetitionResponse struct {
 	ID            uint   `json:"ID"`
 	Name          string `json:"name"`
@@ -20,4 +22,5 @@ type DetailedCompetitionResponse struct {
 	Level                    string
 	UserID                   uint
 	UserName                 string
+	Banner                   *media.Image
 }
@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+type CompetitionResultRequest struct {
+	RegistrationID uint   `json:"registrationID"`
+	Rank           uint   `json:"rank"`
+	AwardTitle     string `json:"awardTitle"`
+}
+
+// CompetitionResultsRequest replaces every result of a competition. The
+// results stay under embargo until PublishAt, or are visible right away when
+// it is left empty.
+type CompetitionResultsRequest struct {
+	Results   []CompetitionResultRequest `json:"results"`
+	PublishAt *time.Time                 `json:"publishAt"`
+}
+
+type CompetitionResultResponse struct {
+	RegistrationID uint   `json:"registrationID"`
+	Rank           uint   `json:"rank"`
+	AwardTitle     string `json:"awardTitle"`
+	UserID         uint   `json:"userID,omitempty"`
+	UserName       string `json:"userName,omitempty"`
+	TeamID         uint   `json:"teamID,omitempty"`
+	TeamName       string `json:"teamName,omitempty"`
+}
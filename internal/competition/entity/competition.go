@@ -16,6 +16,11 @@ type Competition struct {
 	RegistrationPeriodStatus int8   `gorm:"not null"`
 	TeamCapacity             int8   `gorm:"not null"`
 	Level                    string `gorm:"not null"`
+	// BannerKey is the storage key of the banner, see the media package
+	BannerKey string
+	// ResultsPublishAt is when the results become visible, they are under
+	// embargo until then. It is nil while no results have been published.
+	ResultsPublishAt         *time.Time
 	CreatedAt                time.Time
 	UpdatedAt                time.Time
 	UserID                   uint `gorm:"not null"`
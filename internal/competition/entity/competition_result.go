@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	userEntity "github.com/alimikegami/compnouron/internal/user/entity"
+)
+
+// CompetitionResult is the placing of an accepted registration. It stays hidden
+// until the competition's ResultsPublishAt has passed.
+type CompetitionResult struct {
+	ID                        uint   `gorm:"primaryKey"`
+	CompetitionID             uint   `gorm:"not null;index"`
+	CompetitionRegistrationID uint   `gorm:"not null;unique"`
+	Rank                      uint   `gorm:"not null"`
+	AwardTitle                string `gorm:"not null"`
+	CreatedAt                 time.Time
+	UpdatedAt                 time.Time
+	Competition               Competition             `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CompetitionRegistration   CompetitionRegistration `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Achievements              []Achievement
+}
+
+// Achievement credits a result to one participant. A team entry credits every
+// member the team had when the results were published, so later changes to the
+// team don't change who won.
+type Achievement struct {
+	ID                  uint `gorm:"primaryKey"`
+	CompetitionResultID uint `gorm:"not null;index"`
+	UserID              uint `gorm:"not null;index"`
+	CreatedAt           time.Time
+	CompetitionResult   CompetitionResult `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	User                userEntity.User
+}
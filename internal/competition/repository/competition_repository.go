@@ -4,6 +4,7 @@ import (
 	"github.com/alimikegami/compnouron/db/pagination"
 
 	"errors"
+	"time"
 
 	"github.com/alimikegami/compnouron/internal/competition/entity"
 	"gorm.io/gorm"
@@ -29,6 +30,11 @@ type CompetitionRepository interface {
 	CloseCompetitionRegistrationPeriod(id uint) error
 	OpenCompetitionRegistrationPeriod(id uint) error
 	SearchCompetition(limit int, offset int, keyword string) ([]entity.Competition, error)
+	GetRegistrantIDsByOrganizer(organizerID uint) ([]uint, error)
+	PublishCompetitionResults(competitionID uint, publishAt time.Time, results []entity.CompetitionResult) error
+	GetCompetitionResults(competitionID uint) ([]entity.CompetitionResult, error)
+	GetAchievementsByUserID(userID uint, publishedBefore time.Time) ([]entity.Achievement, error)
+	UpdateCompetitionBanner(id uint, bannerKey string) error
 }
 
 func CreateNewCompetitionRepository(db *gorm.DB) CompetitionRepository {
@@ -195,3 +201,91 @@ func (cr *CompetitionRepositoryImpl) SearchCompetition(limit int, offset int, ke
 
 	return competitions, nil
 }
+
+// GetRegistrantIDsByOrganizer returns the IDs of the users who registered for a
+// competition organized by the given user, either on their own or as a member
+// of a registered team.
+func (cr *CompetitionRepositoryImpl) GetRegistrantIDsByOrganizer(organizerID uint) ([]uint, error) {
+	var registrantIDs []uint
+	result := cr.db.Raw(`SELECT competition_registrations.user_id FROM competition_registrations
+		JOIN competitions ON competitions.id = competition_registrations.competition_id
+		WHERE competitions.user_id = ? AND competition_registrations.user_id <> 0
+		UNION
+		SELECT team_members.user_id FROM team_members
+		JOIN competition_registrations ON competition_registrations.team_id = team_members.team_id
+		JOIN competitions ON competitions.id = competition_registrations.competition_id
+		WHERE competitions.user_id = ?`, organizerID, organizerID).Scan(&registrantIDs)
+	if result.Error != nil {
+		return []uint{}, result.Error
+	}
+
+	return registrantIDs, nil
+}
+
+// PublishCompetitionResults replaces the results of a competition, together
+// with the achievements they credit, and sets when they become visible.
+func (cr *CompetitionRepositoryImpl) PublishCompetitionResults(competitionID uint, publishAt time.Time, results []entity.CompetitionResult) error {
+	return cr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("competition_result_id IN (?)", tx.Model(&entity.CompetitionResult{}).Select("id").Where("competition_id = ?", competitionID)).Delete(&entity.Achievement{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Where("competition_id = ?", competitionID).Delete(&entity.CompetitionResult{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Omit("Competition", "CompetitionRegistration").Create(&results)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Model(&entity.Competition{}).Where("id = ?", competitionID).Update("results_publish_at", publishAt)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected != 1 {
+			return errors.New("no rows affected")
+		}
+
+		return nil
+	})
+}
+
+func (cr *CompetitionRepositoryImpl) GetCompetitionResults(competitionID uint) ([]entity.CompetitionResult, error) {
+	var results []entity.CompetitionResult
+	result := cr.db.Preload("CompetitionRegistration.Team").Preload("CompetitionRegistration.User").Where("competition_id = ?", competitionID).Order("`rank`, id").Find(&results)
+	if result.Error != nil {
+		return []entity.CompetitionResult{}, result.Error
+	}
+
+	return results, nil
+}
+
+// GetAchievementsByUserID returns the achievements of a user whose results were
+// published before the given time, most recent first.
+func (cr *CompetitionRepositoryImpl) GetAchievementsByUserID(userID uint, publishedBefore time.Time) ([]entity.Achievement, error) {
+	var achievements []entity.Achievement
+	publishedResults := cr.db.Model(&entity.CompetitionResult{}).Select("competition_results.id").Joins("JOIN competitions ON competitions.id = competition_results.competition_id").Where("competitions.results_publish_at <= ?", publishedBefore)
+	result := cr.db.Preload("CompetitionResult.Competition").Preload("CompetitionResult.CompetitionRegistration.Team").Where("user_id = ? AND competition_result_id IN (?)", userID, publishedResults).Order("id DESC").Find(&achievements)
+	if result.Error != nil {
+		return []entity.Achievement{}, result.Error
+	}
+
+	return achievements, nil
+}
+
+func (cr *CompetitionRepositoryImpl) UpdateCompetitionBanner(id uint, bannerKey string) error {
+	result := cr.db.Model(&entity.Competition{}).Where("id = ?", id).Update("banner_key", bannerKey)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
@@ -30,7 +30,7 @@ func TestCreateCompetition(t *testing.T) {
 	defer mockedDB.Close()
 
 	mockObj.ExpectBegin()
-	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `competitions` (`name`,`description`,`contact_person`,`is_team`,`is_the_same_institution`,`registration_period_status`,`team_capacity`,`level`,`created_at`,`updated_at`,`user_id`) VALUES (?,?,?,?,?,?,?,?,?,?,?)")).WithArgs("Technoscape Hackathon 2022", "Hackathon dengan peserta sebanyak 4 orang per tim", "081239990128", 1, 1, 0, 4, "University Student", utils.AnyTime{}, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `competitions` (`name`,`description`,`contact_person`,`is_team`,`is_the_same_institution`,`registration_period_status`,`team_capacity`,`level`,`banner_key`,`results_publish_at`,`created_at`,`updated_at`,`user_id`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)")).WithArgs("Technoscape Hackathon 2022", "Hackathon dengan peserta sebanyak 4 orang per tim", "081239990128", 1, 1, 0, 4, "University Student", "", nil, utils.AnyTime{}, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(1, 1))
 	mockObj.ExpectCommit()
 
 	err = compRepo.CreateCompetition(&entity.Competition{
@@ -62,7 +62,7 @@ func TestCreateCompetitionUnexpectedDBError(t *testing.T) {
 	defer mockedDB.Close()
 
 	mockObj.ExpectBegin()
-	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `competitions` (`name`,`description`,`contact_person`,`is_team`,`is_the_same_institution`,`registration_period_status`,`team_capacity`,`level`,`created_at`,`updated_at`,`user_id`) VALUES (?,?,?,?,?,?,?,?,?,?,?)")).WithArgs("Technoscape Hackathon 2022", "Hackathon dengan peserta sebanyak 4 orang per tim", "081239990128", 1, 1, 0, 4, "University Student", utils.AnyTime{}, utils.AnyTime{}, 1).WillReturnError(errors.New("unexpected DB error"))
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `competitions` (`name`,`description`,`contact_person`,`is_team`,`is_the_same_institution`,`registration_period_status`,`team_capacity`,`level`,`banner_key`,`results_publish_at`,`created_at`,`updated_at`,`user_id`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)")).WithArgs("Technoscape Hackathon 2022", "Hackathon dengan peserta sebanyak 4 orang per tim", "081239990128", 1, 1, 0, 4, "University Student", "", nil, utils.AnyTime{}, utils.AnyTime{}, 1).WillReturnError(errors.New("unexpected DB error"))
 	mockObj.ExpectCommit()
 
 	err = compRepo.CreateCompetition(&entity.Competition{
@@ -234,3 +234,142 @@ func TestUpdateCompetitionNoAffectedRows(t *testing.T) {
 // func TestRegister(t *testing.T) {
 
 // }
+
+func TestGetRegistrantIDsByOrganizer(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	compRepo := CreateNewCompetitionRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"user_id"}).AddRow(4).AddRow(5)
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT competition_registrations.user_id FROM competition_registrations")).WithArgs(1, 1).WillReturnRows(rows)
+
+		registrantIDs, err := compRepo.GetRegistrantIDsByOrganizer(1)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{4, 5}, registrantIDs)
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT competition_registrations.user_id FROM competition_registrations")).WithArgs(1, 1).WillReturnError(errors.New("unexpected error"))
+
+		_, err := compRepo.GetRegistrantIDsByOrganizer(1)
+		assert.Error(t, err)
+	})
+}
+
+func TestPublishCompetitionResults(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	compRepo := CreateNewCompetitionRepository(db)
+
+	defer mockedDB.Close()
+
+	publishAt := time.Now().Add(24 * time.Hour)
+	results := []entity.CompetitionResult{
+		{CompetitionID: 1, CompetitionRegistrationID: 4, Rank: 1, AwardTitle: "Champion", Achievements: []entity.Achievement{{UserID: 2}, {UserID: 3}}},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `achievements` WHERE competition_result_id IN (SELECT `id` FROM `competition_results` WHERE competition_id = ?)")).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `competition_results` WHERE competition_id = ?")).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `competition_results` (`competition_id`,`competition_registration_id`,`rank`,`award_title`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?)")).WithArgs(1, 4, 1, "Champion", utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(7, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `achievements` (`competition_result_id`,`user_id`,`created_at`) VALUES (?,?,?),(?,?,?)")).WithArgs(7, 2, utils.AnyTime{}, 7, 3, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(1, 2))
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `competitions` SET `results_publish_at`=?,`updated_at`=? WHERE id = ?")).WithArgs(publishAt, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		err := compRepo.PublishCompetitionResults(1, publishAt, results)
+		assert.NoError(t, err)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `achievements`")).WithArgs(1).WillReturnError(errors.New("unexpected error"))
+		mockObj.ExpectRollback()
+
+		err := compRepo.PublishCompetitionResults(1, publishAt, results)
+		assert.Error(t, err)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+}
+
+func TestGetAchievementsByUserID(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	compRepo := CreateNewCompetitionRepository(db)
+
+	defer mockedDB.Close()
+
+	now := time.Now()
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `achievements` WHERE user_id = ? AND competition_result_id IN (SELECT competition_results.id FROM `competition_results` JOIN competitions ON competitions.id = competition_results.competition_id WHERE competitions.results_publish_at <= ?) ORDER BY id DESC")).WithArgs(2, now).WillReturnRows(sqlmock.NewRows([]string{"id", "competition_result_id", "user_id"}).AddRow(1, 7, 2))
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `competition_results` WHERE `competition_results`.`id` = ?")).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"id", "competition_id", "competition_registration_id", "rank", "award_title"}).AddRow(7, 1, 4, 1, "Champion"))
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `competitions` WHERE `competitions`.`id` = ?")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "results_publish_at"}).AddRow(1, "Technoscape Hackathon 2022", now))
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `competition_registrations` WHERE `competition_registrations`.`id` = ?")).WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "competition_id"}).AddRow(4, 0, 1))
+
+	achievements, err := compRepo.GetAchievementsByUserID(2, now)
+	assert.NoError(t, err)
+	assert.Len(t, achievements, 1)
+	assert.Equal(t, "Champion", achievements[0].CompetitionResult.AwardTitle)
+	assert.Equal(t, "Technoscape Hackathon 2022", achievements[0].CompetitionResult.Competition.Name)
+}
+
+func TestUpdateCompetitionBanner(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	compRepo := CreateNewCompetitionRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `competitions` SET `banner_key`=?,`updated_at`=? WHERE id = ?")).WithArgs("banners/1/a.png", utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		err := compRepo.UpdateCompetitionBanner(1, "banners/1/a.png")
+		assert.NoError(t, err)
+	})
+
+	t.Run("no-rows-affected", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `competitions` SET `banner_key`=?,`updated_at`=? WHERE id = ?")).WithArgs("", utils.AnyTime{}, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectCommit()
+
+		err := compRepo.UpdateCompetitionBanner(9, "")
+		assert.EqualError(t, err, "no rows affected")
+	})
+}
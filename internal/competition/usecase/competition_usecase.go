@@ -2,16 +2,25 @@ package usecase
 
 import (
 	"errors"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/alimikegami/compnouron/internal/competition/dto"
 	"github.com/alimikegami/compnouron/internal/competition/entity"
 	"github.com/alimikegami/compnouron/internal/competition/repository"
+	"github.com/alimikegami/compnouron/internal/media"
+	"github.com/alimikegami/compnouron/internal/policy"
+	"github.com/alimikegami/compnouron/internal/privacy"
+	teamEntity "github.com/alimikegami/compnouron/internal/team/entity"
 	teamRepo "github.com/alimikegami/compnouron/internal/team/repository"
 )
 
 type CompetitionUseCaseImpl struct {
 	ur repository.CompetitionRepository
 	tr teamRepo.TeamRepository
+	p  policy.Policy
+	mu media.Uploader
 }
 
 type CompetitionUseCase interface {
@@ -28,13 +37,22 @@ type CompetitionUseCase interface {
 	GetCompetitionRegistration(id uint, userID uint) (interface{}, error)
 	GetAcceptedCompetitionParticipants(id uint, userID uint) (interface{}, error)
 	SearchCompetition(limit int, offset int, keyword string) ([]dto.CompetitionResponse, error)
+	PublishResults(id uint, userID uint, request dto.CompetitionResultsRequest) error
+	GetCompetitionResults(id uint, viewerID uint) ([]dto.CompetitionResultResponse, error)
+	UploadCompetitionBanner(id uint, userID uint, data []byte) (*media.Image, error)
+	DeleteCompetitionBanner(id uint, userID uint) error
 }
 
-func CreateNewCompetitionUseCase(ur repository.CompetitionRepository, tr teamRepo.TeamRepository) CompetitionUseCase {
-	return &CompetitionUseCaseImpl{ur: ur, tr: tr}
+func CreateNewCompetitionUseCase(ur repository.CompetitionRepository, tr teamRepo.TeamRepository, p policy.Policy, mu media.Uploader) CompetitionUseCase {
+	return &CompetitionUseCaseImpl{ur: ur, tr: tr, p: p, mu: mu}
 }
 
 func (cuc *CompetitionUseCaseImpl) CreateCompetition(competition dto.CompetitionRequest, userID uint) error {
+	err := cuc.p.CanCreateCompetition(userID)
+	if err != nil {
+		return err
+	}
+
 	competitionEntity := &entity.Competition{
 		Name:                     competition.Name,
 		Description:              competition.Description,
@@ -46,7 +64,7 @@ func (cuc *CompetitionUseCaseImpl) CreateCompetition(competition dto.Competition
 		UserID:                   userID,
 		RegistrationPeriodStatus: 0,
 	}
-	err := cuc.ur.CreateCompetition(competitionEntity)
+	err = cuc.ur.CreateCompetition(competitionEntity)
 	return err
 }
 
@@ -66,6 +84,7 @@ func (cuc *CompetitionUseCaseImpl) GetCompetitionByID(competitionID uint) (dto.D
 		TeamCapacity:         competitionEntity.TeamCapacity,
 		Level:                competitionEntity.Level,
 		UserID:               competitionEntity.UserID,
+		Banner:               cuc.mu.Image(competitionEntity.BannerKey),
 	}, nil
 }
 
@@ -75,8 +94,9 @@ func (cuc *CompetitionUseCaseImpl) DeleteCompetition(competitionID uint, userID
 		return err
 	}
 
-	if competition.UserID != userID {
-		return errors.New("action unauthorized")
+	err = cuc.p.CanManageCompetition(userID, competition.UserID)
+	if err != nil {
+		return err
 	}
 	err = cuc.ur.DeleteCompetition(competitionID)
 	if err != nil {
@@ -102,8 +122,9 @@ func (cuc *CompetitionUseCaseImpl) UpdateCompetition(competition dto.Competition
 	if err != nil {
 		return err
 	}
-	if competitionData.ID != userID {
-		return errors.New("action unauthorized")
+	err = cuc.p.CanManageCompetition(userID, competitionData.UserID)
+	if err != nil {
+		return err
 	}
 
 	err = cuc.ur.UpdateCompetition(*competitionEntity)
@@ -148,34 +169,29 @@ func (cuc *CompetitionUseCaseImpl) Register(competitionRegistration dto.Competit
 		return errors.New("registration period is over")
 	}
 
-	flag := false
-
 	if comp.IsTeam == 1 {
-		team, err := cuc.tr.GetTeamsByUserID(userID)
+		err = cuc.p.CanManageTeam(userID, competitionRegistration.TeamID)
 		if err != nil {
-			return errors.New("internal error")
+			return err
 		}
-
-		for _, t := range team {
-			if t.ID == competitionRegistration.TeamID {
-				for _, member := range t.TeamMembers {
-					if member.UserID == userID && member.IsLeader == 1 {
-						flag = true
-					}
-				}
-			}
-		}
-
-	}
-
-	if flag == false {
-		return errors.New("you are not this team's member")
 	}
 
 	if userID == comp.UserID {
 		return errors.New("can't register to your own competition")
 	}
 
+	if comp.IsTeam == 1 && comp.IsTheSameInstitution == 1 {
+		team, err := cuc.tr.GetTeamByID(competitionRegistration.TeamID)
+		if err != nil {
+			return errors.New("internal error")
+		}
+
+		err = requireSameInstitution(team.TeamMembers)
+		if err != nil {
+			return err
+		}
+	}
+
 	compReg, err := cuc.ur.GetCompetitionRegistrationByUserID(userID)
 	if err != nil {
 		return errors.New("internal server error")
@@ -199,14 +215,33 @@ func (cuc *CompetitionUseCaseImpl) Register(competitionRegistration dto.Competit
 	return err
 }
 
+// requireSameInstitution checks that every member has a verified institution
+// affiliation and that all of them are affiliated with the same institution.
+func requireSameInstitution(members []teamEntity.TeamMember) error {
+	var institutionID uint
+	for _, member := range members {
+		if member.User.InstitutionID == nil {
+			return errors.New("every team member must have a verified institution")
+		}
+
+		if institutionID != 0 && *member.User.InstitutionID != institutionID {
+			return errors.New("team members must come from the same institution")
+		}
+		institutionID = *member.User.InstitutionID
+	}
+
+	return nil
+}
+
 func (cuc *CompetitionUseCaseImpl) RejectCompetitionRegistration(id uint, userID uint) error {
 	competition, err := cuc.ur.GetCompetitionByID(id)
 	if err != nil {
 		return err
 	}
 
-	if competition.UserID != userID {
-		return errors.New("action unauthorized")
+	err = cuc.p.CanManageCompetition(userID, competition.UserID)
+	if err != nil {
+		return err
 	}
 	err = cuc.ur.RejectCompetitionRegistration(id)
 
@@ -219,8 +254,9 @@ func (cuc *CompetitionUseCaseImpl) AcceptCompetitionRegistration(id uint, userID
 		return err
 	}
 
-	if competition.UserID != userID {
-		return errors.New("action unauthorized")
+	err = cuc.p.CanManageCompetition(userID, competition.UserID)
+	if err != nil {
+		return err
 	}
 
 	err = cuc.ur.AcceptCompetitionRegistration(id)
@@ -234,8 +270,9 @@ func (cuc *CompetitionUseCaseImpl) OpenCompetitionRegistrationPeriod(id uint, us
 		return err
 	}
 
-	if competition.UserID != userID {
-		return errors.New("action unauthorized")
+	err = cuc.p.CanManageCompetition(userID, competition.UserID)
+	if err != nil {
+		return err
 	}
 
 	err = cuc.ur.OpenCompetitionRegistrationPeriod(id)
@@ -249,8 +286,9 @@ func (cuc *CompetitionUseCaseImpl) CloseCompetitionRegistrationPeriod(id uint, u
 		return err
 	}
 
-	if competition.UserID != userID {
-		return errors.New("action unauthorized")
+	err = cuc.p.CanManageCompetition(userID, competition.UserID)
+	if err != nil {
+		return err
 	}
 
 	err = cuc.ur.CloseCompetitionRegistrationPeriod(id)
@@ -264,8 +302,9 @@ func (cuc *CompetitionUseCaseImpl) GetCompetitionRegistration(id uint, userID ui
 		return nil, err
 	}
 
-	if comp.UserID != userID {
-		return nil, errors.New("action unauthorized")
+	err = cuc.p.CanManageCompetition(userID, comp.UserID)
+	if err != nil {
+		return nil, err
 	}
 	competition, err := cuc.ur.GetCompetitionRegistration(id)
 
@@ -290,12 +329,13 @@ func (cuc *CompetitionUseCaseImpl) GetCompetitionRegistration(id uint, userID ui
 
 	var competitionRegistrationsResponse []dto.IndividualCompetitionRegistrationResponse
 	for _, competitionRegistration := range competition.CompetitionRegistrations {
+		relationship := registrantRelationship(userID, comp.UserID, competitionRegistration.User.ID)
 		competitionRegistrationsResponse = append(competitionRegistrationsResponse, dto.IndividualCompetitionRegistrationResponse{
 			ID:                competitionRegistration.ID,
 			UserID:            competitionRegistration.User.ID,
 			UserName:          competitionRegistration.User.Name,
-			PhoneNumber:       competitionRegistration.User.PhoneNumber,
-			Email:             competitionRegistration.User.Email,
+			PhoneNumber:       relationship.PhoneNumber(competitionRegistration.User),
+			Email:             relationship.Email(competitionRegistration.User),
 			SchoolInstitution: competitionRegistration.User.SchoolInstitution,
 			CompetitionID:     competitionRegistration.CompetitionID,
 			AcceptanceStatus:  competitionRegistration.AcceptanceStatus,
@@ -311,8 +351,9 @@ func (cuc *CompetitionUseCaseImpl) GetAcceptedCompetitionParticipants(id uint, u
 		return nil, err
 	}
 
-	if comp.UserID != userID {
-		return nil, errors.New("action unauthorized")
+	err = cuc.p.CanManageCompetition(userID, comp.UserID)
+	if err != nil {
+		return nil, err
 	}
 	competition, err := cuc.ur.GetAcceptedCompetitionParticipants(id)
 
@@ -337,12 +378,13 @@ func (cuc *CompetitionUseCaseImpl) GetAcceptedCompetitionParticipants(id uint, u
 
 	var competitionRegistrationsResponse []dto.IndividualCompetitionRegistrationResponse
 	for _, competitionRegistration := range competition.CompetitionRegistrations {
+		relationship := registrantRelationship(userID, comp.UserID, competitionRegistration.User.ID)
 		competitionRegistrationsResponse = append(competitionRegistrationsResponse, dto.IndividualCompetitionRegistrationResponse{
 			ID:                competitionRegistration.ID,
 			UserID:            competitionRegistration.User.ID,
 			UserName:          competitionRegistration.User.Name,
-			PhoneNumber:       competitionRegistration.User.PhoneNumber,
-			Email:             competitionRegistration.User.Email,
+			PhoneNumber:       relationship.PhoneNumber(competitionRegistration.User),
+			Email:             relationship.Email(competitionRegistration.User),
 			SchoolInstitution: competitionRegistration.User.SchoolInstitution,
 			CompetitionID:     competitionRegistration.CompetitionID,
 			AcceptanceStatus:  competitionRegistration.AcceptanceStatus,
@@ -352,6 +394,17 @@ func (cuc *CompetitionUseCaseImpl) GetAcceptedCompetitionParticipants(id uint, u
 	return competitionRegistrationsResponse, nil
 }
 
+// registrantRelationship is how a viewer who passed CanManageCompetition relates
+// to a registrant of that competition. The viewer is either its organizer or an
+// admin.
+func registrantRelationship(viewerID uint, organizerID uint, registrantID uint) privacy.Relationship {
+	return privacy.Relationship{
+		Self:      viewerID == registrantID,
+		Admin:     viewerID != organizerID,
+		Organizer: viewerID == organizerID,
+	}
+}
+
 func (cuc *CompetitionUseCaseImpl) SearchCompetition(limit int, offset int, keyword string) ([]dto.CompetitionResponse, error) {
 	var competitionsResponse []dto.CompetitionResponse
 	competitions, err := cuc.ur.SearchCompetition(limit, offset, keyword)
@@ -371,3 +424,189 @@ func (cuc *CompetitionUseCaseImpl) SearchCompetition(limit int, offset int, keyw
 
 	return competitionsResponse, nil
 }
+
+// PublishResults replaces the results of a competition. Only accepted
+// registrations can be ranked, and every member of a ranked team is credited
+// with an achievement.
+func (cuc *CompetitionUseCaseImpl) PublishResults(id uint, userID uint, request dto.CompetitionResultsRequest) error {
+	if len(request.Results) == 0 {
+		return errors.New("fill the results")
+	}
+
+	competition, err := cuc.ur.GetCompetitionByID(id)
+	if err != nil {
+		return err
+	}
+
+	err = cuc.p.CanManageCompetition(userID, competition.UserID)
+	if err != nil {
+		return err
+	}
+
+	participants, err := cuc.ur.GetAcceptedCompetitionParticipants(id)
+	if err != nil {
+		return err
+	}
+
+	registrations := map[uint]entity.CompetitionRegistration{}
+	for _, registration := range participants.CompetitionRegistrations {
+		registrations[registration.ID] = registration
+	}
+
+	var results []entity.CompetitionResult
+	ranked := map[uint]bool{}
+	for _, resultRequest := range request.Results {
+		if resultRequest.Rank == 0 {
+			return errors.New("invalid rank")
+		}
+
+		awardTitle := strings.TrimSpace(resultRequest.AwardTitle)
+		if awardTitle == "" {
+			return errors.New("fill the award title")
+		}
+
+		registration, ok := registrations[resultRequest.RegistrationID]
+		if !ok {
+			return errors.New("registration not accepted")
+		}
+
+		if ranked[registration.ID] {
+			return errors.New("duplicate registration")
+		}
+		ranked[registration.ID] = true
+
+		achievements, err := cuc.achievementsFor(registration)
+		if err != nil {
+			return err
+		}
+
+		results = append(results, entity.CompetitionResult{
+			CompetitionID:             id,
+			CompetitionRegistrationID: registration.ID,
+			Rank:                      resultRequest.Rank,
+			AwardTitle:                awardTitle,
+			Achievements:              achievements,
+		})
+	}
+
+	publishAt := time.Now()
+	if request.PublishAt != nil {
+		publishAt = *request.PublishAt
+	}
+
+	return cuc.ur.PublishCompetitionResults(id, publishAt, results)
+}
+
+// achievementsFor credits a result to the registrant, or to every current
+// member of the registered team.
+func (cuc *CompetitionUseCaseImpl) achievementsFor(registration entity.CompetitionRegistration) ([]entity.Achievement, error) {
+	if registration.TeamID == 0 {
+		return []entity.Achievement{{UserID: registration.UserID}}, nil
+	}
+
+	team, err := cuc.tr.GetTeamByID(registration.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	var achievements []entity.Achievement
+	for _, member := range team.TeamMembers {
+		achievements = append(achievements, entity.Achievement{UserID: member.UserID})
+	}
+
+	return achievements, nil
+}
+
+// GetCompetitionResults returns the results of a competition ordered by rank.
+// While the results are under embargo only the organizer and admins can see
+// them. viewerID is 0 for visitors who aren't logged in.
+func (cuc *CompetitionUseCaseImpl) GetCompetitionResults(id uint, viewerID uint) ([]dto.CompetitionResultResponse, error) {
+	competition, err := cuc.ur.GetCompetitionByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if competition.ResultsPublishAt == nil || competition.ResultsPublishAt.After(time.Now()) {
+		if viewerID == 0 || cuc.p.CanManageCompetition(viewerID, competition.UserID) != nil {
+			return nil, errors.New("results not published")
+		}
+	}
+
+	results, err := cuc.ur.GetCompetitionResults(id)
+	if err != nil {
+		return nil, err
+	}
+
+	resultsResponse := []dto.CompetitionResultResponse{}
+	for _, result := range results {
+		resultResponse := dto.CompetitionResultResponse{
+			RegistrationID: result.CompetitionRegistrationID,
+			Rank:           result.Rank,
+			AwardTitle:     result.AwardTitle,
+		}
+		if result.CompetitionRegistration.TeamID != 0 {
+			resultResponse.TeamID = result.CompetitionRegistration.TeamID
+			resultResponse.TeamName = result.CompetitionRegistration.Team.Name
+		} else {
+			resultResponse.UserID = result.CompetitionRegistration.UserID
+			resultResponse.UserName = result.CompetitionRegistration.User.Name
+		}
+
+		resultsResponse = append(resultsResponse, resultResponse)
+	}
+
+	return resultsResponse, nil
+}
+
+func (cuc *CompetitionUseCaseImpl) UploadCompetitionBanner(id uint, userID uint, data []byte) (*media.Image, error) {
+	competition, err := cuc.ur.GetCompetitionByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cuc.p.CanManageCompetition(userID, competition.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := cuc.mu.Upload("banners/"+strconv.FormatUint(uint64(id), 10), data, media.CompetitionBannerSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cuc.ur.UpdateCompetitionBanner(id, key)
+	if err != nil {
+		cuc.mu.Delete(key)
+		return nil, err
+	}
+
+	// the new banner is already saved, a leftover old file only wastes space
+	if competition.BannerKey != "" {
+		cuc.mu.Delete(competition.BannerKey)
+	}
+
+	return cuc.mu.Image(key), nil
+}
+
+func (cuc *CompetitionUseCaseImpl) DeleteCompetitionBanner(id uint, userID uint) error {
+	competition, err := cuc.ur.GetCompetitionByID(id)
+	if err != nil {
+		return err
+	}
+
+	err = cuc.p.CanManageCompetition(userID, competition.UserID)
+	if err != nil {
+		return err
+	}
+
+	if competition.BannerKey == "" {
+		return errors.New("banner not found")
+	}
+
+	err = cuc.ur.UpdateCompetitionBanner(id, "")
+	if err != nil {
+		return err
+	}
+
+	return cuc.mu.Delete(competition.BannerKey)
+}
@@ -2,18 +2,27 @@ package usecase
 
 import (
 	"errors"
+	"github.com/alimikegami/compnouron/internal/policy"
+	"github.com/alimikegami/compnouron/pkg/utils"
 	"testing"
+	"time"
 
 	"github.com/alimikegami/compnouron/internal/competition/dto"
 	"github.com/alimikegami/compnouron/internal/competition/entity"
+	"github.com/alimikegami/compnouron/internal/media"
 	mockRepo "github.com/alimikegami/compnouron/internal/mocks/competition/repository"
+	mediaMocks "github.com/alimikegami/compnouron/internal/mocks/media"
 	teamRepo "github.com/alimikegami/compnouron/internal/mocks/team/repository"
+	userRepo "github.com/alimikegami/compnouron/internal/mocks/user/repository"
+	teamEntity "github.com/alimikegami/compnouron/internal/team/entity"
+	userEntity "github.com/alimikegami/compnouron/internal/user/entity"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestDeleteCompetition(t *testing.T) {
 	mockRepo := mockRepo.NewCompetitionRepository(t)
 	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
 	t.Run("success", func(t *testing.T) {
 		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{
 			ID:                       1,
@@ -28,7 +37,7 @@ func TestDeleteCompetition(t *testing.T) {
 			UserID:                   3,
 		}, nil).Once()
 		mockRepo.On("DeleteCompetition", uint(1)).Return(nil).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.DeleteCompetition(uint(1), uint(3))
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
@@ -48,13 +57,14 @@ func TestDeleteCompetition(t *testing.T) {
 			UserID:                   3,
 		}, nil).Once()
 		mockRepo.On("DeleteCompetition", uint(1)).Return(errors.New("errors db")).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.DeleteCompetition(uint(1), uint(3))
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("action-unauthorized", func(t *testing.T) {
+		userRepository.On("GetUserByID", uint(3)).Return(userEntity.User{ID: 3, Role: utils.RoleStudent}, nil).Once()
 		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{
 			ID:                       1,
 			Name:                     "technoscape",
@@ -67,12 +77,26 @@ func TestDeleteCompetition(t *testing.T) {
 			Level:                    "Uni student",
 			UserID:                   2,
 		}, nil).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.DeleteCompetition(uint(1), uint(3))
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("admin-moderation", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{
+			ID:     1,
+			Name:   "technoscape",
+			UserID: 2,
+		}, nil).Once()
+		userRepository.On("GetUserByID", uint(4)).Return(userEntity.User{ID: 4, Role: utils.RoleAdmin}, nil).Once()
+		mockRepo.On("DeleteCompetition", uint(1)).Return(nil).Once()
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
+		err := testUseCase.DeleteCompetition(uint(1), uint(4))
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("unexpected-get-competition-error", func(t *testing.T) {
 		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{
 			ID:                       1,
@@ -86,7 +110,7 @@ func TestDeleteCompetition(t *testing.T) {
 			Level:                    "Uni student",
 			UserID:                   3,
 		}, errors.New("errors")).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.DeleteCompetition(uint(1), uint(3))
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
@@ -96,6 +120,7 @@ func TestDeleteCompetition(t *testing.T) {
 func TestOpenCompetitionRegistrationPeriod(t *testing.T) {
 	mockRepo := mockRepo.NewCompetitionRepository(t)
 	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
 		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{
@@ -111,7 +136,7 @@ func TestOpenCompetitionRegistrationPeriod(t *testing.T) {
 			UserID:                   3,
 		}, nil).Once()
 		mockRepo.On("OpenCompetitionRegistrationPeriod", uint(1)).Return(nil).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.OpenCompetitionRegistrationPeriod(uint(1), uint(3))
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
@@ -131,13 +156,14 @@ func TestOpenCompetitionRegistrationPeriod(t *testing.T) {
 			UserID:                   3,
 		}, nil).Once()
 		mockRepo.On("OpenCompetitionRegistrationPeriod", uint(1)).Return(errors.New("errors db")).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.OpenCompetitionRegistrationPeriod(uint(1), uint(3))
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("action-unauthorized", func(t *testing.T) {
+		userRepository.On("GetUserByID", uint(3)).Return(userEntity.User{ID: 3, Role: utils.RoleStudent}, nil).Once()
 		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{
 			ID:                       1,
 			Name:                     "technoscape",
@@ -150,7 +176,7 @@ func TestOpenCompetitionRegistrationPeriod(t *testing.T) {
 			Level:                    "Uni student",
 			UserID:                   2,
 		}, nil).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.OpenCompetitionRegistrationPeriod(uint(1), uint(3))
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
@@ -169,7 +195,7 @@ func TestOpenCompetitionRegistrationPeriod(t *testing.T) {
 			Level:                    "Uni student",
 			UserID:                   3,
 		}, errors.New("errors")).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.OpenCompetitionRegistrationPeriod(uint(1), uint(3))
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
@@ -178,6 +204,7 @@ func TestOpenCompetitionRegistrationPeriod(t *testing.T) {
 
 func TestCloseCompetitionRegistrationPeriod(t *testing.T) {
 	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
 
 	mockRepo := mockRepo.NewCompetitionRepository(t)
 	t.Run("success", func(t *testing.T) {
@@ -194,7 +221,7 @@ func TestCloseCompetitionRegistrationPeriod(t *testing.T) {
 			UserID:                   3,
 		}, nil).Once()
 		mockRepo.On("CloseCompetitionRegistrationPeriod", uint(1)).Return(nil).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.CloseCompetitionRegistrationPeriod(uint(1), uint(3))
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
@@ -214,13 +241,14 @@ func TestCloseCompetitionRegistrationPeriod(t *testing.T) {
 			UserID:                   3,
 		}, nil).Once()
 		mockRepo.On("CloseCompetitionRegistrationPeriod", uint(1)).Return(errors.New("errors db")).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.CloseCompetitionRegistrationPeriod(uint(1), uint(3))
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("action-unauthorized", func(t *testing.T) {
+		userRepository.On("GetUserByID", uint(3)).Return(userEntity.User{ID: 3, Role: utils.RoleStudent}, nil).Once()
 		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{
 			ID:                       1,
 			Name:                     "technoscape",
@@ -233,7 +261,7 @@ func TestCloseCompetitionRegistrationPeriod(t *testing.T) {
 			Level:                    "Uni student",
 			UserID:                   2,
 		}, nil).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.CloseCompetitionRegistrationPeriod(uint(1), uint(3))
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
@@ -252,7 +280,7 @@ func TestCloseCompetitionRegistrationPeriod(t *testing.T) {
 			Level:                    "Uni student",
 			UserID:                   3,
 		}, errors.New("errors")).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.CloseCompetitionRegistrationPeriod(uint(1), uint(3))
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
@@ -261,6 +289,7 @@ func TestCloseCompetitionRegistrationPeriod(t *testing.T) {
 
 func TestAcceptCompetitionRegistration(t *testing.T) {
 	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
 
 	mockRepo := mockRepo.NewCompetitionRepository(t)
 	t.Run("success", func(t *testing.T) {
@@ -277,7 +306,7 @@ func TestAcceptCompetitionRegistration(t *testing.T) {
 			UserID:                   3,
 		}, nil).Once()
 		mockRepo.On("AcceptCompetitionRegistration", uint(1)).Return(nil).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.AcceptCompetitionRegistration(uint(1), uint(3))
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
@@ -297,13 +326,14 @@ func TestAcceptCompetitionRegistration(t *testing.T) {
 			UserID:                   3,
 		}, nil).Once()
 		mockRepo.On("AcceptCompetitionRegistration", uint(1)).Return(errors.New("errors db")).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.AcceptCompetitionRegistration(uint(1), uint(3))
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("action-unauthorized", func(t *testing.T) {
+		userRepository.On("GetUserByID", uint(3)).Return(userEntity.User{ID: 3, Role: utils.RoleStudent}, nil).Once()
 		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{
 			ID:                       1,
 			Name:                     "technoscape",
@@ -316,7 +346,7 @@ func TestAcceptCompetitionRegistration(t *testing.T) {
 			Level:                    "Uni student",
 			UserID:                   2,
 		}, nil).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.AcceptCompetitionRegistration(uint(1), uint(3))
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
@@ -335,7 +365,7 @@ func TestAcceptCompetitionRegistration(t *testing.T) {
 			Level:                    "Uni student",
 			UserID:                   3,
 		}, errors.New("errors")).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.AcceptCompetitionRegistration(uint(1), uint(3))
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
@@ -344,6 +374,7 @@ func TestAcceptCompetitionRegistration(t *testing.T) {
 
 func TestRejectCompetitionRegistration(t *testing.T) {
 	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
 	mockRepo := mockRepo.NewCompetitionRepository(t)
 	t.Run("success", func(t *testing.T) {
 		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{
@@ -359,7 +390,7 @@ func TestRejectCompetitionRegistration(t *testing.T) {
 			UserID:                   3,
 		}, nil).Once()
 		mockRepo.On("RejectCompetitionRegistration", uint(1)).Return(nil).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.RejectCompetitionRegistration(uint(1), uint(3))
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
@@ -379,13 +410,14 @@ func TestRejectCompetitionRegistration(t *testing.T) {
 			UserID:                   3,
 		}, nil).Once()
 		mockRepo.On("RejectCompetitionRegistration", uint(1)).Return(errors.New("errors db")).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.RejectCompetitionRegistration(uint(1), uint(3))
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("action-unauthorized", func(t *testing.T) {
+		userRepository.On("GetUserByID", uint(3)).Return(userEntity.User{ID: 3, Role: utils.RoleStudent}, nil).Once()
 		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{
 			ID:                       1,
 			Name:                     "technoscape",
@@ -398,7 +430,7 @@ func TestRejectCompetitionRegistration(t *testing.T) {
 			Level:                    "Uni student",
 			UserID:                   2,
 		}, nil).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.RejectCompetitionRegistration(uint(1), uint(3))
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
@@ -417,7 +449,7 @@ func TestRejectCompetitionRegistration(t *testing.T) {
 			Level:                    "Uni student",
 			UserID:                   3,
 		}, errors.New("errors")).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.RejectCompetitionRegistration(uint(1), uint(3))
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
@@ -427,7 +459,10 @@ func TestRejectCompetitionRegistration(t *testing.T) {
 func TestCrea(t *testing.T) {
 	mockRepo := mockRepo.NewCompetitionRepository(t)
 	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	verifiedAt := time.Now()
 	t.Run("success", func(t *testing.T) {
+		userRepository.On("GetUserByID", uint(3)).Return(userEntity.User{ID: 3, VerifiedAt: &verifiedAt, Role: utils.RoleOrganizer}, nil).Once()
 		mockRepo.On("CreateCompetition", &entity.Competition{
 			Name:                     "technoscape",
 			Description:              "asdf",
@@ -439,7 +474,7 @@ func TestCrea(t *testing.T) {
 			Level:                    "Uni student",
 			UserID:                   3,
 		}).Return(nil).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.CreateCompetition(dto.CompetitionRequest{
 			Name:                 "technoscape",
 			Description:          "asdf",
@@ -454,6 +489,7 @@ func TestCrea(t *testing.T) {
 	})
 
 	t.Run("error", func(t *testing.T) {
+		userRepository.On("GetUserByID", uint(3)).Return(userEntity.User{ID: 3, VerifiedAt: &verifiedAt, Role: utils.RoleOrganizer}, nil).Once()
 		mockRepo.On("CreateCompetition", &entity.Competition{
 			Name:                     "technoscape",
 			Description:              "asdf",
@@ -465,7 +501,7 @@ func TestCrea(t *testing.T) {
 			Level:                    "Uni student",
 			UserID:                   3,
 		}).Return(errors.New("db error")).Once()
-		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository)
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
 		err := testUseCase.CreateCompetition(dto.CompetitionRequest{
 			Name:                 "technoscape",
 			Description:          "asdf",
@@ -478,4 +514,394 @@ func TestCrea(t *testing.T) {
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("email-not-verified", func(t *testing.T) {
+		userRepository.On("GetUserByID", uint(3)).Return(userEntity.User{ID: 3}, nil).Once()
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
+		err := testUseCase.CreateCompetition(dto.CompetitionRequest{
+			Name: "technoscape",
+		}, uint(3))
+		assert.EqualError(t, err, "email is not verified")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("not-organizer", func(t *testing.T) {
+		userRepository.On("GetUserByID", uint(3)).Return(userEntity.User{ID: 3, VerifiedAt: &verifiedAt, Role: utils.RoleStudent}, nil).Once()
+		testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
+		err := testUseCase.CreateCompetition(dto.CompetitionRequest{
+			Name: "technoscape",
+		}, uint(3))
+		assert.EqualError(t, err, "only organizers can create competitions")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetCompetitionRegistration(t *testing.T) {
+	mockRepo := mockRepo.NewCompetitionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	competition := entity.Competition{
+		ID:     1,
+		Name:   "technoscape",
+		IsTeam: 0,
+		UserID: 3,
+		CompetitionRegistrations: []entity.CompetitionRegistration{
+			{
+				ID:            1,
+				UserID:        4,
+				CompetitionID: 1,
+				User: userEntity.User{
+					ID:                    4,
+					Name:                  "Alim Ikegami",
+					Email:                 "alim@gmail.com",
+					PhoneNumber:           "081111111111",
+					EmailVisibility:       "organizers",
+					PhoneNumberVisibility: "private",
+				},
+			},
+		},
+	}
+	testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
+
+	t.Run("organizer", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(competition, nil).Once()
+		mockRepo.On("GetCompetitionRegistration", uint(1)).Return(competition, nil).Once()
+		res, err := testUseCase.GetCompetitionRegistration(uint(1), uint(3))
+		assert.NoError(t, err)
+		registrations := res.([]dto.IndividualCompetitionRegistrationResponse)
+		assert.Equal(t, "alim@gmail.com", registrations[0].Email)
+		assert.Empty(t, registrations[0].PhoneNumber)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(competition, nil).Once()
+		mockRepo.On("GetCompetitionRegistration", uint(1)).Return(competition, nil).Once()
+		userRepository.On("GetUserByID", uint(9)).Return(userEntity.User{ID: 9, Role: utils.RoleAdmin}, nil).Once()
+		res, err := testUseCase.GetCompetitionRegistration(uint(1), uint(9))
+		assert.NoError(t, err)
+		registrations := res.([]dto.IndividualCompetitionRegistrationResponse)
+		assert.Equal(t, "081111111111", registrations[0].PhoneNumber)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRegisterSameInstitution(t *testing.T) {
+	mockRepo := mockRepo.NewCompetitionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	competition := entity.Competition{
+		ID:                       1,
+		Name:                     "technoscape",
+		IsTeam:                   1,
+		IsTheSameInstitution:     1,
+		RegistrationPeriodStatus: 1,
+		TeamCapacity:             3,
+		UserID:                   3,
+	}
+	udayana := uint(1)
+	gadjahMada := uint(2)
+	teamWith := func(institutionIDs ...*uint) teamEntity.Team {
+		team := teamEntity.Team{ID: 5}
+		for i, institutionID := range institutionIDs {
+			// the first member owns the team
+			role := teamEntity.TeamRoleMember
+			if i == 0 {
+				role = teamEntity.TeamRoleOwner
+			}
+			team.TeamMembers = append(team.TeamMembers, teamEntity.TeamMember{
+				TeamID: 5,
+				UserID: uint(i + 1),
+				Role:   role,
+				User:   userEntity.User{ID: uint(i + 1), InstitutionID: institutionID},
+			})
+		}
+		return team
+	}
+	request := dto.CompetitionRegistrationRequest{TeamID: 5, CompetitionID: 1}
+	testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
+
+	t.Run("success", func(t *testing.T) {
+		team := teamWith(&udayana, &udayana)
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(competition, nil).Once()
+		teamRepository.On("GetTeamMember", uint(5), uint(1)).Return(team.TeamMembers[0], nil).Once()
+		teamRepository.On("GetTeamByID", uint(5)).Return(team, nil).Once()
+		mockRepo.On("GetCompetitionRegistrationByUserID", uint(1)).Return([]entity.CompetitionRegistration{}, nil).Once()
+		mockRepo.On("Register", entity.CompetitionRegistration{UserID: 1, CompetitionID: 1, TeamID: 5}).Return(nil).Once()
+		err := testUseCase.Register(request, uint(1))
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("different-institutions", func(t *testing.T) {
+		team := teamWith(&udayana, &gadjahMada)
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(competition, nil).Once()
+		teamRepository.On("GetTeamMember", uint(5), uint(1)).Return(team.TeamMembers[0], nil).Once()
+		teamRepository.On("GetTeamByID", uint(5)).Return(team, nil).Once()
+		err := testUseCase.Register(request, uint(1))
+		assert.EqualError(t, err, "team members must come from the same institution")
+		teamRepository.AssertExpectations(t)
+	})
+
+	t.Run("co-leader", func(t *testing.T) {
+		team := teamWith(&udayana, &udayana)
+		team.TeamMembers[1].Role = teamEntity.TeamRoleCoLeader
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(competition, nil).Once()
+		teamRepository.On("GetTeamMember", uint(5), uint(2)).Return(team.TeamMembers[1], nil).Once()
+		teamRepository.On("GetTeamByID", uint(5)).Return(team, nil).Once()
+		mockRepo.On("GetCompetitionRegistrationByUserID", uint(2)).Return([]entity.CompetitionRegistration{}, nil).Once()
+		mockRepo.On("Register", entity.CompetitionRegistration{UserID: 2, CompetitionID: 1, TeamID: 5}).Return(nil).Once()
+		err := testUseCase.Register(request, uint(2))
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("plain-member", func(t *testing.T) {
+		team := teamWith(&udayana, &udayana)
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(competition, nil).Once()
+		teamRepository.On("GetTeamMember", uint(5), uint(2)).Return(team.TeamMembers[1], nil).Once()
+		userRepository.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2, Role: utils.RoleStudent}, nil).Once()
+		err := testUseCase.Register(request, uint(2))
+		assert.EqualError(t, err, "action unauthorized")
+	})
+
+	t.Run("not-a-member", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(competition, nil).Once()
+		teamRepository.On("GetTeamMember", uint(5), uint(4)).Return(teamEntity.TeamMember{}, nil).Once()
+		userRepository.On("GetUserByID", uint(4)).Return(userEntity.User{ID: 4, Role: utils.RoleStudent}, nil).Once()
+		err := testUseCase.Register(request, uint(4))
+		assert.EqualError(t, err, "action unauthorized")
+	})
+
+	t.Run("unverified-member", func(t *testing.T) {
+		team := teamWith(&udayana, nil)
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(competition, nil).Once()
+		teamRepository.On("GetTeamMember", uint(5), uint(1)).Return(team.TeamMembers[0], nil).Once()
+		teamRepository.On("GetTeamByID", uint(5)).Return(team, nil).Once()
+		err := testUseCase.Register(request, uint(1))
+		assert.EqualError(t, err, "every team member must have a verified institution")
+		teamRepository.AssertExpectations(t)
+	})
+}
+
+func TestRegisterIndividual(t *testing.T) {
+	mockRepo := mockRepo.NewCompetitionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	competition := entity.Competition{
+		ID:                       2,
+		Name:                     "gemastik",
+		IsTeam:                   0,
+		RegistrationPeriodStatus: 1,
+		UserID:                   3,
+	}
+	request := dto.CompetitionRegistrationRequest{UserID: 1, CompetitionID: 2}
+	testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(2)).Return(competition, nil).Once()
+		mockRepo.On("GetCompetitionRegistrationByUserID", uint(1)).Return([]entity.CompetitionRegistration{}, nil).Once()
+		mockRepo.On("Register", entity.CompetitionRegistration{UserID: 1, CompetitionID: 2}).Return(nil).Once()
+		err := testUseCase.Register(request, uint(1))
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("own-competition", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(2)).Return(competition, nil).Once()
+		err := testUseCase.Register(dto.CompetitionRegistrationRequest{UserID: 3, CompetitionID: 2}, uint(3))
+		assert.EqualError(t, err, "can't register to your own competition")
+	})
+}
+
+func TestPublishResults(t *testing.T) {
+	mockRepo := mockRepo.NewCompetitionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	competition := entity.Competition{ID: 1, IsTeam: 1, UserID: 3}
+	participants := entity.Competition{ID: 1, CompetitionRegistrations: []entity.CompetitionRegistration{
+		{ID: 4, UserID: 5, TeamID: 6, CompetitionID: 1, AcceptanceStatus: 1},
+		{ID: 7, UserID: 8, CompetitionID: 1, AcceptanceStatus: 1},
+	}}
+	testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
+
+	t.Run("success", func(t *testing.T) {
+		publishAt := time.Now().Add(24 * time.Hour)
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(competition, nil).Once()
+		mockRepo.On("GetAcceptedCompetitionParticipants", uint(1)).Return(participants, nil).Once()
+		teamRepository.On("GetTeamByID", uint(6)).Return(teamEntity.Team{ID: 6, TeamMembers: []teamEntity.TeamMember{{UserID: 5, TeamID: 6}, {UserID: 9, TeamID: 6}}}, nil).Once()
+		mockRepo.On("PublishCompetitionResults", uint(1), publishAt, []entity.CompetitionResult{
+			{CompetitionID: 1, CompetitionRegistrationID: 4, Rank: 1, AwardTitle: "Champion", Achievements: []entity.Achievement{{UserID: 5}, {UserID: 9}}},
+			{CompetitionID: 1, CompetitionRegistrationID: 7, Rank: 2, AwardTitle: "Runner-up", Achievements: []entity.Achievement{{UserID: 8}}},
+		}).Return(nil).Once()
+		err := testUseCase.PublishResults(1, 3, dto.CompetitionResultsRequest{
+			Results: []dto.CompetitionResultRequest{
+				{RegistrationID: 4, Rank: 1, AwardTitle: "Champion"},
+				{RegistrationID: 7, Rank: 2, AwardTitle: " Runner-up "},
+			},
+			PublishAt: &publishAt,
+		})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		teamRepository.AssertExpectations(t)
+	})
+
+	t.Run("registration-not-accepted", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(competition, nil).Once()
+		mockRepo.On("GetAcceptedCompetitionParticipants", uint(1)).Return(participants, nil).Once()
+		err := testUseCase.PublishResults(1, 3, dto.CompetitionResultsRequest{
+			Results: []dto.CompetitionResultRequest{{RegistrationID: 10, Rank: 1, AwardTitle: "Champion"}},
+		})
+		assert.EqualError(t, err, "registration not accepted")
+	})
+
+	t.Run("duplicate-registration", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(competition, nil).Once()
+		mockRepo.On("GetAcceptedCompetitionParticipants", uint(1)).Return(participants, nil).Once()
+		err := testUseCase.PublishResults(1, 3, dto.CompetitionResultsRequest{
+			Results: []dto.CompetitionResultRequest{
+				{RegistrationID: 7, Rank: 1, AwardTitle: "Champion"},
+				{RegistrationID: 7, Rank: 2, AwardTitle: "Runner-up"},
+			},
+		})
+		assert.EqualError(t, err, "duplicate registration")
+	})
+
+	t.Run("invalid-rank", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(competition, nil).Once()
+		mockRepo.On("GetAcceptedCompetitionParticipants", uint(1)).Return(participants, nil).Once()
+		err := testUseCase.PublishResults(1, 3, dto.CompetitionResultsRequest{
+			Results: []dto.CompetitionResultRequest{{RegistrationID: 7, AwardTitle: "Champion"}},
+		})
+		assert.EqualError(t, err, "invalid rank")
+	})
+
+	t.Run("empty-results", func(t *testing.T) {
+		err := testUseCase.PublishResults(1, 3, dto.CompetitionResultsRequest{})
+		assert.EqualError(t, err, "fill the results")
+	})
+
+	t.Run("not-organizer", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(competition, nil).Once()
+		userRepository.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2, Role: utils.RoleStudent}, nil).Once()
+		err := testUseCase.PublishResults(1, 2, dto.CompetitionResultsRequest{
+			Results: []dto.CompetitionResultRequest{{RegistrationID: 7, Rank: 1, AwardTitle: "Champion"}},
+		})
+		assert.EqualError(t, err, "action unauthorized")
+	})
+}
+
+func TestGetCompetitionResults(t *testing.T) {
+	mockRepo := mockRepo.NewCompetitionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mediaMocks.NewUploader(t))
+	published := time.Now().Add(-time.Hour)
+	embargoed := time.Now().Add(time.Hour)
+	results := []entity.CompetitionResult{
+		{CompetitionID: 1, CompetitionRegistrationID: 4, Rank: 1, AwardTitle: "Champion", CompetitionRegistration: entity.CompetitionRegistration{ID: 4, UserID: 5, TeamID: 6, Team: teamEntity.Team{ID: 6, Name: "Ikegami"}}},
+		{CompetitionID: 1, CompetitionRegistrationID: 7, Rank: 2, AwardTitle: "Runner-up", CompetitionRegistration: entity.CompetitionRegistration{ID: 7, UserID: 8, User: userEntity.User{ID: 8, Name: "Budi"}}},
+	}
+
+	t.Run("published", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{ID: 1, UserID: 3, ResultsPublishAt: &published}, nil).Once()
+		mockRepo.On("GetCompetitionResults", uint(1)).Return(results, nil).Once()
+		res, err := testUseCase.GetCompetitionResults(1, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []dto.CompetitionResultResponse{
+			{RegistrationID: 4, Rank: 1, AwardTitle: "Champion", TeamID: 6, TeamName: "Ikegami"},
+			{RegistrationID: 7, Rank: 2, AwardTitle: "Runner-up", UserID: 8, UserName: "Budi"},
+		}, res)
+	})
+
+	t.Run("embargoed-for-visitors", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{ID: 1, UserID: 3, ResultsPublishAt: &embargoed}, nil).Once()
+		_, err := testUseCase.GetCompetitionResults(1, 0)
+		assert.EqualError(t, err, "results not published")
+	})
+
+	t.Run("embargoed-for-participants", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{ID: 1, UserID: 3, ResultsPublishAt: &embargoed}, nil).Once()
+		userRepository.On("GetUserByID", uint(8)).Return(userEntity.User{ID: 8, Role: utils.RoleStudent}, nil).Once()
+		_, err := testUseCase.GetCompetitionResults(1, 8)
+		assert.EqualError(t, err, "results not published")
+	})
+
+	t.Run("embargoed-for-organizer", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{ID: 1, UserID: 3, ResultsPublishAt: &embargoed}, nil).Once()
+		mockRepo.On("GetCompetitionResults", uint(1)).Return(results, nil).Once()
+		res, err := testUseCase.GetCompetitionResults(1, 3)
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("not-published", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{ID: 1, UserID: 3}, nil).Once()
+		_, err := testUseCase.GetCompetitionResults(1, 0)
+		assert.EqualError(t, err, "results not published")
+	})
+}
+
+func TestUploadCompetitionBanner(t *testing.T) {
+	mockRepo := mockRepo.NewCompetitionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	mockUploader := mediaMocks.NewUploader(t)
+	testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mockUploader)
+	data := []byte("image")
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{ID: 1, UserID: 3}, nil).Once()
+		mockUploader.On("Upload", "banners/1", data, media.CompetitionBannerSpec).Return("banners/1/new.png", nil).Once()
+		mockRepo.On("UpdateCompetitionBanner", uint(1), "banners/1/new.png").Return(nil).Once()
+		mockUploader.On("Image", "banners/1/new.png").Return(&media.Image{URL: "/uploads/banners/1/new.png"}).Once()
+		res, err := testUseCase.UploadCompetitionBanner(1, 3, data)
+		assert.NoError(t, err)
+		assert.Equal(t, "/uploads/banners/1/new.png", res.URL)
+	})
+
+	t.Run("action-unauthorized", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{ID: 1, UserID: 3}, nil).Once()
+		userRepository.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2, Role: utils.RoleStudent}, nil).Once()
+		_, err := testUseCase.UploadCompetitionBanner(1, 2, data)
+		assert.EqualError(t, err, "action unauthorized")
+	})
+
+	t.Run("replaces-old-banner", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{ID: 1, UserID: 3, BannerKey: "banners/1/old.png"}, nil).Once()
+		mockUploader.On("Upload", "banners/1", data, media.CompetitionBannerSpec).Return("banners/1/new.png", nil).Once()
+		mockRepo.On("UpdateCompetitionBanner", uint(1), "banners/1/new.png").Return(nil).Once()
+		mockUploader.On("Delete", "banners/1/old.png").Return(nil).Once()
+		mockUploader.On("Image", "banners/1/new.png").Return(&media.Image{URL: "/uploads/banners/1/new.png"}).Once()
+		_, err := testUseCase.UploadCompetitionBanner(1, 3, data)
+		assert.NoError(t, err)
+	})
+
+	mockRepo.AssertExpectations(t)
+	mockUploader.AssertExpectations(t)
+}
+
+func TestDeleteCompetitionBanner(t *testing.T) {
+	mockRepo := mockRepo.NewCompetitionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	mockUploader := mediaMocks.NewUploader(t)
+	testUseCase := CreateNewCompetitionUseCase(mockRepo, teamRepository, policy.CreateNewPolicy(userRepository, teamRepository), mockUploader)
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{ID: 1, UserID: 3, BannerKey: "banners/1/old.png"}, nil).Once()
+		mockRepo.On("UpdateCompetitionBanner", uint(1), "").Return(nil).Once()
+		mockUploader.On("Delete", "banners/1/old.png").Return(nil).Once()
+		err := testUseCase.DeleteCompetitionBanner(1, 3)
+		assert.NoError(t, err)
+	})
+
+	t.Run("banner-not-found", func(t *testing.T) {
+		mockRepo.On("GetCompetitionByID", uint(1)).Return(entity.Competition{ID: 1, UserID: 3}, nil).Once()
+		err := testUseCase.DeleteCompetitionBanner(1, 3)
+		assert.EqualError(t, err, "banner not found")
+	})
+
+	mockRepo.AssertExpectations(t)
+	mockUploader.AssertExpectations(t)
 }
@@ -0,0 +1,640 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/alimikegami/compnouron/internal/discussion/dto"
+	"github.com/alimikegami/compnouron/internal/discussion/usecase"
+	"github.com/alimikegami/compnouron/pkg/response"
+	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+type DiscussionController struct {
+	router       *echo.Echo
+	discussionUC usecase.DiscussionUseCase
+}
+
+func (dc *DiscussionController) InitializeDiscussionRoute(config middleware.JWTConfig) {
+	r := dc.router.Group("/teams/:id/threads")
+	{
+		r.GET("", dc.GetThreads, middleware.JWTWithConfig(config))
+		r.POST("", dc.CreateThread, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.GET("/:threadID", dc.GetThread, middleware.JWTWithConfig(config))
+		r.PUT("/:threadID", dc.UpdateThread, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.DELETE("/:threadID", dc.DeleteThread, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.PUT("/:threadID/pin", dc.PinThread, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.DELETE("/:threadID/pin", dc.UnpinThread, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.GET("/:threadID/replies", dc.GetReplies, middleware.JWTWithConfig(config))
+		r.POST("/:threadID/replies", dc.CreateReply, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.PUT("/:threadID/replies/:replyID", dc.UpdateReply, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.DELETE("/:threadID/replies/:replyID", dc.DeleteReply, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+	}
+}
+
+// CreateThread godoc
+// @Summary      Start a thread on the discussion board of a team
+// @Description  Given the request body, posts a thread on the board of the team. Only the members of the team can post, the members mentioned in the thread are notified by email
+// @Tags         Discussions
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param data body dto.ThreadRequest true "Request Body"
+// @Success      201  {object}   response.Response{data=dto.ThreadResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/threads [post]
+func (dc *DiscussionController) CreateThread(c echo.Context) error {
+	ids, err := pathIDs(c, "id")
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+	thread := new(dto.ThreadRequest)
+	if err := c.Bind(thread); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	threadResponse, err := dc.discussionUC.CreateThread(ids[0], userID, *thread)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(statusCode(err), response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusCreated, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    threadResponse,
+	})
+}
+
+// GetThreads godoc
+// @Summary      List the threads of a team
+// @Description  Return the threads on the discussion board of the team, pinned threads first, then the most recently active ones, with pagination implemented. Only the members of the team can see its board
+// @Tags         Discussions
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param        limit     query      int     false  "rows retrieved limit"
+// @Param        offset    query      int     false  "skipped rows"
+// @Success      200  {object}   response.Response{data=[]dto.ThreadResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/threads [get]
+func (dc *DiscussionController) GetThreads(c echo.Context) error {
+	ids, err := pathIDs(c, "id")
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	limitInt, offsetInt, err := paginationParams(c)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+
+	threadsResponse, err := dc.discussionUC.GetThreads(ids[0], userID, limitInt, offsetInt)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(statusCode(err), response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    threadsResponse,
+	})
+}
+
+// GetThread godoc
+// @Summary      Get a thread of a team
+// @Description  Return the thread, its replies are listed by the replies endpoint. Only the members of the team can see its board
+// @Tags         Discussions
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param threadID path int true "Thread ID"
+// @Success      200  {object}   response.Response{data=dto.ThreadResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/threads/{threadID} [get]
+func (dc *DiscussionController) GetThread(c echo.Context) error {
+	ids, err := pathIDs(c, "id", "threadID")
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+
+	threadResponse, err := dc.discussionUC.GetThread(ids[0], ids[1], userID)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(statusCode(err), response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    threadResponse,
+	})
+}
+
+// UpdateThread godoc
+// @Summary      Edit a thread
+// @Description  Given the request body, replaces the title, the body and the mentions of the thread. Only the author can edit a thread, the members newly mentioned are notified by email
+// @Tags         Discussions
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param threadID path int true "Thread ID"
+// @Param data body dto.ThreadRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=dto.ThreadResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/threads/{threadID} [put]
+func (dc *DiscussionController) UpdateThread(c echo.Context) error {
+	ids, err := pathIDs(c, "id", "threadID")
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+	thread := new(dto.ThreadRequest)
+	if err := c.Bind(thread); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	threadResponse, err := dc.discussionUC.UpdateThread(ids[0], ids[1], userID, *thread)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(statusCode(err), response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    threadResponse,
+	})
+}
+
+// DeleteThread godoc
+// @Summary      Delete a thread
+// @Description  Deletes the thread along with its replies. Only the author can delete a thread
+// @Tags         Discussions
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param threadID path int true "Thread ID"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/threads/{threadID} [delete]
+func (dc *DiscussionController) DeleteThread(c echo.Context) error {
+	ids, err := pathIDs(c, "id", "threadID")
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+
+	err = dc.discussionUC.DeleteThread(ids[0], ids[1], userID)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(statusCode(err), response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// PinThread godoc
+// @Summary      Pin a thread
+// @Description  Keeps the thread at the top of the discussion board of the team. The owner and the co-leaders can pin threads
+// @Tags         Discussions
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param threadID path int true "Thread ID"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/threads/{threadID}/pin [put]
+func (dc *DiscussionController) PinThread(c echo.Context) error {
+	ids, err := pathIDs(c, "id", "threadID")
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+
+	err = dc.discussionUC.PinThread(ids[0], ids[1], userID)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(statusCode(err), response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// UnpinThread godoc
+// @Summary      Unpin a thread
+// @Description  Lists the thread with the others again. The owner and the co-leaders can unpin threads
+// @Tags         Discussions
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param threadID path int true "Thread ID"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/threads/{threadID}/pin [delete]
+func (dc *DiscussionController) UnpinThread(c echo.Context) error {
+	ids, err := pathIDs(c, "id", "threadID")
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+
+	err = dc.discussionUC.UnpinThread(ids[0], ids[1], userID)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(statusCode(err), response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// CreateReply godoc
+// @Summary      Reply to a thread
+// @Description  Given the request body, posts a reply to the thread. Only the members of the team can reply, the members mentioned in the reply are notified by email
+// @Tags         Discussions
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param threadID path int true "Thread ID"
+// @Param data body dto.ReplyRequest true "Request Body"
+// @Success      201  {object}   response.Response{data=dto.ReplyResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/threads/{threadID}/replies [post]
+func (dc *DiscussionController) CreateReply(c echo.Context) error {
+	ids, err := pathIDs(c, "id", "threadID")
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+	reply := new(dto.ReplyRequest)
+	if err := c.Bind(reply); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	replyResponse, err := dc.discussionUC.CreateReply(ids[0], ids[1], userID, *reply)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(statusCode(err), response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusCreated, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    replyResponse,
+	})
+}
+
+// GetReplies godoc
+// @Summary      List the replies to a thread
+// @Description  Return the replies to the thread in the order they were posted, with pagination implemented. Only the members of the team can see its board
+// @Tags         Discussions
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param threadID path int true "Thread ID"
+// @Param        limit     query      int     false  "rows retrieved limit"
+// @Param        offset    query      int     false  "skipped rows"
+// @Success      200  {object}   response.Response{data=[]dto.ReplyResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/threads/{threadID}/replies [get]
+func (dc *DiscussionController) GetReplies(c echo.Context) error {
+	ids, err := pathIDs(c, "id", "threadID")
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	limitInt, offsetInt, err := paginationParams(c)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+
+	repliesResponse, err := dc.discussionUC.GetReplies(ids[0], ids[1], userID, limitInt, offsetInt)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(statusCode(err), response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    repliesResponse,
+	})
+}
+
+// UpdateReply godoc
+// @Summary      Edit a reply
+// @Description  Given the request body, replaces the body and the mentions of the reply. Only the author can edit a reply, the members newly mentioned are notified by email
+// @Tags         Discussions
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param threadID path int true "Thread ID"
+// @Param replyID path int true "Reply ID"
+// @Param data body dto.ReplyRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=dto.ReplyResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/threads/{threadID}/replies/{replyID} [put]
+func (dc *DiscussionController) UpdateReply(c echo.Context) error {
+	ids, err := pathIDs(c, "id", "threadID", "replyID")
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+	reply := new(dto.ReplyRequest)
+	if err := c.Bind(reply); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	replyResponse, err := dc.discussionUC.UpdateReply(ids[0], ids[1], ids[2], userID, *reply)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(statusCode(err), response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    replyResponse,
+	})
+}
+
+// DeleteReply godoc
+// @Summary      Delete a reply
+// @Description  Deletes the reply. Only the author can delete a reply
+// @Tags         Discussions
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param threadID path int true "Thread ID"
+// @Param replyID path int true "Reply ID"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/threads/{threadID}/replies/{replyID} [delete]
+func (dc *DiscussionController) DeleteReply(c echo.Context) error {
+	ids, err := pathIDs(c, "id", "threadID", "replyID")
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+
+	err = dc.discussionUC.DeleteReply(ids[0], ids[1], ids[2], userID)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(statusCode(err), response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// statusCode maps the errors of the discussion use case to a response status.
+func statusCode(err error) int {
+	switch err.Error() {
+	case "fill the thread title", "the thread title is too long", "fill the message", "the message is too long", "only team members can be mentioned":
+		return http.StatusBadRequest
+	case "action unauthorized":
+		return http.StatusUnauthorized
+	case "record not found", "no rows affected":
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// pathIDs parses the given ID path parameters, in order.
+func pathIDs(c echo.Context, names ...string) ([]uint, error) {
+	ids := []uint{}
+	for _, name := range names {
+		id, err := strconv.ParseUint(c.Param(name), 10, 32)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, uint(id))
+	}
+
+	return ids, nil
+}
+
+// paginationParams reads the optional limit and offset query parameters.
+func paginationParams(c echo.Context) (int, int, error) {
+	limitInt := 0
+	if limit := c.QueryParam("limit"); limit != "" {
+		var err error
+		limitInt, err = strconv.Atoi(limit)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	offsetInt := 0
+	if offset := c.QueryParam("offset"); offset != "" {
+		var err error
+		offsetInt, err = strconv.Atoi(offset)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return limitInt, offsetInt, nil
+}
+
+func CreateNewDiscussionController(e *echo.Echo, discussionUC usecase.DiscussionUseCase) *DiscussionController {
+	return &DiscussionController{router: e, discussionUC: discussionUC}
+}
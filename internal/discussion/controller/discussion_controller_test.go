@@ -0,0 +1,254 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alimikegami/compnouron/internal/discussion/dto"
+	mocks "github.com/alimikegami/compnouron/internal/mocks/discussion/usecase"
+	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateThread(t *testing.T) {
+	mockUseCase := mocks.NewDiscussionUseCase(t)
+	reqBody := dto.ThreadRequest{Title: "Practice schedule", Body: "Let's meet on Monday", MentionedUserIDs: []uint{3}}
+	jsonReqBody, err := json.Marshal(&reqBody)
+	assert.NoError(t, err, "No marshaling error")
+
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"success", nil, http.StatusCreated},
+		{"empty-title", errors.New("fill the thread title"), http.StatusBadRequest},
+		{"mentioned-outsider", errors.New("only team members can be mentioned"), http.StatusBadRequest},
+		{"not-a-member", errors.New("action unauthorized"), http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUseCase.On("CreateThread", uint(1), uint(2), reqBody).Return(dto.ThreadResponse{ID: 5}, tc.err).Once()
+			req, err := http.NewRequest(http.MethodPost, "/", bytes.NewBuffer(jsonReqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			assert.NoError(t, err, "No request error")
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/teams/:id/threads")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			token := utils.CreateJWTToken(2, "asdfa@gmail.com", utils.RoleStudent, 1)
+			c.Set("user", token)
+			testDiscussionController := DiscussionController{
+				router:       e,
+				discussionUC: mockUseCase,
+			}
+
+			testDiscussionController.CreateThread(c)
+			assert.Equal(t, tc.status, rec.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetThreads(t *testing.T) {
+	mockUseCase := mocks.NewDiscussionUseCase(t)
+	cases := []struct {
+		name   string
+		query  string
+		err    error
+		status int
+	}{
+		{"success", "?limit=10&offset=20", nil, http.StatusOK},
+		{"not-a-member", "?limit=10&offset=20", errors.New("action unauthorized"), http.StatusUnauthorized},
+		{"invalid-limit", "?limit=ten", nil, http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.status != http.StatusBadRequest {
+				mockUseCase.On("GetThreads", uint(1), uint(2), 10, 20).Return([]dto.ThreadResponse{{ID: 5}}, tc.err).Once()
+			}
+			req, err := http.NewRequest(http.MethodGet, "/"+tc.query, nil)
+			assert.NoError(t, err, "No request error")
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/teams/:id/threads")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			token := utils.CreateJWTToken(2, "asdfa@gmail.com", utils.RoleStudent, 1)
+			c.Set("user", token)
+			testDiscussionController := DiscussionController{
+				router:       e,
+				discussionUC: mockUseCase,
+			}
+
+			testDiscussionController.GetThreads(c)
+			assert.Equal(t, tc.status, rec.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetThread(t *testing.T) {
+	mockUseCase := mocks.NewDiscussionUseCase(t)
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"success", nil, http.StatusOK},
+		{"not-found", errors.New("record not found"), http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUseCase.On("GetThread", uint(1), uint(5), uint(2)).Return(dto.ThreadResponse{ID: 5}, tc.err).Once()
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			assert.NoError(t, err, "No request error")
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/teams/:id/threads/:threadID")
+			c.SetParamNames("id", "threadID")
+			c.SetParamValues("1", "5")
+			token := utils.CreateJWTToken(2, "asdfa@gmail.com", utils.RoleStudent, 1)
+			c.Set("user", token)
+			testDiscussionController := DiscussionController{
+				router:       e,
+				discussionUC: mockUseCase,
+			}
+
+			testDiscussionController.GetThread(c)
+			assert.Equal(t, tc.status, rec.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPinThread(t *testing.T) {
+	mockUseCase := mocks.NewDiscussionUseCase(t)
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"success", nil, http.StatusOK},
+		{"member", errors.New("action unauthorized"), http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUseCase.On("PinThread", uint(1), uint(5), uint(2)).Return(tc.err).Once()
+			req, err := http.NewRequest(http.MethodPut, "/", nil)
+			assert.NoError(t, err, "No request error")
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/teams/:id/threads/:threadID/pin")
+			c.SetParamNames("id", "threadID")
+			c.SetParamValues("1", "5")
+			token := utils.CreateJWTToken(2, "asdfa@gmail.com", utils.RoleStudent, 1)
+			c.Set("user", token)
+			testDiscussionController := DiscussionController{
+				router:       e,
+				discussionUC: mockUseCase,
+			}
+
+			testDiscussionController.PinThread(c)
+			assert.Equal(t, tc.status, rec.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateReply(t *testing.T) {
+	mockUseCase := mocks.NewDiscussionUseCase(t)
+	reqBody := dto.ReplyRequest{Body: "See you at 7"}
+	jsonReqBody, err := json.Marshal(&reqBody)
+	assert.NoError(t, err, "No marshaling error")
+
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"success", nil, http.StatusOK},
+		{"not-the-author", errors.New("action unauthorized"), http.StatusUnauthorized},
+		{"too-long", errors.New("the message is too long"), http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUseCase.On("UpdateReply", uint(1), uint(5), uint(8), uint(2), reqBody).Return(dto.ReplyResponse{ID: 8}, tc.err).Once()
+			req, err := http.NewRequest(http.MethodPut, "/", bytes.NewBuffer(jsonReqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			assert.NoError(t, err, "No request error")
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/teams/:id/threads/:threadID/replies/:replyID")
+			c.SetParamNames("id", "threadID", "replyID")
+			c.SetParamValues("1", "5", "8")
+			token := utils.CreateJWTToken(2, "asdfa@gmail.com", utils.RoleStudent, 1)
+			c.Set("user", token)
+			testDiscussionController := DiscussionController{
+				router:       e,
+				discussionUC: mockUseCase,
+			}
+
+			testDiscussionController.UpdateReply(c)
+			assert.Equal(t, tc.status, rec.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteReply(t *testing.T) {
+	mockUseCase := mocks.NewDiscussionUseCase(t)
+	cases := []struct {
+		name     string
+		replyID  string
+		err      error
+		status   int
+		expected bool
+	}{
+		{"success", "8", nil, http.StatusOK, true},
+		{"not-found", "8", errors.New("record not found"), http.StatusNotFound, true},
+		{"invalid-id", "eight", nil, http.StatusBadRequest, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expected {
+				mockUseCase.On("DeleteReply", uint(1), uint(5), uint(8), uint(2)).Return(tc.err).Once()
+			}
+			req, err := http.NewRequest(http.MethodDelete, "/", nil)
+			assert.NoError(t, err, "No request error")
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/teams/:id/threads/:threadID/replies/:replyID")
+			c.SetParamNames("id", "threadID", "replyID")
+			c.SetParamValues("1", "5", tc.replyID)
+			token := utils.CreateJWTToken(2, "asdfa@gmail.com", utils.RoleStudent, 1)
+			c.Set("user", token)
+			testDiscussionController := DiscussionController{
+				router:       e,
+				discussionUC: mockUseCase,
+			}
+
+			testDiscussionController.DeleteReply(c)
+			assert.Equal(t, tc.status, rec.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
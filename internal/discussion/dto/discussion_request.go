@@ -0,0 +1,15 @@
+package dto
+
+// ThreadRequest is a new thread or the new content of a thread.
+// MentionedUserIDs lists the team members mentioned in the body, the ones
+// not mentioned before are notified by email.
+type ThreadRequest struct {
+	Title            string `json:"title"`
+	Body             string `json:"body"`
+	MentionedUserIDs []uint `json:"mentionedUserIDs"`
+}
+
+type ReplyRequest struct {
+	Body             string `json:"body"`
+	MentionedUserIDs []uint `json:"mentionedUserIDs"`
+}
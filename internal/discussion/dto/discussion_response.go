@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+type MentionResponse struct {
+	UserID uint   `json:"userID"`
+	Name   string `json:"name"`
+}
+
+type ThreadResponse struct {
+	ID             uint              `json:"id"`
+	TeamID         uint              `json:"teamID"`
+	AuthorID       uint              `json:"authorID"`
+	AuthorName     string            `json:"authorName"`
+	Title          string            `json:"title"`
+	Body           string            `json:"body"`
+	Mentions       []MentionResponse `json:"mentions"`
+	PinnedAt       *time.Time        `json:"pinnedAt"`
+	EditedAt       *time.Time        `json:"editedAt"`
+	LastActivityAt time.Time         `json:"lastActivityAt"`
+	CreatedAt      time.Time         `json:"createdAt"`
+}
+
+type ReplyResponse struct {
+	ID         uint              `json:"id"`
+	ThreadID   uint              `json:"threadID"`
+	AuthorID   uint              `json:"authorID"`
+	AuthorName string            `json:"authorName"`
+	Body       string            `json:"body"`
+	Mentions   []MentionResponse `json:"mentions"`
+	EditedAt   *time.Time        `json:"editedAt"`
+	CreatedAt  time.Time         `json:"createdAt"`
+}
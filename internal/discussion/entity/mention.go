@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	userEntity "github.com/alimikegami/compnouron/internal/user/entity"
+)
+
+// Mention is a team member mentioned in a thread, or in one of its replies
+// when ReplyID is set. The mentioned member is notified by email when the
+// mention is added.
+type Mention struct {
+	ID        uint  `gorm:"primaryKey"`
+	ThreadID  uint  `gorm:"not null;index"`
+	ReplyID   *uint `gorm:"index"`
+	UserID    uint  `gorm:"not null"`
+	CreatedAt time.Time
+	Thread    Thread          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Reply     *Reply          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	User      userEntity.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
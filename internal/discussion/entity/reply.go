@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	userEntity "github.com/alimikegami/compnouron/internal/user/entity"
+)
+
+type Reply struct {
+	ID        uint   `gorm:"primaryKey"`
+	ThreadID  uint   `gorm:"not null;index"`
+	AuthorID  uint   `gorm:"not null"`
+	Body      string `gorm:"type:text;not null"`
+	EditedAt  *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Thread    Thread          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Author    userEntity.User `gorm:"foreignKey:AuthorID"`
+	Mentions  []Mention
+}
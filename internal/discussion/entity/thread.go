@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	teamEntity "github.com/alimikegami/compnouron/internal/team/entity"
+	userEntity "github.com/alimikegami/compnouron/internal/user/entity"
+)
+
+// Thread is a topic on the discussion board of a team, which only the members
+// of the team can see. The board lists the pinned threads first, then the
+// others by LastActivityAt, the time the thread or its latest reply was
+// posted.
+type Thread struct {
+	ID             uint   `gorm:"primaryKey"`
+	TeamID         uint   `gorm:"not null;index"`
+	AuthorID       uint   `gorm:"not null"`
+	Title          string `gorm:"not null"`
+	Body           string `gorm:"type:text;not null"`
+	PinnedAt       *time.Time
+	EditedAt       *time.Time
+	LastActivityAt time.Time `gorm:"not null"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Team           teamEntity.Team `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Author         userEntity.User `gorm:"foreignKey:AuthorID"`
+	// Mentions of the thread itself, the mentions in its replies belong to
+	// the replies
+	Mentions []Mention
+}
@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/alimikegami/compnouron/db/pagination"
+	"github.com/alimikegami/compnouron/internal/discussion/entity"
+	"gorm.io/gorm"
+)
+
+type DiscussionRepository interface {
+	CreateThread(thread entity.Thread) (entity.Thread, error)
+	GetThreadsByTeamID(teamID uint, limit int, offset int) ([]entity.Thread, error)
+	GetThreadByID(teamID uint, id uint) (entity.Thread, error)
+	UpdateThread(thread entity.Thread) error
+	SetThreadPinnedAt(id uint, pinnedAt *time.Time) error
+	DeleteThread(id uint) error
+	CreateReply(reply entity.Reply) (entity.Reply, error)
+	GetRepliesByThreadID(threadID uint, limit int, offset int) ([]entity.Reply, error)
+	GetReplyByID(threadID uint, id uint) (entity.Reply, error)
+	UpdateReply(reply entity.Reply) error
+	DeleteReply(id uint) error
+	GetThreadsByAuthorID(authorID uint) ([]entity.Thread, error)
+	GetRepliesByAuthorID(authorID uint) ([]entity.Reply, error)
+	GetMentionsByUserID(userID uint) ([]entity.Mention, error)
+}
+
+type DiscussionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func CreateNewDiscussionRepository(db *gorm.DB) DiscussionRepository {
+	return &DiscussionRepositoryImpl{db: db}
+}
+
+// CreateThread also creates the mentions of the thread.
+func (dr *DiscussionRepositoryImpl) CreateThread(thread entity.Thread) (entity.Thread, error) {
+	thread.LastActivityAt = time.Now()
+	result := dr.db.Create(&thread)
+	if result.Error != nil {
+		return entity.Thread{}, result.Error
+	}
+
+	return thread, nil
+}
+
+func (dr *DiscussionRepositoryImpl) GetThreadsByTeamID(teamID uint, limit int, offset int) ([]entity.Thread, error) {
+	var threads []entity.Thread
+	result := dr.db.Scopes(pagination.Paginate(limit, offset)).Preload("Author").Preload("Mentions", "reply_id IS NULL").Preload("Mentions.User").Where("team_id = ?", teamID).Order("pinned_at IS NULL, pinned_at DESC, last_activity_at DESC, id DESC").Find(&threads)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return threads, nil
+}
+
+// GetThreadByID only finds the thread on the board of the given team.
+func (dr *DiscussionRepositoryImpl) GetThreadByID(teamID uint, id uint) (entity.Thread, error) {
+	var thread entity.Thread
+	result := dr.db.Preload("Author").Preload("Mentions", "reply_id IS NULL").Preload("Mentions.User").Where("team_id = ?", teamID).First(&thread, id)
+	if result.Error != nil {
+		return entity.Thread{}, result.Error
+	}
+
+	return thread, nil
+}
+
+// UpdateThread saves the title and the body of the thread and replaces its
+// mentions with thread.Mentions.
+func (dr *DiscussionRepositoryImpl) UpdateThread(thread entity.Thread) error {
+	return dr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Thread{}).Where("id = ?", thread.ID).Updates(map[string]interface{}{
+			"title":     thread.Title,
+			"body":      thread.Body,
+			"edited_at": time.Now(),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected != 1 {
+			return errors.New("no rows affected")
+		}
+
+		result = tx.Where("thread_id = ? AND reply_id IS NULL", thread.ID).Delete(&entity.Mention{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if len(thread.Mentions) > 0 {
+			result = tx.Create(&thread.Mentions)
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+
+		return nil
+	})
+}
+
+// SetThreadPinnedAt pins the thread, or unpins it when pinnedAt is nil.
+func (dr *DiscussionRepositoryImpl) SetThreadPinnedAt(id uint, pinnedAt *time.Time) error {
+	result := dr.db.Model(&entity.Thread{}).Where("id = ?", id).Update("pinned_at", pinnedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// DeleteThread deletes the thread along with its replies and mentions.
+func (dr *DiscussionRepositoryImpl) DeleteThread(id uint) error {
+	result := dr.db.Delete(&entity.Thread{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+// CreateReply also creates the mentions of the reply and moves the thread up
+// the board.
+func (dr *DiscussionRepositoryImpl) CreateReply(reply entity.Reply) (entity.Reply, error) {
+	err := dr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Create(&reply)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Model(&entity.Thread{}).Where("id = ?", reply.ThreadID).Update("last_activity_at", reply.CreatedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.Reply{}, err
+	}
+
+	return reply, nil
+}
+
+// GetRepliesByThreadID returns the replies in the order they were posted.
+func (dr *DiscussionRepositoryImpl) GetRepliesByThreadID(threadID uint, limit int, offset int) ([]entity.Reply, error) {
+	var replies []entity.Reply
+	result := dr.db.Scopes(pagination.Paginate(limit, offset)).Preload("Author").Preload("Mentions.User").Where("thread_id = ?", threadID).Order("created_at, id").Find(&replies)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return replies, nil
+}
+
+func (dr *DiscussionRepositoryImpl) GetReplyByID(threadID uint, id uint) (entity.Reply, error) {
+	var reply entity.Reply
+	result := dr.db.Preload("Author").Preload("Mentions.User").Where("thread_id = ?", threadID).First(&reply, id)
+	if result.Error != nil {
+		return entity.Reply{}, result.Error
+	}
+
+	return reply, nil
+}
+
+// UpdateReply saves the body of the reply and replaces its mentions with
+// reply.Mentions.
+func (dr *DiscussionRepositoryImpl) UpdateReply(reply entity.Reply) error {
+	return dr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Reply{}).Where("id = ?", reply.ID).Updates(map[string]interface{}{
+			"body":      reply.Body,
+			"edited_at": time.Now(),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected != 1 {
+			return errors.New("no rows affected")
+		}
+
+		result = tx.Where("reply_id = ?", reply.ID).Delete(&entity.Mention{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if len(reply.Mentions) > 0 {
+			result = tx.Create(&reply.Mentions)
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+
+		return nil
+	})
+}
+
+// DeleteReply deletes the reply along with its mentions.
+func (dr *DiscussionRepositoryImpl) DeleteReply(id uint) error {
+	result := dr.db.Delete(&entity.Reply{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+// GetThreadsByAuthorID returns the threads the user started on every board,
+// along with the mentions in them.
+func (dr *DiscussionRepositoryImpl) GetThreadsByAuthorID(authorID uint) ([]entity.Thread, error) {
+	var threads []entity.Thread
+	result := dr.db.Preload("Mentions", "reply_id IS NULL").Where("author_id = ?", authorID).Order("created_at, id").Find(&threads)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return threads, nil
+}
+
+// GetRepliesByAuthorID returns the replies the user posted on every board,
+// along with the mentions in them.
+func (dr *DiscussionRepositoryImpl) GetRepliesByAuthorID(authorID uint) ([]entity.Reply, error) {
+	var replies []entity.Reply
+	result := dr.db.Preload("Mentions").Where("author_id = ?", authorID).Order("created_at, id").Find(&replies)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return replies, nil
+}
+
+// GetMentionsByUserID returns where the user was mentioned.
+func (dr *DiscussionRepositoryImpl) GetMentionsByUserID(userID uint) ([]entity.Mention, error) {
+	var mentions []entity.Mention
+	result := dr.db.Where("user_id = ?", userID).Order("created_at, id").Find(&mentions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return mentions, nil
+}
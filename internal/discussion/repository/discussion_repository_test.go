@@ -0,0 +1,440 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alimikegami/compnouron/internal/discussion/entity"
+	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestCreateThread(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	discussionRepo := CreateNewDiscussionRepository(db)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `threads` (`team_id`,`author_id`,`title`,`body`,`pinned_at`,`edited_at`,`last_activity_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?)")).WithArgs(1, 2, "Practice schedule", "Let's meet on Monday", nil, nil, utils.AnyTime{}, utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(5, 1))
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `mentions` (`thread_id`,`reply_id`,`user_id`,`created_at`) VALUES (?,?,?,?)")).WithArgs(5, nil, 3, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(1, 1))
+	mockObj.ExpectCommit()
+
+	thread, err := discussionRepo.CreateThread(entity.Thread{
+		TeamID:   1,
+		AuthorID: 2,
+		Title:    "Practice schedule",
+		Body:     "Let's meet on Monday",
+		Mentions: []entity.Mention{{UserID: 3}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(5), thread.ID)
+	assert.False(t, thread.LastActivityAt.IsZero())
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestGetThreadsByTeamID(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	discussionRepo := CreateNewDiscussionRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "team_id", "author_id", "title"}).AddRow(5, 1, 2, "Practice schedule").AddRow(4, 1, 3, "Introductions")
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `threads` WHERE team_id = ? ORDER BY pinned_at IS NULL, pinned_at DESC, last_activity_at DESC, id DESC LIMIT 10 OFFSET 20")).WithArgs(1).WillReturnRows(rows)
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`id` IN (?,?)")).WithArgs(2, 3).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "Alim").AddRow(3, "Budi"))
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mentions` WHERE `mentions`.`thread_id` IN (?,?) AND reply_id IS NULL")).WithArgs(5, 4).WillReturnRows(sqlmock.NewRows([]string{"id", "thread_id", "user_id"}).AddRow(1, 5, 3))
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`id` = ?")).WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "Budi"))
+
+		threads, err := discussionRepo.GetThreadsByTeamID(1, 10, 20)
+		assert.NoError(t, err)
+		assert.Len(t, threads, 2)
+		assert.Equal(t, "Alim", threads[0].Author.Name)
+		assert.Equal(t, "Budi", threads[0].Mentions[0].User.Name)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `threads` WHERE team_id = ?")).WithArgs(1).WillReturnError(errors.New("unexpected error"))
+
+		_, err := discussionRepo.GetThreadsByTeamID(1, 10, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetThreadByID(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	discussionRepo := CreateNewDiscussionRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "team_id", "author_id", "title"}).AddRow(5, 1, 2, "Practice schedule")
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `threads` WHERE team_id = ? AND `threads`.`id` = ? ORDER BY `threads`.`id` LIMIT 1")).WithArgs(1, 5).WillReturnRows(rows)
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`id` = ?")).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "Alim"))
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mentions` WHERE `mentions`.`thread_id` = ? AND reply_id IS NULL")).WithArgs(5).WillReturnRows(sqlmock.NewRows([]string{"id", "thread_id", "user_id"}))
+
+		thread, err := discussionRepo.GetThreadByID(1, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, "Practice schedule", thread.Title)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `threads` WHERE team_id = ? AND `threads`.`id` = ?")).WithArgs(2, 5).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := discussionRepo.GetThreadByID(2, 5)
+		assert.EqualError(t, err, "record not found")
+	})
+}
+
+func TestUpdateThread(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	discussionRepo := CreateNewDiscussionRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `threads` SET `body`=?,`edited_at`=?,`title`=?,`updated_at`=? WHERE id = ?")).WithArgs("Monday at 7", utils.AnyTime{}, "Practice", utils.AnyTime{}, 5).WillReturnResult(driver.RowsAffected(1))
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `mentions` WHERE thread_id = ? AND reply_id IS NULL")).WithArgs(5).WillReturnResult(driver.RowsAffected(1))
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `mentions` (`thread_id`,`reply_id`,`user_id`,`created_at`) VALUES (?,?,?,?)")).WithArgs(5, nil, 4, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(2, 1))
+		mockObj.ExpectCommit()
+
+		err := discussionRepo.UpdateThread(entity.Thread{ID: 5, Title: "Practice", Body: "Monday at 7", Mentions: []entity.Mention{{ThreadID: 5, UserID: 4}}})
+		assert.NoError(t, err)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+
+	t.Run("no-rows-affected", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `threads`")).WillReturnResult(driver.RowsAffected(0))
+		mockObj.ExpectRollback()
+
+		err := discussionRepo.UpdateThread(entity.Thread{ID: 5, Title: "Practice", Body: "Monday at 7"})
+		assert.EqualError(t, err, "no rows affected")
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+}
+
+func TestSetThreadPinnedAt(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	discussionRepo := CreateNewDiscussionRepository(db)
+
+	defer mockedDB.Close()
+
+	now := time.Now()
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `threads` SET `pinned_at`=?,`updated_at`=? WHERE id = ?")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 5).WillReturnResult(driver.RowsAffected(1))
+	mockObj.ExpectCommit()
+
+	err = discussionRepo.SetThreadPinnedAt(5, &now)
+	assert.NoError(t, err)
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `threads` SET `pinned_at`=?,`updated_at`=? WHERE id = ?")).WithArgs(nil, utils.AnyTime{}, 5).WillReturnResult(driver.RowsAffected(1))
+	mockObj.ExpectCommit()
+
+	err = discussionRepo.SetThreadPinnedAt(5, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestDeleteThread(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	discussionRepo := CreateNewDiscussionRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `threads` WHERE `threads`.`id` = ?")).WithArgs(5).WillReturnResult(driver.RowsAffected(1))
+		mockObj.ExpectCommit()
+
+		err := discussionRepo.DeleteThread(5)
+		assert.NoError(t, err)
+	})
+
+	t.Run("no-rows-affected", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `threads` WHERE `threads`.`id` = ?")).WithArgs(5).WillReturnResult(driver.RowsAffected(0))
+		mockObj.ExpectCommit()
+
+		err := discussionRepo.DeleteThread(5)
+		assert.EqualError(t, err, "no rows affected")
+	})
+}
+
+func TestCreateReply(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	discussionRepo := CreateNewDiscussionRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `replies` (`thread_id`,`author_id`,`body`,`edited_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?)")).WithArgs(5, 3, "See you there", nil, utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(8, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `mentions` (`thread_id`,`reply_id`,`user_id`,`created_at`) VALUES (?,?,?,?)")).WithArgs(5, 8, 2, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(3, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `threads` SET `last_activity_at`=?,`updated_at`=? WHERE id = ?")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 5).WillReturnResult(driver.RowsAffected(1))
+		mockObj.ExpectCommit()
+
+		reply, err := discussionRepo.CreateReply(entity.Reply{ThreadID: 5, AuthorID: 3, Body: "See you there", Mentions: []entity.Mention{{ThreadID: 5, UserID: 2}}})
+		assert.NoError(t, err)
+		assert.Equal(t, uint(8), reply.ID)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `replies`")).WillReturnError(errors.New("unexpected error"))
+		mockObj.ExpectRollback()
+
+		_, err := discussionRepo.CreateReply(entity.Reply{ThreadID: 5, AuthorID: 3, Body: "See you there"})
+		assert.Error(t, err)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+}
+
+func TestGetRepliesByThreadID(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	discussionRepo := CreateNewDiscussionRepository(db)
+
+	defer mockedDB.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "thread_id", "author_id", "body"}).AddRow(8, 5, 3, "See you there")
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `replies` WHERE thread_id = ? ORDER BY created_at, id LIMIT 20")).WithArgs(5).WillReturnRows(rows)
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`id` = ?")).WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "Budi"))
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mentions` WHERE `mentions`.`reply_id` = ?")).WithArgs(8).WillReturnRows(sqlmock.NewRows([]string{"id", "thread_id", "reply_id", "user_id"}))
+
+	replies, err := discussionRepo.GetRepliesByThreadID(5, 20, 0)
+	assert.NoError(t, err)
+	assert.Len(t, replies, 1)
+	assert.Equal(t, "Budi", replies[0].Author.Name)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestGetReplyByID(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	discussionRepo := CreateNewDiscussionRepository(db)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `replies` WHERE thread_id = ? AND `replies`.`id` = ? ORDER BY `replies`.`id` LIMIT 1")).WithArgs(6, 8).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err = discussionRepo.GetReplyByID(6, 8)
+	assert.EqualError(t, err, "record not found")
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestUpdateReply(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	discussionRepo := CreateNewDiscussionRepository(db)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `replies` SET `body`=?,`edited_at`=?,`updated_at`=? WHERE id = ?")).WithArgs("See you at 7", utils.AnyTime{}, utils.AnyTime{}, 8).WillReturnResult(driver.RowsAffected(1))
+	mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `mentions` WHERE reply_id = ?")).WithArgs(8).WillReturnResult(driver.RowsAffected(1))
+	mockObj.ExpectCommit()
+
+	err = discussionRepo.UpdateReply(entity.Reply{ID: 8, Body: "See you at 7"})
+	assert.NoError(t, err)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestDeleteReply(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	discussionRepo := CreateNewDiscussionRepository(db)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `replies` WHERE `replies`.`id` = ?")).WithArgs(8).WillReturnResult(driver.RowsAffected(1))
+	mockObj.ExpectCommit()
+
+	err = discussionRepo.DeleteReply(8)
+	assert.NoError(t, err)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestGetThreadsByAuthorID(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	discussionRepo := CreateNewDiscussionRepository(db)
+
+	defer mockedDB.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "team_id", "author_id", "title", "body"}).AddRow(5, 2, 3, "Practice", "Tomorrow at 7 @Ani")
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `threads` WHERE author_id = ? ORDER BY created_at, id")).WithArgs(3).WillReturnRows(rows)
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mentions` WHERE `mentions`.`thread_id` = ? AND reply_id IS NULL")).WithArgs(5).WillReturnRows(sqlmock.NewRows([]string{"id", "thread_id", "reply_id", "user_id"}).AddRow(1, 5, nil, 4))
+
+	threads, err := discussionRepo.GetThreadsByAuthorID(3)
+	assert.NoError(t, err)
+	assert.Len(t, threads, 1)
+	assert.Equal(t, uint(4), threads[0].Mentions[0].UserID)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestGetRepliesByAuthorID(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	discussionRepo := CreateNewDiscussionRepository(db)
+
+	defer mockedDB.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "thread_id", "author_id", "body"}).AddRow(8, 5, 3, "See you there")
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `replies` WHERE author_id = ? ORDER BY created_at, id")).WithArgs(3).WillReturnRows(rows)
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mentions` WHERE `mentions`.`reply_id` = ?")).WithArgs(8).WillReturnRows(sqlmock.NewRows([]string{"id", "thread_id", "reply_id", "user_id"}))
+
+	replies, err := discussionRepo.GetRepliesByAuthorID(3)
+	assert.NoError(t, err)
+	assert.Len(t, replies, 1)
+	assert.Equal(t, "See you there", replies[0].Body)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestGetMentionsByUserID(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	discussionRepo := CreateNewDiscussionRepository(db)
+
+	defer mockedDB.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "thread_id", "reply_id", "user_id"}).AddRow(1, 5, nil, 4).AddRow(2, 5, 8, 4)
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mentions` WHERE user_id = ? ORDER BY created_at, id")).WithArgs(4).WillReturnRows(rows)
+
+	mentions, err := discussionRepo.GetMentionsByUserID(4)
+	assert.NoError(t, err)
+	assert.Len(t, mentions, 2)
+	assert.Equal(t, uint(8), *mentions[1].ReplyID)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
@@ -0,0 +1,501 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alimikegami/compnouron/internal/discussion/dto"
+	"github.com/alimikegami/compnouron/internal/discussion/entity"
+	"github.com/alimikegami/compnouron/internal/discussion/repository"
+	"github.com/alimikegami/compnouron/internal/policy"
+	teamRepo "github.com/alimikegami/compnouron/internal/team/repository"
+	userRepo "github.com/alimikegami/compnouron/internal/user/repository"
+	"github.com/alimikegami/compnouron/pkg/mailer"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 50
+	maxTitleLength   = 200
+	maxBodyLength    = 10000
+)
+
+// DiscussionUseCase runs the discussion boards of the teams. Only the members
+// of a team can read and post on its board, and only the author of a thread or
+// a reply can edit or delete it.
+type DiscussionUseCase interface {
+	CreateThread(teamID uint, userID uint, request dto.ThreadRequest) (dto.ThreadResponse, error)
+	GetThreads(teamID uint, userID uint, limit int, offset int) ([]dto.ThreadResponse, error)
+	GetThread(teamID uint, threadID uint, userID uint) (dto.ThreadResponse, error)
+	UpdateThread(teamID uint, threadID uint, userID uint, request dto.ThreadRequest) (dto.ThreadResponse, error)
+	DeleteThread(teamID uint, threadID uint, userID uint) error
+	PinThread(teamID uint, threadID uint, userID uint) error
+	UnpinThread(teamID uint, threadID uint, userID uint) error
+	CreateReply(teamID uint, threadID uint, userID uint, request dto.ReplyRequest) (dto.ReplyResponse, error)
+	GetReplies(teamID uint, threadID uint, userID uint, limit int, offset int) ([]dto.ReplyResponse, error)
+	UpdateReply(teamID uint, threadID uint, replyID uint, userID uint, request dto.ReplyRequest) (dto.ReplyResponse, error)
+	DeleteReply(teamID uint, threadID uint, replyID uint, userID uint) error
+}
+
+type DiscussionUseCaseImpl struct {
+	dr repository.DiscussionRepository
+	tr teamRepo.TeamRepository
+	ur userRepo.UserRepository
+	p  policy.Policy
+	m  mailer.Mailer
+}
+
+func CreateNewDiscussionUseCase(dr repository.DiscussionRepository, tr teamRepo.TeamRepository, ur userRepo.UserRepository, p policy.Policy, m mailer.Mailer) DiscussionUseCase {
+	return &DiscussionUseCaseImpl{dr: dr, tr: tr, ur: ur, p: p, m: m}
+}
+
+func (duc *DiscussionUseCaseImpl) CreateThread(teamID uint, userID uint, request dto.ThreadRequest) (dto.ThreadResponse, error) {
+	err := duc.p.RequireTeamMember(userID, teamID)
+	if err != nil {
+		return dto.ThreadResponse{}, err
+	}
+
+	title, body, err := threadContent(request)
+	if err != nil {
+		return dto.ThreadResponse{}, err
+	}
+
+	mentionedIDs, err := duc.mentionedMembers(teamID, userID, request.MentionedUserIDs)
+	if err != nil {
+		return dto.ThreadResponse{}, err
+	}
+
+	thread, err := duc.dr.CreateThread(entity.Thread{
+		TeamID:   teamID,
+		AuthorID: userID,
+		Title:    title,
+		Body:     body,
+		Mentions: toMentions(0, nil, mentionedIDs),
+	})
+	if err != nil {
+		return dto.ThreadResponse{}, err
+	}
+
+	thread, err = duc.dr.GetThreadByID(teamID, thread.ID)
+	if err != nil {
+		return dto.ThreadResponse{}, err
+	}
+
+	duc.notifyMentions(thread, thread.Author.Name, mentionedIDs)
+	return toThreadResponse(thread), nil
+}
+
+func (duc *DiscussionUseCaseImpl) GetThreads(teamID uint, userID uint, limit int, offset int) ([]dto.ThreadResponse, error) {
+	err := duc.p.RequireTeamMember(userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	threads, err := duc.dr.GetThreadsByTeamID(teamID, listLimit(limit), offset)
+	if err != nil {
+		return nil, err
+	}
+
+	threadsResponse := []dto.ThreadResponse{}
+	for _, thread := range threads {
+		threadsResponse = append(threadsResponse, toThreadResponse(thread))
+	}
+
+	return threadsResponse, nil
+}
+
+func (duc *DiscussionUseCaseImpl) GetThread(teamID uint, threadID uint, userID uint) (dto.ThreadResponse, error) {
+	thread, err := duc.getThread(teamID, threadID, userID)
+	if err != nil {
+		return dto.ThreadResponse{}, err
+	}
+
+	return toThreadResponse(thread), nil
+}
+
+func (duc *DiscussionUseCaseImpl) UpdateThread(teamID uint, threadID uint, userID uint, request dto.ThreadRequest) (dto.ThreadResponse, error) {
+	thread, err := duc.getOwnThread(teamID, threadID, userID)
+	if err != nil {
+		return dto.ThreadResponse{}, err
+	}
+
+	title, body, err := threadContent(request)
+	if err != nil {
+		return dto.ThreadResponse{}, err
+	}
+
+	mentionedIDs, err := duc.mentionedMembers(teamID, userID, request.MentionedUserIDs)
+	if err != nil {
+		return dto.ThreadResponse{}, err
+	}
+
+	err = duc.dr.UpdateThread(entity.Thread{
+		ID:       thread.ID,
+		Title:    title,
+		Body:     body,
+		Mentions: toMentions(thread.ID, nil, mentionedIDs),
+	})
+	if err != nil {
+		return dto.ThreadResponse{}, err
+	}
+
+	updatedThread, err := duc.dr.GetThreadByID(teamID, thread.ID)
+	if err != nil {
+		return dto.ThreadResponse{}, err
+	}
+
+	duc.notifyMentions(updatedThread, updatedThread.Author.Name, newMentions(thread.Mentions, mentionedIDs))
+	return toThreadResponse(updatedThread), nil
+}
+
+func (duc *DiscussionUseCaseImpl) DeleteThread(teamID uint, threadID uint, userID uint) error {
+	thread, err := duc.getOwnThread(teamID, threadID, userID)
+	if err != nil {
+		return err
+	}
+
+	return duc.dr.DeleteThread(thread.ID)
+}
+
+// PinThread keeps the thread at the top of the board. The owner and the
+// co-leaders can pin threads.
+func (duc *DiscussionUseCaseImpl) PinThread(teamID uint, threadID uint, userID uint) error {
+	return duc.setThreadPinned(teamID, threadID, userID, true)
+}
+
+func (duc *DiscussionUseCaseImpl) UnpinThread(teamID uint, threadID uint, userID uint) error {
+	return duc.setThreadPinned(teamID, threadID, userID, false)
+}
+
+func (duc *DiscussionUseCaseImpl) CreateReply(teamID uint, threadID uint, userID uint, request dto.ReplyRequest) (dto.ReplyResponse, error) {
+	thread, err := duc.getThread(teamID, threadID, userID)
+	if err != nil {
+		return dto.ReplyResponse{}, err
+	}
+
+	body, err := messageBody(request.Body)
+	if err != nil {
+		return dto.ReplyResponse{}, err
+	}
+
+	mentionedIDs, err := duc.mentionedMembers(teamID, userID, request.MentionedUserIDs)
+	if err != nil {
+		return dto.ReplyResponse{}, err
+	}
+
+	reply, err := duc.dr.CreateReply(entity.Reply{
+		ThreadID: thread.ID,
+		AuthorID: userID,
+		Body:     body,
+		Mentions: toMentions(thread.ID, nil, mentionedIDs),
+	})
+	if err != nil {
+		return dto.ReplyResponse{}, err
+	}
+
+	reply, err = duc.dr.GetReplyByID(thread.ID, reply.ID)
+	if err != nil {
+		return dto.ReplyResponse{}, err
+	}
+
+	duc.notifyMentions(thread, reply.Author.Name, mentionedIDs)
+	return toReplyResponse(reply), nil
+}
+
+func (duc *DiscussionUseCaseImpl) GetReplies(teamID uint, threadID uint, userID uint, limit int, offset int) ([]dto.ReplyResponse, error) {
+	thread, err := duc.getThread(teamID, threadID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	replies, err := duc.dr.GetRepliesByThreadID(thread.ID, listLimit(limit), offset)
+	if err != nil {
+		return nil, err
+	}
+
+	repliesResponse := []dto.ReplyResponse{}
+	for _, reply := range replies {
+		repliesResponse = append(repliesResponse, toReplyResponse(reply))
+	}
+
+	return repliesResponse, nil
+}
+
+func (duc *DiscussionUseCaseImpl) UpdateReply(teamID uint, threadID uint, replyID uint, userID uint, request dto.ReplyRequest) (dto.ReplyResponse, error) {
+	thread, reply, err := duc.getOwnReply(teamID, threadID, replyID, userID)
+	if err != nil {
+		return dto.ReplyResponse{}, err
+	}
+
+	body, err := messageBody(request.Body)
+	if err != nil {
+		return dto.ReplyResponse{}, err
+	}
+
+	mentionedIDs, err := duc.mentionedMembers(teamID, userID, request.MentionedUserIDs)
+	if err != nil {
+		return dto.ReplyResponse{}, err
+	}
+
+	err = duc.dr.UpdateReply(entity.Reply{
+		ID:       reply.ID,
+		Body:     body,
+		Mentions: toMentions(thread.ID, &reply.ID, mentionedIDs),
+	})
+	if err != nil {
+		return dto.ReplyResponse{}, err
+	}
+
+	updatedReply, err := duc.dr.GetReplyByID(thread.ID, reply.ID)
+	if err != nil {
+		return dto.ReplyResponse{}, err
+	}
+
+	duc.notifyMentions(thread, updatedReply.Author.Name, newMentions(reply.Mentions, mentionedIDs))
+	return toReplyResponse(updatedReply), nil
+}
+
+func (duc *DiscussionUseCaseImpl) DeleteReply(teamID uint, threadID uint, replyID uint, userID uint) error {
+	_, reply, err := duc.getOwnReply(teamID, threadID, replyID, userID)
+	if err != nil {
+		return err
+	}
+
+	return duc.dr.DeleteReply(reply.ID)
+}
+
+func (duc *DiscussionUseCaseImpl) setThreadPinned(teamID uint, threadID uint, userID uint, pinned bool) error {
+	thread, err := duc.getThread(teamID, threadID, userID)
+	if err != nil {
+		return err
+	}
+
+	err = duc.p.CanPinTeamThreads(userID, teamID)
+	if err != nil {
+		return err
+	}
+
+	if pinned == (thread.PinnedAt != nil) {
+		return nil
+	}
+
+	var pinnedAt *time.Time
+	if pinned {
+		now := time.Now()
+		pinnedAt = &now
+	}
+
+	return duc.dr.SetThreadPinnedAt(thread.ID, pinnedAt)
+}
+
+// getThread returns the thread if the user can see it.
+func (duc *DiscussionUseCaseImpl) getThread(teamID uint, threadID uint, userID uint) (entity.Thread, error) {
+	err := duc.p.RequireTeamMember(userID, teamID)
+	if err != nil {
+		return entity.Thread{}, err
+	}
+
+	return duc.dr.GetThreadByID(teamID, threadID)
+}
+
+// getOwnThread returns the thread if the user wrote it and is still a member
+// of the team.
+func (duc *DiscussionUseCaseImpl) getOwnThread(teamID uint, threadID uint, userID uint) (entity.Thread, error) {
+	thread, err := duc.getThread(teamID, threadID, userID)
+	if err != nil {
+		return entity.Thread{}, err
+	}
+
+	if thread.AuthorID != userID {
+		return entity.Thread{}, errors.New("action unauthorized")
+	}
+
+	return thread, nil
+}
+
+func (duc *DiscussionUseCaseImpl) getOwnReply(teamID uint, threadID uint, replyID uint, userID uint) (entity.Thread, entity.Reply, error) {
+	thread, err := duc.getThread(teamID, threadID, userID)
+	if err != nil {
+		return entity.Thread{}, entity.Reply{}, err
+	}
+
+	reply, err := duc.dr.GetReplyByID(thread.ID, replyID)
+	if err != nil {
+		return entity.Thread{}, entity.Reply{}, err
+	}
+
+	if reply.AuthorID != userID {
+		return entity.Thread{}, entity.Reply{}, errors.New("action unauthorized")
+	}
+
+	return thread, reply, nil
+}
+
+// mentionedMembers checks that the mentioned users are members of the team.
+// The author mentioning themselves is ignored.
+func (duc *DiscussionUseCaseImpl) mentionedMembers(teamID uint, authorID uint, userIDs []uint) ([]uint, error) {
+	mentionedIDs := []uint{}
+	seen := map[uint]bool{authorID: true}
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		member, err := duc.tr.GetTeamMember(teamID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if member.Role == "" {
+			return nil, errors.New("only team members can be mentioned")
+		}
+
+		mentionedIDs = append(mentionedIDs, userID)
+	}
+
+	return mentionedIDs, nil
+}
+
+// notifyMentions emails the mentioned members. The post is already saved, so
+// a failure is only logged.
+func (duc *DiscussionUseCaseImpl) notifyMentions(thread entity.Thread, authorName string, userIDs []uint) {
+	if len(userIDs) == 0 {
+		return
+	}
+
+	team, err := duc.tr.GetTeamByID(thread.TeamID)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	subject := authorName + " mentioned you in " + team.Name
+	body := fmt.Sprintf("%s mentioned you in \"%s\" on the discussion board of %s.\n\n%s/teams/%d/threads/%d", authorName, thread.Title, team.Name, os.Getenv("APP_URL"), thread.TeamID, thread.ID)
+	for _, userID := range userIDs {
+		user, err := duc.ur.GetUserByID(userID)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		if user.AnonymizedAt != nil {
+			continue
+		}
+
+		err = duc.m.Send(user.Email, subject, body)
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+// newMentions returns the users in userIDs who aren't mentioned yet.
+func newMentions(mentions []entity.Mention, userIDs []uint) []uint {
+	mentioned := map[uint]bool{}
+	for _, mention := range mentions {
+		mentioned[mention.UserID] = true
+	}
+
+	newIDs := []uint{}
+	for _, userID := range userIDs {
+		if !mentioned[userID] {
+			newIDs = append(newIDs, userID)
+		}
+	}
+
+	return newIDs
+}
+
+func threadContent(request dto.ThreadRequest) (string, string, error) {
+	title := strings.TrimSpace(request.Title)
+	if title == "" {
+		return "", "", errors.New("fill the thread title")
+	}
+
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		return "", "", errors.New("the thread title is too long")
+	}
+
+	body, err := messageBody(request.Body)
+	if err != nil {
+		return "", "", err
+	}
+
+	return title, body, nil
+}
+
+func messageBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", errors.New("fill the message")
+	}
+
+	if utf8.RuneCountInString(body) > maxBodyLength {
+		return "", errors.New("the message is too long")
+	}
+
+	return body, nil
+}
+
+func listLimit(limit int) int {
+	if limit <= 0 {
+		return defaultListLimit
+	}
+	if limit > maxListLimit {
+		return maxListLimit
+	}
+
+	return limit
+}
+
+func toMentions(threadID uint, replyID *uint, userIDs []uint) []entity.Mention {
+	var mentions []entity.Mention
+	for _, userID := range userIDs {
+		mentions = append(mentions, entity.Mention{ThreadID: threadID, ReplyID: replyID, UserID: userID})
+	}
+
+	return mentions
+}
+
+func toMentionResponses(mentions []entity.Mention) []dto.MentionResponse {
+	mentionsResponse := []dto.MentionResponse{}
+	for _, mention := range mentions {
+		mentionsResponse = append(mentionsResponse, dto.MentionResponse{UserID: mention.UserID, Name: mention.User.Name})
+	}
+
+	return mentionsResponse
+}
+
+func toThreadResponse(thread entity.Thread) dto.ThreadResponse {
+	return dto.ThreadResponse{
+		ID:             thread.ID,
+		TeamID:         thread.TeamID,
+		AuthorID:       thread.AuthorID,
+		AuthorName:     thread.Author.Name,
+		Title:          thread.Title,
+		Body:           thread.Body,
+		Mentions:       toMentionResponses(thread.Mentions),
+		PinnedAt:       thread.PinnedAt,
+		EditedAt:       thread.EditedAt,
+		LastActivityAt: thread.LastActivityAt,
+		CreatedAt:      thread.CreatedAt,
+	}
+}
+
+func toReplyResponse(reply entity.Reply) dto.ReplyResponse {
+	return dto.ReplyResponse{
+		ID:         reply.ID,
+		ThreadID:   reply.ThreadID,
+		AuthorID:   reply.AuthorID,
+		AuthorName: reply.Author.Name,
+		Body:       reply.Body,
+		Mentions:   toMentionResponses(reply.Mentions),
+		EditedAt:   reply.EditedAt,
+		CreatedAt:  reply.CreatedAt,
+	}
+}
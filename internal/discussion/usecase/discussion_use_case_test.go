@@ -0,0 +1,353 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alimikegami/compnouron/internal/discussion/dto"
+	"github.com/alimikegami/compnouron/internal/discussion/entity"
+	mockRepo "github.com/alimikegami/compnouron/internal/mocks/discussion/repository"
+	mailerMocks "github.com/alimikegami/compnouron/internal/mocks/mailer"
+	teamRepo "github.com/alimikegami/compnouron/internal/mocks/team/repository"
+	userRepo "github.com/alimikegami/compnouron/internal/mocks/user/repository"
+	"github.com/alimikegami/compnouron/internal/policy"
+	teamEntity "github.com/alimikegami/compnouron/internal/team/entity"
+	userEntity "github.com/alimikegami/compnouron/internal/user/entity"
+	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreateThread(t *testing.T) {
+	mockRepo := mockRepo.NewDiscussionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	testUseCase := CreateNewDiscussionUseCase(mockRepo, teamRepository, userRepository, policy.CreateNewPolicy(userRepository, teamRepository), mockMailer)
+	request := dto.ThreadRequest{Title: " Practice schedule ", Body: "Let's meet on Monday @Budi", MentionedUserIDs: []uint{3, 3, 1}}
+
+	t.Run("success", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
+		teamRepository.On("GetTeamMember", uint(1), uint(3)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 3, Role: teamEntity.TeamRoleMember}, nil).Once()
+		mockRepo.On("CreateThread", entity.Thread{
+			TeamID:   1,
+			AuthorID: 1,
+			Title:    "Practice schedule",
+			Body:     "Let's meet on Monday @Budi",
+			Mentions: []entity.Mention{{UserID: 3}},
+		}).Return(entity.Thread{ID: 5}, nil).Once()
+		mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(entity.Thread{
+			ID:       5,
+			TeamID:   1,
+			AuthorID: 1,
+			Title:    "Practice schedule",
+			Author:   userEntity.User{ID: 1, Name: "Alim"},
+			Mentions: []entity.Mention{{ThreadID: 5, UserID: 3, User: userEntity.User{ID: 3, Name: "Budi"}}},
+		}, nil).Once()
+		teamRepository.On("GetTeamByID", uint(1)).Return(teamEntity.Team{ID: 1, Name: "Team Rocket"}, nil).Once()
+		userRepository.On("GetUserByID", uint(3)).Return(userEntity.User{ID: 3, Email: "budi@gmail.com"}, nil).Once()
+		mockMailer.On("Send", "budi@gmail.com", "Alim mentioned you in Team Rocket", mock.AnythingOfType("string")).Return(nil).Once()
+
+		res, err := testUseCase.CreateThread(1, 1, request)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(5), res.ID)
+		assert.Equal(t, "Alim", res.AuthorName)
+		assert.Equal(t, []dto.MentionResponse{{UserID: 3, Name: "Budi"}}, res.Mentions)
+	})
+
+	t.Run("not-a-member", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(9)).Return(teamEntity.TeamMember{}, nil).Once()
+
+		_, err := testUseCase.CreateThread(1, 9, request)
+		assert.EqualError(t, err, "action unauthorized")
+	})
+
+	t.Run("empty-title", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
+
+		_, err := testUseCase.CreateThread(1, 1, dto.ThreadRequest{Title: "  ", Body: "Let's meet on Monday"})
+		assert.EqualError(t, err, "fill the thread title")
+	})
+
+	t.Run("mentioned-outsider", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
+		teamRepository.On("GetTeamMember", uint(1), uint(9)).Return(teamEntity.TeamMember{}, nil).Once()
+
+		_, err := testUseCase.CreateThread(1, 1, dto.ThreadRequest{Title: "Practice schedule", Body: "Let's meet on Monday", MentionedUserIDs: []uint{9}})
+		assert.EqualError(t, err, "only team members can be mentioned")
+	})
+}
+
+func TestGetThreads(t *testing.T) {
+	mockRepo := mockRepo.NewDiscussionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	testUseCase := CreateNewDiscussionUseCase(mockRepo, teamRepository, userRepository, policy.CreateNewPolicy(userRepository, teamRepository), mockMailer)
+
+	t.Run("success", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(3)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 3, Role: teamEntity.TeamRoleMember}, nil).Once()
+		mockRepo.On("GetThreadsByTeamID", uint(1), defaultListLimit, 0).Return([]entity.Thread{{ID: 5, TeamID: 1}, {ID: 4, TeamID: 1}}, nil).Once()
+
+		res, err := testUseCase.GetThreads(1, 3, 0, 0)
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("limit-capped", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(3)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 3, Role: teamEntity.TeamRoleMember}, nil).Once()
+		mockRepo.On("GetThreadsByTeamID", uint(1), maxListLimit, 40).Return([]entity.Thread{}, nil).Once()
+
+		res, err := testUseCase.GetThreads(1, 3, 1000, 40)
+		assert.NoError(t, err)
+		assert.Equal(t, []dto.ThreadResponse{}, res)
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(9)).Return(teamEntity.TeamMember{}, nil).Once()
+
+		_, err := testUseCase.GetThreads(1, 9, 0, 0)
+		assert.EqualError(t, err, "action unauthorized")
+	})
+}
+
+func TestUpdateThread(t *testing.T) {
+	mockRepo := mockRepo.NewDiscussionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	testUseCase := CreateNewDiscussionUseCase(mockRepo, teamRepository, userRepository, policy.CreateNewPolicy(userRepository, teamRepository), mockMailer)
+	thread := entity.Thread{ID: 5, TeamID: 1, AuthorID: 3, Title: "Practice", Mentions: []entity.Mention{{ThreadID: 5, UserID: 1}}}
+
+	t.Run("success", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(3)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 3, Role: teamEntity.TeamRoleMember}, nil).Once()
+		mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(thread, nil).Once()
+		teamRepository.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
+		teamRepository.On("GetTeamMember", uint(1), uint(2)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 2, Role: teamEntity.TeamRoleCoLeader}, nil).Once()
+		mockRepo.On("UpdateThread", entity.Thread{
+			ID:       5,
+			Title:    "Practice",
+			Body:     "Monday at 7",
+			Mentions: []entity.Mention{{ThreadID: 5, UserID: 1}, {ThreadID: 5, UserID: 2}},
+		}).Return(nil).Once()
+		mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(entity.Thread{ID: 5, TeamID: 1, AuthorID: 3, Title: "Practice", Author: userEntity.User{ID: 3, Name: "Budi"}}, nil).Once()
+		teamRepository.On("GetTeamByID", uint(1)).Return(teamEntity.Team{ID: 1, Name: "Team Rocket"}, nil).Once()
+		// only the co-leader is newly mentioned
+		userRepository.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2, Email: "citra@gmail.com"}, nil).Once()
+		mockMailer.On("Send", "citra@gmail.com", "Budi mentioned you in Team Rocket", mock.AnythingOfType("string")).Return(nil).Once()
+
+		res, err := testUseCase.UpdateThread(1, 5, 3, dto.ThreadRequest{Title: "Practice", Body: "Monday at 7", MentionedUserIDs: []uint{1, 2}})
+		assert.NoError(t, err)
+		assert.Equal(t, uint(5), res.ID)
+	})
+
+	t.Run("not-the-author", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
+		mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(thread, nil).Once()
+
+		_, err := testUseCase.UpdateThread(1, 5, 1, dto.ThreadRequest{Title: "Practice", Body: "Monday at 7"})
+		assert.EqualError(t, err, "action unauthorized")
+	})
+
+	t.Run("other-team", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(3)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 3, Role: teamEntity.TeamRoleMember}, nil).Once()
+		mockRepo.On("GetThreadByID", uint(1), uint(6)).Return(entity.Thread{}, gorm.ErrRecordNotFound).Once()
+
+		_, err := testUseCase.UpdateThread(1, 6, 3, dto.ThreadRequest{Title: "Practice", Body: "Monday at 7"})
+		assert.EqualError(t, err, "record not found")
+	})
+}
+
+func TestDeleteThread(t *testing.T) {
+	mockRepo := mockRepo.NewDiscussionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	testUseCase := CreateNewDiscussionUseCase(mockRepo, teamRepository, userRepository, policy.CreateNewPolicy(userRepository, teamRepository), mockMailer)
+	thread := entity.Thread{ID: 5, TeamID: 1, AuthorID: 3}
+
+	t.Run("success", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(3)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 3, Role: teamEntity.TeamRoleMember}, nil).Once()
+		mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(thread, nil).Once()
+		mockRepo.On("DeleteThread", uint(5)).Return(nil).Once()
+
+		assert.NoError(t, testUseCase.DeleteThread(1, 5, 3))
+	})
+
+	t.Run("owner-deletes-others-thread", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
+		mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(thread, nil).Once()
+
+		assert.EqualError(t, testUseCase.DeleteThread(1, 5, 1), "action unauthorized")
+	})
+}
+
+func TestPinThread(t *testing.T) {
+	mockRepo := mockRepo.NewDiscussionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	testUseCase := CreateNewDiscussionUseCase(mockRepo, teamRepository, userRepository, policy.CreateNewPolicy(userRepository, teamRepository), mockMailer)
+
+	t.Run("co-leader", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(2)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 2, Role: teamEntity.TeamRoleCoLeader}, nil).Once()
+		mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(entity.Thread{ID: 5, TeamID: 1, AuthorID: 3}, nil).Once()
+		teamRepository.On("GetTeamMember", uint(1), uint(2)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 2, Role: teamEntity.TeamRoleCoLeader}, nil).Once()
+		mockRepo.On("SetThreadPinnedAt", uint(5), mock.MatchedBy(func(pinnedAt *time.Time) bool { return pinnedAt != nil })).Return(nil).Once()
+
+		assert.NoError(t, testUseCase.PinThread(1, 5, 2))
+	})
+
+	t.Run("already-pinned", func(t *testing.T) {
+		pinnedAt := time.Now()
+		teamRepository.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
+		mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(entity.Thread{ID: 5, TeamID: 1, PinnedAt: &pinnedAt}, nil).Once()
+		teamRepository.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
+
+		assert.NoError(t, testUseCase.PinThread(1, 5, 1))
+	})
+
+	t.Run("member", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(3)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 3, Role: teamEntity.TeamRoleMember}, nil).Once()
+		mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(entity.Thread{ID: 5, TeamID: 1, AuthorID: 3}, nil).Once()
+		teamRepository.On("GetTeamMember", uint(1), uint(3)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 3, Role: teamEntity.TeamRoleMember}, nil).Once()
+		userRepository.On("GetUserByID", uint(3)).Return(userEntity.User{ID: 3, Role: utils.RoleStudent}, nil).Once()
+
+		assert.EqualError(t, testUseCase.PinThread(1, 5, 3), "action unauthorized")
+	})
+}
+
+func TestUnpinThread(t *testing.T) {
+	mockRepo := mockRepo.NewDiscussionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	testUseCase := CreateNewDiscussionUseCase(mockRepo, teamRepository, userRepository, policy.CreateNewPolicy(userRepository, teamRepository), mockMailer)
+	pinnedAt := time.Now()
+	teamRepository.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
+	mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(entity.Thread{ID: 5, TeamID: 1, PinnedAt: &pinnedAt}, nil).Once()
+	teamRepository.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
+	mockRepo.On("SetThreadPinnedAt", uint(5), (*time.Time)(nil)).Return(nil).Once()
+
+	assert.NoError(t, testUseCase.UnpinThread(1, 5, 1))
+}
+
+func TestCreateReply(t *testing.T) {
+	mockRepo := mockRepo.NewDiscussionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	testUseCase := CreateNewDiscussionUseCase(mockRepo, teamRepository, userRepository, policy.CreateNewPolicy(userRepository, teamRepository), mockMailer)
+
+	t.Run("success", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(3)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 3, Role: teamEntity.TeamRoleMember}, nil).Once()
+		mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(entity.Thread{ID: 5, TeamID: 1, AuthorID: 1, Title: "Practice"}, nil).Once()
+		teamRepository.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
+		mockRepo.On("CreateReply", entity.Reply{
+			ThreadID: 5,
+			AuthorID: 3,
+			Body:     "See you there",
+			Mentions: []entity.Mention{{ThreadID: 5, UserID: 1}},
+		}).Return(entity.Reply{ID: 8}, nil).Once()
+		mockRepo.On("GetReplyByID", uint(5), uint(8)).Return(entity.Reply{ID: 8, ThreadID: 5, AuthorID: 3, Body: "See you there", Author: userEntity.User{ID: 3, Name: "Budi"}}, nil).Once()
+		teamRepository.On("GetTeamByID", uint(1)).Return(teamEntity.Team{ID: 1, Name: "Team Rocket"}, nil).Once()
+		userRepository.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Email: "alim@gmail.com"}, nil).Once()
+		mockMailer.On("Send", "alim@gmail.com", "Budi mentioned you in Team Rocket", mock.AnythingOfType("string")).Return(nil).Once()
+
+		res, err := testUseCase.CreateReply(1, 5, 3, dto.ReplyRequest{Body: "See you there", MentionedUserIDs: []uint{1}})
+		assert.NoError(t, err)
+		assert.Equal(t, dto.ReplyResponse{ID: 8, ThreadID: 5, AuthorID: 3, AuthorName: "Budi", Body: "See you there", Mentions: []dto.MentionResponse{}}, res)
+	})
+
+	t.Run("anonymized-member-mentioned", func(t *testing.T) {
+		anonymizedAt := time.Now()
+		teamRepository.On("GetTeamMember", uint(1), uint(3)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 3, Role: teamEntity.TeamRoleMember}, nil).Once()
+		mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(entity.Thread{ID: 5, TeamID: 1, AuthorID: 1}, nil).Once()
+		teamRepository.On("GetTeamMember", uint(1), uint(2)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 2, Role: teamEntity.TeamRoleCoLeader}, nil).Once()
+		mockRepo.On("CreateReply", mock.Anything).Return(entity.Reply{ID: 8}, nil).Once()
+		mockRepo.On("GetReplyByID", uint(5), uint(8)).Return(entity.Reply{ID: 8, ThreadID: 5}, nil).Once()
+		teamRepository.On("GetTeamByID", uint(1)).Return(teamEntity.Team{ID: 1, Name: "Team Rocket"}, nil).Once()
+		userRepository.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2, AnonymizedAt: &anonymizedAt}, nil).Once()
+
+		_, err := testUseCase.CreateReply(1, 5, 3, dto.ReplyRequest{Body: "See you there", MentionedUserIDs: []uint{2}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("empty-body", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(3)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 3, Role: teamEntity.TeamRoleMember}, nil).Once()
+		mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(entity.Thread{ID: 5, TeamID: 1}, nil).Once()
+
+		_, err := testUseCase.CreateReply(1, 5, 3, dto.ReplyRequest{Body: "\n"})
+		assert.EqualError(t, err, "fill the message")
+	})
+}
+
+func TestGetReplies(t *testing.T) {
+	mockRepo := mockRepo.NewDiscussionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	testUseCase := CreateNewDiscussionUseCase(mockRepo, teamRepository, userRepository, policy.CreateNewPolicy(userRepository, teamRepository), mockMailer)
+	teamRepository.On("GetTeamMember", uint(1), uint(2)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 2, Role: teamEntity.TeamRoleCoLeader}, nil).Once()
+	mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(entity.Thread{ID: 5, TeamID: 1}, nil).Once()
+	mockRepo.On("GetRepliesByThreadID", uint(5), 10, 10).Return([]entity.Reply{{ID: 8, ThreadID: 5}}, nil).Once()
+
+	res, err := testUseCase.GetReplies(1, 5, 2, 10, 10)
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+}
+
+func TestUpdateReply(t *testing.T) {
+	mockRepo := mockRepo.NewDiscussionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	testUseCase := CreateNewDiscussionUseCase(mockRepo, teamRepository, userRepository, policy.CreateNewPolicy(userRepository, teamRepository), mockMailer)
+
+	t.Run("success", func(t *testing.T) {
+		replyID := uint(8)
+		teamRepository.On("GetTeamMember", uint(1), uint(3)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 3, Role: teamEntity.TeamRoleMember}, nil).Once()
+		mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(entity.Thread{ID: 5, TeamID: 1}, nil).Once()
+		mockRepo.On("GetReplyByID", uint(5), uint(8)).Return(entity.Reply{ID: 8, ThreadID: 5, AuthorID: 3, Mentions: []entity.Mention{{ThreadID: 5, ReplyID: &replyID, UserID: 1}}}, nil).Once()
+		teamRepository.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
+		mockRepo.On("UpdateReply", entity.Reply{ID: 8, Body: "See you at 7", Mentions: []entity.Mention{{ThreadID: 5, ReplyID: &replyID, UserID: 1}}}).Return(nil).Once()
+		mockRepo.On("GetReplyByID", uint(5), uint(8)).Return(entity.Reply{ID: 8, ThreadID: 5, AuthorID: 3, Body: "See you at 7"}, nil).Once()
+
+		res, err := testUseCase.UpdateReply(1, 5, 8, 3, dto.ReplyRequest{Body: "See you at 7", MentionedUserIDs: []uint{1}})
+		assert.NoError(t, err)
+		assert.Equal(t, "See you at 7", res.Body)
+	})
+
+	t.Run("not-the-author", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(2)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 2, Role: teamEntity.TeamRoleCoLeader}, nil).Once()
+		mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(entity.Thread{ID: 5, TeamID: 1}, nil).Once()
+		mockRepo.On("GetReplyByID", uint(5), uint(8)).Return(entity.Reply{ID: 8, ThreadID: 5, AuthorID: 3}, nil).Once()
+
+		_, err := testUseCase.UpdateReply(1, 5, 8, 2, dto.ReplyRequest{Body: "See you at 7"})
+		assert.EqualError(t, err, "action unauthorized")
+	})
+}
+
+func TestDeleteReply(t *testing.T) {
+	mockRepo := mockRepo.NewDiscussionRepository(t)
+	teamRepository := teamRepo.NewTeamRepository(t)
+	userRepository := userRepo.NewUserRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	testUseCase := CreateNewDiscussionUseCase(mockRepo, teamRepository, userRepository, policy.CreateNewPolicy(userRepository, teamRepository), mockMailer)
+
+	t.Run("success", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(3)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 3, Role: teamEntity.TeamRoleMember}, nil).Once()
+		mockRepo.On("GetThreadByID", uint(1), uint(5)).Return(entity.Thread{ID: 5, TeamID: 1}, nil).Once()
+		mockRepo.On("GetReplyByID", uint(5), uint(8)).Return(entity.Reply{ID: 8, ThreadID: 5, AuthorID: 3}, nil).Once()
+		mockRepo.On("DeleteReply", uint(8)).Return(nil).Once()
+
+		assert.NoError(t, testUseCase.DeleteReply(1, 5, 8, 3))
+	})
+
+	t.Run("former-member", func(t *testing.T) {
+		teamRepository.On("GetTeamMember", uint(1), uint(7)).Return(teamEntity.TeamMember{}, nil).Once()
+
+		assert.EqualError(t, testUseCase.DeleteReply(1, 5, 8, 7), "action unauthorized")
+	})
+}
@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/alimikegami/compnouron/internal/institution/dto"
+	"github.com/alimikegami/compnouron/internal/institution/usecase"
+	"github.com/alimikegami/compnouron/pkg/response"
+	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+type InstitutionController struct {
+	router        *echo.Echo
+	institutionUC usecase.InstitutionUseCase
+}
+
+func (ic *InstitutionController) InitializeInstitutionRoute(config middleware.JWTConfig) {
+	r := ic.router.Group("/institutions")
+	{
+		r.GET("", ic.GetInstitutions)
+		r.GET("/search", ic.SearchInstitutions)
+		r.POST("", ic.CreateInstitution, middleware.JWTWithConfig(config), utils.RequireRole(utils.RoleAdmin))
+	}
+}
+
+// GetInstitutions godoc
+// @Summary      List the institutions
+// @Description  Return the institutions ordered by name with pagination implemented
+// @Tags         Institutions
+// @Produce      json
+// @Param        limit     query      int     false  "rows retrieved limit"
+// @Param        offset    query      int     false  "skipped rows"
+// @Success      200  {object}   response.Response{data=[]dto.InstitutionResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /institutions [get]
+func (ic *InstitutionController) GetInstitutions(c echo.Context) error {
+	limitInt, offsetInt, err := paginationParams(c)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	institutionsResponse, err := ic.institutionUC.GetInstitutions(limitInt, offsetInt)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    institutionsResponse,
+	})
+}
+
+// SearchInstitutions godoc
+// @Summary      Search the institutions
+// @Description  Return the institutions whose name contains the keyword or whose email domain starts with it
+// @Tags         Institutions
+// @Produce      json
+// @Param        keyword   query      string  false  "institution name or domain keyword"
+// @Param        limit     query      int     false  "rows retrieved limit"
+// @Param        offset    query      int     false  "skipped rows"
+// @Success      200  {object}   response.Response{data=[]dto.InstitutionResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /institutions/search [get]
+func (ic *InstitutionController) SearchInstitutions(c echo.Context) error {
+	limitInt, offsetInt, err := paginationParams(c)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	institutionsResponse, err := ic.institutionUC.SearchInstitutions(c.QueryParam("keyword"), limitInt, offsetInt)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    institutionsResponse,
+	})
+}
+
+// CreateInstitution godoc
+// @Summary      Add an institution
+// @Description  Given the request body, create an institution with the email domains its members sign up with. Only admins can manage institutions
+// @Tags         Institutions
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param data body dto.InstitutionRequest true "Request Body"
+// @Success      201  {object}   response.Response{data=dto.InstitutionResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /institutions [post]
+func (ic *InstitutionController) CreateInstitution(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	institution := new(dto.InstitutionRequest)
+	if err := c.Bind(institution); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	institutionResponse, err := ic.institutionUC.CreateInstitution(userID, *institution)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "fill the institution name" || err.Error() == "fill the institution domains" || err.Error() == "invalid domain" {
+			statusCode = http.StatusBadRequest
+		} else if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "institution already exists" || err.Error() == "domain is already used" {
+			statusCode = http.StatusConflict
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusCreated, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    institutionResponse,
+	})
+}
+
+// paginationParams reads the optional limit and offset query parameters.
+func paginationParams(c echo.Context) (int, int, error) {
+	limitInt := 0
+	if limit := c.QueryParam("limit"); limit != "" {
+		var err error
+		limitInt, err = strconv.Atoi(limit)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	offsetInt := 0
+	if offset := c.QueryParam("offset"); offset != "" {
+		var err error
+		offsetInt, err = strconv.Atoi(offset)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return limitInt, offsetInt, nil
+}
+
+func CreateNewInstitutionController(e *echo.Echo, institutionUC usecase.InstitutionUseCase) *InstitutionController {
+	return &InstitutionController{router: e, institutionUC: institutionUC}
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alimikegami/compnouron/internal/institution/dto"
+	mocks "github.com/alimikegami/compnouron/internal/mocks/institution/usecase"
+	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetInstitutions(t *testing.T) {
+	mockUseCase := mocks.NewInstitutionUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("GetInstitutions", 5, 10).Return([]dto.InstitutionResponse{
+			{
+				ID:      1,
+				Name:    "Udayana University",
+				Domains: []string{"unud.ac.id"},
+			},
+		}, nil).Once()
+		req, err := http.NewRequest(http.MethodGet, "/institutions?limit=5&offset=10", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		institutionController := InstitutionController{
+			router:        e,
+			institutionUC: mockUseCase,
+		}
+
+		institutionController.GetInstitutions(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid-offset", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/institutions?offset=abc", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		institutionController := InstitutionController{
+			router:        e,
+			institutionUC: mockUseCase,
+		}
+
+		institutionController.GetInstitutions(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestSearchInstitutions(t *testing.T) {
+	mockUseCase := mocks.NewInstitutionUseCase(t)
+	mockUseCase.On("SearchInstitutions", "unud", 0, 0).Return(nil, errors.New("unexpected error")).Once()
+	req, err := http.NewRequest(http.MethodGet, "/institutions/search?keyword=unud", nil)
+	assert.NoError(t, err, "No request error")
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	institutionController := InstitutionController{
+		router:        e,
+		institutionUC: mockUseCase,
+	}
+
+	institutionController.SearchInstitutions(c)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestCreateInstitution(t *testing.T) {
+	mockUseCase := mocks.NewInstitutionUseCase(t)
+	institutionRequest := dto.InstitutionRequest{
+		Name:    "Udayana University",
+		Domains: []string{"unud.ac.id"},
+	}
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("CreateInstitution", uint(1), institutionRequest).Return(dto.InstitutionResponse{ID: 1, Name: "Udayana University", Domains: []string{"unud.ac.id"}}, nil).Once()
+		jsonReqBody, err := json.Marshal(&institutionRequest)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPost, "/institutions", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleAdmin, 1)
+		c.Set("user", token)
+		institutionController := InstitutionController{
+			router:        e,
+			institutionUC: mockUseCase,
+		}
+
+		institutionController.CreateInstitution(c)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("domain-used", func(t *testing.T) {
+		mockUseCase.On("CreateInstitution", uint(1), institutionRequest).Return(dto.InstitutionResponse{}, errors.New("domain is already used")).Once()
+		jsonReqBody, err := json.Marshal(&institutionRequest)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPost, "/institutions", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleAdmin, 1)
+		c.Set("user", token)
+		institutionController := InstitutionController{
+			router:        e,
+			institutionUC: mockUseCase,
+		}
+
+		institutionController.CreateInstitution(c)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,6 @@
+package dto
+
+type InstitutionRequest struct {
+	Name    string   `json:"name"`
+	Domains []string `json:"domains"`
+}
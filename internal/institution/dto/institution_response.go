@@ -0,0 +1,7 @@
+package dto
+
+type InstitutionResponse struct {
+	ID      uint     `json:"id"`
+	Name    string   `json:"name"`
+	Domains []string `json:"domains"`
+}
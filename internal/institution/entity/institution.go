@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+type Institution struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"unique;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Domains   []InstitutionDomain
+}
+
+// InstitutionDomain is an email domain owned by an institution. Addresses on
+// the domain or on any of its subdomains belong to the institution.
+type InstitutionDomain struct {
+	ID            uint   `gorm:"primaryKey"`
+	InstitutionID uint   `gorm:"not null;index"`
+	Domain        string `gorm:"unique;not null"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/alimikegami/compnouron/internal/institution/entity"
+	userEntity "github.com/alimikegami/compnouron/internal/user/entity"
+	"gorm.io/gorm"
+)
+
+type InstitutionRepository interface {
+	CreateInstitution(institution entity.Institution) (entity.Institution, error)
+	GetInstitutionByName(name string) (entity.Institution, error)
+	GetInstitutionByDomains(domains []string) (entity.Institution, error)
+	GetInstitutions(limit int, offset int) ([]entity.Institution, error)
+	SearchInstitutions(keyword string, limit int, offset int) ([]entity.Institution, error)
+}
+
+type InstitutionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func CreateNewInstitutionRepository(db *gorm.DB) InstitutionRepository {
+	return &InstitutionRepositoryImpl{db: db}
+}
+
+// NormalizeDomain is the form domains are stored and looked up in, so
+// "@Unud.ac.id" and "unud.ac.id" are the same domain.
+func NormalizeDomain(domain string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+}
+
+// EmailDomains returns the domain of the email address followed by each of
+// its parent domains, most specific first, so an address on a faculty's
+// subdomain still belongs to the university.
+func EmailDomains(email string) []string {
+	at := strings.LastIndex(email, "@")
+	if at == -1 {
+		return []string{}
+	}
+
+	domains := []string{}
+	for domain := NormalizeDomain(email[at+1:]); strings.Contains(domain, "."); domain = domain[strings.Index(domain, ".")+1:] {
+		domains = append(domains, domain)
+	}
+
+	return domains
+}
+
+// CreateInstitution also affiliates the users who had already verified an
+// address on one of the institution's domains.
+func (ir *InstitutionRepositoryImpl) CreateInstitution(institution entity.Institution) (entity.Institution, error) {
+	err := ir.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Create(&institution)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		for _, domain := range institution.Domains {
+			result = tx.Model(&userEntity.User{}).Where("verified_at IS NOT NULL AND institution_id IS NULL AND (email LIKE ? OR email LIKE ?)", "%@"+domain.Domain, "%."+domain.Domain).Update("institution_id", institution.ID)
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.Institution{}, err
+	}
+
+	return institution, nil
+}
+
+func (ir *InstitutionRepositoryImpl) GetInstitutionByName(name string) (entity.Institution, error) {
+	var institution entity.Institution
+	result := ir.db.Where("name = ?", name).First(&institution)
+	if result.Error != nil {
+		return entity.Institution{}, result.Error
+	}
+
+	return institution, nil
+}
+
+// GetInstitutionByDomains returns the institution owning the longest of the
+// given domains.
+func (ir *InstitutionRepositoryImpl) GetInstitutionByDomains(domains []string) (entity.Institution, error) {
+	var institution entity.Institution
+	result := ir.db.Joins("JOIN institution_domains ON institution_domains.institution_id = institutions.id").Where("institution_domains.domain IN ?", domains).Order("LENGTH(institution_domains.domain) DESC").First(&institution)
+	if result.Error != nil {
+		return entity.Institution{}, result.Error
+	}
+
+	return institution, nil
+}
+
+func (ir *InstitutionRepositoryImpl) GetInstitutions(limit int, offset int) ([]entity.Institution, error) {
+	var institutions []entity.Institution
+	result := ir.db.Preload("Domains").Order("name").Limit(limit).Offset(offset).Find(&institutions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return institutions, nil
+}
+
+func (ir *InstitutionRepositoryImpl) SearchInstitutions(keyword string, limit int, offset int) ([]entity.Institution, error) {
+	var institutions []entity.Institution
+	result := ir.db.Preload("Domains").Where("name LIKE ?", "%"+keyword+"%").Or("id IN (?)", ir.db.Model(&entity.InstitutionDomain{}).Select("institution_id").Where("domain LIKE ?", NormalizeDomain(keyword)+"%")).Order("name").Limit(limit).Offset(offset).Find(&institutions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return institutions, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alimikegami/compnouron/internal/institution/entity"
+	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestEmailDomains(t *testing.T) {
+	assert.Equal(t, []string{"student.unud.ac.id", "unud.ac.id", "ac.id"}, EmailDomains("Alim@Student.Unud.ac.id"))
+	assert.Equal(t, []string{"gmail.com"}, EmailDomains("alim@gmail.com"))
+	assert.Equal(t, []string{}, EmailDomains("alim"))
+	assert.Equal(t, "unud.ac.id", NormalizeDomain(" @UNUD.ac.id "))
+}
+
+func TestCreateInstitution(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	institutionRepo := CreateNewInstitutionRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `institutions` (`name`,`created_at`,`updated_at`) VALUES (?,?,?)")).WithArgs("Udayana University", utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `institution_domains` (`institution_id`,`domain`,`created_at`,`updated_at`) VALUES (?,?,?,?) ON DUPLICATE KEY UPDATE `institution_id`=VALUES(`institution_id`)")).WithArgs(1, "unud.ac.id", utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `institution_id`=?,`updated_at`=? WHERE verified_at IS NOT NULL AND institution_id IS NULL AND (email LIKE ? OR email LIKE ?)")).WithArgs(1, utils.AnyTime{}, "%@unud.ac.id", "%.unud.ac.id").WillReturnResult(sqlmock.NewResult(0, 3))
+		mockObj.ExpectCommit()
+
+		institution, err := institutionRepo.CreateInstitution(entity.Institution{
+			Name:    "Udayana University",
+			Domains: []entity.InstitutionDomain{{Domain: "unud.ac.id"}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), institution.ID)
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `institutions` (`name`,`created_at`,`updated_at`) VALUES (?,?,?)")).WithArgs("Udayana University", utils.AnyTime{}, utils.AnyTime{}).WillReturnError(errors.New("unexpected error"))
+		mockObj.ExpectRollback()
+
+		_, err := institutionRepo.CreateInstitution(entity.Institution{Name: "Udayana University"})
+		assert.Error(t, err)
+	})
+}
+
+func TestGetInstitutionByDomains(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	institutionRepo := CreateNewInstitutionRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Udayana University")
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT `institutions`.`id`,`institutions`.`name`,`institutions`.`created_at`,`institutions`.`updated_at` FROM `institutions` JOIN institution_domains ON institution_domains.institution_id = institutions.id WHERE institution_domains.domain IN (?,?) ORDER BY LENGTH(institution_domains.domain) DESC,`institutions`.`id` LIMIT 1")).WithArgs("student.unud.ac.id", "unud.ac.id").WillReturnRows(rows)
+
+		institution, err := institutionRepo.GetInstitutionByDomains([]string{"student.unud.ac.id", "unud.ac.id"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Udayana University", institution.Name)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockObj.ExpectQuery(regexp.QuoteMeta("FROM `institutions` JOIN institution_domains")).WithArgs("gmail.com").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+		_, err := institutionRepo.GetInstitutionByDomains([]string{"gmail.com"})
+		assert.Equal(t, gorm.ErrRecordNotFound, err)
+	})
+}
+
+func TestSearchInstitutions(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	institutionRepo := CreateNewInstitutionRepository(db)
+
+	defer mockedDB.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Udayana University")
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `institutions` WHERE name LIKE ? OR id IN (SELECT `institution_id` FROM `institution_domains` WHERE domain LIKE ?) ORDER BY name LIMIT 10")).WithArgs("%Unud%", "unud%").WillReturnRows(rows)
+	domainRows := sqlmock.NewRows([]string{"id", "institution_id", "domain"}).AddRow(1, 1, "unud.ac.id")
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `institution_domains` WHERE `institution_domains`.`institution_id` = ?")).WithArgs(1).WillReturnRows(domainRows)
+
+	institutions, err := institutionRepo.SearchInstitutions("Unud", 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, institutions, 1)
+	assert.Equal(t, "unud.ac.id", institutions[0].Domains[0].Domain)
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/alimikegami/compnouron/internal/institution/dto"
+	"github.com/alimikegami/compnouron/internal/institution/entity"
+	"github.com/alimikegami/compnouron/internal/institution/repository"
+	"github.com/alimikegami/compnouron/internal/policy"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultListLimit = 10
+	maxListLimit     = 50
+)
+
+var domainPattern = regexp.MustCompile(`^[a-z0-9-]+(\.[a-z0-9-]+)+$`)
+
+type InstitutionUseCase interface {
+	CreateInstitution(userID uint, institution dto.InstitutionRequest) (dto.InstitutionResponse, error)
+	GetInstitutions(limit int, offset int) ([]dto.InstitutionResponse, error)
+	SearchInstitutions(keyword string, limit int, offset int) ([]dto.InstitutionResponse, error)
+}
+
+type InstitutionUseCaseImpl struct {
+	ir repository.InstitutionRepository
+	p  policy.Policy
+}
+
+func CreateNewInstitutionUseCase(ir repository.InstitutionRepository, p policy.Policy) InstitutionUseCase {
+	return &InstitutionUseCaseImpl{ir: ir, p: p}
+}
+
+func (iuc *InstitutionUseCaseImpl) CreateInstitution(userID uint, institution dto.InstitutionRequest) (dto.InstitutionResponse, error) {
+	err := iuc.p.CanManageInstitutions(userID)
+	if err != nil {
+		return dto.InstitutionResponse{}, err
+	}
+
+	name := strings.Join(strings.Fields(institution.Name), " ")
+	if name == "" {
+		return dto.InstitutionResponse{}, errors.New("fill the institution name")
+	}
+
+	_, err = iuc.ir.GetInstitutionByName(name)
+	if err == nil {
+		return dto.InstitutionResponse{}, errors.New("institution already exists")
+	}
+	if err != gorm.ErrRecordNotFound {
+		return dto.InstitutionResponse{}, err
+	}
+
+	institutionEntity := entity.Institution{Name: name}
+	seen := map[string]bool{}
+	for _, domain := range institution.Domains {
+		domain = repository.NormalizeDomain(domain)
+		if seen[domain] {
+			continue
+		}
+		seen[domain] = true
+
+		if !domainPattern.MatchString(domain) {
+			return dto.InstitutionResponse{}, errors.New("invalid domain")
+		}
+
+		_, err = iuc.ir.GetInstitutionByDomains([]string{domain})
+		if err == nil {
+			return dto.InstitutionResponse{}, errors.New("domain is already used")
+		}
+		if err != gorm.ErrRecordNotFound {
+			return dto.InstitutionResponse{}, err
+		}
+
+		institutionEntity.Domains = append(institutionEntity.Domains, entity.InstitutionDomain{Domain: domain})
+	}
+
+	if len(institutionEntity.Domains) == 0 {
+		return dto.InstitutionResponse{}, errors.New("fill the institution domains")
+	}
+
+	institutionEntity, err = iuc.ir.CreateInstitution(institutionEntity)
+	if err != nil {
+		return dto.InstitutionResponse{}, err
+	}
+
+	return toInstitutionResponse(institutionEntity), nil
+}
+
+func (iuc *InstitutionUseCaseImpl) GetInstitutions(limit int, offset int) ([]dto.InstitutionResponse, error) {
+	institutions, err := iuc.ir.GetInstitutions(listLimit(limit), offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return toInstitutionResponses(institutions), nil
+}
+
+func (iuc *InstitutionUseCaseImpl) SearchInstitutions(keyword string, limit int, offset int) ([]dto.InstitutionResponse, error) {
+	institutions, err := iuc.ir.SearchInstitutions(strings.TrimSpace(keyword), listLimit(limit), offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return toInstitutionResponses(institutions), nil
+}
+
+func listLimit(limit int) int {
+	if limit <= 0 {
+		return defaultListLimit
+	}
+	if limit > maxListLimit {
+		return maxListLimit
+	}
+
+	return limit
+}
+
+func toInstitutionResponses(institutions []entity.Institution) []dto.InstitutionResponse {
+	institutionsResponse := []dto.InstitutionResponse{}
+	for _, institution := range institutions {
+		institutionsResponse = append(institutionsResponse, toInstitutionResponse(institution))
+	}
+
+	return institutionsResponse
+}
+
+func toInstitutionResponse(institution entity.Institution) dto.InstitutionResponse {
+	domains := []string{}
+	for _, domain := range institution.Domains {
+		domains = append(domains, domain.Domain)
+	}
+
+	return dto.InstitutionResponse{
+		ID:      institution.ID,
+		Name:    institution.Name,
+		Domains: domains,
+	}
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/alimikegami/compnouron/internal/institution/dto"
+	"github.com/alimikegami/compnouron/internal/institution/entity"
+	institutionRepo "github.com/alimikegami/compnouron/internal/mocks/institution/repository"
+	userRepo "github.com/alimikegami/compnouron/internal/mocks/user/repository"
+	"github.com/alimikegami/compnouron/internal/policy"
+	userEntity "github.com/alimikegami/compnouron/internal/user/entity"
+	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateInstitution(t *testing.T) {
+	mockRepo := institutionRepo.NewInstitutionRepository(t)
+	mockUserRepo := userRepo.NewUserRepository(t)
+	testUseCase := CreateNewInstitutionUseCase(mockRepo, policy.CreateNewPolicy(mockUserRepo, nil))
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		mockRepo.On("GetInstitutionByName", "Udayana University").Return(entity.Institution{}, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("GetInstitutionByDomains", []string{"unud.ac.id"}).Return(entity.Institution{}, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("GetInstitutionByDomains", []string{"student.unud.ac.id"}).Return(entity.Institution{}, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("CreateInstitution", entity.Institution{
+			Name:    "Udayana University",
+			Domains: []entity.InstitutionDomain{{Domain: "unud.ac.id"}, {Domain: "student.unud.ac.id"}},
+		}).Return(entity.Institution{
+			ID:      1,
+			Name:    "Udayana University",
+			Domains: []entity.InstitutionDomain{{ID: 1, InstitutionID: 1, Domain: "unud.ac.id"}, {ID: 2, InstitutionID: 1, Domain: "student.unud.ac.id"}},
+		}, nil).Once()
+		res, err := testUseCase.CreateInstitution(1, dto.InstitutionRequest{
+			Name:    " Udayana  University ",
+			Domains: []string{"@Unud.ac.id", "unud.ac.id", "student.unud.ac.id"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, dto.InstitutionResponse{ID: 1, Name: "Udayana University", Domains: []string{"unud.ac.id", "student.unud.ac.id"}}, res)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("action-unauthorized", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2, Role: utils.RoleStudent}, nil).Once()
+		_, err := testUseCase.CreateInstitution(2, dto.InstitutionRequest{Name: "Udayana University"})
+		assert.EqualError(t, err, "action unauthorized")
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid-domain", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		mockRepo.On("GetInstitutionByName", "Udayana University").Return(entity.Institution{}, gorm.ErrRecordNotFound).Once()
+		_, err := testUseCase.CreateInstitution(1, dto.InstitutionRequest{Name: "Udayana University", Domains: []string{"unud_ac.id"}})
+		assert.EqualError(t, err, "invalid domain")
+	})
+
+	t.Run("no-domains", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		mockRepo.On("GetInstitutionByName", "Udayana University").Return(entity.Institution{}, gorm.ErrRecordNotFound).Once()
+		_, err := testUseCase.CreateInstitution(1, dto.InstitutionRequest{Name: "Udayana University"})
+		assert.EqualError(t, err, "fill the institution domains")
+	})
+
+	t.Run("domain-used", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		mockRepo.On("GetInstitutionByName", "Udayana University").Return(entity.Institution{}, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("GetInstitutionByDomains", []string{"unud.ac.id"}).Return(entity.Institution{ID: 3}, nil).Once()
+		_, err := testUseCase.CreateInstitution(1, dto.InstitutionRequest{Name: "Udayana University", Domains: []string{"unud.ac.id"}})
+		assert.EqualError(t, err, "domain is already used")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("institution-exists", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		mockRepo.On("GetInstitutionByName", "Udayana University").Return(entity.Institution{ID: 1, Name: "Udayana University"}, nil).Once()
+		_, err := testUseCase.CreateInstitution(1, dto.InstitutionRequest{Name: "Udayana University", Domains: []string{"unud.ac.id"}})
+		assert.EqualError(t, err, "institution already exists")
+	})
+}
+
+func TestGetInstitutions(t *testing.T) {
+	mockRepo := institutionRepo.NewInstitutionRepository(t)
+	testUseCase := CreateNewInstitutionUseCase(mockRepo, nil)
+	t.Run("default-limit", func(t *testing.T) {
+		mockRepo.On("GetInstitutions", 10, 0).Return([]entity.Institution{}, nil).Once()
+		res, err := testUseCase.GetInstitutions(0, 0)
+		assert.NoError(t, err)
+		assert.Len(t, res, 0)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("capped-limit", func(t *testing.T) {
+		mockRepo.On("SearchInstitutions", "unud", 50, 20).Return([]entity.Institution{{ID: 1, Name: "Udayana University"}}, nil).Once()
+		res, err := testUseCase.SearchInstitutions(" unud ", 500, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, []dto.InstitutionResponse{{ID: 1, Name: "Udayana University", Domains: []string{}}}, res)
+		mockRepo.AssertExpectations(t)
+	})
+}
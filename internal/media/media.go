@@ -0,0 +1,118 @@
+// Package media stores the images attached to users, teams and competitions.
+// Entities only keep the storage key of the original image; the thumbnail sits
+// next to it under a derived key and both URLs are computed from the key, so
+// moving to another storage backend doesn't require rewriting rows.
+package media
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"path"
+	"strings"
+
+	"github.com/alimikegami/compnouron/pkg/imaging"
+	"github.com/alimikegami/compnouron/pkg/storage"
+)
+
+// Spec is what an upload of one kind of image must satisfy.
+type Spec struct {
+	MaxBytes        int
+	ThumbnailWidth  int
+	ThumbnailHeight int
+}
+
+var (
+	AvatarSpec            = Spec{MaxBytes: 2 << 20, ThumbnailWidth: 128, ThumbnailHeight: 128}
+	TeamLogoSpec          = Spec{MaxBytes: 2 << 20, ThumbnailWidth: 128, ThumbnailHeight: 128}
+	CompetitionBannerSpec = Spec{MaxBytes: 5 << 20, ThumbnailWidth: 480, ThumbnailHeight: 160}
+)
+
+// MaxUploadBytes is the largest upload any spec accepts. Controllers pass it
+// to utils.ReadUploadedFile, which stops reading the request body shortly past
+// it.
+const MaxUploadBytes = 5 << 20
+
+// Image is how an image appears in responses.
+type Image struct {
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnailURL"`
+}
+
+type Uploader interface {
+	// Upload validates the image against spec, strips its metadata, stores it
+	// with a thumbnail under prefix and returns its key.
+	Upload(prefix string, data []byte, spec Spec) (string, error)
+	// Delete removes the image and its thumbnail.
+	Delete(key string) error
+	// Image returns the URLs of the image, or nil when key is empty.
+	Image(key string) *Image
+}
+
+type UploaderImpl struct {
+	s storage.Storage
+}
+
+func CreateNewUploader(s storage.Storage) Uploader {
+	return &UploaderImpl{s: s}
+}
+
+func (u *UploaderImpl) Upload(prefix string, data []byte, spec Spec) (string, error) {
+	img, contentType, err := imaging.Decode(data, spec.MaxBytes)
+	if err != nil {
+		return "", err
+	}
+
+	original, err := imaging.Encode(img, contentType)
+	if err != nil {
+		return "", err
+	}
+
+	thumbnail, err := imaging.Encode(imaging.Thumbnail(img, spec.ThumbnailWidth, spec.ThumbnailHeight), contentType)
+	if err != nil {
+		return "", err
+	}
+
+	// a fresh name for every upload, so caches never serve the old image
+	name := make([]byte, 16)
+	_, err = rand.Read(name)
+	if err != nil {
+		return "", err
+	}
+	key := prefix + "/" + hex.EncodeToString(name) + original.Extension
+
+	err = u.s.Put(key, original.ContentType, original.Data)
+	if err != nil {
+		return "", err
+	}
+
+	err = u.s.Put(ThumbnailKey(key), thumbnail.ContentType, thumbnail.Data)
+	if err != nil {
+		u.s.Delete(key)
+		return "", err
+	}
+
+	return key, nil
+}
+
+func (u *UploaderImpl) Delete(key string) error {
+	err := u.s.Delete(key)
+	if err != nil {
+		return err
+	}
+
+	return u.s.Delete(ThumbnailKey(key))
+}
+
+func (u *UploaderImpl) Image(key string) *Image {
+	if key == "" {
+		return nil
+	}
+
+	return &Image{URL: u.s.URL(key), ThumbnailURL: u.s.URL(ThumbnailKey(key))}
+}
+
+// ThumbnailKey returns the key of the thumbnail of the image stored under key.
+func ThumbnailKey(key string) string {
+	extension := path.Ext(key)
+	return strings.TrimSuffix(key, extension) + "_thumb" + extension
+}
@@ -0,0 +1,70 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"strings"
+	"testing"
+
+	"github.com/alimikegami/compnouron/pkg/storage"
+	"github.com/alimikegami/compnouron/pkg/storage/s3test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploader(t *testing.T) {
+	server := s3test.NewServer("compnouron")
+	defer server.Close()
+	uploader := CreateNewUploader(storage.CreateNewS3Storage(server.Config(), server.Client()))
+
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 300, 200)), nil))
+
+	t.Run("upload", func(t *testing.T) {
+		key, err := uploader.Upload("avatars/1", buf.Bytes(), AvatarSpec)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(key, "avatars/1/"))
+		assert.True(t, strings.HasSuffix(key, ".jpg"))
+
+		_, contentType, ok := server.Object(key)
+		assert.True(t, ok)
+		assert.Equal(t, "image/jpeg", contentType)
+
+		thumbnail, _, ok := server.Object(ThumbnailKey(key))
+		assert.True(t, ok)
+		config, err := jpeg.DecodeConfig(bytes.NewReader(thumbnail))
+		assert.NoError(t, err)
+		assert.Equal(t, 128, config.Width)
+		assert.Equal(t, 128, config.Height)
+
+		assert.Equal(t, &Image{
+			URL:          server.URL + "/compnouron/" + key,
+			ThumbnailURL: server.URL + "/compnouron/" + ThumbnailKey(key),
+		}, uploader.Image(key))
+
+		assert.NoError(t, uploader.Delete(key))
+		_, _, ok = server.Object(key)
+		assert.False(t, ok)
+		_, _, ok = server.Object(ThumbnailKey(key))
+		assert.False(t, ok)
+	})
+
+	t.Run("too-large", func(t *testing.T) {
+		_, err := uploader.Upload("avatars/1", buf.Bytes(), Spec{MaxBytes: 10, ThumbnailWidth: 1, ThumbnailHeight: 1})
+		assert.EqualError(t, err, "file too large")
+	})
+
+	t.Run("not-an-image", func(t *testing.T) {
+		_, err := uploader.Upload("avatars/1", []byte("%PDF-1.4"), AvatarSpec)
+		assert.EqualError(t, err, "unsupported image type")
+	})
+
+	t.Run("no-image", func(t *testing.T) {
+		assert.Nil(t, uploader.Image(""))
+	})
+}
+
+func TestThumbnailKey(t *testing.T) {
+	assert.Equal(t, "avatars/1/ab_thumb.png", ThumbnailKey("avatars/1/ab.png"))
+	assert.Equal(t, "banners/2/cd_thumb.jpg", ThumbnailKey("banners/2/cd.jpg"))
+}
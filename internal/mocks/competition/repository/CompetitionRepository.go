@@ -4,6 +4,8 @@ package mocks
 
 import (
 	entity "github.com/alimikegami/compnouron/internal/competition/entity"
+	time "time"
+
 	mock "github.com/stretchr/testify/mock"
 
 	testing "testing"
@@ -91,6 +93,29 @@ func (_m *CompetitionRepository) GetAcceptedCompetitionParticipants(competitionI
 	return r0, r1
 }
 
+// GetAchievementsByUserID provides a mock function with given fields: userID, publishedBefore
+func (_m *CompetitionRepository) GetAchievementsByUserID(userID uint, publishedBefore time.Time) ([]entity.Achievement, error) {
+	ret := _m.Called(userID, publishedBefore)
+
+	var r0 []entity.Achievement
+	if rf, ok := ret.Get(0).(func(uint, time.Time) []entity.Achievement); ok {
+		r0 = rf(userID, publishedBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Achievement)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, time.Time) error); ok {
+		r1 = rf(userID, publishedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCompetitionByID provides a mock function with given fields: ID
 func (_m *CompetitionRepository) GetCompetitionByID(ID uint) (entity.Competition, error) {
 	ret := _m.Called(ID)
@@ -179,6 +204,29 @@ func (_m *CompetitionRepository) GetCompetitionRegistrationByUserID(userID uint)
 	return r0, r1
 }
 
+// GetCompetitionResults provides a mock function with given fields: competitionID
+func (_m *CompetitionRepository) GetCompetitionResults(competitionID uint) ([]entity.CompetitionResult, error) {
+	ret := _m.Called(competitionID)
+
+	var r0 []entity.CompetitionResult
+	if rf, ok := ret.Get(0).(func(uint) []entity.CompetitionResult); ok {
+		r0 = rf(competitionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.CompetitionResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(competitionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCompetitions provides a mock function with given fields: limit, offset
 func (_m *CompetitionRepository) GetCompetitions(limit int, offset int) ([]entity.Competition, error) {
 	ret := _m.Called(limit, offset)
@@ -202,6 +250,29 @@ func (_m *CompetitionRepository) GetCompetitions(limit int, offset int) ([]entit
 	return r0, r1
 }
 
+// GetRegistrantIDsByOrganizer provides a mock function with given fields: organizerID
+func (_m *CompetitionRepository) GetRegistrantIDsByOrganizer(organizerID uint) ([]uint, error) {
+	ret := _m.Called(organizerID)
+
+	var r0 []uint
+	if rf, ok := ret.Get(0).(func(uint) []uint); ok {
+		r0 = rf(organizerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(organizerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // OpenCompetitionRegistrationPeriod provides a mock function with given fields: id
 func (_m *CompetitionRepository) OpenCompetitionRegistrationPeriod(id uint) error {
 	ret := _m.Called(id)
@@ -216,6 +287,20 @@ func (_m *CompetitionRepository) OpenCompetitionRegistrationPeriod(id uint) erro
 	return r0
 }
 
+// PublishCompetitionResults provides a mock function with given fields: competitionID, publishAt, results
+func (_m *CompetitionRepository) PublishCompetitionResults(competitionID uint, publishAt time.Time, results []entity.CompetitionResult) error {
+	ret := _m.Called(competitionID, publishAt, results)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, time.Time, []entity.CompetitionResult) error); ok {
+		r0 = rf(competitionID, publishAt, results)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Register provides a mock function with given fields: competitionRegistration
 func (_m *CompetitionRepository) Register(competitionRegistration entity.CompetitionRegistration) error {
 	ret := _m.Called(competitionRegistration)
@@ -281,6 +366,20 @@ func (_m *CompetitionRepository) UpdateCompetition(competition entity.Competitio
 	return r0
 }
 
+// UpdateCompetitionBanner provides a mock function with given fields: id, bannerKey
+func (_m *CompetitionRepository) UpdateCompetitionBanner(id uint, bannerKey string) error {
+	ret := _m.Called(id, bannerKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
+		r0 = rf(id, bannerKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewCompetitionRepository creates a new instance of CompetitionRepository. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
 func NewCompetitionRepository(t testing.TB) *CompetitionRepository {
 	mock := &CompetitionRepository{}
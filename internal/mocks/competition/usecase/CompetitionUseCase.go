@@ -4,6 +4,8 @@ package mocks
 
 import (
 	dto "github.com/alimikegami/compnouron/internal/competition/dto"
+	media "github.com/alimikegami/compnouron/internal/media"
+
 	mock "github.com/stretchr/testify/mock"
 
 	testing "testing"
@@ -70,6 +72,20 @@ func (_m *CompetitionUseCase) DeleteCompetition(competitionID uint, userID uint)
 	return r0
 }
 
+// DeleteCompetitionBanner provides a mock function with given fields: id, userID
+func (_m *CompetitionUseCase) DeleteCompetitionBanner(id uint, userID uint) error {
+	ret := _m.Called(id, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetAcceptedCompetitionParticipants provides a mock function with given fields: id, userID
 func (_m *CompetitionUseCase) GetAcceptedCompetitionParticipants(id uint, userID uint) (interface{}, error) {
 	ret := _m.Called(id, userID)
@@ -137,6 +153,29 @@ func (_m *CompetitionUseCase) GetCompetitionRegistration(id uint, userID uint) (
 	return r0, r1
 }
 
+// GetCompetitionResults provides a mock function with given fields: id, viewerID
+func (_m *CompetitionUseCase) GetCompetitionResults(id uint, viewerID uint) ([]dto.CompetitionResultResponse, error) {
+	ret := _m.Called(id, viewerID)
+
+	var r0 []dto.CompetitionResultResponse
+	if rf, ok := ret.Get(0).(func(uint, uint) []dto.CompetitionResultResponse); ok {
+		r0 = rf(id, viewerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.CompetitionResultResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(id, viewerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCompetitions provides a mock function with given fields: limit, offset
 func (_m *CompetitionUseCase) GetCompetitions(limit int, offset int) ([]dto.CompetitionResponse, error) {
 	ret := _m.Called(limit, offset)
@@ -174,6 +213,20 @@ func (_m *CompetitionUseCase) OpenCompetitionRegistrationPeriod(id uint, userID
 	return r0
 }
 
+// PublishResults provides a mock function with given fields: id, userID, request
+func (_m *CompetitionUseCase) PublishResults(id uint, userID uint, request dto.CompetitionResultsRequest) error {
+	ret := _m.Called(id, userID, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint, dto.CompetitionResultsRequest) error); ok {
+		r0 = rf(id, userID, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Register provides a mock function with given fields: competitionRegistration, userID
 func (_m *CompetitionUseCase) Register(competitionRegistration dto.CompetitionRegistrationRequest, userID uint) error {
 	ret := _m.Called(competitionRegistration, userID)
@@ -239,6 +292,29 @@ func (_m *CompetitionUseCase) UpdateCompetition(competition dto.CompetitionReque
 	return r0
 }
 
+// UploadCompetitionBanner provides a mock function with given fields: id, userID, data
+func (_m *CompetitionUseCase) UploadCompetitionBanner(id uint, userID uint, data []byte) (*media.Image, error) {
+	ret := _m.Called(id, userID, data)
+
+	var r0 *media.Image
+	if rf, ok := ret.Get(0).(func(uint, uint, []byte) *media.Image); ok {
+		r0 = rf(id, userID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*media.Image)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, uint, []byte) error); ok {
+		r1 = rf(id, userID, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewCompetitionUseCase creates a new instance of CompetitionUseCase. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
 func NewCompetitionUseCase(t testing.TB) *CompetitionUseCase {
 	mock := &CompetitionUseCase{}
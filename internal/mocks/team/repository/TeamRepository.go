@@ -14,13 +14,13 @@ type TeamRepository struct {
 	mock.Mock
 }
 
-// AddTeamMember provides a mock function with given fields: userID, teamID, isLeader
-func (_m *TeamRepository) AddTeamMember(userID uint, teamID uint, isLeader uint) error {
-	ret := _m.Called(userID, teamID, isLeader)
+// AcceptTeamInvitation provides a mock function with given fields: invitation, userID
+func (_m *TeamRepository) AcceptTeamInvitation(invitation entity.TeamInvitation, userID uint) error {
+	ret := _m.Called(invitation, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint, uint) error); ok {
-		r0 = rf(userID, teamID, isLeader)
+	if rf, ok := ret.Get(0).(func(entity.TeamInvitation, uint) error); ok {
+		r0 = rf(invitation, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -28,6 +28,41 @@ func (_m *TeamRepository) AddTeamMember(userID uint, teamID uint, isLeader uint)
 	return r0
 }
 
+// AddTeamMember provides a mock function with given fields: userID, teamID, role
+func (_m *TeamRepository) AddTeamMember(userID uint, teamID uint, role string) error {
+	ret := _m.Called(userID, teamID, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint, string) error); ok {
+		r0 = rf(userID, teamID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountTeamMembers provides a mock function with given fields: teamID
+func (_m *TeamRepository) CountTeamMembers(teamID uint) (int64, error) {
+	ret := _m.Called(teamID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(uint) int64); ok {
+		r0 = rf(teamID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateTeam provides a mock function with given fields: team
 func (_m *TeamRepository) CreateTeam(team entity.Team) (entity.Team, error) {
 	ret := _m.Called(team)
@@ -49,6 +84,27 @@ func (_m *TeamRepository) CreateTeam(team entity.Team) (entity.Team, error) {
 	return r0, r1
 }
 
+// CreateTeamInvitation provides a mock function with given fields: invitation
+func (_m *TeamRepository) CreateTeamInvitation(invitation entity.TeamInvitation) (entity.TeamInvitation, error) {
+	ret := _m.Called(invitation)
+
+	var r0 entity.TeamInvitation
+	if rf, ok := ret.Get(0).(func(entity.TeamInvitation) entity.TeamInvitation); ok {
+		r0 = rf(invitation)
+	} else {
+		r0 = ret.Get(0).(entity.TeamInvitation)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(entity.TeamInvitation) error); ok {
+		r1 = rf(invitation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteTeam provides a mock function with given fields: id
 func (_m *TeamRepository) DeleteTeam(id uint) error {
 	ret := _m.Called(id)
@@ -63,6 +119,20 @@ func (_m *TeamRepository) DeleteTeam(id uint) error {
 	return r0
 }
 
+// DeleteTeamInvitation provides a mock function with given fields: teamID, id
+func (_m *TeamRepository) DeleteTeamInvitation(teamID uint, id uint) error {
+	ret := _m.Called(teamID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(teamID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetTeamByID provides a mock function with given fields: teamID
 func (_m *TeamRepository) GetTeamByID(teamID uint) (entity.Team, error) {
 	ret := _m.Called(teamID)
@@ -84,8 +154,163 @@ func (_m *TeamRepository) GetTeamByID(teamID uint) (entity.Team, error) {
 	return r0, r1
 }
 
-// GetTeamLeader provides a mock function with given fields: teamID
-func (_m *TeamRepository) GetTeamLeader(teamID uint) (uint, error) {
+// GetTeamForUpdate provides a mock function with given fields: teamID
+func (_m *TeamRepository) GetTeamForUpdate(teamID uint) (entity.Team, error) {
+	ret := _m.Called(teamID)
+
+	var r0 entity.Team
+	if rf, ok := ret.Get(0).(func(uint) entity.Team); ok {
+		r0 = rf(teamID)
+	} else {
+		r0 = ret.Get(0).(entity.Team)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTeamInvitationByID provides a mock function with given fields: id
+func (_m *TeamRepository) GetTeamInvitationByID(id uint) (entity.TeamInvitation, error) {
+	ret := _m.Called(id)
+
+	var r0 entity.TeamInvitation
+	if rf, ok := ret.Get(0).(func(uint) entity.TeamInvitation); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(entity.TeamInvitation)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTeamInvitationsByInviterID provides a mock function with given fields: inviterID
+func (_m *TeamRepository) GetTeamInvitationsByInviterID(inviterID uint) ([]entity.TeamInvitation, error) {
+	ret := _m.Called(inviterID)
+
+	var r0 []entity.TeamInvitation
+	if rf, ok := ret.Get(0).(func(uint) []entity.TeamInvitation); ok {
+		r0 = rf(inviterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.TeamInvitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(inviterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTeamInvitationsByTeamID provides a mock function with given fields: teamID
+func (_m *TeamRepository) GetTeamInvitationsByTeamID(teamID uint) ([]entity.TeamInvitation, error) {
+	ret := _m.Called(teamID)
+
+	var r0 []entity.TeamInvitation
+	if rf, ok := ret.Get(0).(func(uint) []entity.TeamInvitation); ok {
+		r0 = rf(teamID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.TeamInvitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTeamInvitationsForUser provides a mock function with given fields: userID, email
+func (_m *TeamRepository) GetTeamInvitationsForUser(userID uint, email string) ([]entity.TeamInvitation, error) {
+	ret := _m.Called(userID, email)
+
+	var r0 []entity.TeamInvitation
+	if rf, ok := ret.Get(0).(func(uint, string) []entity.TeamInvitation); ok {
+		r0 = rf(userID, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.TeamInvitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, string) error); ok {
+		r1 = rf(userID, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTeamMember provides a mock function with given fields: teamID, userID
+func (_m *TeamRepository) GetTeamMember(teamID uint, userID uint) (entity.TeamMember, error) {
+	ret := _m.Called(teamID, userID)
+
+	var r0 entity.TeamMember
+	if rf, ok := ret.Get(0).(func(uint, uint) entity.TeamMember); ok {
+		r0 = rf(teamID, userID)
+	} else {
+		r0 = ret.Get(0).(entity.TeamMember)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(teamID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTeamMembershipsByUserID provides a mock function with given fields: userID
+func (_m *TeamRepository) GetTeamMembershipsByUserID(userID uint) ([]entity.TeamMember, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entity.TeamMember
+	if rf, ok := ret.Get(0).(func(uint) []entity.TeamMember); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.TeamMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTeamOwner provides a mock function with given fields: teamID
+func (_m *TeamRepository) GetTeamOwner(teamID uint) (uint, error) {
 	ret := _m.Called(teamID)
 
 	var r0 uint
@@ -105,6 +330,29 @@ func (_m *TeamRepository) GetTeamLeader(teamID uint) (uint, error) {
 	return r0, r1
 }
 
+// GetTeammateIDs provides a mock function with given fields: userID
+func (_m *TeamRepository) GetTeammateIDs(userID uint) ([]uint, error) {
+	ret := _m.Called(userID)
+
+	var r0 []uint
+	if rf, ok := ret.Get(0).(func(uint) []uint); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTeamsByUserID provides a mock function with given fields: ID
 func (_m *TeamRepository) GetTeamsByUserID(ID uint) ([]entity.Team, error) {
 	ret := _m.Called(ID)
@@ -128,6 +376,55 @@ func (_m *TeamRepository) GetTeamsByUserID(ID uint) ([]entity.Team, error) {
 	return r0, r1
 }
 
+// HasPendingTeamInvitation provides a mock function with given fields: teamID, userID, email
+func (_m *TeamRepository) HasPendingTeamInvitation(teamID uint, userID uint, email string) (bool, error) {
+	ret := _m.Called(teamID, userID, email)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint, uint, string) bool); ok {
+		r0 = rf(teamID, userID, email)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, uint, string) error); ok {
+		r1 = rf(teamID, userID, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveTeamMember provides a mock function with given fields: teamID, userID
+func (_m *TeamRepository) RemoveTeamMember(teamID uint, userID uint) error {
+	ret := _m.Called(teamID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(teamID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TransferTeamOwnership provides a mock function with given fields: teamID, fromUserID, toUserID
+func (_m *TeamRepository) TransferTeamOwnership(teamID uint, fromUserID uint, toUserID uint) error {
+	ret := _m.Called(teamID, fromUserID, toUserID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) error); ok {
+		r0 = rf(teamID, fromUserID, toUserID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTeam provides a mock function with given fields: team
 func (_m *TeamRepository) UpdateTeam(team entity.Team) error {
 	ret := _m.Called(team)
@@ -142,6 +439,48 @@ func (_m *TeamRepository) UpdateTeam(team entity.Team) error {
 	return r0
 }
 
+// UpdateTeamInvitationStatus provides a mock function with given fields: id, status
+func (_m *TeamRepository) UpdateTeamInvitationStatus(id uint, status string) error {
+	ret := _m.Called(id, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
+		r0 = rf(id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTeamLogo provides a mock function with given fields: id, logoKey
+func (_m *TeamRepository) UpdateTeamLogo(id uint, logoKey string) error {
+	ret := _m.Called(id, logoKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
+		r0 = rf(id, logoKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTeamMemberRole provides a mock function with given fields: teamID, userID, role
+func (_m *TeamRepository) UpdateTeamMemberRole(teamID uint, userID uint, role string) error {
+	ret := _m.Called(teamID, userID, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint, string) error); ok {
+		r0 = rf(teamID, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTeamRepository creates a new instance of TeamRepository. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
 func NewTeamRepository(t testing.TB) *TeamRepository {
 	mock := &TeamRepository{}
@@ -3,7 +3,9 @@
 package mocks
 
 import (
+	media "github.com/alimikegami/compnouron/internal/media"
 	dto "github.com/alimikegami/compnouron/internal/team/dto"
+
 	mock "github.com/stretchr/testify/mock"
 
 	testing "testing"
@@ -14,6 +16,20 @@ type TeamUseCase struct {
 	mock.Mock
 }
 
+// AcceptTeamInvitation provides a mock function with given fields: invitationID, userID
+func (_m *TeamUseCase) AcceptTeamInvitation(invitationID uint, userID uint) error {
+	ret := _m.Called(invitationID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(invitationID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateTeam provides a mock function with given fields: userID, team
 func (_m *TeamUseCase) CreateTeam(userID uint, team dto.TeamRequest) error {
 	ret := _m.Called(userID, team)
@@ -28,6 +44,20 @@ func (_m *TeamUseCase) CreateTeam(userID uint, team dto.TeamRequest) error {
 	return r0
 }
 
+// DeclineTeamInvitation provides a mock function with given fields: invitationID, userID
+func (_m *TeamUseCase) DeclineTeamInvitation(invitationID uint, userID uint) error {
+	ret := _m.Called(invitationID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(invitationID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteTeam provides a mock function with given fields: id, userID
 func (_m *TeamUseCase) DeleteTeam(id uint, userID uint) error {
 	ret := _m.Called(id, userID)
@@ -42,20 +72,80 @@ func (_m *TeamUseCase) DeleteTeam(id uint, userID uint) error {
 	return r0
 }
 
-// GetTeamDetailsByID provides a mock function with given fields: teamID
-func (_m *TeamUseCase) GetTeamDetailsByID(teamID uint) (dto.TeamDetailsResponse, error) {
-	ret := _m.Called(teamID)
+// DeleteTeamLogo provides a mock function with given fields: teamID, userID
+func (_m *TeamUseCase) DeleteTeamLogo(teamID uint, userID uint) error {
+	ret := _m.Called(teamID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(teamID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetReceivedTeamInvitations provides a mock function with given fields: userID
+func (_m *TeamUseCase) GetReceivedTeamInvitations(userID uint) ([]dto.TeamInvitationResponse, error) {
+	ret := _m.Called(userID)
+
+	var r0 []dto.TeamInvitationResponse
+	if rf, ok := ret.Get(0).(func(uint) []dto.TeamInvitationResponse); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.TeamInvitationResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTeamDetailsByID provides a mock function with given fields: teamID, viewerID
+func (_m *TeamUseCase) GetTeamDetailsByID(teamID uint, viewerID uint) (dto.TeamDetailsResponse, error) {
+	ret := _m.Called(teamID, viewerID)
 
 	var r0 dto.TeamDetailsResponse
-	if rf, ok := ret.Get(0).(func(uint) dto.TeamDetailsResponse); ok {
-		r0 = rf(teamID)
+	if rf, ok := ret.Get(0).(func(uint, uint) dto.TeamDetailsResponse); ok {
+		r0 = rf(teamID, viewerID)
 	} else {
 		r0 = ret.Get(0).(dto.TeamDetailsResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(teamID)
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(teamID, viewerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTeamInvitations provides a mock function with given fields: teamID, userID
+func (_m *TeamUseCase) GetTeamInvitations(teamID uint, userID uint) ([]dto.TeamInvitationResponse, error) {
+	ret := _m.Called(teamID, userID)
+
+	var r0 []dto.TeamInvitationResponse
+	if rf, ok := ret.Get(0).(func(uint, uint) []dto.TeamInvitationResponse); ok {
+		r0 = rf(teamID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.TeamInvitationResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(teamID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -86,6 +176,83 @@ func (_m *TeamUseCase) GetTeamsByUserID(userID uint) ([]dto.BriefTeamResponse, e
 	return r0, r1
 }
 
+// InviteTeamMember provides a mock function with given fields: teamID, userID, request
+func (_m *TeamUseCase) InviteTeamMember(teamID uint, userID uint, request dto.TeamInvitationRequest) (dto.TeamInvitationResponse, error) {
+	ret := _m.Called(teamID, userID, request)
+
+	var r0 dto.TeamInvitationResponse
+	if rf, ok := ret.Get(0).(func(uint, uint, dto.TeamInvitationRequest) dto.TeamInvitationResponse); ok {
+		r0 = rf(teamID, userID, request)
+	} else {
+		r0 = ret.Get(0).(dto.TeamInvitationResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, uint, dto.TeamInvitationRequest) error); ok {
+		r1 = rf(teamID, userID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LeaveTeam provides a mock function with given fields: teamID, userID
+func (_m *TeamUseCase) LeaveTeam(teamID uint, userID uint) error {
+	ret := _m.Called(teamID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(teamID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveTeamMember provides a mock function with given fields: teamID, memberID, userID
+func (_m *TeamUseCase) RemoveTeamMember(teamID uint, memberID uint, userID uint) error {
+	ret := _m.Called(teamID, memberID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) error); ok {
+		r0 = rf(teamID, memberID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeTeamInvitation provides a mock function with given fields: teamID, invitationID, userID
+func (_m *TeamUseCase) RevokeTeamInvitation(teamID uint, invitationID uint, userID uint) error {
+	ret := _m.Called(teamID, invitationID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) error); ok {
+		r0 = rf(teamID, invitationID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TransferTeamOwnership provides a mock function with given fields: teamID, newOwnerID, userID
+func (_m *TeamUseCase) TransferTeamOwnership(teamID uint, newOwnerID uint, userID uint) error {
+	ret := _m.Called(teamID, newOwnerID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) error); ok {
+		r0 = rf(teamID, newOwnerID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTeam provides a mock function with given fields: userID, team, teamID
 func (_m *TeamUseCase) UpdateTeam(userID uint, team dto.TeamRequest, teamID uint) error {
 	ret := _m.Called(userID, team, teamID)
@@ -100,6 +267,43 @@ func (_m *TeamUseCase) UpdateTeam(userID uint, team dto.TeamRequest, teamID uint
 	return r0
 }
 
+// UpdateTeamMemberRole provides a mock function with given fields: teamID, memberID, userID, request
+func (_m *TeamUseCase) UpdateTeamMemberRole(teamID uint, memberID uint, userID uint, request dto.TeamMemberRoleRequest) error {
+	ret := _m.Called(teamID, memberID, userID, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint, uint, dto.TeamMemberRoleRequest) error); ok {
+		r0 = rf(teamID, memberID, userID, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UploadTeamLogo provides a mock function with given fields: teamID, userID, data
+func (_m *TeamUseCase) UploadTeamLogo(teamID uint, userID uint, data []byte) (*media.Image, error) {
+	ret := _m.Called(teamID, userID, data)
+
+	var r0 *media.Image
+	if rf, ok := ret.Get(0).(func(uint, uint, []byte) *media.Image); ok {
+		r0 = rf(teamID, userID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*media.Image)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, uint, []byte) error); ok {
+		r1 = rf(teamID, userID, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTeamUseCase creates a new instance of TeamUseCase. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
 func NewTeamUseCase(t testing.TB) *TeamUseCase {
 	mock := &TeamUseCase{}
@@ -4,6 +4,9 @@ package mocks
 
 import (
 	entity "github.com/alimikegami/compnouron/internal/user/entity"
+	repository "github.com/alimikegami/compnouron/internal/user/repository"
+	time "time"
+
 	mock "github.com/stretchr/testify/mock"
 
 	testing "testing"
@@ -14,13 +17,125 @@ type UserRepository struct {
 	mock.Mock
 }
 
-// AddUserSkills provides a mock function with given fields: skill
-func (_m *UserRepository) AddUserSkills(skill []entity.Skill) error {
-	ret := _m.Called(skill)
+// AddUserSkills provides a mock function with given fields: skills
+func (_m *UserRepository) AddUserSkills(skills []entity.UserSkill) error {
+	ret := _m.Called(skills)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]entity.UserSkill) error); ok {
+		r0 = rf(skills)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AnonymizeUser provides a mock function with given fields: id
+func (_m *UserRepository) AnonymizeUser(id uint) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateImpersonation provides a mock function with given fields: impersonation
+func (_m *UserRepository) CreateImpersonation(impersonation entity.Impersonation) (uint, error) {
+	ret := _m.Called(impersonation)
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(entity.Impersonation) uint); ok {
+		r0 = rf(impersonation)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(entity.Impersonation) error); ok {
+		r1 = rf(impersonation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateImpersonationAction provides a mock function with given fields: action
+func (_m *UserRepository) CreateImpersonationAction(action entity.ImpersonationAction) error {
+	ret := _m.Called(action)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(entity.ImpersonationAction) error); ok {
+		r0 = rf(action)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateLockoutEvent provides a mock function with given fields: lockoutEvent
+func (_m *UserRepository) CreateLockoutEvent(lockoutEvent entity.LockoutEvent) error {
+	ret := _m.Called(lockoutEvent)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(entity.LockoutEvent) error); ok {
+		r0 = rf(lockoutEvent)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreatePasswordResetToken provides a mock function with given fields: passwordResetToken
+func (_m *UserRepository) CreatePasswordResetToken(passwordResetToken entity.PasswordResetToken) error {
+	ret := _m.Called(passwordResetToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(entity.PasswordResetToken) error); ok {
+		r0 = rf(passwordResetToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreatePersonalAccessToken provides a mock function with given fields: token
+func (_m *UserRepository) CreatePersonalAccessToken(token entity.PersonalAccessToken) (uint, error) {
+	ret := _m.Called(token)
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(entity.PersonalAccessToken) uint); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(entity.PersonalAccessToken) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRefreshToken provides a mock function with given fields: refreshToken
+func (_m *UserRepository) CreateRefreshToken(refreshToken entity.RefreshToken) error {
+	ret := _m.Called(refreshToken)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func([]entity.Skill) error); ok {
-		r0 = rf(skill)
+	if rf, ok := ret.Get(0).(func(entity.RefreshToken) error); ok {
+		r0 = rf(refreshToken)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -28,6 +143,27 @@ func (_m *UserRepository) AddUserSkills(skill []entity.Skill) error {
 	return r0
 }
 
+// CreateSession provides a mock function with given fields: session
+func (_m *UserRepository) CreateSession(session entity.Session) (uint, error) {
+	ret := _m.Called(session)
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(entity.Session) uint); ok {
+		r0 = rf(session)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(entity.Session) error); ok {
+		r1 = rf(session)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateUser provides a mock function with given fields: user
 func (_m *UserRepository) CreateUser(user entity.User) (uint, error) {
 	ret := _m.Called(user)
@@ -49,19 +185,835 @@ func (_m *UserRepository) CreateUser(user entity.User) (uint, error) {
 	return r0, r1
 }
 
-// GetUserByEmail provides a mock function with given fields: email
-func (_m *UserRepository) GetUserByEmail(email string) *entity.User {
-	ret := _m.Called(email)
+// CreateUserIdentity provides a mock function with given fields: userIdentity
+func (_m *UserRepository) CreateUserIdentity(userIdentity entity.UserIdentity) error {
+	ret := _m.Called(userIdentity)
 
-	var r0 *entity.User
-	if rf, ok := ret.Get(0).(func(string) *entity.User); ok {
-		r0 = rf(email)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(entity.UserIdentity) error); ok {
+		r0 = rf(userIdentity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateUserWithIdentity provides a mock function with given fields: user, userIdentity
+func (_m *UserRepository) CreateUserWithIdentity(user entity.User, userIdentity entity.UserIdentity) (uint, error) {
+	ret := _m.Called(user, userIdentity)
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(entity.User, entity.UserIdentity) uint); ok {
+		r0 = rf(user, userIdentity)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(entity.User, entity.UserIdentity) error); ok {
+		r1 = rf(user, userIdentity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUserSkill provides a mock function with given fields: userID, skillID
+func (_m *UserRepository) DeleteUserSkill(userID uint, skillID uint) error {
+	ret := _m.Called(userID, skillID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(userID, skillID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisableTwoFactor provides a mock function with given fields: id
+func (_m *UserRepository) DisableTwoFactor(id uint) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableTwoFactor provides a mock function with given fields: id, step, recoveryCodes
+func (_m *UserRepository) EnableTwoFactor(id uint, step int64, recoveryCodes []entity.RecoveryCode) error {
+	ret := _m.Called(id, step, recoveryCodes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, int64, []entity.RecoveryCode) error); ok {
+		r0 = rf(id, step, recoveryCodes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EndImpersonation provides a mock function with given fields: id
+func (_m *UserRepository) EndImpersonation(id uint) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActiveLockoutEvents provides a mock function
+func (_m *UserRepository) GetActiveLockoutEvents() ([]entity.LockoutEvent, error) {
+	ret := _m.Called()
+
+	var r0 []entity.LockoutEvent
+	if rf, ok := ret.Get(0).(func() []entity.LockoutEvent); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.User)
+			r0 = ret.Get(0).([]entity.LockoutEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActiveSessions provides a mock function with given fields: userID, lastSeenAfter
+func (_m *UserRepository) GetActiveSessions(userID uint, lastSeenAfter time.Time) ([]entity.Session, error) {
+	ret := _m.Called(userID, lastSeenAfter)
+
+	var r0 []entity.Session
+	if rf, ok := ret.Get(0).(func(uint, time.Time) []entity.Session); ok {
+		r0 = rf(userID, lastSeenAfter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, time.Time) error); ok {
+		r1 = rf(userID, lastSeenAfter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllPersonalAccessTokens provides a mock function with given fields: userID
+func (_m *UserRepository) GetAllPersonalAccessTokens(userID uint) ([]entity.PersonalAccessToken, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entity.PersonalAccessToken
+	if rf, ok := ret.Get(0).(func(uint) []entity.PersonalAccessToken); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.PersonalAccessToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetImpersonationActionsByUserID provides a mock function with given fields: userID
+func (_m *UserRepository) GetImpersonationActionsByUserID(userID uint) ([]entity.ImpersonationAction, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entity.ImpersonationAction
+	if rf, ok := ret.Get(0).(func(uint) []entity.ImpersonationAction); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ImpersonationAction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetImpersonationByID provides a mock function with given fields: id
+func (_m *UserRepository) GetImpersonationByID(id uint) (entity.Impersonation, error) {
+	ret := _m.Called(id)
+
+	var r0 entity.Impersonation
+	if rf, ok := ret.Get(0).(func(uint) entity.Impersonation); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(entity.Impersonation)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetImpersonations provides a mock function
+func (_m *UserRepository) GetImpersonations() ([]entity.Impersonation, error) {
+	ret := _m.Called()
+
+	var r0 []entity.Impersonation
+	if rf, ok := ret.Get(0).(func() []entity.Impersonation); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Impersonation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLockoutEventsByUserID provides a mock function with given fields: userID
+func (_m *UserRepository) GetLockoutEventsByUserID(userID uint) ([]entity.LockoutEvent, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entity.LockoutEvent
+	if rf, ok := ret.Get(0).(func(uint) []entity.LockoutEvent); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.LockoutEvent)
 		}
 	}
 
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPasswordResetTokenByHash provides a mock function with given fields: tokenHash
+func (_m *UserRepository) GetPasswordResetTokenByHash(tokenHash string) (entity.PasswordResetToken, error) {
+	ret := _m.Called(tokenHash)
+
+	var r0 entity.PasswordResetToken
+	if rf, ok := ret.Get(0).(func(string) entity.PasswordResetToken); ok {
+		r0 = rf(tokenHash)
+	} else {
+		r0 = ret.Get(0).(entity.PasswordResetToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPersonalAccessTokenByHash provides a mock function with given fields: tokenHash
+func (_m *UserRepository) GetPersonalAccessTokenByHash(tokenHash string) (entity.PersonalAccessToken, error) {
+	ret := _m.Called(tokenHash)
+
+	var r0 entity.PersonalAccessToken
+	if rf, ok := ret.Get(0).(func(string) entity.PersonalAccessToken); ok {
+		r0 = rf(tokenHash)
+	} else {
+		r0 = ret.Get(0).(entity.PersonalAccessToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPersonalAccessTokens provides a mock function with given fields: userID
+func (_m *UserRepository) GetPersonalAccessTokens(userID uint) ([]entity.PersonalAccessToken, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entity.PersonalAccessToken
+	if rf, ok := ret.Get(0).(func(uint) []entity.PersonalAccessToken); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.PersonalAccessToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRefreshTokenByHash provides a mock function with given fields: tokenHash
+func (_m *UserRepository) GetRefreshTokenByHash(tokenHash string) (entity.RefreshToken, error) {
+	ret := _m.Called(tokenHash)
+
+	var r0 entity.RefreshToken
+	if rf, ok := ret.Get(0).(func(string) entity.RefreshToken); ok {
+		r0 = rf(tokenHash)
+	} else {
+		r0 = ret.Get(0).(entity.RefreshToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionByFamilyID provides a mock function with given fields: familyID
+func (_m *UserRepository) GetSessionByFamilyID(familyID string) (entity.Session, error) {
+	ret := _m.Called(familyID)
+
+	var r0 entity.Session
+	if rf, ok := ret.Get(0).(func(string) entity.Session); ok {
+		r0 = rf(familyID)
+	} else {
+		r0 = ret.Get(0).(entity.Session)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(familyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionByID provides a mock function with given fields: id
+func (_m *UserRepository) GetSessionByID(id uint) (entity.Session, error) {
+	ret := _m.Called(id)
+
+	var r0 entity.Session
+	if rf, ok := ret.Get(0).(func(uint) entity.Session); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(entity.Session)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionsByUserID provides a mock function with given fields: userID
+func (_m *UserRepository) GetSessionsByUserID(userID uint) ([]entity.Session, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entity.Session
+	if rf, ok := ret.Get(0).(func(uint) []entity.Session); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByEmail provides a mock function with given fields: email
+func (_m *UserRepository) GetUserByEmail(email string) *entity.User {
+	ret := _m.Called(email)
+
+	var r0 *entity.User
+	if rf, ok := ret.Get(0).(func(string) *entity.User); ok {
+		r0 = rf(email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	return r0
+}
+
+// GetUserByID provides a mock function with given fields: id
+func (_m *UserRepository) GetUserByID(id uint) (entity.User, error) {
+	ret := _m.Called(id)
+
+	var r0 entity.User
+	if rf, ok := ret.Get(0).(func(uint) entity.User); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(entity.User)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserIdentitiesByUserID provides a mock function with given fields: userID
+func (_m *UserRepository) GetUserIdentitiesByUserID(userID uint) ([]entity.UserIdentity, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entity.UserIdentity
+	if rf, ok := ret.Get(0).(func(uint) []entity.UserIdentity); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.UserIdentity)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserIdentity provides a mock function with given fields: provider, subject
+func (_m *UserRepository) GetUserIdentity(provider string, subject string) *entity.UserIdentity {
+	ret := _m.Called(provider, subject)
+
+	var r0 *entity.UserIdentity
+	if rf, ok := ret.Get(0).(func(string, string) *entity.UserIdentity); ok {
+		r0 = rf(provider, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.UserIdentity)
+		}
+	}
+
+	return r0
+}
+
+// GetUserWithSkillsByID provides a mock function with given fields: id
+func (_m *UserRepository) GetUserWithSkillsByID(id uint) (entity.User, error) {
+	ret := _m.Called(id)
+
+	var r0 entity.User
+	if rf, ok := ret.Get(0).(func(uint) entity.User); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(entity.User)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvalidateUserPasswordResetTokens provides a mock function with given fields: userID
+func (_m *UserRepository) InvalidateUserPasswordResetTokens(userID uint) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokePersonalAccessToken provides a mock function with given fields: userID, id
+func (_m *UserRepository) RevokePersonalAccessToken(userID uint, id uint) error {
+	ret := _m.Called(userID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeRefreshToken provides a mock function with given fields: id
+func (_m *UserRepository) RevokeRefreshToken(id uint) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeRefreshTokenFamily provides a mock function with given fields: familyID
+func (_m *UserRepository) RevokeRefreshTokenFamily(familyID string) error {
+	ret := _m.Called(familyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSession provides a mock function with given fields: userID, id
+func (_m *UserRepository) RevokeSession(userID uint, id uint) error {
+	ret := _m.Called(userID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeUserPersonalAccessTokens provides a mock function with given fields: userID
+func (_m *UserRepository) RevokeUserPersonalAccessTokens(userID uint) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeUserSessions provides a mock function with given fields: userID, exceptSessionID
+func (_m *UserRepository) RevokeUserSessions(userID uint, exceptSessionID uint) error {
+	ret := _m.Called(userID, exceptSessionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(userID, exceptSessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchUsers provides a mock function with given fields: filter, limit, offset
+func (_m *UserRepository) SearchUsers(filter repository.
+	UserSearchFilter, limit int, offset int) ([]entity.User, error) {
+	ret := _m.Called(filter, limit, offset)
+
+	var r0 []entity.User
+	if rf, ok := ret.Get(0).(func(repository.
+		UserSearchFilter, int, int) []entity.User); ok {
+		r0 = rf(filter, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(repository.
+		UserSearchFilter, int, int) error); ok {
+		r1 = rf(filter, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetTwoFactorSecret provides a mock function with given fields: id, secret
+func (_m *UserRepository) SetTwoFactorSecret(id uint, secret string) error {
+	ret := _m.Called(id, secret)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
+		r0 = rf(id, secret)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TouchPersonalAccessToken provides a mock function with given fields: id
+func (_m *UserRepository) TouchPersonalAccessToken(id uint) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TouchSession provides a mock function with given fields: id
+func (_m *UserRepository) TouchSession(id uint) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnlockLockoutEvents provides a mock function with given fields: userID, unlockedBy
+func (_m *UserRepository) UnlockLockoutEvents(userID uint, unlockedBy uint) error {
+	ret := _m.Called(userID, unlockedBy)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(userID, unlockedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUser provides a mock function with given fields: user
+func (_m *UserRepository) UpdateUser(user entity.User) error {
+	ret := _m.Called(user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(entity.User) error); ok {
+		r0 = rf(user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserAvatar provides a mock function with given fields: id, avatarKey
+func (_m *UserRepository) UpdateUserAvatar(id uint, avatarKey string) error {
+	ret := _m.Called(id, avatarKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
+		r0 = rf(id, avatarKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserEmail provides a mock function with given fields: id, email
+func (_m *UserRepository) UpdateUserEmail(id uint, email string) error {
+	ret := _m.Called(id, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
+		r0 = rf(id, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserInstitution provides a mock function with given fields: id, institutionID
+func (_m *UserRepository) UpdateUserInstitution(id uint, institutionID uint) error {
+	ret := _m.Called(id, institutionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(id, institutionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserPassword provides a mock function with given fields: id, password
+func (_m *UserRepository) UpdateUserPassword(id uint, password string) error {
+	ret := _m.Called(id, password)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
+		r0 = rf(id, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserPrivacySettings provides a mock function with given fields: id, emailVisibility, phoneNumberVisibility, searchable, availableForTeams
+func (_m *UserRepository) UpdateUserPrivacySettings(id uint, emailVisibility string, phoneNumberVisibility string, searchable bool, availableForTeams bool) error {
+	ret := _m.Called(id, emailVisibility, phoneNumberVisibility, searchable, availableForTeams)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string, string, bool, bool) error); ok {
+		r0 = rf(id, emailVisibility, phoneNumberVisibility, searchable, availableForTeams)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserRole provides a mock function with given fields: id, role
+func (_m *UserRepository) UpdateUserRole(id uint, role string) error {
+	ret := _m.Called(id, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
+		r0 = rf(id, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UsePasswordResetToken provides a mock function with given fields: id
+func (_m *UserRepository) UsePasswordResetToken(id uint) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseRecoveryCode provides a mock function with given fields: userID, codeHash
+func (_m *UserRepository) UseRecoveryCode(userID uint, codeHash string) error {
+	ret := _m.Called(userID, codeHash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
+		r0 = rf(userID, codeHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseTwoFactorStep provides a mock function with given fields: id, step
+func (_m *UserRepository) UseTwoFactorStep(id uint, step int64) error {
+	ret := _m.Called(id, step)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, int64) error); ok {
+		r0 = rf(id, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyUserEmail provides a mock function with given fields: id
+func (_m *UserRepository) VerifyUserEmail(id uint) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
 	return r0
 }
 
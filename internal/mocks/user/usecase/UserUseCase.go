@@ -4,7 +4,9 @@ package mocks
 
 import (
 	competitiondto "github.com/alimikegami/compnouron/internal/competition/dto"
+	media "github.com/alimikegami/compnouron/internal/media"
 	dto "github.com/alimikegami/compnouron/internal/user/dto"
+	utils "github.com/alimikegami/compnouron/pkg/utils"
 
 	mock "github.com/stretchr/testify/mock"
 
@@ -16,6 +18,97 @@ type UserUseCase struct {
 	mock.Mock
 }
 
+// AddUserSkill provides a mock function with given fields: userID, skill
+func (_m *UserUseCase) AddUserSkill(userID uint, skill dto.UserSkillRequest) error {
+	ret := _m.Called(userID, skill)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, dto.UserSkillRequest) error); ok {
+		r0 = rf(userID, skill)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthenticatePersonalAccessToken provides a mock function with given fields: token
+func (_m *UserUseCase) AuthenticatePersonalAccessToken(token string) (utils.JwtCustomClaims, error) {
+	ret := _m.Called(token)
+
+	var r0 utils.JwtCustomClaims
+	if rf, ok := ret.Get(0).(func(string) utils.JwtCustomClaims); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(utils.JwtCustomClaims)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangePassword provides a mock function with given fields: userID, request
+func (_m *UserUseCase) ChangePassword(userID uint, request dto.PasswordChangeRequest) error {
+	ret := _m.Called(userID, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, dto.PasswordChangeRequest) error); ok {
+		r0 = rf(userID, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CompleteOIDCLogin provides a mock function with given fields: provider, request, ipAddress, userAgent
+func (_m *UserUseCase) CompleteOIDCLogin(provider string, request dto.OIDCCallbackRequest, ipAddress string, userAgent string) (dto.TokenResponse, error) {
+	ret := _m.Called(provider, request, ipAddress, userAgent)
+
+	var r0 dto.TokenResponse
+	if rf, ok := ret.Get(0).(func(string, dto.OIDCCallbackRequest, string, string) dto.TokenResponse); ok {
+		r0 = rf(provider, request, ipAddress, userAgent)
+	} else {
+		r0 = ret.Get(0).(dto.TokenResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, dto.OIDCCallbackRequest, string, string) error); ok {
+		r1 = rf(provider, request, ipAddress, userAgent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePersonalAccessToken provides a mock function with given fields: userID, request
+func (_m *UserUseCase) CreatePersonalAccessToken(userID uint, request dto.PersonalAccessTokenRequest) (dto.CreatedPersonalAccessTokenResponse, error) {
+	ret := _m.Called(userID, request)
+
+	var r0 dto.CreatedPersonalAccessTokenResponse
+	if rf, ok := ret.Get(0).(func(uint, dto.PersonalAccessTokenRequest) dto.CreatedPersonalAccessTokenResponse); ok {
+		r0 = rf(userID, request)
+	} else {
+		r0 = ret.Get(0).(dto.CreatedPersonalAccessTokenResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, dto.PersonalAccessTokenRequest) error); ok {
+		r1 = rf(userID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateUser provides a mock function with given fields: user
 func (_m *UserUseCase) CreateUser(user *dto.UserRegistrationRequest) error {
 	ret := _m.Called(user)
@@ -30,6 +123,164 @@ func (_m *UserUseCase) CreateUser(user *dto.UserRegistrationRequest) error {
 	return r0
 }
 
+// DeleteAccount provides a mock function with given fields: userID, request
+func (_m *UserUseCase) DeleteAccount(userID uint, request dto.AccountDeletionRequest) error {
+	ret := _m.Called(userID, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, dto.AccountDeletionRequest) error); ok {
+		r0 = rf(userID, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAvatar provides a mock function with given fields: userID
+func (_m *UserUseCase) DeleteAvatar(userID uint) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisableTwoFactor provides a mock function with given fields: userID, request
+func (_m *UserUseCase) DisableTwoFactor(userID uint, request dto.TwoFactorDisableRequest) error {
+	ret := _m.Called(userID, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, dto.TwoFactorDisableRequest) error); ok {
+		r0 = rf(userID, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableTwoFactor provides a mock function with given fields: userID, request
+func (_m *UserUseCase) EnableTwoFactor(userID uint, request dto.TwoFactorCodeRequest) (dto.RecoveryCodesResponse, error) {
+	ret := _m.Called(userID, request)
+
+	var r0 dto.RecoveryCodesResponse
+	if rf, ok := ret.Get(0).(func(uint, dto.TwoFactorCodeRequest) dto.RecoveryCodesResponse); ok {
+		r0 = rf(userID, request)
+	} else {
+		r0 = ret.Get(0).(dto.RecoveryCodesResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, dto.TwoFactorCodeRequest) error); ok {
+		r1 = rf(userID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EndImpersonation provides a mock function with given fields: impersonationID
+func (_m *UserUseCase) EndImpersonation(impersonationID uint) error {
+	ret := _m.Called(impersonationID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(impersonationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportUserData provides a mock function with given fields: userID
+func (_m *UserUseCase) ExportUserData(userID uint) (dto.UserDataExport, error) {
+	ret := _m.Called(userID)
+
+	var r0 dto.UserDataExport
+	if rf, ok := ret.Get(0).(func(uint) dto.UserDataExport); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(dto.UserDataExport)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ForgotPassword provides a mock function with given fields: email
+func (_m *UserUseCase) ForgotPassword(email string) error {
+	ret := _m.Called(email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAchievements provides a mock function with given fields: userID
+func (_m *UserUseCase) GetAchievements(userID uint) ([]dto.AchievementResponse, error) {
+	ret := _m.Called(userID)
+
+	var r0 []dto.AchievementResponse
+	if rf, ok := ret.Get(0).(func(uint) []dto.AchievementResponse); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.AchievementResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActiveLockouts provides a mock function with given fields: adminID
+func (_m *UserUseCase) GetActiveLockouts(adminID uint) ([]dto.LockoutEventResponse, error) {
+	ret := _m.Called(adminID)
+
+	var r0 []dto.LockoutEventResponse
+	if rf, ok := ret.Get(0).(func(uint) []dto.LockoutEventResponse); ok {
+		r0 = rf(adminID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.LockoutEventResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(adminID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCompetitionRegistrationHistory provides a mock function with given fields: userID
 func (_m *UserUseCase) GetCompetitionRegistrationHistory(userID uint) ([]dto.UserCompetitionHistory, error) {
 	ret := _m.Called(userID)
@@ -76,6 +327,52 @@ func (_m *UserUseCase) GetCompetitionsData(userID uint) ([]competitiondto.Compet
 	return r0, r1
 }
 
+// GetImpersonations provides a mock function with given fields: adminID
+func (_m *UserUseCase) GetImpersonations(adminID uint) ([]dto.ImpersonationResponse, error) {
+	ret := _m.Called(adminID)
+
+	var r0 []dto.ImpersonationResponse
+	if rf, ok := ret.Get(0).(func(uint) []dto.ImpersonationResponse); ok {
+		r0 = rf(adminID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.ImpersonationResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(adminID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPersonalAccessTokens provides a mock function with given fields: userID
+func (_m *UserUseCase) GetPersonalAccessTokens(userID uint) ([]dto.PersonalAccessTokenResponse, error) {
+	ret := _m.Called(userID)
+
+	var r0 []dto.PersonalAccessTokenResponse
+	if rf, ok := ret.Get(0).(func(uint) []dto.PersonalAccessTokenResponse); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.PersonalAccessTokenResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRecruitmentApplicationHistory provides a mock function with given fields: userID
 func (_m *UserUseCase) GetRecruitmentApplicationHistory(userID uint) ([]dto.UserRecruitmentApplicationHistory, error) {
 	ret := _m.Called(userID)
@@ -99,20 +396,304 @@ func (_m *UserUseCase) GetRecruitmentApplicationHistory(userID uint) ([]dto.User
 	return r0, r1
 }
 
-// Login provides a mock function with given fields: credential
-func (_m *UserUseCase) Login(credential *dto.Credential) (string, error) {
-	ret := _m.Called(credential)
+// GetSessions provides a mock function with given fields: userID, currentSessionID
+func (_m *UserUseCase) GetSessions(userID uint, currentSessionID uint) ([]dto.SessionResponse, error) {
+	ret := _m.Called(userID, currentSessionID)
+
+	var r0 []dto.SessionResponse
+	if rf, ok := ret.Get(0).(func(uint, uint) []dto.SessionResponse); ok {
+		r0 = rf(userID, currentSessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.SessionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(userID, currentSessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserDetails provides a mock function with given fields: userID
+func (_m *UserUseCase) GetUserDetails(userID uint) (dto.UserDetailsResponse, error) {
+	ret := _m.Called(userID)
+
+	var r0 dto.UserDetailsResponse
+	if rf, ok := ret.Get(0).(func(uint) dto.UserDetailsResponse); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(dto.UserDetailsResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImpersonateUser provides a mock function with given fields: adminID, adminSessionID, userID, request, ipAddress
+func (_m *UserUseCase) ImpersonateUser(adminID uint, adminSessionID uint, userID uint, request dto.ImpersonationRequest, ipAddress string) (dto.ImpersonationTokenResponse, error) {
+	ret := _m.Called(adminID, adminSessionID, userID, request, ipAddress)
+
+	var r0 dto.ImpersonationTokenResponse
+	if rf, ok := ret.Get(0).(func(uint, uint, uint, dto.ImpersonationRequest, string) dto.ImpersonationTokenResponse); ok {
+		r0 = rf(adminID, adminSessionID, userID, request, ipAddress)
+	} else {
+		r0 = ret.Get(0).(dto.ImpersonationTokenResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, uint, uint, dto.ImpersonationRequest, string) error); ok {
+		r1 = rf(adminID, adminSessionID, userID, request, ipAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: credential, ipAddress, userAgent
+func (_m *UserUseCase) Login(credential *dto.Credential, ipAddress string, userAgent string) (dto.TokenResponse, error) {
+	ret := _m.Called(credential, ipAddress, userAgent)
+
+	var r0 dto.TokenResponse
+	if rf, ok := ret.Get(0).(func(*dto.Credential, string, string) dto.TokenResponse); ok {
+		r0 = rf(credential, ipAddress, userAgent)
+	} else {
+		r0 = ret.Get(0).(dto.TokenResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*dto.Credential, string, string) error); ok {
+		r1 = rf(credential, ipAddress, userAgent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginWithTwoFactor provides a mock function with given fields: request, ipAddress, userAgent
+func (_m *UserUseCase) LoginWithTwoFactor(request dto.TwoFactorLoginRequest, ipAddress string, userAgent string) (dto.TokenResponse, error) {
+	ret := _m.Called(request, ipAddress, userAgent)
+
+	var r0 dto.TokenResponse
+	if rf, ok := ret.Get(0).(func(dto.TwoFactorLoginRequest, string, string) dto.TokenResponse); ok {
+		r0 = rf(request, ipAddress, userAgent)
+	} else {
+		r0 = ret.Get(0).(dto.TokenResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(dto.TwoFactorLoginRequest, string, string) error); ok {
+		r1 = rf(request, ipAddress, userAgent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: refreshToken
+func (_m *UserUseCase) Logout(refreshToken string) error {
+	ret := _m.Called(refreshToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordImpersonatedRequest provides a mock function with given fields: impersonationID, actorID, userID, method, path, status, ipAddress
+func (_m *UserUseCase) RecordImpersonatedRequest(impersonationID uint, actorID uint, userID uint, method string, path string, status int, ipAddress string) error {
+	ret := _m.Called(impersonationID, actorID, userID, method, path, status, ipAddress)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint, uint, string, string, int, string) error); ok {
+		r0 = rf(impersonationID, actorID, userID, method, path, status, ipAddress)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshToken provides a mock function with given fields: refreshToken
+func (_m *UserUseCase) RefreshToken(refreshToken string) (dto.TokenResponse, error) {
+	ret := _m.Called(refreshToken)
+
+	var r0 dto.TokenResponse
+	if rf, ok := ret.Get(0).(func(string) dto.TokenResponse); ok {
+		r0 = rf(refreshToken)
+	} else {
+		r0 = ret.Get(0).(dto.TokenResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveUserSkill provides a mock function with given fields: userID, skillID
+func (_m *UserUseCase) RemoveUserSkill(userID uint, skillID uint) error {
+	ret := _m.Called(userID, skillID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(userID, skillID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResendVerificationEmail provides a mock function with given fields: userID
+func (_m *UserUseCase) ResendVerificationEmail(userID uint) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetPassword provides a mock function with given fields: request
+func (_m *UserUseCase) ResetPassword(request dto.ResetPasswordRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(dto.ResetPasswordRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeOtherSessions provides a mock function with given fields: userID, currentSessionID
+func (_m *UserUseCase) RevokeOtherSessions(userID uint, currentSessionID uint) error {
+	ret := _m.Called(userID, currentSessionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(userID, currentSessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokePersonalAccessToken provides a mock function with given fields: userID, tokenID
+func (_m *UserUseCase) RevokePersonalAccessToken(userID uint, tokenID uint) error {
+	ret := _m.Called(userID, tokenID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(userID, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSession provides a mock function with given fields: userID, sessionID
+func (_m *UserUseCase) RevokeSession(userID uint, sessionID uint) error {
+	ret := _m.Called(userID, sessionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchUsers provides a mock function with given fields: viewerID, request
+func (_m *UserUseCase) SearchUsers(viewerID uint, request dto.UserSearchRequest) ([]dto.UserProfileResponse, error) {
+	ret := _m.Called(viewerID, request)
+
+	var r0 []dto.UserProfileResponse
+	if rf, ok := ret.Get(0).(func(uint, dto.UserSearchRequest) []dto.UserProfileResponse); ok {
+		r0 = rf(viewerID, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.UserProfileResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, dto.UserSearchRequest) error); ok {
+		r1 = rf(viewerID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetupTwoFactor provides a mock function with given fields: userID
+func (_m *UserUseCase) SetupTwoFactor(userID uint) (dto.TwoFactorSetupResponse, error) {
+	ret := _m.Called(userID)
+
+	var r0 dto.TwoFactorSetupResponse
+	if rf, ok := ret.Get(0).(func(uint) dto.TwoFactorSetupResponse); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(dto.TwoFactorSetupResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartOIDCLogin provides a mock function with given fields: provider
+func (_m *UserUseCase) StartOIDCLogin(provider string) (dto.OIDCAuthorization, error) {
+	ret := _m.Called(provider)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(*dto.Credential) string); ok {
-		r0 = rf(credential)
+	var r0 dto.OIDCAuthorization
+	if rf, ok := ret.Get(0).(func(string) dto.OIDCAuthorization); ok {
+		r0 = rf(provider)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(dto.OIDCAuthorization)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*dto.Credential) error); ok {
-		r1 = rf(credential)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(provider)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -120,6 +701,127 @@ func (_m *UserUseCase) Login(credential *dto.Credential) (string, error) {
 	return r0, r1
 }
 
+// UnlockUser provides a mock function with given fields: adminID, userID
+func (_m *UserUseCase) UnlockUser(adminID uint, userID uint) error {
+	ret := _m.Called(adminID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(adminID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePrivacySettings provides a mock function with given fields: userID, request
+func (_m *UserUseCase) UpdatePrivacySettings(userID uint, request dto.PrivacySettingsRequest) error {
+	ret := _m.Called(userID, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, dto.PrivacySettingsRequest) error); ok {
+		r0 = rf(userID, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUser provides a mock function with given fields: userID, user
+func (_m *UserUseCase) UpdateUser(userID uint, user dto.UserUpdateRequest) error {
+	ret := _m.Called(userID, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, dto.UserUpdateRequest) error); ok {
+		r0 = rf(userID, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserRole provides a mock function with given fields: adminID, userID, role
+func (_m *UserUseCase) UpdateUserRole(adminID uint, userID uint, role string) error {
+	ret := _m.Called(adminID, userID, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint, string) error); ok {
+		r0 = rf(adminID, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UploadAvatar provides a mock function with given fields: userID, data
+func (_m *UserUseCase) UploadAvatar(userID uint, data []byte) (*media.Image, error) {
+	ret := _m.Called(userID, data)
+
+	var r0 *media.Image
+	if rf, ok := ret.Get(0).(func(uint, []byte) *media.Image); ok {
+		r0 = rf(userID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*media.Image)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, []byte) error); ok {
+		r1 = rf(userID, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateImpersonation provides a mock function with given fields: impersonationID, actorID, userID
+func (_m *UserUseCase) ValidateImpersonation(impersonationID uint, actorID uint, userID uint) error {
+	ret := _m.Called(impersonationID, actorID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) error); ok {
+		r0 = rf(impersonationID, actorID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ValidateSession provides a mock function with given fields: userID, sessionID
+func (_m *UserUseCase) ValidateSession(userID uint, sessionID uint) error {
+	ret := _m.Called(userID, sessionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyEmail provides a mock function with given fields: token
+func (_m *UserUseCase) VerifyEmail(token string) error {
+	ret := _m.Called(token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserUseCase creates a new instance of UserUseCase. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
 func NewUserUseCase(t testing.TB) *UserUseCase {
 	mock := &UserUseCase{}
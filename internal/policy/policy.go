@@ -0,0 +1,181 @@
+package policy
+
+import (
+	"errors"
+
+	teamEntity "github.com/alimikegami/compnouron/internal/team/entity"
+	teamRepo "github.com/alimikegami/compnouron/internal/team/repository"
+	userRepo "github.com/alimikegami/compnouron/internal/user/repository"
+	"github.com/alimikegami/compnouron/pkg/utils"
+)
+
+// Policy answers whether a user may perform an action. The user's role is read
+// from the database rather than from the JWT token, so a role change takes
+// effect immediately instead of when the token expires.
+type Policy interface {
+	RequireVerifiedEmail(userID uint) error
+	CanCreateCompetition(userID uint) error
+	CanManageCompetition(userID uint, ownerID uint) error
+	CanManageTeam(userID uint, teamID uint) error
+	CanManageTeamMembers(userID uint, teamID uint) error
+	CanManageTeamRoles(userID uint, teamID uint) error
+	CanDeleteTeam(userID uint, teamID uint) error
+	RequireTeamMember(userID uint, teamID uint) error
+	CanPinTeamThreads(userID uint, teamID uint) error
+	CanAssignRoles(userID uint) error
+	CanManageSkillCatalog(userID uint) error
+	CanManageLockouts(userID uint) error
+	CanManageInstitutions(userID uint) error
+	CanImpersonate(userID uint, subjectRole string) error
+	CanAuditImpersonations(userID uint) error
+}
+
+type PolicyImpl struct {
+	ur userRepo.UserRepository
+	tr teamRepo.TeamRepository
+}
+
+func CreateNewPolicy(ur userRepo.UserRepository, tr teamRepo.TeamRepository) Policy {
+	return &PolicyImpl{ur: ur, tr: tr}
+}
+
+func (p *PolicyImpl) RequireVerifiedEmail(userID uint) error {
+	user, err := p.ur.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.VerifiedAt == nil {
+		return errors.New("email is not verified")
+	}
+
+	return nil
+}
+
+func (p *PolicyImpl) CanCreateCompetition(userID uint) error {
+	user, err := p.ur.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.VerifiedAt == nil {
+		return errors.New("email is not verified")
+	}
+
+	if user.Role != utils.RoleOrganizer && user.Role != utils.RoleAdmin {
+		return errors.New("only organizers can create competitions")
+	}
+
+	return nil
+}
+
+func (p *PolicyImpl) CanManageCompetition(userID uint, ownerID uint) error {
+	if userID == ownerID {
+		return nil
+	}
+
+	return p.requireAdmin(userID)
+}
+
+// CanManageTeam lets the owner and the co-leaders of the team run it: edit it,
+// recruit, invite and register it to competitions.
+func (p *PolicyImpl) CanManageTeam(userID uint, teamID uint) error {
+	return p.requireTeamPermission(userID, teamID, teamEntity.PermissionManageTeam)
+}
+
+func (p *PolicyImpl) CanManageTeamMembers(userID uint, teamID uint) error {
+	return p.requireTeamPermission(userID, teamID, teamEntity.PermissionManageMembers)
+}
+
+// CanManageTeamRoles is reserved to the owner of the team, it covers changing
+// roles and transferring the ownership.
+func (p *PolicyImpl) CanManageTeamRoles(userID uint, teamID uint) error {
+	return p.requireTeamPermission(userID, teamID, teamEntity.PermissionManageRoles)
+}
+
+func (p *PolicyImpl) CanDeleteTeam(userID uint, teamID uint) error {
+	return p.requireTeamPermission(userID, teamID, teamEntity.PermissionDeleteTeam)
+}
+
+// RequireTeamMember passes for the members of the team only. Unlike the team
+// permissions it makes no exception for admins: what the members share with
+// each other on the discussion board isn't anyone else's business.
+func (p *PolicyImpl) RequireTeamMember(userID uint, teamID uint) error {
+	member, err := p.tr.GetTeamMember(teamID, userID)
+	if err != nil {
+		return errors.New("internal server error")
+	}
+
+	if member.Role == "" {
+		return errors.New("action unauthorized")
+	}
+
+	return nil
+}
+
+func (p *PolicyImpl) CanPinTeamThreads(userID uint, teamID uint) error {
+	return p.requireTeamPermission(userID, teamID, teamEntity.PermissionPinThreads)
+}
+
+func (p *PolicyImpl) CanAssignRoles(userID uint) error {
+	return p.requireAdmin(userID)
+}
+
+func (p *PolicyImpl) CanManageSkillCatalog(userID uint) error {
+	return p.requireAdmin(userID)
+}
+
+func (p *PolicyImpl) CanManageLockouts(userID uint) error {
+	return p.requireAdmin(userID)
+}
+
+func (p *PolicyImpl) CanManageInstitutions(userID uint) error {
+	return p.requireAdmin(userID)
+}
+
+// CanImpersonate lets admins act as other users for support. Admins can't be
+// impersonated, so impersonation never grants more than a regular account has.
+func (p *PolicyImpl) CanImpersonate(userID uint, subjectRole string) error {
+	err := p.requireAdmin(userID)
+	if err != nil {
+		return err
+	}
+
+	if subjectRole == utils.RoleAdmin {
+		return errors.New("admins can't be impersonated")
+	}
+
+	return nil
+}
+
+func (p *PolicyImpl) CanAuditImpersonations(userID uint) error {
+	return p.requireAdmin(userID)
+}
+
+// requireTeamPermission passes when the role of the user in the team grants
+// the permission. Admins may do anything to any team.
+func (p *PolicyImpl) requireTeamPermission(userID uint, teamID uint, permission teamEntity.TeamPermission) error {
+	member, err := p.tr.GetTeamMember(teamID, userID)
+	if err != nil {
+		return errors.New("internal server error")
+	}
+
+	if member.Can(permission) {
+		return nil
+	}
+
+	return p.requireAdmin(userID)
+}
+
+func (p *PolicyImpl) requireAdmin(userID uint) error {
+	user, err := p.ur.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Role != utils.RoleAdmin {
+		return errors.New("action unauthorized")
+	}
+
+	return nil
+}
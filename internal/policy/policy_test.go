@@ -0,0 +1,217 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	teamRepo "github.com/alimikegami/compnouron/internal/mocks/team/repository"
+	userRepo "github.com/alimikegami/compnouron/internal/mocks/user/repository"
+	teamEntity "github.com/alimikegami/compnouron/internal/team/entity"
+	"github.com/alimikegami/compnouron/internal/user/entity"
+	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanCreateCompetition(t *testing.T) {
+	mockUserRepo := userRepo.NewUserRepository(t)
+	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	verifiedAt := time.Now()
+	t.Run("organizer", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, VerifiedAt: &verifiedAt, Role: utils.RoleOrganizer}, nil).Once()
+		testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+		err := testPolicy.CanCreateCompetition(1)
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, VerifiedAt: &verifiedAt, Role: utils.RoleAdmin}, nil).Once()
+		testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+		err := testPolicy.CanCreateCompetition(1)
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("student", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, VerifiedAt: &verifiedAt, Role: utils.RoleStudent}, nil).Once()
+		testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+		err := testPolicy.CanCreateCompetition(1)
+		assert.EqualError(t, err, "only organizers can create competitions")
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("unverified-organizer", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Role: utils.RoleOrganizer}, nil).Once()
+		testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+		err := testPolicy.CanCreateCompetition(1)
+		assert.EqualError(t, err, "email is not verified")
+		mockUserRepo.AssertExpectations(t)
+	})
+}
+
+func TestCanManageCompetition(t *testing.T) {
+	mockUserRepo := userRepo.NewUserRepository(t)
+	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	t.Run("owner", func(t *testing.T) {
+		testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+		err := testPolicy.CanManageCompetition(1, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(2)).Return(entity.User{ID: 2, Role: utils.RoleAdmin}, nil).Once()
+		testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+		err := testPolicy.CanManageCompetition(2, 1)
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("other-organizer", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(2)).Return(entity.User{ID: 2, Role: utils.RoleOrganizer}, nil).Once()
+		testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+		err := testPolicy.CanManageCompetition(2, 1)
+		assert.EqualError(t, err, "action unauthorized")
+		mockUserRepo.AssertExpectations(t)
+	})
+}
+
+func TestCanManageTeam(t *testing.T) {
+	mockUserRepo := userRepo.NewUserRepository(t)
+	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	t.Run("owner", func(t *testing.T) {
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
+		testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+		err := testPolicy.CanManageTeam(1, 1)
+		assert.NoError(t, err)
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("co-leader", func(t *testing.T) {
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(3)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 3, Role: teamEntity.TeamRoleCoLeader}, nil).Once()
+		testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+		err := testPolicy.CanManageTeam(3, 1)
+		assert.NoError(t, err)
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("member", func(t *testing.T) {
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(2)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 2, Role: teamEntity.TeamRoleMember}, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(2)).Return(entity.User{ID: 2, Role: utils.RoleStudent}, nil).Once()
+		testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+		err := testPolicy.CanManageTeam(2, 1)
+		assert.EqualError(t, err, "action unauthorized")
+		mockTeamRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(4)).Return(teamEntity.TeamMember{}, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(4)).Return(entity.User{ID: 4, Role: utils.RoleAdmin}, nil).Once()
+		testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+		err := testPolicy.CanManageTeam(4, 1)
+		assert.NoError(t, err)
+		mockTeamRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{}, errors.New("unexpected db error")).Once()
+		testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+		err := testPolicy.CanManageTeam(1, 1)
+		assert.Error(t, err)
+		mockTeamRepo.AssertExpectations(t)
+	})
+}
+
+func TestTeamPermissionMatrix(t *testing.T) {
+	mockUserRepo := userRepo.NewUserRepository(t)
+	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+	coLeader := teamEntity.TeamMember{TeamID: 1, UserID: 3, Role: teamEntity.TeamRoleCoLeader}
+	owner := teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}
+
+	t.Run("co-leader-manages-members", func(t *testing.T) {
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(3)).Return(coLeader, nil).Once()
+		assert.NoError(t, testPolicy.CanManageTeamMembers(3, 1))
+	})
+
+	t.Run("co-leader-manages-roles", func(t *testing.T) {
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(3)).Return(coLeader, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(3)).Return(entity.User{ID: 3, Role: utils.RoleStudent}, nil).Once()
+		assert.EqualError(t, testPolicy.CanManageTeamRoles(3, 1), "action unauthorized")
+	})
+
+	t.Run("co-leader-deletes-team", func(t *testing.T) {
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(3)).Return(coLeader, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(3)).Return(entity.User{ID: 3, Role: utils.RoleStudent}, nil).Once()
+		assert.EqualError(t, testPolicy.CanDeleteTeam(3, 1), "action unauthorized")
+	})
+
+	t.Run("co-leader-pins-threads", func(t *testing.T) {
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(3)).Return(coLeader, nil).Once()
+		assert.NoError(t, testPolicy.CanPinTeamThreads(3, 1))
+	})
+
+	t.Run("member-pins-threads", func(t *testing.T) {
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(4)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 4, Role: teamEntity.TeamRoleMember}, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(4)).Return(entity.User{ID: 4, Role: utils.RoleStudent}, nil).Once()
+		assert.EqualError(t, testPolicy.CanPinTeamThreads(4, 1), "action unauthorized")
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(owner, nil).Times(3)
+		assert.NoError(t, testPolicy.CanManageTeamRoles(1, 1))
+		assert.NoError(t, testPolicy.CanDeleteTeam(1, 1))
+		assert.NoError(t, testPolicy.CanPinTeamThreads(1, 1))
+	})
+}
+
+func TestRequireTeamMember(t *testing.T) {
+	mockUserRepo := userRepo.NewUserRepository(t)
+	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+
+	t.Run("member", func(t *testing.T) {
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(4)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 4, Role: teamEntity.TeamRoleMember}, nil).Once()
+		assert.NoError(t, testPolicy.RequireTeamMember(4, 1))
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(9)).Return(teamEntity.TeamMember{}, nil).Once()
+		assert.EqualError(t, testPolicy.RequireTeamMember(9, 1), "action unauthorized")
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(4)).Return(teamEntity.TeamMember{}, errors.New("unexpected error")).Once()
+		assert.EqualError(t, testPolicy.RequireTeamMember(4, 1), "internal server error")
+	})
+}
+
+func TestCanImpersonate(t *testing.T) {
+	mockUserRepo := userRepo.NewUserRepository(t)
+	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	t.Run("admin", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+		err := testPolicy.CanImpersonate(1, utils.RoleStudent)
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("other-admin", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+		err := testPolicy.CanImpersonate(1, utils.RoleAdmin)
+		assert.EqualError(t, err, "admins can't be impersonated")
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("organizer", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Role: utils.RoleOrganizer}, nil).Once()
+		testPolicy := CreateNewPolicy(mockUserRepo, mockTeamRepo)
+		err := testPolicy.CanImpersonate(1, utils.RoleStudent)
+		assert.EqualError(t, err, "action unauthorized")
+		mockUserRepo.AssertExpectations(t)
+	})
+}
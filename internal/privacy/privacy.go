@@ -0,0 +1,77 @@
+// Package privacy decides which of a user's contact details another user may
+// see. Each detail has its own visibility, and the visibilities form a ladder:
+// every level is also visible to the audiences of the levels below it.
+//
+//	private     only the user and admins
+//	organizers  also organizers of competitions the user registered for
+//	team        also members of the user's teams
+//	public      everyone, including visitors who aren't logged in
+package privacy
+
+import (
+	"github.com/alimikegami/compnouron/internal/user/entity"
+)
+
+const (
+	VisibilityPublic     = "public"
+	VisibilityTeam       = "team"
+	VisibilityOrganizers = "organizers"
+	VisibilityPrivate    = "private"
+
+	// DefaultVisibility applies to users who haven't chosen one
+	DefaultVisibility = VisibilityTeam
+)
+
+func IsValidVisibility(visibility string) bool {
+	return visibility == VisibilityPublic || visibility == VisibilityTeam || visibility == VisibilityOrganizers || visibility == VisibilityPrivate
+}
+
+// Relationship is how the viewer of a response relates to the user the
+// response is about.
+type Relationship struct {
+	Self      bool
+	Admin     bool
+	Teammate  bool
+	Organizer bool
+}
+
+// CanSee reports whether the viewer may see a detail with the given visibility.
+func (r Relationship) CanSee(visibility string) bool {
+	if r.Self || r.Admin {
+		return true
+	}
+
+	if visibility == "" {
+		visibility = DefaultVisibility
+	}
+
+	if visibility == VisibilityPublic {
+		return true
+	} else if visibility == VisibilityTeam {
+		return r.Teammate || r.Organizer
+	} else if visibility == VisibilityOrganizers {
+		return r.Organizer
+	}
+
+	return false
+}
+
+// Email returns the user's email, or an empty string when the viewer may not
+// see it.
+func (r Relationship) Email(user entity.User) string {
+	if !r.CanSee(user.EmailVisibility) {
+		return ""
+	}
+
+	return user.Email
+}
+
+// PhoneNumber returns the user's phone number, or an empty string when the
+// viewer may not see it.
+func (r Relationship) PhoneNumber(user entity.User) string {
+	if !r.CanSee(user.PhoneNumberVisibility) {
+		return ""
+	}
+
+	return user.PhoneNumber
+}
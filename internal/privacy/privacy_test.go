@@ -0,0 +1,119 @@
+package privacy
+
+import (
+	"errors"
+	"testing"
+
+	compRepo "github.com/alimikegami/compnouron/internal/mocks/competition/repository"
+	teamRepo "github.com/alimikegami/compnouron/internal/mocks/team/repository"
+	userRepo "github.com/alimikegami/compnouron/internal/mocks/user/repository"
+	"github.com/alimikegami/compnouron/internal/user/entity"
+	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanSee(t *testing.T) {
+	testCases := []struct {
+		name         string
+		relationship Relationship
+		visible      []string
+		hidden       []string
+	}{
+		{
+			name:         "visitor",
+			relationship: Relationship{},
+			visible:      []string{VisibilityPublic},
+			hidden:       []string{VisibilityTeam, VisibilityOrganizers, VisibilityPrivate, ""},
+		},
+		{
+			name:         "teammate",
+			relationship: Relationship{Teammate: true},
+			visible:      []string{VisibilityPublic, VisibilityTeam, ""},
+			hidden:       []string{VisibilityOrganizers, VisibilityPrivate},
+		},
+		{
+			name:         "organizer",
+			relationship: Relationship{Organizer: true},
+			visible:      []string{VisibilityPublic, VisibilityTeam, VisibilityOrganizers, ""},
+			hidden:       []string{VisibilityPrivate},
+		},
+		{
+			name:         "self",
+			relationship: Relationship{Self: true},
+			visible:      []string{VisibilityPublic, VisibilityTeam, VisibilityOrganizers, VisibilityPrivate},
+		},
+		{
+			name:         "admin",
+			relationship: Relationship{Admin: true},
+			visible:      []string{VisibilityPublic, VisibilityTeam, VisibilityOrganizers, VisibilityPrivate},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			for _, visibility := range testCase.visible {
+				assert.True(t, testCase.relationship.CanSee(visibility), visibility)
+			}
+			for _, visibility := range testCase.hidden {
+				assert.False(t, testCase.relationship.CanSee(visibility), visibility)
+			}
+		})
+	}
+}
+
+func TestEmailAndPhoneNumber(t *testing.T) {
+	user := entity.User{
+		Email:                 "alim@gmail.com",
+		PhoneNumber:           "081111111111",
+		EmailVisibility:       VisibilityOrganizers,
+		PhoneNumberVisibility: VisibilityPrivate,
+	}
+
+	relationship := Relationship{Organizer: true}
+	assert.Equal(t, "alim@gmail.com", relationship.Email(user))
+	assert.Equal(t, "", relationship.PhoneNumber(user))
+}
+
+func TestViewer(t *testing.T) {
+	mockUserRepo := userRepo.NewUserRepository(t)
+	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	mockCompRepo := compRepo.NewCompetitionRepository(t)
+	testShaper := CreateNewShaper(mockUserRepo, mockTeamRepo, mockCompRepo)
+
+	t.Run("visitor", func(t *testing.T) {
+		viewer, err := testShaper.Viewer(0)
+		assert.NoError(t, err)
+		assert.Equal(t, Relationship{}, viewer.RelationshipTo(0))
+	})
+
+	t.Run("logged-in", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Role: utils.RoleOrganizer}, nil).Once()
+		mockTeamRepo.On("GetTeammateIDs", uint(1)).Return([]uint{2}, nil).Once()
+		mockCompRepo.On("GetRegistrantIDsByOrganizer", uint(1)).Return([]uint{2, 3}, nil).Once()
+
+		viewer, err := testShaper.Viewer(1)
+		assert.NoError(t, err)
+		assert.Equal(t, Relationship{Self: true}, viewer.RelationshipTo(1))
+		assert.Equal(t, Relationship{Teammate: true, Organizer: true}, viewer.RelationshipTo(2))
+		assert.Equal(t, Relationship{Organizer: true}, viewer.RelationshipTo(3))
+		assert.Equal(t, Relationship{}, viewer.RelationshipTo(4))
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		mockTeamRepo.On("GetTeammateIDs", uint(1)).Return([]uint{}, nil).Once()
+		mockCompRepo.On("GetRegistrantIDsByOrganizer", uint(1)).Return([]uint{}, nil).Once()
+
+		viewer, err := testShaper.Viewer(1)
+		assert.NoError(t, err)
+		assert.True(t, viewer.RelationshipTo(4).CanSee(VisibilityPrivate))
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeammateIDs", uint(1)).Return([]uint{}, errors.New("unexpected error")).Once()
+
+		_, err := testShaper.Viewer(1)
+		assert.Error(t, err)
+	})
+}
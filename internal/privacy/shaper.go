@@ -0,0 +1,82 @@
+package privacy
+
+import (
+	compRepo "github.com/alimikegami/compnouron/internal/competition/repository"
+	teamRepo "github.com/alimikegami/compnouron/internal/team/repository"
+	userRepo "github.com/alimikegami/compnouron/internal/user/repository"
+	"github.com/alimikegami/compnouron/pkg/utils"
+)
+
+// Viewer holds what is needed to work out how one viewer relates to any number
+// of users, so shaping a list costs the same as shaping a single user.
+type Viewer struct {
+	ID          uint
+	Admin       bool
+	Teammates   map[uint]bool
+	Registrants map[uint]bool
+}
+
+func (v Viewer) RelationshipTo(userID uint) Relationship {
+	if v.ID == 0 {
+		return Relationship{}
+	}
+
+	return Relationship{
+		Self:      v.ID == userID,
+		Admin:     v.Admin,
+		Teammate:  v.Teammates[userID],
+		Organizer: v.Registrants[userID],
+	}
+}
+
+// Shaper loads the viewer of a response. A viewer ID of 0 is a visitor who
+// isn't logged in.
+type Shaper interface {
+	Viewer(viewerID uint) (Viewer, error)
+}
+
+type ShaperImpl struct {
+	ur userRepo.UserRepository
+	tr teamRepo.TeamRepository
+	cr compRepo.CompetitionRepository
+}
+
+func CreateNewShaper(ur userRepo.UserRepository, tr teamRepo.TeamRepository, cr compRepo.CompetitionRepository) Shaper {
+	return &ShaperImpl{ur: ur, tr: tr, cr: cr}
+}
+
+func (s *ShaperImpl) Viewer(viewerID uint) (Viewer, error) {
+	if viewerID == 0 {
+		return Viewer{}, nil
+	}
+
+	user, err := s.ur.GetUserByID(viewerID)
+	if err != nil {
+		return Viewer{}, err
+	}
+
+	teammateIDs, err := s.tr.GetTeammateIDs(viewerID)
+	if err != nil {
+		return Viewer{}, err
+	}
+
+	registrantIDs, err := s.cr.GetRegistrantIDsByOrganizer(viewerID)
+	if err != nil {
+		return Viewer{}, err
+	}
+
+	viewer := Viewer{
+		ID:          viewerID,
+		Admin:       user.Role == utils.RoleAdmin,
+		Teammates:   map[uint]bool{},
+		Registrants: map[uint]bool{},
+	}
+	for _, id := range teammateIDs {
+		viewer.Teammates[id] = true
+	}
+	for _, id := range registrantIDs {
+		viewer.Registrants[id] = true
+	}
+
+	return viewer, nil
+}
@@ -21,18 +21,18 @@ type RecruitmentController struct {
 func (rc *RecruitmentController) InitializeRecruitmentRoute(config middleware.JWTConfig) {
 	r := rc.router.Group("/recruitments")
 	{
-		r.POST("", rc.CreateRecruitment, middleware.JWTWithConfig(config))
+		r.POST("", rc.CreateRecruitment, utils.JWTWithScope(config, utils.ScopeRecruitmentsWrite))
 		r.GET("", rc.GetRecruitments)
-		r.PUT("/:id", rc.UpdateRecruitment, middleware.JWTWithConfig(config))
-		r.POST("/applications", rc.CreateRecruitmentApplication, middleware.JWTWithConfig(config))
+		r.PUT("/:id", rc.UpdateRecruitment, utils.JWTWithScope(config, utils.ScopeRecruitmentsWrite))
+		r.POST("/applications", rc.CreateRecruitmentApplication, utils.JWTWithScope(config, utils.ScopeRecruitmentsWrite))
 		r.GET("/:id", rc.GetRecruitmentByID)
-		r.GET("/:id/details", rc.GetRecruitmentDetailsByID, middleware.JWTWithConfig(config))
-		r.GET("/teams/:id", rc.GetRecruitmentByTeamID, middleware.JWTWithConfig(config))
-		r.PUT("/applications/:id/accept", rc.AcceptRecruitmentApplication, middleware.JWTWithConfig(config))
-		r.PUT("/applications/:id/reject", rc.RejectRecruitmentApplication, middleware.JWTWithConfig(config))
-		r.DELETE("/:id", rc.DeleteRecruitmentByID, middleware.JWTWithConfig(config))
-		r.PUT("/:id/open", rc.OpenRecruitmentApplicationPeriod, middleware.JWTWithConfig(config))
-		r.PUT("/:id/close", rc.CloseRecruitmentApplicationPeriod, middleware.JWTWithConfig(config))
+		r.GET("/:id/details", rc.GetRecruitmentDetailsByID, utils.JWTWithScope(config, utils.ScopeRecruitmentsRead))
+		r.GET("/teams/:id", rc.GetRecruitmentByTeamID, utils.JWTWithScope(config, utils.ScopeRecruitmentsRead))
+		r.PUT("/applications/:id/accept", rc.AcceptRecruitmentApplication, utils.JWTWithScope(config, utils.ScopeRecruitmentsWrite))
+		r.PUT("/applications/:id/reject", rc.RejectRecruitmentApplication, utils.JWTWithScope(config, utils.ScopeRecruitmentsWrite))
+		r.DELETE("/:id", rc.DeleteRecruitmentByID, utils.JWTWithScope(config, utils.ScopeRecruitmentsWrite))
+		r.PUT("/:id/open", rc.OpenRecruitmentApplicationPeriod, utils.JWTWithScope(config, utils.ScopeRecruitmentsWrite))
+		r.PUT("/:id/close", rc.CloseRecruitmentApplicationPeriod, utils.JWTWithScope(config, utils.ScopeRecruitmentsWrite))
 	}
 }
 
@@ -48,6 +48,7 @@ func (rc *RecruitmentController) InitializeRecruitmentRoute(config middleware.JW
 // @Success      201  {object}  response.Response{data=string,status=string,message=string}
 // @Failure      400  {object}  response.Response
 // @Failure      401  {object}  response.Response
+// @Failure      403  {object}  response.Response
 // @Failure      500  {object}  response.Response
 // @Router       /recruitments [post]
 func (rc *RecruitmentController) CreateRecruitment(c echo.Context) error {
@@ -63,6 +64,13 @@ func (rc *RecruitmentController) CreateRecruitment(c echo.Context) error {
 	}
 	err := rc.recruitmentUC.CreateRecruitment(*recruitment, userID)
 	if err != nil {
+		if err.Error() == "email is not verified" {
+			return c.JSON(http.StatusForbidden, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
 		if err.Error() == "action unauthorized" {
 			return c.JSON(http.StatusUnauthorized, response.Response{
 				Status:  "error",
@@ -437,6 +445,8 @@ func (rc *RecruitmentController) RejectRecruitmentApplication(c echo.Context) er
 // @Success      200  {object}   response.Response{data=string,status=string,message=string}
 // @Failure      400  {object}  response.Response
 // @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
 // @Failure      500  {object}  response.Response
 // @Router       /recruitments/applications/{id}/accept [put]
 func (rc *RecruitmentController) AcceptRecruitmentApplication(c echo.Context) error {
@@ -460,6 +470,21 @@ func (rc *RecruitmentController) AcceptRecruitmentApplication(c echo.Context) er
 				Data:    nil,
 			})
 		}
+		if err.Error() == "record not found" {
+			return c.JSON(http.StatusNotFound, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		// the application was answered or the team filled up in the meantime
+		if err.Error() == "The team is full" || err.Error() == "already a team member" || err.Error() == "no rows affected" {
+			return c.JSON(http.StatusConflict, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
 
 		return c.JSON(http.StatusInternalServerError, response.Response{
 			Status:  "error",
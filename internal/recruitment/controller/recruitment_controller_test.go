@@ -39,7 +39,7 @@ func TestCreateRecruitmentApplication(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(1, "gmail@gmail.com")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		// setup controller/handler
 		testRecruitmentController := RecruitmentController{
@@ -75,7 +75,7 @@ func TestCreateRecruitmentApplication(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(1, "gmail@gmail.com")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		// setup controller/handler
 		testRecruitmentController := RecruitmentController{
@@ -101,7 +101,7 @@ func TestAcceptRecruitmentApplication(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id/accept")
 		c.SetParamNames("id")
@@ -126,7 +126,7 @@ func TestAcceptRecruitmentApplication(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id/accept")
 		c.SetParamNames("id")
@@ -142,6 +142,31 @@ func TestAcceptRecruitmentApplication(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
 		mockUseCase.AssertExpectations(t)
 	})
+
+	t.Run("team-full", func(t *testing.T) {
+		mockUseCase.On("AcceptRecruitmentApplication", uint(1), uint(1)).Return(errors.New("The team is full")).Once()
+		req, err := http.NewRequest(http.MethodPut, "/recruitments/applications", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		c.SetPath("/:id/accept")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		// setup controller/handler
+		compController := RecruitmentController{
+			router:        e,
+			recruitmentUC: mockUseCase,
+		}
+
+		// get the response
+		compController.AcceptRecruitmentApplication(c)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
 }
 
 func TestRejectRecruitmentApplication(t *testing.T) {
@@ -155,7 +180,7 @@ func TestRejectRecruitmentApplication(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id/reject")
 		c.SetParamNames("id")
@@ -180,7 +205,7 @@ func TestRejectRecruitmentApplication(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id/reject")
 		c.SetParamNames("id")
@@ -209,7 +234,7 @@ func TestOpenRecruitmentApplicationPeriod(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id/open")
 		c.SetParamNames("id")
@@ -234,7 +259,7 @@ func TestOpenRecruitmentApplicationPeriod(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id/open")
 		c.SetParamNames("id")
@@ -263,7 +288,7 @@ func TestCloseRecruitmentApplicationPeriod(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id/close")
 		c.SetParamNames("id")
@@ -288,7 +313,7 @@ func TestCloseRecruitmentApplicationPeriod(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id/close")
 		c.SetParamNames("id")
@@ -317,7 +342,7 @@ func TestDeleteRecruitmentByID(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id")
 		c.SetParamNames("id")
@@ -342,7 +367,7 @@ func TestDeleteRecruitmentByID(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id")
 		c.SetParamNames("id")
@@ -380,7 +405,7 @@ func TestUpdateRecruitment(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id")
 		c.SetParamNames("id")
@@ -406,7 +431,7 @@ func TestUpdateRecruitment(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com")
+		token := utils.CreateJWTToken(uint(1), "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id")
 		c.SetParamNames("id")
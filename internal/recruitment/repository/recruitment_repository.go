@@ -144,8 +144,10 @@ func (rr *RecruitmentRepositoryImpl) RejectRecruitmentApplication(id uint) error
 	return nil
 }
 
+// AcceptRecruitmentApplication accepts the application if it is still pending,
+// so an application is never accepted twice.
 func (rr *RecruitmentRepositoryImpl) AcceptRecruitmentApplication(id uint) error {
-	result := rr.db.Debug().Model(&entity.RecruitmentApplication{}).Where("id = ?", id).Update("acceptance_status", 1)
+	result := rr.db.Model(&entity.RecruitmentApplication{}).Where("id = ? AND acceptance_status = 0", id).Update("acceptance_status", 1)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -159,8 +161,7 @@ func (rr *RecruitmentRepositoryImpl) AcceptRecruitmentApplication(id uint) error
 
 func (rr *RecruitmentRepositoryImpl) GetRecruitmentApplicationByID(id uint) (entity.RecruitmentApplication, error) {
 	var recruitmentApplication entity.RecruitmentApplication
-	result := rr.db.Preload(clause.Associations).Find(&recruitmentApplication)
-	fmt.Println(result)
+	result := rr.db.Preload(clause.Associations).First(&recruitmentApplication, id)
 	if result.Error != nil {
 		return recruitmentApplication, result.Error
 	}
@@ -325,13 +325,36 @@ func TestAcceptRecruitmentApplication(t *testing.T) {
 	defer mockedDB.Close()
 
 	mockObj.ExpectBegin()
-	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE")).WithArgs(1, utils.AnyTime{}, 1).WillReturnResult(driver.RowsAffected(1))
+	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `recruitment_applications` SET `acceptance_status`=?,`updated_at`=? WHERE id = ? AND acceptance_status = 0")).WithArgs(1, utils.AnyTime{}, 1).WillReturnResult(driver.RowsAffected(1))
 	mockObj.ExpectCommit()
 
 	err = recruitmentRepo.AcceptRecruitmentApplication(1)
 	assert.NoError(t, err)
 }
 
+func TestAcceptRecruitmentApplicationAlreadyAnswered(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		&mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	recruitmentRepo := CreateNewRecruitmentRepository(db)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `recruitment_applications` SET `acceptance_status`=?,`updated_at`=? WHERE id = ? AND acceptance_status = 0")).WithArgs(1, utils.AnyTime{}, 1).WillReturnResult(driver.RowsAffected(0))
+	mockObj.ExpectCommit()
+
+	err = recruitmentRepo.AcceptRecruitmentApplication(1)
+	assert.EqualError(t, err, "no rows affected")
+}
+
 func TestAcceptRecruitmentApplicationUnexpectedDBError(t *testing.T) {
 	mockedDB, mockObj, err := sqlmock.New()
 	db, err := gorm.Open(mysql.Dialector{
@@ -370,7 +393,7 @@ func TestGetRecruitmentByID(t *testing.T) {
 
 	defer mockedDB.Close()
 
-	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT `recruitments`.`id`,`recruitments`.`role`,`recruitments`.`description`,`recruitments`.`team_id`,`recruitments`.`application_acceptance_status`,`recruitments`.`created_at`,`recruitments`.`updated_at`,`Team`.`id` AS `Team__id`,`Team`.`name` AS `Team__name`,`Team`.`description` AS `Team__description`,`Team`.`capacity` AS `Team__capacity`,`Team`.`created_at` AS `Team__created_at`,`Team`.`updated_at` AS `Team__updated_at` FROM `recruitments` LEFT JOIN `teams` `Team` ON `recruitments`.`team_id` = `Team`.`id` WHERE recruitments.id = ? ORDER BY `recruitments`.`id` LIMIT 1")).WithArgs(uint(1)).WillReturnRows(sqlmock.NewRows([]string{"recruitments.id", "recruitments.role", "recruitments.description", "recruitments.team_id", "recruitments.application_acceptance_status", "recruitments.created_at", "recruitments.updated_at", "Team__id", "Team__name", "Team__description", "Team__Team__capacity", "Team__created_at", "Team__updated_at"}).AddRow(1, "Backend Engineer", "asdfasdf", uint(1), 0, time.Now(), time.Now(), uint(1), "Team 1", "Team hackahton", 4, time.Now(), time.Now()))
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT `recruitments`.`id`,`recruitments`.`role`,`recruitments`.`description`,`recruitments`.`team_id`,`recruitments`.`application_acceptance_status`,`recruitments`.`created_at`,`recruitments`.`updated_at`,`Team`.`id` AS `Team__id`,`Team`.`name` AS `Team__name`,`Team`.`description` AS `Team__description`,`Team`.`capacity` AS `Team__capacity`,`Team`.`logo_key` AS `Team__logo_key`,`Team`.`created_at` AS `Team__created_at`,`Team`.`updated_at` AS `Team__updated_at` FROM `recruitments` LEFT JOIN `teams` `Team` ON `recruitments`.`team_id` = `Team`.`id` WHERE recruitments.id = ? ORDER BY `recruitments`.`id` LIMIT 1")).WithArgs(uint(1)).WillReturnRows(sqlmock.NewRows([]string{"recruitments.id", "recruitments.role", "recruitments.description", "recruitments.team_id", "recruitments.application_acceptance_status", "recruitments.created_at", "recruitments.updated_at", "Team__id", "Team__name", "Team__description", "Team__Team__capacity", "Team__created_at", "Team__updated_at"}).AddRow(1, "Backend Engineer", "asdfasdf", uint(1), 0, time.Now(), time.Now(), uint(1), "Team 1", "Team hackahton", 4, time.Now(), time.Now()))
 	entity, err := recruitmentRepo.GetRecruitmentByID(uint(1))
 	assert.NotEmpty(t, entity)
 	assert.NoError(t, err)
@@ -391,7 +414,7 @@ func TestGetRecruitmentByIDNoRows(t *testing.T) {
 
 	defer mockedDB.Close()
 
-	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT `recruitments`.`id`,`recruitments`.`role`,`recruitments`.`description`,`recruitments`.`team_id`,`recruitments`.`application_acceptance_status`,`recruitments`.`created_at`,`recruitments`.`updated_at`,`Team`.`id` AS `Team__id`,`Team`.`name` AS `Team__name`,`Team`.`description` AS `Team__description`,`Team`.`capacity` AS `Team__capacity`,`Team`.`created_at` AS `Team__created_at`,`Team`.`updated_at` AS `Team__updated_at` FROM `recruitments` LEFT JOIN `teams` `Team` ON `recruitments`.`team_id` = `Team`.`id` WHERE recruitments.id = ? ORDER BY `recruitments`.`id` LIMIT 1")).WithArgs(uint(1)).WillReturnRows(sqlmock.NewRows(nil))
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT `recruitments`.`id`,`recruitments`.`role`,`recruitments`.`description`,`recruitments`.`team_id`,`recruitments`.`application_acceptance_status`,`recruitments`.`created_at`,`recruitments`.`updated_at`,`Team`.`id` AS `Team__id`,`Team`.`name` AS `Team__name`,`Team`.`description` AS `Team__description`,`Team`.`capacity` AS `Team__capacity`,`Team`.`logo_key` AS `Team__logo_key`,`Team`.`created_at` AS `Team__created_at`,`Team`.`updated_at` AS `Team__updated_at` FROM `recruitments` LEFT JOIN `teams` `Team` ON `recruitments`.`team_id` = `Team`.`id` WHERE recruitments.id = ? ORDER BY `recruitments`.`id` LIMIT 1")).WithArgs(uint(1)).WillReturnRows(sqlmock.NewRows(nil))
 	entity, err := recruitmentRepo.GetRecruitmentByID(uint(1))
 	assert.Empty(t, entity)
 	assert.Error(t, err)
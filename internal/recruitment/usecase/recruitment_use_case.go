@@ -3,10 +3,13 @@ package usecase
 import (
 	"errors"
 
+	"github.com/alimikegami/compnouron/internal/policy"
 	"github.com/alimikegami/compnouron/internal/recruitment/dto"
 	"github.com/alimikegami/compnouron/internal/recruitment/entity"
 	"github.com/alimikegami/compnouron/internal/recruitment/repository"
+	teamEntity "github.com/alimikegami/compnouron/internal/team/entity"
 	teamRepository "github.com/alimikegami/compnouron/internal/team/repository"
+	"github.com/alimikegami/compnouron/internal/unitofwork"
 )
 
 type RecruitmentUseCase interface {
@@ -26,22 +29,25 @@ type RecruitmentUseCase interface {
 }
 
 type RecruitmentUseCaseImpl struct {
-	rr repository.RecruitmentRepository
-	tr teamRepository.TeamRepository
+	rr  repository.RecruitmentRepository
+	tr  teamRepository.TeamRepository
+	p   policy.Policy
+	uow unitofwork.UnitOfWork
 }
 
-func CreateNewRecruitmentUseCase(rr repository.RecruitmentRepository, tr teamRepository.TeamRepository) RecruitmentUseCase {
-	return &RecruitmentUseCaseImpl{rr: rr, tr: tr}
+func CreateNewRecruitmentUseCase(rr repository.RecruitmentRepository, tr teamRepository.TeamRepository, p policy.Policy, uow unitofwork.UnitOfWork) RecruitmentUseCase {
+	return &RecruitmentUseCaseImpl{rr: rr, tr: tr, p: p, uow: uow}
 }
 
 func (ruc *RecruitmentUseCaseImpl) CreateRecruitment(recruitmentRequest dto.RecruitmentRequest, userID uint) error {
-	teamOwner, err := ruc.tr.GetTeamLeader(recruitmentRequest.TeamID)
+	err := ruc.p.RequireVerifiedEmail(userID)
 	if err != nil {
-		return errors.New("internal server error")
+		return err
 	}
 
-	if teamOwner != userID {
-		return errors.New("action unauthorized")
+	err = ruc.p.CanManageTeam(userID, recruitmentRequest.TeamID)
+	if err != nil {
+		return err
 	}
 
 	recruitmentEntity := entity.Recruitment{
@@ -54,21 +60,19 @@ func (ruc *RecruitmentUseCaseImpl) CreateRecruitment(recruitmentRequest dto.Recr
 	return err
 }
 
+// UpdateRecruitment changes the role and description of the recruitment. A
+// recruitment stays with the team it was opened for.
 func (ruc *RecruitmentUseCaseImpl) UpdateRecruitment(recruitmentRequest dto.RecruitmentRequest, id uint, userID uint) error {
-	teamOwner, err := ruc.tr.GetTeamLeader(id)
+	recruitment, err := ruc.getManagedRecruitment(id, userID)
 	if err != nil {
-		return errors.New("internal server error")
-	}
-
-	if teamOwner != userID {
-		return errors.New("action unauthorized")
+		return err
 	}
 
 	recruitmentEntity := entity.Recruitment{
 		ID:          id,
 		Role:        recruitmentRequest.Role,
 		Description: recruitmentRequest.Description,
-		TeamID:      recruitmentRequest.TeamID,
+		TeamID:      recruitment.TeamID,
 	}
 
 	err = ruc.rr.UpdateRecruitment(recruitmentEntity)
@@ -135,17 +139,8 @@ func (ruc *RecruitmentUseCaseImpl) GetRecruitments(limit int, offset int) ([]dto
 }
 
 func (ruc *RecruitmentUseCaseImpl) GetRecruitmentDetailsByID(id uint, userID uint) (dto.RecruitmentDetailsResponse, error) {
-	teamOwner, err := ruc.tr.GetTeamLeader(id)
-	if err != nil {
-		return dto.RecruitmentDetailsResponse{}, errors.New("internal server error")
-	}
-
-	if teamOwner != userID {
-		return dto.RecruitmentDetailsResponse{}, errors.New("action unauthorized")
-	}
-
 	var recruitmentApplicationsResponse []dto.RecruitmentApplicationResponse
-	recruitment, err := ruc.rr.GetRecruitmentByID(id)
+	recruitment, err := ruc.getManagedRecruitment(id, userID)
 	if err != nil {
 		return dto.RecruitmentDetailsResponse{}, err
 	}
@@ -198,49 +193,56 @@ func (ruc *RecruitmentUseCaseImpl) GetRecruitmentByTeamID(id uint) (dto.Recruitm
 }
 
 func (ruc *RecruitmentUseCaseImpl) RejectRecruitmentApplication(id uint, userID uint) error {
-	teamOwner, err := ruc.tr.GetTeamLeader(id)
+	_, err := ruc.getManagedRecruitmentApplication(id, userID)
 	if err != nil {
-		return errors.New("internal server error")
-	}
-
-	if teamOwner != userID {
-		return errors.New("action unauthorized")
+		return err
 	}
 	err = ruc.rr.RejectRecruitmentApplication(id)
 
 	return err
 }
 
+// AcceptRecruitmentApplication accepts the application and adds the applicant
+// to the team in one transaction. The team row is locked before the members
+// are counted, so concurrent accepts can't take the team over its capacity.
 func (ruc *RecruitmentUseCaseImpl) AcceptRecruitmentApplication(id uint, userID uint) error {
-	teamOwner, err := ruc.tr.GetTeamLeader(id)
+	recruitmentApplication, err := ruc.getManagedRecruitmentApplication(id, userID)
 	if err != nil {
-		return errors.New("internal server error")
+		return err
 	}
 
-	if teamOwner != userID {
-		return errors.New("action unauthorized")
-	}
+	teamID := recruitmentApplication.Recruitment.TeamID
+	return ruc.uow.Do(func(repos unitofwork.Repositories) error {
+		team, err := repos.Teams.GetTeamForUpdate(teamID)
+		if err != nil {
+			return err
+		}
 
-	recruitmentApplication, err := ruc.rr.GetRecruitmentApplicationByID(id)
-	if err != nil {
-		return err
-	}
+		member, err := repos.Teams.GetTeamMember(teamID, recruitmentApplication.UserID)
+		if err != nil {
+			return err
+		}
 
-	team, err := ruc.tr.GetTeamByID(recruitmentApplication.Recruitment.TeamID)
-	if int(team.Capacity) <= len(team.TeamMembers) {
-		return errors.New("The team is full")
-	}
+		if member.Role != "" {
+			return errors.New("already a team member")
+		}
 
-	err = ruc.rr.AcceptRecruitmentApplication(id)
-	if err != nil {
-		return err
-	}
+		memberCount, err := repos.Teams.CountTeamMembers(teamID)
+		if err != nil {
+			return err
+		}
 
-	err = ruc.tr.AddTeamMember(recruitmentApplication.UserID, recruitmentApplication.Recruitment.TeamID, 0)
-	if err != nil {
-		return err
-	}
-	return nil
+		if int64(team.Capacity) <= memberCount {
+			return errors.New("The team is full")
+		}
+
+		err = repos.Recruitments.AcceptRecruitmentApplication(id)
+		if err != nil {
+			return err
+		}
+
+		return repos.Teams.AddTeamMember(recruitmentApplication.UserID, teamID, teamEntity.TeamRoleMember)
+	})
 }
 
 func (ruc *RecruitmentUseCaseImpl) SearchRecruitment(limit int, offset int, keyword string) ([]dto.BriefRecruitmentResponse, error) {
@@ -281,13 +283,9 @@ func (ruc *RecruitmentUseCaseImpl) GetRecruitmentByID(id uint) (dto.RecruitmentR
 }
 
 func (ruc *RecruitmentUseCaseImpl) DeleteRecruitmentByID(id uint, userID uint) error {
-	teamOwner, err := ruc.tr.GetTeamLeader(id)
+	_, err := ruc.getManagedRecruitment(id, userID)
 	if err != nil {
-		return errors.New("internal server error")
-	}
-
-	if teamOwner != userID {
-		return errors.New("action unauthorized")
+		return err
 	}
 
 	err = ruc.rr.DeleteRecruitmentByID(id)
@@ -296,13 +294,9 @@ func (ruc *RecruitmentUseCaseImpl) DeleteRecruitmentByID(id uint, userID uint) e
 }
 
 func (ruc *RecruitmentUseCaseImpl) OpenRecruitmentApplicationPeriod(id uint, userID uint) error {
-	teamOwner, err := ruc.tr.GetTeamLeader(id)
+	_, err := ruc.getManagedRecruitment(id, userID)
 	if err != nil {
-		return errors.New("internal server error")
-	}
-
-	if teamOwner != userID {
-		return errors.New("action unauthorized")
+		return err
 	}
 
 	err = ruc.rr.OpenRecruitmentApplicationPeriod(id)
@@ -311,16 +305,44 @@ func (ruc *RecruitmentUseCaseImpl) OpenRecruitmentApplicationPeriod(id uint, use
 }
 
 func (ruc *RecruitmentUseCaseImpl) CloseRecruitmentApplicationPeriod(id uint, userID uint) error {
-	teamOwner, err := ruc.tr.GetTeamLeader(id)
+	_, err := ruc.getManagedRecruitment(id, userID)
 	if err != nil {
-		return errors.New("internal server error")
-	}
-
-	if teamOwner != userID {
-		return errors.New("action unauthorized")
+		return err
 	}
 
 	err = ruc.rr.CloseRecruitmentApplicationPeriod(id)
 
 	return err
 }
+
+// getManagedRecruitment returns the recruitment when the user can manage the
+// team it belongs to.
+func (ruc *RecruitmentUseCaseImpl) getManagedRecruitment(id uint, userID uint) (entity.Recruitment, error) {
+	recruitment, err := ruc.rr.GetRecruitmentByID(id)
+	if err != nil {
+		return entity.Recruitment{}, err
+	}
+
+	err = ruc.p.CanManageTeam(userID, recruitment.TeamID)
+	if err != nil {
+		return entity.Recruitment{}, err
+	}
+
+	return recruitment, nil
+}
+
+// getManagedRecruitmentApplication returns the application when the user can
+// manage the team it applies to.
+func (ruc *RecruitmentUseCaseImpl) getManagedRecruitmentApplication(id uint, userID uint) (entity.RecruitmentApplication, error) {
+	recruitmentApplication, err := ruc.rr.GetRecruitmentApplicationByID(id)
+	if err != nil {
+		return entity.RecruitmentApplication{}, err
+	}
+
+	err = ruc.p.CanManageTeam(userID, recruitmentApplication.Recruitment.TeamID)
+	if err != nil {
+		return entity.RecruitmentApplication{}, err
+	}
+
+	return recruitmentApplication, nil
+}
@@ -2,14 +2,20 @@ package usecase
 
 import (
 	"errors"
+	"github.com/alimikegami/compnouron/internal/policy"
+	"github.com/alimikegami/compnouron/pkg/utils"
 	"testing"
 	"time"
 
 	recruitmentRepo "github.com/alimikegami/compnouron/internal/mocks/recruitment/repository"
 	teamRepo "github.com/alimikegami/compnouron/internal/mocks/team/repository"
+	uowMocks "github.com/alimikegami/compnouron/internal/mocks/unitofwork"
+	userRepo "github.com/alimikegami/compnouron/internal/mocks/user/repository"
 	"github.com/alimikegami/compnouron/internal/recruitment/dto"
 	"github.com/alimikegami/compnouron/internal/recruitment/entity"
 	teamEntity "github.com/alimikegami/compnouron/internal/team/entity"
+	"github.com/alimikegami/compnouron/internal/unitofwork"
+	userEntity "github.com/alimikegami/compnouron/internal/user/entity"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -22,20 +28,23 @@ type MockRecruitmentRepository struct {
 func TestCreateRecruitment(t *testing.T) {
 	mockRecuitmentRepo := recruitmentRepo.NewRecruitmentRepository(t)
 	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	mockUserRepo := userRepo.NewUserRepository(t)
 	req := dto.RecruitmentRequest{
 		Role:        "Backend Engineer",
 		Description: "Need Node.JS Developer",
 		TeamID:      1,
 	}
+	verifiedAt := time.Now()
 	t.Run("success", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(1), nil).Once()
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, VerifiedAt: &verifiedAt}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
 		mockRecuitmentRepo.On("CreateRecruitment", entity.Recruitment{
 			Role:                        "Backend Engineer",
 			Description:                 "Need Node.JS Developer",
 			TeamID:                      1,
 			ApplicationAcceptanceStatus: 0,
 		}).Return(nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.CreateRecruitment(req, uint(1))
 		assert.NoError(t, err)
 		mockTeamRepo.AssertExpectations(t)
@@ -43,14 +52,15 @@ func TestCreateRecruitment(t *testing.T) {
 	})
 
 	t.Run("unexpected-create-recruitment-error", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(1), nil).Once()
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, VerifiedAt: &verifiedAt}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
 		mockRecuitmentRepo.On("CreateRecruitment", entity.Recruitment{
 			Role:                        "Backend Engineer",
 			Description:                 "Need Node.JS Developer",
 			TeamID:                      1,
 			ApplicationAcceptanceStatus: 0,
 		}).Return(errors.New("unxpected db error")).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.CreateRecruitment(req, uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
@@ -58,32 +68,45 @@ func TestCreateRecruitment(t *testing.T) {
 	})
 
 	t.Run("action-unauthorized", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(2), nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, VerifiedAt: &verifiedAt}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{}, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleStudent}, nil).Once()
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.CreateRecruitment(req, uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
 	})
 
-	t.Run("unexpected-get-team-leader-error", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(0), errors.New("unexpected db error")).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+	t.Run("unexpected-get-team-member-error", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, VerifiedAt: &verifiedAt}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{}, errors.New("unexpected db error")).Once()
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.CreateRecruitment(req, uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
 	})
+
+	t.Run("email-not-verified", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1}, nil).Once()
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
+		err := testUseCase.CreateRecruitment(req, uint(1))
+		assert.EqualError(t, err, "email is not verified")
+		mockUserRepo.AssertExpectations(t)
+	})
 }
 
 func TestUpdateRecruitment(t *testing.T) {
 	mockRecuitmentRepo := recruitmentRepo.NewRecruitmentRepository(t)
 	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	mockUserRepo := userRepo.NewUserRepository(t)
 	req := dto.RecruitmentRequest{
 		Role:        "Backend Engineer",
 		Description: "Need Node.JS Developer",
 		TeamID:      1,
 	}
 	t.Run("success", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(1), nil).Once()
+		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{ID: 1, TeamID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
 		mockRecuitmentRepo.On("UpdateRecruitment", entity.Recruitment{
 			ID:                          1,
 			Role:                        "Backend Engineer",
@@ -91,7 +114,7 @@ func TestUpdateRecruitment(t *testing.T) {
 			TeamID:                      1,
 			ApplicationAcceptanceStatus: 0,
 		}).Return(nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.UpdateRecruitment(req, uint(1), uint(1))
 		assert.NoError(t, err)
 		mockTeamRepo.AssertExpectations(t)
@@ -99,7 +122,8 @@ func TestUpdateRecruitment(t *testing.T) {
 	})
 
 	t.Run("unexpected-update-error", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(1), nil).Once()
+		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{ID: 1, TeamID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
 		mockRecuitmentRepo.On("UpdateRecruitment", entity.Recruitment{
 			ID:                          1,
 			Role:                        "Backend Engineer",
@@ -107,7 +131,7 @@ func TestUpdateRecruitment(t *testing.T) {
 			TeamID:                      1,
 			ApplicationAcceptanceStatus: 0,
 		}).Return(errors.New("unxpected db error")).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.UpdateRecruitment(req, uint(1), uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
@@ -115,16 +139,19 @@ func TestUpdateRecruitment(t *testing.T) {
 	})
 
 	t.Run("action-unauthorized", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(2), nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleStudent}, nil).Once()
+		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{ID: 1, TeamID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{}, nil).Once()
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.UpdateRecruitment(req, uint(1), uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
 	})
 
-	t.Run("unexpected-get-team-leader-error", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(0), errors.New("unexpected db error")).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+	t.Run("unexpected-get-team-member-error", func(t *testing.T) {
+		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{ID: 1, TeamID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{}, errors.New("unexpected db error")).Once()
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.UpdateRecruitment(req, uint(1), uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
@@ -134,6 +161,7 @@ func TestUpdateRecruitment(t *testing.T) {
 func TestGetRecruitmentByID(t *testing.T) {
 	mockRecuitmentRepo := recruitmentRepo.NewRecruitmentRepository(t)
 	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	mockUserRepo := userRepo.NewUserRepository(t)
 	t.Run("success", func(t *testing.T) {
 		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{
 			ID:                          1,
@@ -144,7 +172,7 @@ func TestGetRecruitmentByID(t *testing.T) {
 			CreatedAt:                   time.Now(),
 			UpdatedAt:                   time.Time{},
 		}, nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		resp, err := testUseCase.GetRecruitmentByID(uint(1))
 		assert.NoError(t, err)
 		assert.NotEmpty(t, resp)
@@ -154,7 +182,7 @@ func TestGetRecruitmentByID(t *testing.T) {
 
 	t.Run("unexpected-get-recruitment-by-id-error", func(t *testing.T) {
 		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{}, errors.New("unexpected db error")).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		resp, err := testUseCase.GetRecruitmentByID(uint(1))
 		assert.Error(t, err)
 		assert.Empty(t, resp)
@@ -165,6 +193,7 @@ func TestGetRecruitmentByID(t *testing.T) {
 func TestCreateRecruitmentApplication(t *testing.T) {
 	mockRecuitmentRepo := recruitmentRepo.NewRecruitmentRepository(t)
 	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	mockUserRepo := userRepo.NewUserRepository(t)
 	t.Run("success", func(t *testing.T) {
 		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{
 			ID:                          1,
@@ -188,7 +217,7 @@ func TestCreateRecruitmentApplication(t *testing.T) {
 			RecruitmentID:    1,
 			AcceptanceStatus: 0,
 		}).Return(nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.CreateRecruitmentApplication(dto.RecruitmentApplicationRequest{
 			RecruitmentID: 1,
 		}, uint(1))
@@ -220,7 +249,7 @@ func TestCreateRecruitmentApplication(t *testing.T) {
 			RecruitmentID:    1,
 			AcceptanceStatus: 0,
 		}).Return(errors.New("unexpected db error")).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.CreateRecruitmentApplication(dto.RecruitmentApplicationRequest{
 			RecruitmentID: 1,
 		}, uint(1))
@@ -254,7 +283,7 @@ func TestCreateRecruitmentApplication(t *testing.T) {
 				AcceptanceStatus: 1,
 			},
 		}, nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.CreateRecruitmentApplication(dto.RecruitmentApplicationRequest{
 			RecruitmentID: 1,
 		}, uint(1))
@@ -293,7 +322,7 @@ func TestCreateRecruitmentApplication(t *testing.T) {
 			RecruitmentID:    1,
 			AcceptanceStatus: 0,
 		}).Return(nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.CreateRecruitmentApplication(dto.RecruitmentApplicationRequest{
 			RecruitmentID: 1,
 		}, uint(1))
@@ -306,10 +335,12 @@ func TestCreateRecruitmentApplication(t *testing.T) {
 func TestRejectRecruitmentApplication(t *testing.T) {
 	mockRecuitmentRepo := recruitmentRepo.NewRecruitmentRepository(t)
 	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	mockUserRepo := userRepo.NewUserRepository(t)
 	t.Run("success", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(1), nil).Once()
+		mockRecuitmentRepo.On("GetRecruitmentApplicationByID", uint(1)).Return(entity.RecruitmentApplication{ID: 1, Recruitment: entity.Recruitment{ID: 1, TeamID: 1}}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
 		mockRecuitmentRepo.On("RejectRecruitmentApplication", uint(1)).Return(nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.RejectRecruitmentApplication(uint(1), uint(1))
 		assert.NoError(t, err)
 		mockTeamRepo.AssertExpectations(t)
@@ -317,9 +348,10 @@ func TestRejectRecruitmentApplication(t *testing.T) {
 	})
 
 	t.Run("unexpected-reject-error", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(1), nil).Once()
+		mockRecuitmentRepo.On("GetRecruitmentApplicationByID", uint(1)).Return(entity.RecruitmentApplication{ID: 1, Recruitment: entity.Recruitment{ID: 1, TeamID: 1}}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
 		mockRecuitmentRepo.On("RejectRecruitmentApplication", uint(1)).Return(errors.New("unxpected db error")).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.RejectRecruitmentApplication(uint(1), uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
@@ -327,29 +359,87 @@ func TestRejectRecruitmentApplication(t *testing.T) {
 	})
 
 	t.Run("action-unauthorized", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(2), nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleStudent}, nil).Once()
+		mockRecuitmentRepo.On("GetRecruitmentApplicationByID", uint(1)).Return(entity.RecruitmentApplication{ID: 1, Recruitment: entity.Recruitment{ID: 1, TeamID: 1}}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{}, nil).Once()
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.RejectRecruitmentApplication(uint(1), uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
 	})
 
-	t.Run("unexpected-get-team-leader-error", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(0), errors.New("unexpected db error")).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+	t.Run("unexpected-get-team-member-error", func(t *testing.T) {
+		mockRecuitmentRepo.On("GetRecruitmentApplicationByID", uint(1)).Return(entity.RecruitmentApplication{ID: 1, Recruitment: entity.Recruitment{ID: 1, TeamID: 1}}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{}, errors.New("unexpected db error")).Once()
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.RejectRecruitmentApplication(uint(1), uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
 	})
 }
 
+func TestAcceptRecruitmentApplication(t *testing.T) {
+	mockRecuitmentRepo := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	mockUserRepo := userRepo.NewUserRepository(t)
+	mockUnitOfWork := uowMocks.NewUnitOfWork(t)
+	mockUnitOfWork.On("Do", mock.Anything).Return(func(fn func(unitofwork.Repositories) error) error {
+		return fn(unitofwork.Repositories{Teams: mockTeamRepo, Recruitments: mockRecuitmentRepo})
+	})
+	testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), mockUnitOfWork)
+	application := entity.RecruitmentApplication{ID: 4, UserID: 5, Recruitment: entity.Recruitment{ID: 3, TeamID: 2}}
+	team := teamEntity.Team{ID: 2, Capacity: 3}
+	coLeader := teamEntity.TeamMember{TeamID: 2, UserID: 1, Role: teamEntity.TeamRoleCoLeader}
+
+	t.Run("co-leader", func(t *testing.T) {
+		mockRecuitmentRepo.On("GetRecruitmentApplicationByID", uint(4)).Return(application, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(2), uint(1)).Return(coLeader, nil).Once()
+		mockTeamRepo.On("GetTeamForUpdate", uint(2)).Return(team, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(2), uint(5)).Return(teamEntity.TeamMember{}, nil).Once()
+		mockTeamRepo.On("CountTeamMembers", uint(2)).Return(int64(2), nil).Once()
+		mockRecuitmentRepo.On("AcceptRecruitmentApplication", uint(4)).Return(nil).Once()
+		mockTeamRepo.On("AddTeamMember", uint(5), uint(2), teamEntity.TeamRoleMember).Return(nil).Once()
+		err := testUseCase.AcceptRecruitmentApplication(uint(4), uint(1))
+		assert.NoError(t, err)
+	})
+
+	t.Run("team-full", func(t *testing.T) {
+		mockRecuitmentRepo.On("GetRecruitmentApplicationByID", uint(4)).Return(application, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(2), uint(1)).Return(coLeader, nil).Once()
+		mockTeamRepo.On("GetTeamForUpdate", uint(2)).Return(team, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(2), uint(5)).Return(teamEntity.TeamMember{}, nil).Once()
+		mockTeamRepo.On("CountTeamMembers", uint(2)).Return(int64(3), nil).Once()
+		err := testUseCase.AcceptRecruitmentApplication(uint(4), uint(1))
+		assert.EqualError(t, err, "The team is full")
+	})
+
+	t.Run("already-a-member", func(t *testing.T) {
+		mockRecuitmentRepo.On("GetRecruitmentApplicationByID", uint(4)).Return(application, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(2), uint(1)).Return(coLeader, nil).Once()
+		mockTeamRepo.On("GetTeamForUpdate", uint(2)).Return(team, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(2), uint(5)).Return(teamEntity.TeamMember{TeamID: 2, UserID: 5, Role: teamEntity.TeamRoleMember}, nil).Once()
+		err := testUseCase.AcceptRecruitmentApplication(uint(4), uint(1))
+		assert.EqualError(t, err, "already a team member")
+	})
+
+	t.Run("plain-member", func(t *testing.T) {
+		mockRecuitmentRepo.On("GetRecruitmentApplicationByID", uint(4)).Return(application, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(2), uint(6)).Return(teamEntity.TeamMember{TeamID: 2, UserID: 6, Role: teamEntity.TeamRoleMember}, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(6)).Return(userEntity.User{ID: 6, Role: utils.RoleStudent}, nil).Once()
+		err := testUseCase.AcceptRecruitmentApplication(uint(4), uint(6))
+		assert.EqualError(t, err, "action unauthorized")
+	})
+}
+
 func TestOpenRecruitmentApplicationPeriod(t *testing.T) {
 	mockRecuitmentRepo := recruitmentRepo.NewRecruitmentRepository(t)
 	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	mockUserRepo := userRepo.NewUserRepository(t)
 	t.Run("success", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(1), nil).Once()
+		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{ID: 1, TeamID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
 		mockRecuitmentRepo.On("OpenRecruitmentApplicationPeriod", uint(1)).Return(nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.OpenRecruitmentApplicationPeriod(uint(1), uint(1))
 		assert.NoError(t, err)
 		mockTeamRepo.AssertExpectations(t)
@@ -357,9 +447,10 @@ func TestOpenRecruitmentApplicationPeriod(t *testing.T) {
 	})
 
 	t.Run("unexpected-open-recruitment-error", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(1), nil).Once()
+		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{ID: 1, TeamID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
 		mockRecuitmentRepo.On("OpenRecruitmentApplicationPeriod", uint(1)).Return(errors.New("unxpected db error")).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.OpenRecruitmentApplicationPeriod(uint(1), uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
@@ -367,16 +458,19 @@ func TestOpenRecruitmentApplicationPeriod(t *testing.T) {
 	})
 
 	t.Run("action-unauthorized", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(2), nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleStudent}, nil).Once()
+		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{ID: 1, TeamID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{}, nil).Once()
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.OpenRecruitmentApplicationPeriod(uint(1), uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
 	})
 
-	t.Run("unexpected-get-team-leader-error", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(0), errors.New("unexpected db error")).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+	t.Run("unexpected-get-team-member-error", func(t *testing.T) {
+		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{ID: 1, TeamID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{}, errors.New("unexpected db error")).Once()
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.OpenRecruitmentApplicationPeriod(uint(1), uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
@@ -386,10 +480,12 @@ func TestOpenRecruitmentApplicationPeriod(t *testing.T) {
 func TestCloseRecruitmentApplicationPeriod(t *testing.T) {
 	mockRecuitmentRepo := recruitmentRepo.NewRecruitmentRepository(t)
 	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	mockUserRepo := userRepo.NewUserRepository(t)
 	t.Run("success", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(1), nil).Once()
+		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{ID: 1, TeamID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
 		mockRecuitmentRepo.On("CloseRecruitmentApplicationPeriod", uint(1)).Return(nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.CloseRecruitmentApplicationPeriod(uint(1), uint(1))
 		assert.NoError(t, err)
 		mockTeamRepo.AssertExpectations(t)
@@ -397,9 +493,10 @@ func TestCloseRecruitmentApplicationPeriod(t *testing.T) {
 	})
 
 	t.Run("unexpected-close-recruitment-error", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(1), nil).Once()
+		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{ID: 1, TeamID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
 		mockRecuitmentRepo.On("CloseRecruitmentApplicationPeriod", uint(1)).Return(errors.New("unxpected db error")).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.CloseRecruitmentApplicationPeriod(uint(1), uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
@@ -407,16 +504,19 @@ func TestCloseRecruitmentApplicationPeriod(t *testing.T) {
 	})
 
 	t.Run("action-unauthorized", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(2), nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleStudent}, nil).Once()
+		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{ID: 1, TeamID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{}, nil).Once()
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.CloseRecruitmentApplicationPeriod(uint(1), uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
 	})
 
-	t.Run("unexpected-get-team-leader-error", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(0), errors.New("unexpected db error")).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+	t.Run("unexpected-get-team-member-error", func(t *testing.T) {
+		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{ID: 1, TeamID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{}, errors.New("unexpected db error")).Once()
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.CloseRecruitmentApplicationPeriod(uint(1), uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
@@ -426,10 +526,12 @@ func TestCloseRecruitmentApplicationPeriod(t *testing.T) {
 func TestDeleteRecruitmentByID(t *testing.T) {
 	mockRecuitmentRepo := recruitmentRepo.NewRecruitmentRepository(t)
 	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	mockUserRepo := userRepo.NewUserRepository(t)
 	t.Run("success", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(1), nil).Once()
+		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{ID: 1, TeamID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
 		mockRecuitmentRepo.On("DeleteRecruitmentByID", uint(1)).Return(nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.DeleteRecruitmentByID(uint(1), uint(1))
 		assert.NoError(t, err)
 		mockTeamRepo.AssertExpectations(t)
@@ -437,9 +539,10 @@ func TestDeleteRecruitmentByID(t *testing.T) {
 	})
 
 	t.Run("unexpected-delete-error", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(1), nil).Once()
+		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{ID: 1, TeamID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{TeamID: 1, UserID: 1, Role: teamEntity.TeamRoleOwner}, nil).Once()
 		mockRecuitmentRepo.On("DeleteRecruitmentByID", uint(1)).Return(errors.New("unxpected db error")).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.DeleteRecruitmentByID(uint(1), uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
@@ -447,16 +550,19 @@ func TestDeleteRecruitmentByID(t *testing.T) {
 	})
 
 	t.Run("action-unauthorized", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(2), nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleStudent}, nil).Once()
+		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{ID: 1, TeamID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{}, nil).Once()
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.DeleteRecruitmentByID(uint(1), uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
 	})
 
-	t.Run("unexpected-get-team-leader-error", func(t *testing.T) {
-		mockTeamRepo.On("GetTeamLeader", uint(1)).Return(uint(0), errors.New("unexpected db error")).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+	t.Run("unexpected-get-team-member-error", func(t *testing.T) {
+		mockRecuitmentRepo.On("GetRecruitmentByID", uint(1)).Return(entity.Recruitment{ID: 1, TeamID: 1}, nil).Once()
+		mockTeamRepo.On("GetTeamMember", uint(1), uint(1)).Return(teamEntity.TeamMember{}, errors.New("unexpected db error")).Once()
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.DeleteRecruitmentByID(uint(1), uint(1))
 		assert.Error(t, err)
 		mockTeamRepo.AssertExpectations(t)
@@ -466,6 +572,7 @@ func TestDeleteRecruitmentByID(t *testing.T) {
 func TestGetRecruitmentByTeamID(t *testing.T) {
 	mockRecuitmentRepo := recruitmentRepo.NewRecruitmentRepository(t)
 	mockTeamRepo := teamRepo.NewTeamRepository(t)
+	mockUserRepo := userRepo.NewUserRepository(t)
 	t.Run("success", func(t *testing.T) {
 		mockRecuitmentRepo.On("GetRecruitmentByTeamID", uint(1)).Return([]entity.Recruitment{
 			{
@@ -487,7 +594,7 @@ func TestGetRecruitmentByTeamID(t *testing.T) {
 				UpdatedAt:                   time.Time{},
 			},
 		}, nil).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		resp, err := testUseCase.GetRecruitmentByTeamID(uint(1))
 		assert.NoError(t, err)
 		assert.NotEmpty(t, resp)
@@ -497,7 +604,7 @@ func TestGetRecruitmentByTeamID(t *testing.T) {
 
 	t.Run("unexpected-get-recruitment-by-team-id-error", func(t *testing.T) {
 		mockRecuitmentRepo.On("GetRecruitmentByTeamID", uint(1)).Return([]entity.Recruitment{}, errors.New("unexpected db error")).Once()
-		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo)
+		testUseCase := CreateNewRecruitmentUseCase(mockRecuitmentRepo, mockTeamRepo, policy.CreateNewPolicy(mockUserRepo, mockTeamRepo), uowMocks.NewUnitOfWork(t))
 		resp, err := testUseCase.GetRecruitmentByTeamID(uint(1))
 		assert.Error(t, err)
 		assert.Empty(t, resp)
@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/alimikegami/compnouron/internal/skill/dto"
+	"github.com/alimikegami/compnouron/internal/skill/usecase"
+	"github.com/alimikegami/compnouron/pkg/response"
+	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+type SkillController struct {
+	router  *echo.Echo
+	skillUC usecase.SkillUseCase
+}
+
+func (sc *SkillController) InitializeSkillRoute(config middleware.JWTConfig) {
+	r := sc.router.Group("/skills")
+	{
+		r.GET("", sc.SearchSkills)
+		r.POST("", sc.CreateSkill, middleware.JWTWithConfig(config), utils.RequireRole(utils.RoleAdmin))
+	}
+}
+
+// SearchSkills godoc
+// @Summary      Autocomplete skills from the catalog
+// @Description  Return the catalog skills whose name or alias starts with the given keyword
+// @Tags         Skills
+// @Produce      json
+// @Param        keyword   query      string  false  "skill name prefix"
+// @Param        limit     query      int     false  "rows retrieved limit"
+// @Success      200  {object}   response.Response{data=[]dto.SkillResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /skills [get]
+func (sc *SkillController) SearchSkills(c echo.Context) error {
+	keyword := c.QueryParam("keyword")
+	limitInt := 0
+	if limit := c.QueryParam("limit"); limit != "" {
+		var err error
+		limitInt, err = strconv.Atoi(limit)
+		if err != nil {
+			fmt.Println(err)
+			return c.JSON(http.StatusBadRequest, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+	}
+
+	skillsResponse, err := sc.skillUC.SearchSkills(keyword, limitInt)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    skillsResponse,
+	})
+}
+
+// CreateSkill godoc
+// @Summary      Add a skill to the catalog
+// @Description  Given the request body, create a canonical skill with its aliases. Only admins can manage the catalog
+// @Tags         Skills
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param data body dto.SkillRequest true "Request Body"
+// @Success      201  {object}   response.Response{data=dto.SkillResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /skills [post]
+func (sc *SkillController) CreateSkill(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	skill := new(dto.SkillRequest)
+	if err := c.Bind(skill); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	skillResponse, err := sc.skillUC.CreateSkill(userID, *skill)
+	if err != nil {
+		fmt.Println(err)
+		if err.Error() == "fill the skill name" {
+			return c.JSON(http.StatusBadRequest, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		if err.Error() == "action unauthorized" {
+			return c.JSON(http.StatusUnauthorized, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		if err.Error() == "skill already exists" || err.Error() == "alias is already used" {
+			return c.JSON(http.StatusConflict, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusCreated, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    skillResponse,
+	})
+}
+
+func CreateNewSkillController(e *echo.Echo, skillUC usecase.SkillUseCase) *SkillController {
+	return &SkillController{router: e, skillUC: skillUC}
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mocks "github.com/alimikegami/compnouron/internal/mocks/skill/usecase"
+	"github.com/alimikegami/compnouron/internal/skill/dto"
+	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchSkills(t *testing.T) {
+	mockUseCase := mocks.NewSkillUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("SearchSkills", "go", 5).Return([]dto.SkillResponse{
+			{
+				ID:      1,
+				Name:    "Go",
+				Aliases: []string{"golang"},
+			},
+		}, nil).Once()
+		req, err := http.NewRequest(http.MethodGet, "/skills?keyword=go&limit=5", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		skillController := SkillController{
+			router:  e,
+			skillUC: mockUseCase,
+		}
+
+		skillController.SearchSkills(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid-limit", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/skills?keyword=go&limit=abc", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		skillController := SkillController{
+			router:  e,
+			skillUC: mockUseCase,
+		}
+
+		skillController.SearchSkills(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockUseCase.On("SearchSkills", "go", 0).Return(nil, errors.New("unexpected error")).Once()
+		req, err := http.NewRequest(http.MethodGet, "/skills?keyword=go", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		skillController := SkillController{
+			router:  e,
+			skillUC: mockUseCase,
+		}
+
+		skillController.SearchSkills(c)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestCreateSkill(t *testing.T) {
+	mockUseCase := mocks.NewSkillUseCase(t)
+	reqBody := dto.SkillRequest{
+		Name:    "Go",
+		Aliases: []string{"golang"},
+	}
+	jsonReqBody, err := json.Marshal(&reqBody)
+	assert.NoError(t, err, "No marshaling error")
+
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("CreateSkill", uint(1), reqBody).Return(dto.SkillResponse{ID: 1, Name: "Go", Aliases: []string{"golang"}}, nil).Once()
+		req, err := http.NewRequest(http.MethodPost, "/skills", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleAdmin, 1)
+		c.Set("user", token)
+		skillController := SkillController{
+			router:  e,
+			skillUC: mockUseCase,
+		}
+
+		skillController.CreateSkill(c)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("skill-exists", func(t *testing.T) {
+		mockUseCase.On("CreateSkill", uint(1), reqBody).Return(dto.SkillResponse{}, errors.New("skill already exists")).Once()
+		req, err := http.NewRequest(http.MethodPost, "/skills", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleAdmin, 1)
+		c.Set("user", token)
+		skillController := SkillController{
+			router:  e,
+			skillUC: mockUseCase,
+		}
+
+		skillController.CreateSkill(c)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("not-admin", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/skills", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(2, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		skillController := SkillController{
+			router:  e,
+			skillUC: mockUseCase,
+		}
+
+		utils.RequireRole(utils.RoleAdmin)(skillController.CreateSkill)(c)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
@@ -0,0 +1,7 @@
+package dto
+
+type SkillResponse struct {
+	ID      uint     `json:"id"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
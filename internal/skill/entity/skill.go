@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+type Skill struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"not null"`
+	Slug      string `gorm:"unique;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Aliases   []SkillAlias
+}
+
+type SkillAlias struct {
+	ID        uint   `gorm:"primaryKey"`
+	SkillID   uint   `gorm:"not null"`
+	Alias     string `gorm:"unique;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/alimikegami/compnouron/internal/skill/entity"
+	"gorm.io/gorm"
+)
+
+type SkillRepository interface {
+	CreateSkill(skill entity.Skill) (entity.Skill, error)
+	GetSkillByName(name string) (entity.Skill, error)
+	FindOrCreateSkill(name string) (entity.Skill, error)
+	SearchSkills(keyword string, limit int) ([]entity.Skill, error)
+}
+
+type SkillRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func CreateNewSkillRepository(db *gorm.DB) SkillRepository {
+	return &SkillRepositoryImpl{db: db}
+}
+
+// NormalizeSkillName is the form skill names and aliases are stored and
+// looked up in, so "Golang", " golang " and "GoLang" are the same skill.
+func NormalizeSkillName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+func (sr *SkillRepositoryImpl) CreateSkill(skill entity.Skill) (entity.Skill, error) {
+	result := sr.db.Create(&skill)
+	if result.Error != nil {
+		return entity.Skill{}, result.Error
+	}
+
+	return skill, nil
+}
+
+func (sr *SkillRepositoryImpl) GetSkillByName(name string) (entity.Skill, error) {
+	var skill entity.Skill
+	slug := NormalizeSkillName(name)
+	result := sr.db.Where("slug = ?", slug).Or("id IN (?)", sr.db.Model(&entity.SkillAlias{}).Select("skill_id").Where("alias = ?", slug)).First(&skill)
+	if result.Error != nil {
+		return entity.Skill{}, result.Error
+	}
+
+	return skill, nil
+}
+
+func (sr *SkillRepositoryImpl) FindOrCreateSkill(name string) (entity.Skill, error) {
+	skill, err := sr.GetSkillByName(name)
+	if err == nil {
+		return skill, nil
+	}
+
+	if err != gorm.ErrRecordNotFound {
+		return entity.Skill{}, err
+	}
+
+	skill, err = sr.CreateSkill(entity.Skill{
+		Name: strings.Join(strings.Fields(name), " "),
+		Slug: NormalizeSkillName(name),
+	})
+	if err != nil {
+		// another request may have created the same skill in the meantime
+		return sr.GetSkillByName(name)
+	}
+
+	return skill, nil
+}
+
+func (sr *SkillRepositoryImpl) SearchSkills(keyword string, limit int) ([]entity.Skill, error) {
+	var skills []entity.Skill
+	prefix := NormalizeSkillName(keyword) + "%"
+	result := sr.db.Preload("Aliases").Where("slug LIKE ?", prefix).Or("id IN (?)", sr.db.Model(&entity.SkillAlias{}).Select("skill_id").Where("alias LIKE ?", prefix)).Order("name").Limit(limit).Find(&skills)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return skills, nil
+}
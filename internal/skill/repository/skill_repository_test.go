@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alimikegami/compnouron/internal/skill/entity"
+	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestNormalizeSkillName(t *testing.T) {
+	assert.Equal(t, "node.js", NormalizeSkillName("  Node.JS "))
+	assert.Equal(t, "machine learning", NormalizeSkillName("Machine   Learning"))
+	assert.Equal(t, "", NormalizeSkillName("   "))
+}
+
+func TestCreateSkill(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	skillRepo := CreateNewSkillRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `skills` (`name`,`slug`,`created_at`,`updated_at`) VALUES (?,?,?,?)")).WithArgs("Go", "go", utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `skill_aliases` (`skill_id`,`alias`,`created_at`,`updated_at`) VALUES (?,?,?,?) ON DUPLICATE KEY UPDATE `skill_id`=VALUES(`skill_id`)")).WithArgs(1, "golang", utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockObj.ExpectCommit()
+
+		skill, err := skillRepo.CreateSkill(entity.Skill{
+			Name:    "Go",
+			Slug:    "go",
+			Aliases: []entity.SkillAlias{{Alias: "golang"}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), skill.ID)
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `skills` (`name`,`slug`,`created_at`,`updated_at`) VALUES (?,?,?,?)")).WithArgs("Go", "go", utils.AnyTime{}, utils.AnyTime{}).WillReturnError(errors.New("unexpected error"))
+		mockObj.ExpectRollback()
+
+		_, err := skillRepo.CreateSkill(entity.Skill{Name: "Go", Slug: "go"})
+		assert.Error(t, err)
+	})
+}
+
+func TestGetSkillByName(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	skillRepo := CreateNewSkillRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "slug"}).AddRow(1, "Go", "go")
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `skills` WHERE slug = ? OR id IN (SELECT `skill_id` FROM `skill_aliases` WHERE alias = ?) ORDER BY `skills`.`id` LIMIT 1")).WithArgs("golang", "golang").WillReturnRows(rows)
+
+		skill, err := skillRepo.GetSkillByName(" GoLang ")
+		assert.NoError(t, err)
+		assert.Equal(t, "Go", skill.Name)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `skills` WHERE slug = ? OR id IN (SELECT `skill_id` FROM `skill_aliases` WHERE alias = ?) ORDER BY `skills`.`id` LIMIT 1")).WithArgs("rust", "rust").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug"}))
+
+		_, err := skillRepo.GetSkillByName("Rust")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestFindOrCreateSkill(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	skillRepo := CreateNewSkillRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("existing", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "slug"}).AddRow(1, "Go", "go")
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `skills` WHERE slug = ? OR id IN (SELECT `skill_id` FROM `skill_aliases` WHERE alias = ?) ORDER BY `skills`.`id` LIMIT 1")).WithArgs("golang", "golang").WillReturnRows(rows)
+
+		skill, err := skillRepo.FindOrCreateSkill("Golang")
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), skill.ID)
+	})
+
+	t.Run("new", func(t *testing.T) {
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `skills` WHERE slug = ? OR id IN (SELECT `skill_id` FROM `skill_aliases` WHERE alias = ?) ORDER BY `skills`.`id` LIMIT 1")).WithArgs("machine learning", "machine learning").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug"}))
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `skills` (`name`,`slug`,`created_at`,`updated_at`) VALUES (?,?,?,?)")).WithArgs("Machine Learning", "machine learning", utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(2, 1))
+		mockObj.ExpectCommit()
+
+		skill, err := skillRepo.FindOrCreateSkill(" Machine  Learning")
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), skill.ID)
+		assert.Equal(t, "Machine Learning", skill.Name)
+	})
+}
+
+func TestSearchSkills(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	skillRepo := CreateNewSkillRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "slug"}).AddRow(1, "Go", "go")
+		aliasRows := sqlmock.NewRows([]string{"id", "skill_id", "alias"}).AddRow(1, 1, "golang")
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `skills` WHERE slug LIKE ? OR id IN (SELECT `skill_id` FROM `skill_aliases` WHERE alias LIKE ?) ORDER BY name LIMIT 10")).WithArgs("go%", "go%").WillReturnRows(rows)
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `skill_aliases` WHERE `skill_aliases`.`skill_id` = ?")).WithArgs(1).WillReturnRows(aliasRows)
+
+		skills, err := skillRepo.SearchSkills("Go", 10)
+		assert.NoError(t, err)
+		assert.Len(t, skills, 1)
+		assert.Equal(t, "golang", skills[0].Aliases[0].Alias)
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `skills` WHERE slug LIKE ? OR id IN (SELECT `skill_id` FROM `skill_aliases` WHERE alias LIKE ?) ORDER BY name LIMIT 10")).WithArgs("go%", "go%").WillReturnError(errors.New("unexpected error"))
+
+		_, err := skillRepo.SearchSkills("Go", 10)
+		assert.Error(t, err)
+	})
+}
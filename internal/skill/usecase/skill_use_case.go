@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/alimikegami/compnouron/internal/policy"
+	"github.com/alimikegami/compnouron/internal/skill/dto"
+	"github.com/alimikegami/compnouron/internal/skill/entity"
+	"github.com/alimikegami/compnouron/internal/skill/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+)
+
+type SkillUseCase interface {
+	CreateSkill(userID uint, skill dto.SkillRequest) (dto.SkillResponse, error)
+	SearchSkills(keyword string, limit int) ([]dto.SkillResponse, error)
+}
+
+type SkillUseCaseImpl struct {
+	sr repository.SkillRepository
+	p  policy.Policy
+}
+
+func CreateNewSkillUseCase(sr repository.SkillRepository, p policy.Policy) SkillUseCase {
+	return &SkillUseCaseImpl{sr: sr, p: p}
+}
+
+func (suc *SkillUseCaseImpl) CreateSkill(userID uint, skill dto.SkillRequest) (dto.SkillResponse, error) {
+	err := suc.p.CanManageSkillCatalog(userID)
+	if err != nil {
+		return dto.SkillResponse{}, err
+	}
+
+	slug := repository.NormalizeSkillName(skill.Name)
+	if slug == "" {
+		return dto.SkillResponse{}, errors.New("fill the skill name")
+	}
+
+	_, err = suc.sr.GetSkillByName(slug)
+	if err == nil {
+		return dto.SkillResponse{}, errors.New("skill already exists")
+	}
+	if err != gorm.ErrRecordNotFound {
+		return dto.SkillResponse{}, err
+	}
+
+	skillEntity := entity.Skill{
+		Name: strings.Join(strings.Fields(skill.Name), " "),
+		Slug: slug,
+	}
+	seen := map[string]bool{slug: true}
+	for _, alias := range skill.Aliases {
+		alias = repository.NormalizeSkillName(alias)
+		if alias == "" || seen[alias] {
+			continue
+		}
+		seen[alias] = true
+
+		_, err = suc.sr.GetSkillByName(alias)
+		if err == nil {
+			return dto.SkillResponse{}, errors.New("alias is already used")
+		}
+		if err != gorm.ErrRecordNotFound {
+			return dto.SkillResponse{}, err
+		}
+
+		skillEntity.Aliases = append(skillEntity.Aliases, entity.SkillAlias{Alias: alias})
+	}
+
+	skillEntity, err = suc.sr.CreateSkill(skillEntity)
+	if err != nil {
+		return dto.SkillResponse{}, err
+	}
+
+	return toSkillResponse(skillEntity), nil
+}
+
+func (suc *SkillUseCaseImpl) SearchSkills(keyword string, limit int) ([]dto.SkillResponse, error) {
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	skills, err := suc.sr.SearchSkills(keyword, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	skillsResponse := []dto.SkillResponse{}
+	for _, skill := range skills {
+		skillsResponse = append(skillsResponse, toSkillResponse(skill))
+	}
+
+	return skillsResponse, nil
+}
+
+func toSkillResponse(skill entity.Skill) dto.SkillResponse {
+	aliases := []string{}
+	for _, alias := range skill.Aliases {
+		aliases = append(aliases, alias.Alias)
+	}
+
+	return dto.SkillResponse{
+		ID:      skill.ID,
+		Name:    skill.Name,
+		Aliases: aliases,
+	}
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	skillRepo "github.com/alimikegami/compnouron/internal/mocks/skill/repository"
+	userRepo "github.com/alimikegami/compnouron/internal/mocks/user/repository"
+	"github.com/alimikegami/compnouron/internal/policy"
+	"github.com/alimikegami/compnouron/internal/skill/dto"
+	"github.com/alimikegami/compnouron/internal/skill/entity"
+	userEntity "github.com/alimikegami/compnouron/internal/user/entity"
+	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateSkill(t *testing.T) {
+	mockRepo := skillRepo.NewSkillRepository(t)
+	mockUserRepo := userRepo.NewUserRepository(t)
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		mockRepo.On("GetSkillByName", "go").Return(entity.Skill{}, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("GetSkillByName", "golang").Return(entity.Skill{}, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("CreateSkill", entity.Skill{
+			Name:    "Go",
+			Slug:    "go",
+			Aliases: []entity.SkillAlias{{Alias: "golang"}},
+		}).Return(entity.Skill{
+			ID:      1,
+			Name:    "Go",
+			Slug:    "go",
+			Aliases: []entity.SkillAlias{{ID: 1, SkillID: 1, Alias: "golang"}},
+		}, nil).Once()
+		testUseCase := CreateNewSkillUseCase(mockRepo, policy.CreateNewPolicy(mockUserRepo, nil))
+		res, err := testUseCase.CreateSkill(1, dto.SkillRequest{
+			Name:    "Go",
+			Aliases: []string{"GoLang", "golang", "go"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, dto.SkillResponse{ID: 1, Name: "Go", Aliases: []string{"golang"}}, res)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("action-unauthorized", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2, Role: utils.RoleStudent}, nil).Once()
+		testUseCase := CreateNewSkillUseCase(mockRepo, policy.CreateNewPolicy(mockUserRepo, nil))
+		_, err := testUseCase.CreateSkill(2, dto.SkillRequest{Name: "Go"})
+		assert.EqualError(t, err, "action unauthorized")
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("empty-name", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		testUseCase := CreateNewSkillUseCase(mockRepo, policy.CreateNewPolicy(mockUserRepo, nil))
+		_, err := testUseCase.CreateSkill(1, dto.SkillRequest{Name: " "})
+		assert.EqualError(t, err, "fill the skill name")
+	})
+
+	t.Run("skill-exists", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		mockRepo.On("GetSkillByName", "go").Return(entity.Skill{ID: 1, Name: "Go", Slug: "go"}, nil).Once()
+		testUseCase := CreateNewSkillUseCase(mockRepo, policy.CreateNewPolicy(mockUserRepo, nil))
+		_, err := testUseCase.CreateSkill(1, dto.SkillRequest{Name: "Go"})
+		assert.EqualError(t, err, "skill already exists")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("alias-used", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		mockRepo.On("GetSkillByName", "javascript").Return(entity.Skill{}, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("GetSkillByName", "js").Return(entity.Skill{ID: 4, Name: "JS", Slug: "js"}, nil).Once()
+		testUseCase := CreateNewSkillUseCase(mockRepo, policy.CreateNewPolicy(mockUserRepo, nil))
+		_, err := testUseCase.CreateSkill(1, dto.SkillRequest{Name: "JavaScript", Aliases: []string{"JS"}})
+		assert.EqualError(t, err, "alias is already used")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestSearchSkills(t *testing.T) {
+	mockRepo := skillRepo.NewSkillRepository(t)
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("SearchSkills", "go", 10).Return([]entity.Skill{
+			{
+				ID:      1,
+				Name:    "Go",
+				Slug:    "go",
+				Aliases: []entity.SkillAlias{{ID: 1, SkillID: 1, Alias: "golang"}},
+			},
+		}, nil).Once()
+		testUseCase := CreateNewSkillUseCase(mockRepo, nil)
+		res, err := testUseCase.SearchSkills("go", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []dto.SkillResponse{{ID: 1, Name: "Go", Aliases: []string{"golang"}}}, res)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("limit-capped", func(t *testing.T) {
+		mockRepo.On("SearchSkills", "go", 50).Return([]entity.Skill{}, nil).Once()
+		testUseCase := CreateNewSkillUseCase(mockRepo, nil)
+		res, err := testUseCase.SearchSkills("go", 1000)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockRepo.On("SearchSkills", "go", 10).Return(nil, errors.New("unexpected error")).Once()
+		testUseCase := CreateNewSkillUseCase(mockRepo, nil)
+		_, err := testUseCase.SearchSkills("go", 10)
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/alimikegami/compnouron/internal/media"
 	"github.com/alimikegami/compnouron/internal/team/dto"
 	"github.com/alimikegami/compnouron/internal/team/usecase"
 	"github.com/alimikegami/compnouron/pkg/response"
@@ -21,11 +22,23 @@ type TeamController struct {
 func (tc *TeamController) InitializeTeamRoute(config middleware.JWTConfig) {
 	r := tc.router.Group("/teams")
 	{
-		r.POST("", tc.CreateTeam, middleware.JWTWithConfig(config))
-		r.PUT("/:id", tc.UpdateTeam, middleware.JWTWithConfig(config))
-		r.DELETE("/:id", tc.DeleteTeam, middleware.JWTWithConfig(config))
+		r.POST("", tc.CreateTeam, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.PUT("/:id", tc.UpdateTeam, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.DELETE("/:id", tc.DeleteTeam, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
 		r.GET("/users/:id", tc.GetTeamsByUserID)
-		r.GET("/:id", tc.GetTeamDetailsByID)
+		r.GET("/:id", tc.GetTeamDetailsByID, utils.OptionalJWT(config))
+		r.PUT("/:id/logo", tc.UploadTeamLogo, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.DELETE("/:id/logo", tc.DeleteTeamLogo, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.POST("/:id/invitations", tc.InviteTeamMember, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.GET("/:id/invitations", tc.GetTeamInvitations, middleware.JWTWithConfig(config))
+		r.DELETE("/:id/invitations/:invitationID", tc.RevokeTeamInvitation, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.GET("/invitations", tc.GetReceivedTeamInvitations, middleware.JWTWithConfig(config))
+		r.POST("/invitations/:id/accept", tc.AcceptTeamInvitation, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.POST("/invitations/:id/decline", tc.DeclineTeamInvitation, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.POST("/:id/leave", tc.LeaveTeam, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.DELETE("/:id/members/:userID", tc.RemoveTeamMember, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.PUT("/:id/owner", tc.TransferTeamOwnership, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
+		r.PUT("/:id/members/:userID/role", tc.UpdateTeamMemberRole, utils.JWTWithScope(config, utils.ScopeTeamsWrite))
 	}
 }
 
@@ -101,6 +114,7 @@ func (tc *TeamController) UpdateTeam(c echo.Context) error {
 // @Param Authorization header string true "Bearer"
 // @Success      201  {object}   response.Response{data=string,status=string,message=string}
 // @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
 // @Failure      500  {object}  response.Response
 // @Router       /teams [post]
 func (tc *TeamController) CreateTeam(c echo.Context) error {
@@ -118,6 +132,13 @@ func (tc *TeamController) CreateTeam(c echo.Context) error {
 	err := tc.teamUC.CreateTeam(userID, *team)
 	if err != nil {
 		fmt.Println(err)
+		if err.Error() == "email is not verified" {
+			return c.JSON(http.StatusForbidden, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, response.Response{
 			Status:  "error",
 			Message: err.Error(),
@@ -223,9 +244,10 @@ func (tc *TeamController) GetTeamsByUserID(c echo.Context) error {
 
 // GetTeamDetailsByID godoc
 // @Summary      Get detailed team's data by team ID
-// @Description  Given the team ID, retrieve the detailed team's data that are associated with that particular ID
+// @Description  Given the team ID, retrieve the detailed team's data that are associated with that particular ID. The members' emails and phone numbers are left out unless their privacy settings let the caller see them, and visitors who aren't logged in only see the public ones
 // @Tags         Teams
 // @Produce      json
+// @Param Authorization header string false "Bearer"
 // @Param id path int true "Team ID"
 // @Success      200  {object}   response.Response{data=[]dto.TeamDetailsResponse,status=string,message=string}
 // @Failure      400  {object}  response.Response
@@ -242,7 +264,7 @@ func (tc *TeamController) GetTeamDetailsByID(c echo.Context) error {
 		})
 	}
 
-	result, err := tc.teamUC.GetTeamDetailsByID(uint(teamIDUint))
+	result, err := tc.teamUC.GetTeamDetailsByID(uint(teamIDUint), utils.GetOptionalUserID(c))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, response.Response{
 			Status:  "error",
@@ -258,6 +280,692 @@ func (tc *TeamController) GetTeamDetailsByID(c echo.Context) error {
 	})
 }
 
+// UploadTeamLogo godoc
+// @Summary      Upload team logo
+// @Description  Replaces the team logo with the uploaded image and returns its URLs, a thumbnail is generated as well. Only the owner and the co-leaders can change the logo
+// @Tags         Teams
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param image formData file true "JPEG, PNG or GIF image"
+// @Success      200  {object}   response.Response{data=media.Image,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      413  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/logo [put]
+func (tc *TeamController) UploadTeamLogo(c echo.Context) error {
+	teamID := c.Param("id")
+	teamIDUint, err := strconv.ParseUint(teamID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+	data, err := utils.ReadUploadedFile(c, "image", media.MaxUploadBytes)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "file too large" {
+			statusCode = http.StatusRequestEntityTooLarge
+		} else {
+			statusCode = http.StatusBadRequest
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	result, err := tc.teamUC.UploadTeamLogo(uint(teamIDUint), userID, data)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "file too large" {
+			statusCode = http.StatusRequestEntityTooLarge
+		} else if err.Error() == "unsupported image type" || err.Error() == "invalid image" || err.Error() == "image dimensions too large" {
+			statusCode = http.StatusBadRequest
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    result,
+	})
+}
+
+// DeleteTeamLogo godoc
+// @Summary      Delete team logo
+// @Description  Removes the team logo. Only the owner and the co-leaders can remove the logo
+// @Tags         Teams
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/logo [delete]
+func (tc *TeamController) DeleteTeamLogo(c echo.Context) error {
+	teamID := c.Param("id")
+	teamIDUint, err := strconv.ParseUint(teamID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+
+	err = tc.teamUC.DeleteTeamLogo(uint(teamIDUint), userID)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "logo not found" {
+			statusCode = http.StatusNotFound
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
 func CreateNewTeamController(e *echo.Echo, teamUC usecase.TeamUseCase) *TeamController {
 	return &TeamController{router: e, teamUC: teamUC}
 }
+
+// InviteTeamMember godoc
+// @Summary      Invite a user to the team
+// @Description  Given the request body, invites a registered user by ID or anyone by email to join the team. The invitee is notified by email and has 7 days to answer. Only the owner and the co-leaders can invite
+// @Tags         Teams
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param data body dto.TeamInvitationRequest true "Request Body"
+// @Success      201  {object}   response.Response{data=dto.TeamInvitationResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/invitations [post]
+func (tc *TeamController) InviteTeamMember(c echo.Context) error {
+	teamID := c.Param("id")
+	teamIDUint, err := strconv.ParseUint(teamID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+	invitationRequest := new(dto.TeamInvitationRequest)
+	if err := c.Bind(invitationRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	result, err := tc.teamUC.InviteTeamMember(uint(teamIDUint), userID, *invitationRequest)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "fill either the user ID or the email" {
+			statusCode = http.StatusBadRequest
+		} else if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "record not found" {
+			statusCode = http.StatusNotFound
+		} else if err.Error() == "already a team member" || err.Error() == "invitation already sent" {
+			statusCode = http.StatusConflict
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusCreated, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    result,
+	})
+}
+
+// GetTeamInvitations godoc
+// @Summary      Get the invitations sent by the team
+// @Description  Returns the invitations of the team, latest first, with their status. Only the owner and the co-leaders can see them
+// @Tags         Teams
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Success      200  {object}   response.Response{data=[]dto.TeamInvitationResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/invitations [get]
+func (tc *TeamController) GetTeamInvitations(c echo.Context) error {
+	teamID := c.Param("id")
+	teamIDUint, err := strconv.ParseUint(teamID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+	result, err := tc.teamUC.GetTeamInvitations(uint(teamIDUint), userID)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    result,
+	})
+}
+
+// RevokeTeamInvitation godoc
+// @Summary      Revoke a team invitation
+// @Description  Deletes the invitation so it can't be accepted anymore. Only the owner and the co-leaders can revoke invitations
+// @Tags         Teams
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param invitationID path int true "Invitation ID"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/invitations/{invitationID} [delete]
+func (tc *TeamController) RevokeTeamInvitation(c echo.Context) error {
+	teamID := c.Param("id")
+	teamIDUint, err := strconv.ParseUint(teamID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	invitationID := c.Param("invitationID")
+	invitationIDUint, err := strconv.ParseUint(invitationID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+	err = tc.teamUC.RevokeTeamInvitation(uint(teamIDUint), uint(invitationIDUint), userID)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "no rows affected" {
+			statusCode = http.StatusNotFound
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// GetReceivedTeamInvitations godoc
+// @Summary      Get the team invitations received by the logged in user
+// @Description  Returns the invitations sent to the user on the JWT Token, by ID or to their verified email address, latest first
+// @Tags         Teams
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Success      200  {object}   response.Response{data=[]dto.TeamInvitationResponse,status=string,message=string}
+// @Failure      500  {object}  response.Response
+// @Router       /teams/invitations [get]
+func (tc *TeamController) GetReceivedTeamInvitations(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	result, err := tc.teamUC.GetReceivedTeamInvitations(userID)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    result,
+	})
+}
+
+// AcceptTeamInvitation godoc
+// @Summary      Accept a team invitation
+// @Description  Joins the team the invitation is from, as long as the team isn't full. Only the invitee can accept it
+// @Tags         Teams
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Invitation ID"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/invitations/{id}/accept [post]
+func (tc *TeamController) AcceptTeamInvitation(c echo.Context) error {
+	invitationID := c.Param("id")
+	invitationIDUint, err := strconv.ParseUint(invitationID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+	err = tc.teamUC.AcceptTeamInvitation(uint(invitationIDUint), userID)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "record not found" {
+			statusCode = http.StatusNotFound
+		} else if err.Error() == "The team is full" || err.Error() == "already a team member" || err.Error() == "invitation expired" || err.Error() == "invitation already answered" || err.Error() == "no rows affected" {
+			statusCode = http.StatusConflict
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// DeclineTeamInvitation godoc
+// @Summary      Decline a team invitation
+// @Description  Declines the invitation. Only the invitee can decline it
+// @Tags         Teams
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Invitation ID"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/invitations/{id}/decline [post]
+func (tc *TeamController) DeclineTeamInvitation(c echo.Context) error {
+	invitationID := c.Param("id")
+	invitationIDUint, err := strconv.ParseUint(invitationID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+	err = tc.teamUC.DeclineTeamInvitation(uint(invitationIDUint), userID)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "record not found" {
+			statusCode = http.StatusNotFound
+		} else if err.Error() == "invitation expired" || err.Error() == "invitation already answered" || err.Error() == "no rows affected" {
+			statusCode = http.StatusConflict
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// LeaveTeam godoc
+// @Summary      Leave a team
+// @Description  Removes the user from the team. The owner has to transfer the ownership before leaving. The competition registrations and recruitments of the team are kept
+// @Tags         Teams
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/leave [post]
+func (tc *TeamController) LeaveTeam(c echo.Context) error {
+	teamID := c.Param("id")
+	teamIDUint, err := strconv.ParseUint(teamID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+	err = tc.teamUC.LeaveTeam(uint(teamIDUint), userID)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "record not found" || err.Error() == "not a team member" {
+			statusCode = http.StatusNotFound
+		} else if err.Error() == "transfer the ownership before leaving" || err.Error() == "no rows affected" {
+			statusCode = http.StatusConflict
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// RemoveTeamMember godoc
+// @Summary      Remove a member from a team
+// @Description  Removes the member from the team. The owner and the co-leaders can remove members, only the owner can remove co-leaders. The owner can't be removed. The competition registrations and recruitments of the team are kept
+// @Tags         Teams
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param userID path int true "User ID of the member"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/members/{userID} [delete]
+func (tc *TeamController) RemoveTeamMember(c echo.Context) error {
+	teamID := c.Param("id")
+	teamIDUint, err := strconv.ParseUint(teamID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	memberID := c.Param("userID")
+	memberIDUint, err := strconv.ParseUint(memberID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+	err = tc.teamUC.RemoveTeamMember(uint(teamIDUint), uint(memberIDUint), userID)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "record not found" || err.Error() == "not a team member" {
+			statusCode = http.StatusNotFound
+		} else if err.Error() == "the owner can't be removed" || err.Error() == "no rows affected" {
+			statusCode = http.StatusConflict
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// TransferTeamOwnership godoc
+// @Summary      Transfer the ownership of a team
+// @Description  Given the request body, makes another member the team owner, the previous owner becomes a co-leader. The active competition registrations of the team move to the new owner. Only the owner can transfer the ownership
+// @Tags         Teams
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param data body dto.TeamOwnershipRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/owner [put]
+func (tc *TeamController) TransferTeamOwnership(c echo.Context) error {
+	teamID := c.Param("id")
+	teamIDUint, err := strconv.ParseUint(teamID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+	ownershipRequest := new(dto.TeamOwnershipRequest)
+	if err := c.Bind(ownershipRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	err = tc.teamUC.TransferTeamOwnership(uint(teamIDUint), ownershipRequest.UserID, userID)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "record not found" || err.Error() == "not a team member" {
+			statusCode = http.StatusNotFound
+		} else if err.Error() == "already the team owner" || err.Error() == "no rows affected" {
+			statusCode = http.StatusConflict
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// UpdateTeamMemberRole godoc
+// @Summary      Change the role of a team member
+// @Description  Given the request body, makes the member a co-leader or a plain member. Co-leaders can manage the team, its recruitments, invitations and competition registrations, and remove plain members. Only the owner can change roles
+// @Tags         Teams
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Team ID"
+// @Param userID path int true "User ID of the member"
+// @Param data body dto.TeamMemberRoleRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /teams/{id}/members/{userID}/role [put]
+func (tc *TeamController) UpdateTeamMemberRole(c echo.Context) error {
+	teamID := c.Param("id")
+	teamIDUint, err := strconv.ParseUint(teamID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	memberID := c.Param("userID")
+	memberIDUint, err := strconv.ParseUint(memberID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	userID, _ := utils.GetUserDetails(c)
+	roleRequest := new(dto.TeamMemberRoleRequest)
+	if err := c.Bind(roleRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	err = tc.teamUC.UpdateTeamMemberRole(uint(teamIDUint), uint(memberIDUint), userID, *roleRequest)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "invalid team role" {
+			statusCode = http.StatusBadRequest
+		} else if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "record not found" || err.Error() == "not a team member" {
+			statusCode = http.StatusNotFound
+		} else if err.Error() == "transfer the ownership to change the owner's role" || err.Error() == "no rows affected" {
+			statusCode = http.StatusConflict
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
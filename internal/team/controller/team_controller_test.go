@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/alimikegami/compnouron/internal/media"
 	mocks "github.com/alimikegami/compnouron/internal/mocks/team/usecase"
 
 	"github.com/alimikegami/compnouron/internal/team/dto"
@@ -43,7 +45,7 @@ func TestCreateTeam(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(1, "gmail@gmail.com")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		// setup controller/handler
 		testTeamController := TeamController{
@@ -71,7 +73,7 @@ func TestCreateTeam(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(1, "gmail@gmail.com")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		// setup controller/handler
 		testTeamController := TeamController{
@@ -100,7 +102,7 @@ func TestDeleteTeam(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(1, "gmail@gmail.com")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id")
 		c.SetParamNames("id")
@@ -128,7 +130,7 @@ func TestDeleteTeam(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(1, "gmail@gmail.com")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id")
 		c.SetParamNames("id")
@@ -156,7 +158,7 @@ func TestDeleteTeam(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		token := utils.CreateJWTToken(2, "gmail@gmail.com")
+		token := utils.CreateJWTToken(2, "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		c.SetPath("/:id")
 		c.SetParamNames("id")
@@ -201,7 +203,7 @@ func TestUpdateTeam(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	token := utils.CreateJWTToken(1, "gmail@gmail.com")
+	token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
 	c.Set("user", token)
 	c.SetPath("/:id")
 	c.SetParamNames("id")
@@ -246,7 +248,7 @@ func TestUpdateTeamError(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	token := utils.CreateJWTToken(1, "gmail@gmail.com")
+	token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
 	c.Set("user", token)
 	c.SetPath("/:id")
 	c.SetParamNames("id")
@@ -285,7 +287,7 @@ func TestGetTeamsByUserID(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	token := utils.CreateJWTToken(1, "gmail@gmail.com")
+	token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
 	c.Set("user", token)
 	c.SetPath("/:id")
 	c.SetParamNames("id")
@@ -315,7 +317,7 @@ func TestGetTeamsByUserIDNoRowsFound(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	token := utils.CreateJWTToken(1, "gmail@gmail.com")
+	token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
 	c.Set("user", token)
 	c.SetPath("/:id")
 	c.SetParamNames("id")
@@ -335,7 +337,7 @@ func TestGetTeamsByUserIDNoRowsFound(t *testing.T) {
 
 func TestGetTeamDetailsByID(t *testing.T) {
 	mockUseCase := mocks.NewTeamUseCase(t)
-	mockUseCase.On("GetTeamDetailsByID", uint(1)).Return(dto.TeamDetailsResponse{
+	mockUseCase.On("GetTeamDetailsByID", uint(1), uint(1)).Return(dto.TeamDetailsResponse{
 		Name:        "Team 1",
 		Description: "Team Hackathon Technoscape 2022",
 		Capacity:    4,
@@ -344,6 +346,7 @@ func TestGetTeamDetailsByID(t *testing.T) {
 				UserID:   1,
 				Name:     "Alim Ikegami",
 				IsLeader: 1,
+				Role:     "owner",
 			},
 		},
 	}, nil)
@@ -356,7 +359,7 @@ func TestGetTeamDetailsByID(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	token := utils.CreateJWTToken(1, "gmail@gmail.com")
+	token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
 	c.Set("user", token)
 	c.SetPath("/:id")
 	c.SetParamNames("id")
@@ -376,7 +379,7 @@ func TestGetTeamDetailsByID(t *testing.T) {
 
 func TestGetTeamDetailsByIDNoRowsFound(t *testing.T) {
 	mockUseCase := mocks.NewTeamUseCase(t)
-	mockUseCase.On("GetTeamDetailsByID", uint(1)).Return(dto.TeamDetailsResponse{}, errors.New("no rows found"))
+	mockUseCase.On("GetTeamDetailsByID", uint(1), uint(1)).Return(dto.TeamDetailsResponse{}, errors.New("no rows found"))
 
 	// setup the endpoint
 	req, err := http.NewRequest(http.MethodGet, "/teams", nil)
@@ -386,7 +389,7 @@ func TestGetTeamDetailsByIDNoRowsFound(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	token := utils.CreateJWTToken(1, "gmail@gmail.com")
+	token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
 	c.Set("user", token)
 	c.SetPath("/:id")
 	c.SetParamNames("id")
@@ -403,3 +406,292 @@ func TestGetTeamDetailsByIDNoRowsFound(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 	mockUseCase.AssertExpectations(t)
 }
+
+func newImageUploadRequest(t *testing.T, data []byte) *http.Request {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("image", "logo.png")
+	assert.NoError(t, err)
+	_, err = part.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPut, "/teams/1/logo", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestUploadTeamLogo(t *testing.T) {
+	mockUseCase := mocks.NewTeamUseCase(t)
+	data := []byte("image")
+
+	testCases := []struct {
+		name       string
+		req        *http.Request
+		err        error
+		statusCode int
+	}{
+		{name: "success", req: newImageUploadRequest(t, data), statusCode: http.StatusOK},
+		{name: "action-unauthorized", req: newImageUploadRequest(t, data), err: errors.New("action unauthorized"), statusCode: http.StatusUnauthorized},
+		{name: "invalid-image", req: newImageUploadRequest(t, data), err: errors.New("invalid image"), statusCode: http.StatusBadRequest},
+		{name: "file-too-large", req: newImageUploadRequest(t, data), err: errors.New("file too large"), statusCode: http.StatusRequestEntityTooLarge},
+		{name: "missing-file", req: httptest.NewRequest(http.MethodPut, "/teams/1/logo", nil), statusCode: http.StatusBadRequest},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if testCase.name != "missing-file" {
+				mockUseCase.On("UploadTeamLogo", uint(1), uint(1), data).Return(&media.Image{URL: "/uploads/logos/1/a.png"}, testCase.err).Once()
+			}
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(testCase.req, rec)
+			c.Set("user", utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1))
+			c.SetPath("/teams/:id/logo")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			testTeamController := TeamController{
+				router: e,
+				teamUC: mockUseCase,
+			}
+
+			testTeamController.UploadTeamLogo(c)
+			assert.Equal(t, testCase.statusCode, rec.Code)
+		})
+	}
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestDeleteTeamLogo(t *testing.T) {
+	mockUseCase := mocks.NewTeamUseCase(t)
+
+	testCases := []struct {
+		name       string
+		err        error
+		statusCode int
+	}{
+		{name: "success", statusCode: http.StatusOK},
+		{name: "logo-not-found", err: errors.New("logo not found"), statusCode: http.StatusNotFound},
+		{name: "action-unauthorized", err: errors.New("action unauthorized"), statusCode: http.StatusUnauthorized},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockUseCase.On("DeleteTeamLogo", uint(1), uint(1)).Return(testCase.err).Once()
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodDelete, "/teams/1/logo", nil), rec)
+			c.Set("user", utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1))
+			c.SetPath("/teams/:id/logo")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			testTeamController := TeamController{
+				router: e,
+				teamUC: mockUseCase,
+			}
+
+			testTeamController.DeleteTeamLogo(c)
+			assert.Equal(t, testCase.statusCode, rec.Code)
+		})
+	}
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestInviteTeamMember(t *testing.T) {
+	mockUseCase := mocks.NewTeamUseCase(t)
+	reqBody := dto.TeamInvitationRequest{Email: "friend@gmail.com"}
+	jsonReqBody, err := json.Marshal(&reqBody)
+	assert.NoError(t, err, "No marshaling error")
+
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"success", nil, http.StatusCreated},
+		{"already-sent", errors.New("invitation already sent"), http.StatusConflict},
+		{"action-unauthorized", errors.New("action unauthorized"), http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUseCase.On("InviteTeamMember", uint(1), uint(1), reqBody).Return(dto.TeamInvitationResponse{ID: 3}, tc.err).Once()
+			req, err := http.NewRequest(http.MethodPost, "/", bytes.NewBuffer(jsonReqBody))
+			req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			assert.NoError(t, err, "No request error")
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/teams/:id/invitations")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+			c.Set("user", token)
+			testTeamController := TeamController{
+				router: e,
+				teamUC: mockUseCase,
+			}
+
+			testTeamController.InviteTeamMember(c)
+			assert.Equal(t, tc.status, rec.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAcceptTeamInvitation(t *testing.T) {
+	mockUseCase := mocks.NewTeamUseCase(t)
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"success", nil, http.StatusOK},
+		{"team-full", errors.New("The team is full"), http.StatusConflict},
+		{"expired", errors.New("invitation expired"), http.StatusConflict},
+		{"not-the-invitee", errors.New("action unauthorized"), http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUseCase.On("AcceptTeamInvitation", uint(3), uint(2)).Return(tc.err).Once()
+			req, err := http.NewRequest(http.MethodPost, "/", nil)
+			assert.NoError(t, err, "No request error")
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/teams/invitations/:id/accept")
+			c.SetParamNames("id")
+			c.SetParamValues("3")
+			token := utils.CreateJWTToken(2, "asdfa@gmail.com", utils.RoleStudent, 1)
+			c.Set("user", token)
+			testTeamController := TeamController{
+				router: e,
+				teamUC: mockUseCase,
+			}
+
+			testTeamController.AcceptTeamInvitation(c)
+			assert.Equal(t, tc.status, rec.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLeaveTeam(t *testing.T) {
+	mockUseCase := mocks.NewTeamUseCase(t)
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"success", nil, http.StatusOK},
+		{"owner", errors.New("transfer the ownership before leaving"), http.StatusConflict},
+		{"not-a-member", errors.New("not a team member"), http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUseCase.On("LeaveTeam", uint(1), uint(2)).Return(tc.err).Once()
+			req, err := http.NewRequest(http.MethodPost, "/", nil)
+			assert.NoError(t, err, "No request error")
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/teams/:id/leave")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			token := utils.CreateJWTToken(2, "asdfa@gmail.com", utils.RoleStudent, 1)
+			c.Set("user", token)
+			testTeamController := TeamController{
+				router: e,
+				teamUC: mockUseCase,
+			}
+
+			testTeamController.LeaveTeam(c)
+			assert.Equal(t, tc.status, rec.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransferTeamOwnership(t *testing.T) {
+	mockUseCase := mocks.NewTeamUseCase(t)
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"success", nil, http.StatusOK},
+		{"not-the-owner", errors.New("action unauthorized"), http.StatusUnauthorized},
+		{"not-a-member", errors.New("not a team member"), http.StatusNotFound},
+		{"already-owner", errors.New("already the team owner"), http.StatusConflict},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUseCase.On("TransferTeamOwnership", uint(1), uint(3), uint(2)).Return(tc.err).Once()
+			jsonReqBody, _ := json.Marshal(dto.TeamOwnershipRequest{UserID: 3})
+			req, err := http.NewRequest(http.MethodPut, "/", bytes.NewBuffer(jsonReqBody))
+			assert.NoError(t, err, "No request error")
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/teams/:id/owner")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			token := utils.CreateJWTToken(2, "asdfa@gmail.com", utils.RoleStudent, 1)
+			c.Set("user", token)
+			testTeamController := TeamController{
+				router: e,
+				teamUC: mockUseCase,
+			}
+
+			testTeamController.TransferTeamOwnership(c)
+			assert.Equal(t, tc.status, rec.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateTeamMemberRole(t *testing.T) {
+	mockUseCase := mocks.NewTeamUseCase(t)
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"success", nil, http.StatusOK},
+		{"invalid-role", errors.New("invalid team role"), http.StatusBadRequest},
+		{"not-the-owner", errors.New("action unauthorized"), http.StatusUnauthorized},
+		{"owner", errors.New("transfer the ownership to change the owner's role"), http.StatusConflict},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUseCase.On("UpdateTeamMemberRole", uint(1), uint(3), uint(2), dto.TeamMemberRoleRequest{Role: "co-leader"}).Return(tc.err).Once()
+			jsonReqBody, _ := json.Marshal(dto.TeamMemberRoleRequest{Role: "co-leader"})
+			req, err := http.NewRequest(http.MethodPut, "/", bytes.NewBuffer(jsonReqBody))
+			assert.NoError(t, err, "No request error")
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/teams/:id/members/:userID/role")
+			c.SetParamNames("id", "userID")
+			c.SetParamValues("1", "3")
+			token := utils.CreateJWTToken(2, "asdfa@gmail.com", utils.RoleStudent, 1)
+			c.Set("user", token)
+			testTeamController := TeamController{
+				router: e,
+				teamUC: mockUseCase,
+			}
+
+			testTeamController.UpdateTeamMemberRole(c)
+			assert.Equal(t, tc.status, rec.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
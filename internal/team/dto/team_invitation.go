@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+// TeamInvitationRequest invites either a registered user by ID or anyone by
+// email, exactly one of them has to be filled.
+type TeamInvitationRequest struct {
+	UserID uint   `json:"userID"`
+	Email  string `json:"email"`
+}
+
+type TeamInvitationResponse struct {
+	ID          uint       `json:"id"`
+	TeamID      uint       `json:"teamID"`
+	TeamName    string     `json:"teamName,omitempty"`
+	InviterID   uint       `json:"inviterID"`
+	InviterName string     `json:"inviterName,omitempty"`
+	InviteeID   *uint      `json:"inviteeID"`
+	Email       string     `json:"email,omitempty"`
+	Status      string     `json:"status"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	RespondedAt *time.Time `json:"respondedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
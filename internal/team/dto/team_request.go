@@ -5,3 +5,13 @@ type TeamRequest struct {
 	Description string `json:"description"`
 	Capacity    uint   `json:"capacity"`
 }
+
+type TeamOwnershipRequest struct {
+	UserID uint `json:"userID"`
+}
+
+// TeamMemberRoleRequest sets the role of a member, either "co-leader" or
+// "member".
+type TeamMemberRoleRequest struct {
+	Role string `json:"role"`
+}
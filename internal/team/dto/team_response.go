@@ -1,5 +1,7 @@
 package dto
 
+import "github.com/alimikegami/compnouron/internal/media"
+
 type BriefTeamResponse struct {
 	ID   uint   `json:"id"`
 	Name string `json:"name"`
@@ -9,14 +11,17 @@ type TeamDetailsResponse struct {
 	Name        string               `json:"name"`
 	Description string               `json:"description"`
 	Capacity    uint                 `json:"capacity"`
+	Logo        *media.Image         `json:"logo"`
 	TeamMembers []TeamMemberResponse `json:"members"`
 }
 
 type TeamMemberResponse struct {
 	UserID            uint   `json:"id"`
 	Name              string `json:"name"`
-	PhoneNumber       string `json:"phoneNumber"`
-	Email             string `json:"email"`
+	PhoneNumber       string `json:"phoneNumber,omitempty"`
+	Email             string `json:"email,omitempty"`
 	SchoolInstitution string `json:"schoolInstitution"`
-	IsLeader          uint   `json:"isLeader"`
+	// IsLeader is 1 for the owner, kept for the clients predating Role
+	IsLeader uint   `json:"isLeader"`
+	Role     string `json:"role"`
 }
@@ -7,6 +7,8 @@ type Team struct {
 	Name        string `gorm:"not null"`
 	Description string `gorm:"not null"`
 	Capacity    uint   `gorm:"not null"`
+	// LogoKey is the storage key of the logo, see the media package
+	LogoKey     string
 	TeamMembers []TeamMember
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
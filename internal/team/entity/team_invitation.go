@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	userEntity "github.com/alimikegami/compnouron/internal/user/entity"
+)
+
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusDeclined = "declined"
+	InvitationStatusExpired  = "expired"
+)
+
+// TeamInvitation is an invitation from the team owner or a co-leader to join
+// the team. It is addressed either to a user by ID or to an email address,
+// which lets the team invite someone who hasn't registered yet. A pending
+// invitation past ExpiresAt is expired even while its Status hasn't been
+// updated yet.
+type TeamInvitation struct {
+	ID          uint   `gorm:"primaryKey"`
+	TeamID      uint   `gorm:"not null;index"`
+	InviterID   uint   `gorm:"not null"`
+	InviteeID   *uint  `gorm:"index"`
+	Email       string `gorm:"index"`
+	Status      string `gorm:"not null"`
+	ExpiresAt   time.Time
+	RespondedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Team        Team            `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Inviter     userEntity.User `gorm:"foreignKey:InviterID"`
+}
+
+// CurrentStatus is Status with expiry taken into account.
+func (ti TeamInvitation) CurrentStatus() string {
+	if ti.Status == InvitationStatusPending && time.Now().After(ti.ExpiresAt) {
+		return InvitationStatusExpired
+	}
+
+	return ti.Status
+}
@@ -7,12 +7,23 @@ import (
 )
 
 type TeamMember struct {
-	ID        uint `gorm:"primaryKey"`
-	TeamID    uint `gorm:"not null"`
-	UserID    uint `gorm:"not null"`
-	IsLeader  uint `gorm:"not null"`
+	ID        uint   `gorm:"primaryKey"`
+	TeamID    uint   `gorm:"not null"`
+	UserID    uint   `gorm:"not null"`
+	Role      string `gorm:"not null;default:member"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	User      userEntity.User
 	Team      Team
 }
+
+// Can reports whether the role of the member grants the permission.
+func (m TeamMember) Can(permission TeamPermission) bool {
+	for _, granted := range teamRolePermissions[m.Role] {
+		if granted == permission {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,37 @@
+package entity
+
+// Every team has exactly one owner, who created the team or was handed the
+// ownership. Co-leaders help the owner run the team.
+const (
+	TeamRoleOwner    = "owner"
+	TeamRoleCoLeader = "co-leader"
+	TeamRoleMember   = "member"
+)
+
+type TeamPermission string
+
+const (
+	// PermissionManageTeam covers the team details and logo, recruitments,
+	// invitations and competition registrations
+	PermissionManageTeam TeamPermission = "manage_team"
+	// PermissionManageMembers allows removing members
+	PermissionManageMembers TeamPermission = "manage_members"
+	// PermissionManageRoles allows changing the roles of the members, removing
+	// co-leaders and transferring the ownership
+	PermissionManageRoles TeamPermission = "manage_roles"
+	PermissionDeleteTeam  TeamPermission = "delete_team"
+	// PermissionPinThreads allows pinning threads on the discussion board
+	PermissionPinThreads TeamPermission = "pin_threads"
+)
+
+var teamRolePermissions = map[string][]TeamPermission{
+	TeamRoleOwner:    {PermissionManageTeam, PermissionManageMembers, PermissionManageRoles, PermissionDeleteTeam, PermissionPinThreads},
+	TeamRoleCoLeader: {PermissionManageTeam, PermissionManageMembers, PermissionPinThreads},
+	TeamRoleMember:   {},
+}
+
+// IsAssignableTeamRole reports whether role can be given to a member. The
+// owner role only changes hands through an ownership transfer.
+func IsAssignableTeamRole(role string) bool {
+	return role == TeamRoleCoLeader || role == TeamRoleMember
+}
@@ -2,19 +2,39 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"github.com/alimikegami/compnouron/internal/team/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TeamRepository interface {
 	CreateTeam(team entity.Team) (entity.Team, error)
-	AddTeamMember(userID uint, teamID uint, isLeader uint) error
+	AddTeamMember(userID uint, teamID uint, role string) error
 	UpdateTeam(team entity.Team) error
 	DeleteTeam(id uint) error
 	GetTeamsByUserID(ID uint) ([]entity.Team, error)
+	GetTeamMembershipsByUserID(userID uint) ([]entity.TeamMember, error)
 	GetTeamByID(teamID uint) (entity.Team, error)
-	GetTeamLeader(teamID uint) (uint, error)
+	GetTeamOwner(teamID uint) (uint, error)
+	GetTeamMember(teamID uint, userID uint) (entity.TeamMember, error)
+	GetTeamForUpdate(teamID uint) (entity.Team, error)
+	CountTeamMembers(teamID uint) (int64, error)
+	GetTeammateIDs(userID uint) ([]uint, error)
+	UpdateTeamLogo(id uint, logoKey string) error
+	RemoveTeamMember(teamID uint, userID uint) error
+	UpdateTeamMemberRole(teamID uint, userID uint, role string) error
+	TransferTeamOwnership(teamID uint, fromUserID uint, toUserID uint) error
+	CreateTeamInvitation(invitation entity.TeamInvitation) (entity.TeamInvitation, error)
+	GetTeamInvitationByID(id uint) (entity.TeamInvitation, error)
+	GetTeamInvitationsByTeamID(teamID uint) ([]entity.TeamInvitation, error)
+	GetTeamInvitationsForUser(userID uint, email string) ([]entity.TeamInvitation, error)
+	GetTeamInvitationsByInviterID(inviterID uint) ([]entity.TeamInvitation, error)
+	HasPendingTeamInvitation(teamID uint, userID uint, email string) (bool, error)
+	UpdateTeamInvitationStatus(id uint, status string) error
+	AcceptTeamInvitation(invitation entity.TeamInvitation, userID uint) error
+	DeleteTeamInvitation(teamID uint, id uint) error
 }
 
 type TeamRepositoryImpl struct {
@@ -34,22 +54,58 @@ func (cr *TeamRepositoryImpl) CreateTeam(team entity.Team) (entity.Team, error)
 	return team, nil
 }
 
-func (cr *TeamRepositoryImpl) GetTeamLeader(teamID uint) (uint, error) {
-	var leader entity.TeamMember
-	result := cr.db.First(&leader, "team_id = ? AND is_leader = 1", teamID)
+func (cr *TeamRepositoryImpl) GetTeamOwner(teamID uint) (uint, error) {
+	var owner entity.TeamMember
+	result := cr.db.First(&owner, "team_id = ? AND role = ?", teamID, entity.TeamRoleOwner)
 
 	if result.Error != nil {
 		return 0, result.Error
 	}
 
-	return leader.UserID, nil
+	return owner.UserID, nil
 }
 
-func (cr *TeamRepositoryImpl) AddTeamMember(userID uint, teamID uint, isLeader uint) error {
+// GetTeamMember returns the membership of the user in the team, or an empty
+// one without a role when the user isn't a member.
+func (cr *TeamRepositoryImpl) GetTeamMember(teamID uint, userID uint) (entity.TeamMember, error) {
+	var member entity.TeamMember
+	result := cr.db.Where("team_id = ? AND user_id = ?", teamID, userID).Limit(1).Find(&member)
+	if result.Error != nil {
+		return member, result.Error
+	}
+
+	return member, nil
+}
+
+// GetTeamForUpdate returns the team and locks its row until the transaction
+// the repository runs in ends. Taking it before counting the members
+// serializes the writes that add members to the team, see the unitofwork
+// package. Outside of a transaction the lock is released right away.
+func (cr *TeamRepositoryImpl) GetTeamForUpdate(teamID uint) (entity.Team, error) {
+	var team entity.Team
+	result := cr.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&team, teamID)
+	if result.Error != nil {
+		return team, result.Error
+	}
+
+	return team, nil
+}
+
+func (cr *TeamRepositoryImpl) CountTeamMembers(teamID uint) (int64, error) {
+	var count int64
+	result := cr.db.Model(&entity.TeamMember{}).Where("team_id = ?", teamID).Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+func (cr *TeamRepositoryImpl) AddTeamMember(userID uint, teamID uint, role string) error {
 	result := cr.db.Create(&entity.TeamMember{
-		TeamID:   teamID,
-		UserID:   userID,
-		IsLeader: isLeader,
+		TeamID: teamID,
+		UserID: userID,
+		Role:   role,
 	})
 
 	if result.Error != nil {
@@ -96,6 +152,16 @@ func (tr *TeamRepositoryImpl) GetTeamsByUserID(ID uint) ([]entity.Team, error) {
 	return teams, nil
 }
 
+func (tr *TeamRepositoryImpl) GetTeamMembershipsByUserID(userID uint) ([]entity.TeamMember, error) {
+	var teamMembers []entity.TeamMember
+	result := tr.db.Joins("Team").Find(&teamMembers, "team_members.user_id = ?", userID)
+	if result.Error != nil {
+		return []entity.TeamMember{}, result.Error
+	}
+
+	return teamMembers, nil
+}
+
 func (tr *TeamRepositoryImpl) GetTeamByID(teamID uint) (entity.Team, error) {
 	var team entity.Team
 
@@ -106,3 +172,225 @@ func (tr *TeamRepositoryImpl) GetTeamByID(teamID uint) (entity.Team, error) {
 
 	return team, nil
 }
+
+// GetTeammateIDs returns the IDs of the users who share at least one team with
+// the given user.
+func (tr *TeamRepositoryImpl) GetTeammateIDs(userID uint) ([]uint, error) {
+	var teammateIDs []uint
+	result := tr.db.Model(&entity.TeamMember{}).Distinct("teammates.user_id").Joins("JOIN team_members teammates ON teammates.team_id = team_members.team_id").Where("team_members.user_id = ? AND teammates.user_id <> ?", userID, userID).Pluck("teammates.user_id", &teammateIDs)
+	if result.Error != nil {
+		return []uint{}, result.Error
+	}
+
+	return teammateIDs, nil
+}
+
+func (tr *TeamRepositoryImpl) UpdateTeamLogo(id uint, logoKey string) error {
+	result := tr.db.Model(&entity.Team{}).Where("id = ?", id).Update("logo_key", logoKey)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+// RemoveTeamMember removes a member other than the owner from the team.
+func (tr *TeamRepositoryImpl) RemoveTeamMember(teamID uint, userID uint) error {
+	result := tr.db.Where("team_id = ? AND user_id = ? AND role <> ?", teamID, userID, entity.TeamRoleOwner).Delete(&entity.TeamMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+// UpdateTeamMemberRole changes the role of a member other than the owner.
+func (tr *TeamRepositoryImpl) UpdateTeamMemberRole(teamID uint, userID uint, role string) error {
+	result := tr.db.Model(&entity.TeamMember{}).Where("team_id = ? AND user_id = ? AND role <> ?", teamID, userID, entity.TeamRoleOwner).Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+// TransferTeamOwnership makes toUserID the owner in place of fromUserID, who
+// stays on as a co-leader. The active competition registrations of the team,
+// the pending and accepted ones, move to the new owner as well.
+func (tr *TeamRepositoryImpl) TransferTeamOwnership(teamID uint, fromUserID uint, toUserID uint) error {
+	return tr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.TeamMember{}).Where("team_id = ? AND user_id = ? AND role = ?", teamID, fromUserID, entity.TeamRoleOwner).Update("role", entity.TeamRoleCoLeader)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected != 1 {
+			return errors.New("no rows affected")
+		}
+
+		result = tx.Model(&entity.TeamMember{}).Where("team_id = ? AND user_id = ? AND role <> ?", teamID, toUserID, entity.TeamRoleOwner).Update("role", entity.TeamRoleOwner)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected != 1 {
+			return errors.New("no rows affected")
+		}
+
+		result = tx.Table("competition_registrations").Where("team_id = ? AND acceptance_status IN (0, 1)", teamID).Updates(map[string]interface{}{
+			"user_id":    toUserID,
+			"updated_at": time.Now(),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return nil
+	})
+}
+
+func (tr *TeamRepositoryImpl) CreateTeamInvitation(invitation entity.TeamInvitation) (entity.TeamInvitation, error) {
+	result := tr.db.Create(&invitation)
+	if result.Error != nil {
+		return invitation, result.Error
+	}
+
+	return invitation, nil
+}
+
+func (tr *TeamRepositoryImpl) GetTeamInvitationByID(id uint) (entity.TeamInvitation, error) {
+	var invitation entity.TeamInvitation
+	result := tr.db.First(&invitation, id)
+	if result.Error != nil {
+		return entity.TeamInvitation{}, result.Error
+	}
+
+	return invitation, nil
+}
+
+func (tr *TeamRepositoryImpl) GetTeamInvitationsByTeamID(teamID uint) ([]entity.TeamInvitation, error) {
+	var invitations []entity.TeamInvitation
+	result := tr.db.Where("team_id = ?", teamID).Order("created_at DESC").Find(&invitations)
+	if result.Error != nil {
+		return []entity.TeamInvitation{}, result.Error
+	}
+
+	return invitations, nil
+}
+
+// GetTeamInvitationsForUser returns the invitations addressed to the user,
+// either by ID or by email. email should be empty unless the user verified
+// it, so nobody sees invitations sent to an address they don't own.
+func (tr *TeamRepositoryImpl) GetTeamInvitationsForUser(userID uint, email string) ([]entity.TeamInvitation, error) {
+	var invitations []entity.TeamInvitation
+	query := tr.db.Joins("Team").Joins("Inviter")
+	if email != "" {
+		query = query.Where("team_invitations.invitee_id = ? OR team_invitations.email = ?", userID, email)
+	} else {
+		query = query.Where("team_invitations.invitee_id = ?", userID)
+	}
+
+	result := query.Order("team_invitations.created_at DESC").Find(&invitations)
+	if result.Error != nil {
+		return []entity.TeamInvitation{}, result.Error
+	}
+
+	return invitations, nil
+}
+
+// GetTeamInvitationsByInviterID returns the invitations the user sent, on
+// behalf of any team.
+func (tr *TeamRepositoryImpl) GetTeamInvitationsByInviterID(inviterID uint) ([]entity.TeamInvitation, error) {
+	var invitations []entity.TeamInvitation
+	result := tr.db.Joins("Team").Where("team_invitations.inviter_id = ?", inviterID).Order("team_invitations.created_at DESC").Find(&invitations)
+	if result.Error != nil {
+		return []entity.TeamInvitation{}, result.Error
+	}
+
+	return invitations, nil
+}
+
+// HasPendingTeamInvitation reports whether the team already has an invitation
+// waiting for an answer from the user with the given ID or email.
+func (tr *TeamRepositoryImpl) HasPendingTeamInvitation(teamID uint, userID uint, email string) (bool, error) {
+	var count int64
+	result := tr.db.Model(&entity.TeamInvitation{}).Where("team_id = ? AND status = ? AND expires_at > ? AND (invitee_id = ? OR (email <> '' AND email = ?))", teamID, entity.InvitationStatusPending, time.Now(), userID, email).Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
+
+// UpdateTeamInvitationStatus answers a pending invitation. It fails when the
+// invitation has already been answered.
+func (tr *TeamRepositoryImpl) UpdateTeamInvitationStatus(id uint, status string) error {
+	result := tr.db.Model(&entity.TeamInvitation{}).Where("id = ? AND status = ?", id, entity.InvitationStatusPending).Updates(map[string]interface{}{
+		"status":       status,
+		"responded_at": time.Now(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+// AcceptTeamInvitation marks the invitation as accepted by the user and adds
+// them to the team.
+func (tr *TeamRepositoryImpl) AcceptTeamInvitation(invitation entity.TeamInvitation, userID uint) error {
+	return tr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.TeamInvitation{}).Where("id = ? AND status = ?", invitation.ID, entity.InvitationStatusPending).Updates(map[string]interface{}{
+			"status":       entity.InvitationStatusAccepted,
+			"invitee_id":   userID,
+			"responded_at": time.Now(),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected != 1 {
+			return errors.New("no rows affected")
+		}
+
+		result = tx.Create(&entity.TeamMember{
+			TeamID: invitation.TeamID,
+			UserID: userID,
+			Role:   entity.TeamRoleMember,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return nil
+	})
+}
+
+func (tr *TeamRepositoryImpl) DeleteTeamInvitation(teamID uint, id uint) error {
+	result := tr.db.Where("team_id = ?", teamID).Delete(&entity.TeamInvitation{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
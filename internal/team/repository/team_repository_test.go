@@ -30,7 +30,7 @@ func TestCreateTeam(t *testing.T) {
 	defer mockedDB.Close()
 
 	mockObj.ExpectBegin()
-	mockObj.ExpectExec(regexp.QuoteMeta("INSERT")).WithArgs("Team 1", "Software engineering team for Technoscape Hackathon 2022", 4, utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(2, 1))
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT")).WithArgs("Team 1", "Software engineering team for Technoscape Hackathon 2022", 4, "", utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(2, 1))
 	mockObj.ExpectCommit()
 
 	team, err := teamRepo.CreateTeam(entity.Team{
@@ -58,10 +58,10 @@ func TestAddTeamMember(t *testing.T) {
 	defer mockedDB.Close()
 
 	mockObj.ExpectBegin()
-	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `team_members` (`team_id`,`user_id`,`is_leader`,`created_at`,`updated_at`) VALUES (?,?,?,?,?)")).WithArgs(1, 1, 1, utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(2, 1))
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `team_members` (`team_id`,`user_id`,`role`,`created_at`,`updated_at`) VALUES (?,?,?,?,?)")).WithArgs(1, 1, "owner", utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(2, 1))
 	mockObj.ExpectCommit()
 
-	err = teamRepo.AddTeamMember(1, 1, 1)
+	err = teamRepo.AddTeamMember(1, 1, entity.TeamRoleOwner)
 	assert.NoError(t, err)
 }
 
@@ -205,7 +205,7 @@ func TestGetTeamsByUserID(t *testing.T) {
 
 	defer mockedDB.Close()
 
-	mockObj.ExpectQuery("SELECT `teams`.`id`,`teams`.`name`,`teams`.`description`,`teams`.`capacity`,`teams`.`created_at`,`teams`.`updated_at` FROM `teams` JOIN team_members ON team_members.team_id = teams.id WHERE team_members.user_id = \\?").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"teams.id", "teams.name", "teams.description", "teams.capacity", "teams.created_at", "teams.updated_at"}).AddRow(1, "Team 1", "Team Hackathon Technoscape 2023", 4, time.Now(), time.Now()))
+	mockObj.ExpectQuery("SELECT `teams`.`id`,`teams`.`name`,`teams`.`description`,`teams`.`capacity`,`teams`.`logo_key`,`teams`.`created_at`,`teams`.`updated_at` FROM `teams` JOIN team_members ON team_members.team_id = teams.id WHERE team_members.user_id = \\?").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"teams.id", "teams.name", "teams.description", "teams.capacity", "teams.created_at", "teams.updated_at"}).AddRow(1, "Team 1", "Team Hackathon Technoscape 2023", 4, time.Now(), time.Now()))
 
 	entity, err := teamRepo.GetTeamsByUserID(1)
 	assert.NoError(t, err)
@@ -227,9 +227,484 @@ func TestGetTeamsByUserIDNoRows(t *testing.T) {
 
 	defer mockedDB.Close()
 
-	mockObj.ExpectQuery("SELECT `teams`.`id`,`teams`.`name`,`teams`.`description`,`teams`.`capacity`,`teams`.`created_at`,`teams`.`updated_at` FROM `teams` JOIN team_members ON team_members.team_id = teams.id WHERE team_members.user_id = \\?").WithArgs(1).WillReturnRows(sqlmock.NewRows(nil))
+	mockObj.ExpectQuery("SELECT `teams`.`id`,`teams`.`name`,`teams`.`description`,`teams`.`capacity`,`teams`.`logo_key`,`teams`.`created_at`,`teams`.`updated_at` FROM `teams` JOIN team_members ON team_members.team_id = teams.id WHERE team_members.user_id = \\?").WithArgs(1).WillReturnRows(sqlmock.NewRows(nil))
 
 	entity, err := teamRepo.GetTeamsByUserID(1)
 	assert.NoError(t, err)
 	assert.Len(t, entity, 0)
 }
+
+func TestGetTeamMembershipsByUserID(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	teamRepo := CreateNewTeamRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "team_id", "user_id", "role", "Team__id", "Team__name"}).AddRow(1, 2, 1, "owner", 2, "Team 1")
+		mockObj.ExpectQuery(regexp.QuoteMeta("FROM `team_members` LEFT JOIN `teams` `Team` ON `team_members`.`team_id` = `Team`.`id` WHERE team_members.user_id = ?")).WithArgs(1).WillReturnRows(rows)
+
+		teamMembers, err := teamRepo.GetTeamMembershipsByUserID(1)
+		assert.NoError(t, err)
+		assert.Len(t, teamMembers, 1)
+		assert.Equal(t, "Team 1", teamMembers[0].Team.Name)
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockObj.ExpectQuery(regexp.QuoteMeta("FROM `team_members` LEFT JOIN `teams` `Team` ON `team_members`.`team_id` = `Team`.`id` WHERE team_members.user_id = ?")).WithArgs(1).WillReturnError(errors.New("unexpected error"))
+
+		_, err := teamRepo.GetTeamMembershipsByUserID(1)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetTeammateIDs(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	teamRepo := CreateNewTeamRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"user_id"}).AddRow(2).AddRow(3)
+		mockObj.ExpectQuery(regexp.QuoteMeta("FROM `team_members` JOIN team_members teammates ON teammates.team_id = team_members.team_id WHERE team_members.user_id = ? AND teammates.user_id <> ?")).WithArgs(1, 1).WillReturnRows(rows)
+
+		teammateIDs, err := teamRepo.GetTeammateIDs(1)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{2, 3}, teammateIDs)
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockObj.ExpectQuery(regexp.QuoteMeta("FROM `team_members` JOIN team_members teammates ON teammates.team_id = team_members.team_id WHERE team_members.user_id = ? AND teammates.user_id <> ?")).WithArgs(1, 1).WillReturnError(errors.New("unexpected error"))
+
+		_, err := teamRepo.GetTeammateIDs(1)
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateTeamLogo(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	teamRepo := CreateNewTeamRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `teams` SET `logo_key`=?,`updated_at`=? WHERE id = ?")).WithArgs("logos/1/a.png", utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		err := teamRepo.UpdateTeamLogo(1, "logos/1/a.png")
+		assert.NoError(t, err)
+	})
+
+	t.Run("no-rows-affected", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `teams` SET `logo_key`=?,`updated_at`=? WHERE id = ?")).WithArgs("", utils.AnyTime{}, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectCommit()
+
+		err := teamRepo.UpdateTeamLogo(9, "")
+		assert.EqualError(t, err, "no rows affected")
+	})
+}
+
+func TestCreateTeamInvitation(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		&mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	teamRepo := CreateNewTeamRepository(db)
+
+	defer mockedDB.Close()
+
+	expiresAt := time.Now().Add(7 * 24 * time.Hour)
+	inviteeID := uint(2)
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `team_invitations` (`team_id`,`inviter_id`,`invitee_id`,`email`,`status`,`expires_at`,`responded_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?)")).WithArgs(1, 1, 2, "", "pending", expiresAt, nil, utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(3, 1))
+	mockObj.ExpectCommit()
+
+	invitation, err := teamRepo.CreateTeamInvitation(entity.TeamInvitation{TeamID: 1, InviterID: 1, InviteeID: &inviteeID, Status: entity.InvitationStatusPending, ExpiresAt: expiresAt})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), invitation.ID)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestGetTeamInvitationsForUser(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		&mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	teamRepo := CreateNewTeamRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("verified-email", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "team_id", "email", "status", "Team__id", "Team__name"}).AddRow(3, 1, "asdfa@gmail.com", "pending", 1, "Team 1")
+		mockObj.ExpectQuery("SELECT .* FROM `team_invitations` LEFT JOIN `teams` `Team` .* LEFT JOIN `users` `Inviter` .* WHERE team_invitations.invitee_id = \\? OR team_invitations.email = \\? ORDER BY team_invitations.created_at DESC").WithArgs(2, "asdfa@gmail.com").WillReturnRows(rows)
+
+		invitations, err := teamRepo.GetTeamInvitationsForUser(2, "asdfa@gmail.com")
+		assert.NoError(t, err)
+		assert.Len(t, invitations, 1)
+		assert.Equal(t, "Team 1", invitations[0].Team.Name)
+	})
+
+	t.Run("unverified-email", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "team_id", "status"})
+		mockObj.ExpectQuery("SELECT .* FROM `team_invitations` .* WHERE team_invitations.invitee_id = \\? ORDER BY team_invitations.created_at DESC").WithArgs(2).WillReturnRows(rows)
+
+		invitations, err := teamRepo.GetTeamInvitationsForUser(2, "")
+		assert.NoError(t, err)
+		assert.Len(t, invitations, 0)
+	})
+
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestGetTeamInvitationsByInviterID(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		&mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	teamRepo := CreateNewTeamRepository(db)
+
+	defer mockedDB.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "team_id", "inviter_id", "email", "status", "Team__id", "Team__name"}).AddRow(3, 1, 2, "asdfa@gmail.com", "pending", 1, "Team 1")
+	mockObj.ExpectQuery("SELECT .* FROM `team_invitations` LEFT JOIN `teams` `Team` .* WHERE team_invitations.inviter_id = \\? ORDER BY team_invitations.created_at DESC").WithArgs(2).WillReturnRows(rows)
+
+	invitations, err := teamRepo.GetTeamInvitationsByInviterID(2)
+	assert.NoError(t, err)
+	assert.Len(t, invitations, 1)
+	assert.Equal(t, "Team 1", invitations[0].Team.Name)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestHasPendingTeamInvitation(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		&mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	teamRepo := CreateNewTeamRepository(db)
+
+	defer mockedDB.Close()
+
+	rows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `team_invitations` WHERE team_id = ? AND status = ? AND expires_at > ? AND (invitee_id = ? OR (email <> '' AND email = ?))")).WithArgs(1, "pending", utils.AnyTime{}, 2, "asdfa@gmail.com").WillReturnRows(rows)
+
+	pending, err := teamRepo.HasPendingTeamInvitation(1, 2, "asdfa@gmail.com")
+	assert.NoError(t, err)
+	assert.True(t, pending)
+}
+
+func TestUpdateTeamInvitationStatus(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		&mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	teamRepo := CreateNewTeamRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `team_invitations` SET `responded_at`=?,`status`=?,`updated_at`=? WHERE id = ? AND status = ?")).WithArgs(utils.AnyTime{}, "declined", utils.AnyTime{}, 3, "pending").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		assert.NoError(t, teamRepo.UpdateTeamInvitationStatus(3, entity.InvitationStatusDeclined))
+	})
+
+	t.Run("already-answered", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `team_invitations` SET `responded_at`=?,`status`=?,`updated_at`=? WHERE id = ? AND status = ?")).WithArgs(utils.AnyTime{}, "declined", utils.AnyTime{}, 3, "pending").WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectCommit()
+
+		assert.EqualError(t, teamRepo.UpdateTeamInvitationStatus(3, entity.InvitationStatusDeclined), "no rows affected")
+	})
+}
+
+func TestAcceptTeamInvitation(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		&mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	teamRepo := CreateNewTeamRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `team_invitations` SET `invitee_id`=?,`responded_at`=?,`status`=?,`updated_at`=? WHERE id = ? AND status = ?")).WithArgs(2, utils.AnyTime{}, "accepted", utils.AnyTime{}, 3, "pending").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `team_members` (`team_id`,`user_id`,`role`,`created_at`,`updated_at`) VALUES (?,?,?,?,?)")).WithArgs(1, 2, "member", utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(5, 1))
+		mockObj.ExpectCommit()
+
+		assert.NoError(t, teamRepo.AcceptTeamInvitation(entity.TeamInvitation{ID: 3, TeamID: 1}, 2))
+	})
+
+	t.Run("already-answered", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `team_invitations` SET `invitee_id`=?,`responded_at`=?,`status`=?,`updated_at`=? WHERE id = ? AND status = ?")).WithArgs(2, utils.AnyTime{}, "accepted", utils.AnyTime{}, 3, "pending").WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectRollback()
+
+		assert.EqualError(t, teamRepo.AcceptTeamInvitation(entity.TeamInvitation{ID: 3, TeamID: 1}, 2), "no rows affected")
+	})
+
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestRemoveTeamMember(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		&mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	teamRepo := CreateNewTeamRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `team_members` WHERE team_id = ? AND user_id = ? AND role <> ?")).WithArgs(1, 2, "owner").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		assert.NoError(t, teamRepo.RemoveTeamMember(1, 2))
+	})
+
+	t.Run("not-a-member", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `team_members` WHERE team_id = ? AND user_id = ? AND role <> ?")).WithArgs(1, 3, "owner").WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectCommit()
+
+		assert.EqualError(t, teamRepo.RemoveTeamMember(1, 3), "no rows affected")
+	})
+
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestTransferTeamOwnership(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		&mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	teamRepo := CreateNewTeamRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `team_members` SET `role`=?,`updated_at`=? WHERE team_id = ? AND user_id = ? AND role = ?")).WithArgs("co-leader", utils.AnyTime{}, 1, 1, "owner").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `team_members` SET `role`=?,`updated_at`=? WHERE team_id = ? AND user_id = ? AND role <> ?")).WithArgs("owner", utils.AnyTime{}, 1, 2, "owner").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `competition_registrations` SET `updated_at`=?,`user_id`=? WHERE team_id = ? AND acceptance_status IN (0, 1)")).WithArgs(utils.AnyTime{}, 2, 1).WillReturnResult(sqlmock.NewResult(0, 2))
+		mockObj.ExpectCommit()
+
+		assert.NoError(t, teamRepo.TransferTeamOwnership(1, 1, 2))
+	})
+
+	t.Run("new-leader-not-a-member", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `team_members` SET `role`=?,`updated_at`=? WHERE team_id = ? AND user_id = ? AND role = ?")).WithArgs("co-leader", utils.AnyTime{}, 1, 1, "owner").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `team_members` SET `role`=?,`updated_at`=? WHERE team_id = ? AND user_id = ? AND role <> ?")).WithArgs("owner", utils.AnyTime{}, 1, 3, "owner").WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectRollback()
+
+		assert.EqualError(t, teamRepo.TransferTeamOwnership(1, 1, 3), "no rows affected")
+	})
+
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestGetTeamMember(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	teamRepo := CreateNewTeamRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("member", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "team_id", "user_id", "role"}).AddRow(1, 1, 2, "co-leader")
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `team_members` WHERE team_id = ? AND user_id = ? LIMIT 1")).WithArgs(1, 2).WillReturnRows(rows)
+
+		member, err := teamRepo.GetTeamMember(1, 2)
+		assert.NoError(t, err)
+		assert.True(t, member.Can(entity.PermissionManageTeam))
+	})
+
+	t.Run("not-a-member", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "team_id", "user_id", "role"})
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `team_members` WHERE team_id = ? AND user_id = ? LIMIT 1")).WithArgs(1, 3).WillReturnRows(rows)
+
+		member, err := teamRepo.GetTeamMember(1, 3)
+		assert.NoError(t, err)
+		assert.False(t, member.Can(entity.PermissionManageTeam))
+	})
+
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestUpdateTeamMemberRole(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	teamRepo := CreateNewTeamRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `team_members` SET `role`=?,`updated_at`=? WHERE team_id = ? AND user_id = ? AND role <> ?")).WithArgs("co-leader", utils.AnyTime{}, 1, 2, "owner").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		assert.NoError(t, teamRepo.UpdateTeamMemberRole(1, 2, entity.TeamRoleCoLeader))
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `team_members` SET `role`=?,`updated_at`=? WHERE team_id = ? AND user_id = ? AND role <> ?")).WithArgs("member", utils.AnyTime{}, 1, 1, "owner").WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectCommit()
+
+		assert.EqualError(t, teamRepo.UpdateTeamMemberRole(1, 1, entity.TeamRoleMember), "no rows affected")
+	})
+
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestGetTeamForUpdate(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	teamRepo := CreateNewTeamRepository(db)
+
+	defer mockedDB.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "name", "capacity"}).AddRow(1, "Team 1", 4)
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `teams` WHERE `teams`.`id` = ? ORDER BY `teams`.`id` LIMIT 1 FOR UPDATE")).WithArgs(1).WillReturnRows(rows)
+
+	team, err := teamRepo.GetTeamForUpdate(1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), team.Capacity)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestCountTeamMembers(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	teamRepo := CreateNewTeamRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"count"}).AddRow(3)
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `team_members` WHERE team_id = ?")).WithArgs(1).WillReturnRows(rows)
+
+		count, err := teamRepo.CountTeamMembers(1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `team_members` WHERE team_id = ?")).WithArgs(1).WillReturnError(errors.New("unexpected error"))
+
+		_, err := teamRepo.CountTeamMembers(1)
+		assert.Error(t, err)
+	})
+}
@@ -2,10 +2,21 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/alimikegami/compnouron/internal/media"
+	"github.com/alimikegami/compnouron/internal/policy"
+	"github.com/alimikegami/compnouron/internal/privacy"
 	"github.com/alimikegami/compnouron/internal/team/dto"
 	"github.com/alimikegami/compnouron/internal/team/entity"
 	"github.com/alimikegami/compnouron/internal/team/repository"
+	"github.com/alimikegami/compnouron/internal/unitofwork"
+	userRepo "github.com/alimikegami/compnouron/internal/user/repository"
+	"github.com/alimikegami/compnouron/pkg/mailer"
 )
 
 type TeamUseCase interface {
@@ -13,42 +24,63 @@ type TeamUseCase interface {
 	DeleteTeam(id uint, userID uint) error
 	UpdateTeam(userID uint, team dto.TeamRequest, teamID uint) error
 	GetTeamsByUserID(userID uint) ([]dto.BriefTeamResponse, error)
-	GetTeamDetailsByID(teamID uint) (dto.TeamDetailsResponse, error)
+	GetTeamDetailsByID(teamID uint, viewerID uint) (dto.TeamDetailsResponse, error)
+	UploadTeamLogo(teamID uint, userID uint, data []byte) (*media.Image, error)
+	DeleteTeamLogo(teamID uint, userID uint) error
+	InviteTeamMember(teamID uint, userID uint, request dto.TeamInvitationRequest) (dto.TeamInvitationResponse, error)
+	GetTeamInvitations(teamID uint, userID uint) ([]dto.TeamInvitationResponse, error)
+	RevokeTeamInvitation(teamID uint, invitationID uint, userID uint) error
+	GetReceivedTeamInvitations(userID uint) ([]dto.TeamInvitationResponse, error)
+	AcceptTeamInvitation(invitationID uint, userID uint) error
+	DeclineTeamInvitation(invitationID uint, userID uint) error
+	LeaveTeam(teamID uint, userID uint) error
+	RemoveTeamMember(teamID uint, memberID uint, userID uint) error
+	TransferTeamOwnership(teamID uint, newOwnerID uint, userID uint) error
+	UpdateTeamMemberRole(teamID uint, memberID uint, userID uint, request dto.TeamMemberRoleRequest) error
 }
 
+const invitationLifetime = 7 * 24 * time.Hour
+
 type TeamUseCaseImpl struct {
-	tr repository.TeamRepository
+	tr  repository.TeamRepository
+	ur  userRepo.UserRepository
+	p   policy.Policy
+	s   privacy.Shaper
+	mu  media.Uploader
+	m   mailer.Mailer
+	uow unitofwork.UnitOfWork
 }
 
-func CreateNewTeamUseCase(tr repository.TeamRepository) TeamUseCase {
-	return &TeamUseCaseImpl{tr: tr}
+func CreateNewTeamUseCase(tr repository.TeamRepository, ur userRepo.UserRepository, p policy.Policy, s privacy.Shaper, mu media.Uploader, m mailer.Mailer, uow unitofwork.UnitOfWork) TeamUseCase {
+	return &TeamUseCaseImpl{tr: tr, ur: ur, p: p, s: s, mu: mu, m: m, uow: uow}
 }
 
 func (tuc *TeamUseCaseImpl) CreateTeam(userID uint, team dto.TeamRequest) error {
+	err := tuc.p.RequireVerifiedEmail(userID)
+	if err != nil {
+		return err
+	}
+
 	teamEntity := entity.Team{
 		Name:        team.Name,
 		Description: team.Description,
 		Capacity:    team.Capacity,
 	}
 
-	teamEntity, err := tuc.tr.CreateTeam(teamEntity)
+	teamEntity, err = tuc.tr.CreateTeam(teamEntity)
 	if err != nil {
 		return err
 	}
 
-	err = tuc.tr.AddTeamMember(userID, teamEntity.ID, 1)
+	err = tuc.tr.AddTeamMember(userID, teamEntity.ID, entity.TeamRoleOwner)
 
 	return err
 }
 
 func (tuc *TeamUseCaseImpl) DeleteTeam(id uint, userID uint) error {
-	teamOwner, err := tuc.tr.GetTeamLeader(id)
+	err := tuc.p.CanDeleteTeam(userID, id)
 	if err != nil {
-		return errors.New("internal server error")
-	}
-
-	if teamOwner != userID {
-		return errors.New("action unauthorized")
+		return err
 	}
 
 	err = tuc.tr.DeleteTeam(id)
@@ -57,13 +89,9 @@ func (tuc *TeamUseCaseImpl) DeleteTeam(id uint, userID uint) error {
 }
 
 func (tuc *TeamUseCaseImpl) UpdateTeam(userID uint, team dto.TeamRequest, teamID uint) error {
-	teamOwner, err := tuc.tr.GetTeamLeader(teamID)
+	err := tuc.p.CanManageTeam(userID, teamID)
 	if err != nil {
-		return errors.New("internal server error")
-	}
-
-	if teamOwner != userID {
-		return errors.New("action unauthorized")
+		return err
 	}
 
 	teamEntity := entity.Team{
@@ -94,29 +122,436 @@ func (tuc *TeamUseCaseImpl) GetTeamsByUserID(userID uint) ([]dto.BriefTeamRespon
 	return teamsResponse, nil
 }
 
-func (tuc *TeamUseCaseImpl) GetTeamDetailsByID(teamID uint) (dto.TeamDetailsResponse, error) {
+// GetTeamDetailsByID returns the team with the contact details of its members
+// redacted according to their privacy settings. viewerID is 0 for visitors who
+// aren't logged in.
+func (tuc *TeamUseCaseImpl) GetTeamDetailsByID(teamID uint, viewerID uint) (dto.TeamDetailsResponse, error) {
 	team, err := tuc.tr.GetTeamByID(teamID)
 
 	if err != nil {
 		return dto.TeamDetailsResponse{}, err
 	}
 
+	viewer, err := tuc.s.Viewer(viewerID)
+	if err != nil {
+		return dto.TeamDetailsResponse{}, err
+	}
+
 	teamDetails := dto.TeamDetailsResponse{
 		Name:        team.Name,
 		Description: team.Description,
 		Capacity:    team.Capacity,
+		Logo:        tuc.mu.Image(team.LogoKey),
 	}
 
 	for _, member := range team.TeamMembers {
+		relationship := viewer.RelationshipTo(member.UserID)
+		var isLeader uint
+		if member.Role == entity.TeamRoleOwner {
+			isLeader = 1
+		}
 		teamDetails.TeamMembers = append(teamDetails.TeamMembers, dto.TeamMemberResponse{
-			UserID:            member.ID,
+			UserID:            member.UserID,
 			Name:              member.User.Name,
-			IsLeader:          member.IsLeader,
+			IsLeader:          isLeader,
+			Role:              member.Role,
 			SchoolInstitution: member.User.SchoolInstitution,
-			Email:             member.User.Email,
-			PhoneNumber:       member.User.PhoneNumber,
+			Email:             relationship.Email(member.User),
+			PhoneNumber:       relationship.PhoneNumber(member.User),
 		})
 	}
 
 	return teamDetails, nil
 }
+
+func (tuc *TeamUseCaseImpl) UploadTeamLogo(teamID uint, userID uint, data []byte) (*media.Image, error) {
+	err := tuc.p.CanManageTeam(userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := tuc.tr.GetTeamByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := tuc.mu.Upload("logos/"+strconv.FormatUint(uint64(teamID), 10), data, media.TeamLogoSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tuc.tr.UpdateTeamLogo(teamID, key)
+	if err != nil {
+		tuc.mu.Delete(key)
+		return nil, err
+	}
+
+	// the new logo is already saved, a leftover old file only wastes space
+	if team.LogoKey != "" {
+		tuc.mu.Delete(team.LogoKey)
+	}
+
+	return tuc.mu.Image(key), nil
+}
+
+func (tuc *TeamUseCaseImpl) DeleteTeamLogo(teamID uint, userID uint) error {
+	err := tuc.p.CanManageTeam(userID, teamID)
+	if err != nil {
+		return err
+	}
+
+	team, err := tuc.tr.GetTeamByID(teamID)
+	if err != nil {
+		return err
+	}
+
+	if team.LogoKey == "" {
+		return errors.New("logo not found")
+	}
+
+	err = tuc.tr.UpdateTeamLogo(teamID, "")
+	if err != nil {
+		return err
+	}
+
+	return tuc.mu.Delete(team.LogoKey)
+}
+
+// InviteTeamMember invites a registered user by ID, or anyone by email, to
+// join the team. The invitee is told by email and answers through the
+// received invitations. The owner and the co-leaders can invite.
+func (tuc *TeamUseCaseImpl) InviteTeamMember(teamID uint, userID uint, request dto.TeamInvitationRequest) (dto.TeamInvitationResponse, error) {
+	request.Email = strings.TrimSpace(request.Email)
+	if (request.UserID == 0) == (request.Email == "") {
+		return dto.TeamInvitationResponse{}, errors.New("fill either the user ID or the email")
+	}
+
+	err := tuc.p.CanManageTeam(userID, teamID)
+	if err != nil {
+		return dto.TeamInvitationResponse{}, err
+	}
+
+	team, err := tuc.tr.GetTeamByID(teamID)
+	if err != nil {
+		return dto.TeamInvitationResponse{}, err
+	}
+
+	inviter, err := tuc.ur.GetUserByID(userID)
+	if err != nil {
+		return dto.TeamInvitationResponse{}, err
+	}
+
+	invitation := entity.TeamInvitation{
+		TeamID:    teamID,
+		InviterID: userID,
+		Email:     request.Email,
+		Status:    entity.InvitationStatusPending,
+		ExpiresAt: time.Now().Add(invitationLifetime),
+	}
+	to := request.Email
+	if request.UserID != 0 {
+		invitee, err := tuc.ur.GetUserByID(request.UserID)
+		if err != nil {
+			return dto.TeamInvitationResponse{}, err
+		}
+
+		if invitee.AnonymizedAt != nil {
+			return dto.TeamInvitationResponse{}, errors.New("record not found")
+		}
+
+		for _, member := range team.TeamMembers {
+			if member.UserID == invitee.ID {
+				return dto.TeamInvitationResponse{}, errors.New("already a team member")
+			}
+		}
+
+		invitation.InviteeID = &invitee.ID
+		to = invitee.Email
+	}
+
+	pending, err := tuc.tr.HasPendingTeamInvitation(teamID, request.UserID, request.Email)
+	if err != nil {
+		return dto.TeamInvitationResponse{}, err
+	}
+
+	if pending {
+		return dto.TeamInvitationResponse{}, errors.New("invitation already sent")
+	}
+
+	invitation, err = tuc.tr.CreateTeamInvitation(invitation)
+	if err != nil {
+		return dto.TeamInvitationResponse{}, err
+	}
+
+	body := fmt.Sprintf("%s invited you to join the team %s on Compnouron. The invitation expires in 7 days, open the link below to accept or decline it. If you don't have an account yet, register with this email address first.\n\n%s/teams/invitations", inviter.Name, team.Name, os.Getenv("APP_URL"))
+	err = tuc.m.Send(to, "You are invited to join "+team.Name, body)
+	if err != nil {
+		// nobody was told about the invitation, remove it so that it can be
+		// sent again instead of blocking the retry as already sent
+		tuc.tr.DeleteTeamInvitation(teamID, invitation.ID)
+		return dto.TeamInvitationResponse{}, err
+	}
+
+	return toTeamInvitationResponse(invitation), nil
+}
+
+func (tuc *TeamUseCaseImpl) GetTeamInvitations(teamID uint, userID uint) ([]dto.TeamInvitationResponse, error) {
+	err := tuc.p.CanManageTeam(userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := tuc.tr.GetTeamInvitationsByTeamID(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []dto.TeamInvitationResponse{}
+	for _, invitation := range invitations {
+		responses = append(responses, toTeamInvitationResponse(invitation))
+	}
+
+	return responses, nil
+}
+
+func (tuc *TeamUseCaseImpl) RevokeTeamInvitation(teamID uint, invitationID uint, userID uint) error {
+	err := tuc.p.CanManageTeam(userID, teamID)
+	if err != nil {
+		return err
+	}
+
+	return tuc.tr.DeleteTeamInvitation(teamID, invitationID)
+}
+
+// GetReceivedTeamInvitations returns the invitations sent to the user, by ID
+// or, once the user has verified it, to their email address.
+func (tuc *TeamUseCaseImpl) GetReceivedTeamInvitations(userID uint) ([]dto.TeamInvitationResponse, error) {
+	user, err := tuc.ur.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	email := ""
+	if user.VerifiedAt != nil {
+		email = user.Email
+	}
+
+	invitations, err := tuc.tr.GetTeamInvitationsForUser(userID, email)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []dto.TeamInvitationResponse{}
+	for _, invitation := range invitations {
+		response := toTeamInvitationResponse(invitation)
+		response.TeamName = invitation.Team.Name
+		response.InviterName = invitation.Inviter.Name
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+func (tuc *TeamUseCaseImpl) AcceptTeamInvitation(invitationID uint, userID uint) error {
+	invitation, err := tuc.getPendingInvitation(invitationID, userID)
+	if err != nil {
+		return err
+	}
+
+	// the team is locked while it is checked and joined, like when accepting a
+	// recruitment application, so the two can't overfill it together
+	return tuc.uow.Do(func(repos unitofwork.Repositories) error {
+		team, err := repos.Teams.GetTeamForUpdate(invitation.TeamID)
+		if err != nil {
+			return err
+		}
+
+		member, err := repos.Teams.GetTeamMember(invitation.TeamID, userID)
+		if err != nil {
+			return err
+		}
+
+		if member.Role != "" {
+			return errors.New("already a team member")
+		}
+
+		memberCount, err := repos.Teams.CountTeamMembers(invitation.TeamID)
+		if err != nil {
+			return err
+		}
+
+		if int64(team.Capacity) <= memberCount {
+			return errors.New("The team is full")
+		}
+
+		return repos.Teams.AcceptTeamInvitation(invitation, userID)
+	})
+}
+
+func (tuc *TeamUseCaseImpl) DeclineTeamInvitation(invitationID uint, userID uint) error {
+	invitation, err := tuc.getPendingInvitation(invitationID, userID)
+	if err != nil {
+		return err
+	}
+
+	return tuc.tr.UpdateTeamInvitationStatus(invitation.ID, entity.InvitationStatusDeclined)
+}
+
+// LeaveTeam removes the user from the team. The owner has to hand the
+// ownership over first, so a team is never left without one. The competition
+// registrations and recruitments of the team aren't affected by a member
+// leaving: they belong to the team, not to its members.
+func (tuc *TeamUseCaseImpl) LeaveTeam(teamID uint, userID uint) error {
+	member, err := tuc.getTeamMember(teamID, userID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == entity.TeamRoleOwner {
+		return errors.New("transfer the ownership before leaving")
+	}
+
+	return tuc.tr.RemoveTeamMember(teamID, userID)
+}
+
+// RemoveTeamMember removes a member from the team on behalf of its owner or a
+// co-leader. Only the owner can remove a co-leader. As with LeaveTeam, the
+// registrations and recruitments of the team stay.
+func (tuc *TeamUseCaseImpl) RemoveTeamMember(teamID uint, memberID uint, userID uint) error {
+	err := tuc.p.CanManageTeamMembers(userID, teamID)
+	if err != nil {
+		return err
+	}
+
+	member, err := tuc.getTeamMember(teamID, memberID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == entity.TeamRoleOwner {
+		return errors.New("the owner can't be removed")
+	}
+
+	if member.Role == entity.TeamRoleCoLeader {
+		err = tuc.p.CanManageTeamRoles(userID, teamID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tuc.tr.RemoveTeamMember(teamID, memberID)
+}
+
+// TransferTeamOwnership makes another member the owner of the team, the
+// previous owner stays on as a co-leader. The active competition
+// registrations of the team move to the new owner.
+func (tuc *TeamUseCaseImpl) TransferTeamOwnership(teamID uint, newOwnerID uint, userID uint) error {
+	err := tuc.p.CanManageTeamRoles(userID, teamID)
+	if err != nil {
+		return err
+	}
+
+	ownerID, err := tuc.tr.GetTeamOwner(teamID)
+	if err != nil {
+		return err
+	}
+
+	if ownerID == newOwnerID {
+		return errors.New("already the team owner")
+	}
+
+	_, err = tuc.getTeamMember(teamID, newOwnerID)
+	if err != nil {
+		return err
+	}
+
+	return tuc.tr.TransferTeamOwnership(teamID, ownerID, newOwnerID)
+}
+
+// UpdateTeamMemberRole promotes a member to co-leader or demotes a co-leader.
+// The owner's role only changes through TransferTeamOwnership.
+func (tuc *TeamUseCaseImpl) UpdateTeamMemberRole(teamID uint, memberID uint, userID uint, request dto.TeamMemberRoleRequest) error {
+	if !entity.IsAssignableTeamRole(request.Role) {
+		return errors.New("invalid team role")
+	}
+
+	err := tuc.p.CanManageTeamRoles(userID, teamID)
+	if err != nil {
+		return err
+	}
+
+	member, err := tuc.getTeamMember(teamID, memberID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == entity.TeamRoleOwner {
+		return errors.New("transfer the ownership to change the owner's role")
+	}
+
+	return tuc.tr.UpdateTeamMemberRole(teamID, memberID, request.Role)
+}
+
+func (tuc *TeamUseCaseImpl) getTeamMember(teamID uint, userID uint) (entity.TeamMember, error) {
+	member, err := tuc.tr.GetTeamMember(teamID, userID)
+	if err != nil {
+		return entity.TeamMember{}, err
+	}
+
+	if member.Role == "" {
+		return entity.TeamMember{}, errors.New("not a team member")
+	}
+
+	return member, nil
+}
+
+// getPendingInvitation returns the invitation when it was sent to the user and
+// still waits for an answer. An invitation found to be past its expiry is
+// marked as expired on the way.
+func (tuc *TeamUseCaseImpl) getPendingInvitation(invitationID uint, userID uint) (entity.TeamInvitation, error) {
+	invitation, err := tuc.tr.GetTeamInvitationByID(invitationID)
+	if err != nil {
+		return entity.TeamInvitation{}, err
+	}
+
+	user, err := tuc.ur.GetUserByID(userID)
+	if err != nil {
+		return entity.TeamInvitation{}, err
+	}
+
+	invitedByID := invitation.InviteeID != nil && *invitation.InviteeID == userID
+	invitedByEmail := invitation.Email != "" && user.VerifiedAt != nil && strings.EqualFold(invitation.Email, user.Email)
+	if !invitedByID && !invitedByEmail {
+		return entity.TeamInvitation{}, errors.New("action unauthorized")
+	}
+
+	switch invitation.CurrentStatus() {
+	case entity.InvitationStatusPending:
+		return invitation, nil
+	case entity.InvitationStatusExpired:
+		if invitation.Status == entity.InvitationStatusPending {
+			err = tuc.tr.UpdateTeamInvitationStatus(invitation.ID, entity.InvitationStatusExpired)
+			if err != nil {
+				return entity.TeamInvitation{}, err
+			}
+		}
+
+		return entity.TeamInvitation{}, errors.New("invitation expired")
+	default:
+		return entity.TeamInvitation{}, errors.New("invitation already answered")
+	}
+}
+
+func toTeamInvitationResponse(invitation entity.TeamInvitation) dto.TeamInvitationResponse {
+	return dto.TeamInvitationResponse{
+		ID:          invitation.ID,
+		TeamID:      invitation.TeamID,
+		InviterID:   invitation.InviterID,
+		InviteeID:   invitation.InviteeID,
+		Email:       invitation.Email,
+		Status:      invitation.CurrentStatus(),
+		ExpiresAt:   invitation.ExpiresAt,
+		RespondedAt: invitation.RespondedAt,
+		CreatedAt:   invitation.CreatedAt,
+	}
+}
@@ -2,13 +2,25 @@ package usecase
 
 import (
 	"errors"
+	"github.com/alimikegami/compnouron/internal/media"
+	"github.com/alimikegami/compnouron/internal/policy"
+	"github.com/alimikegami/compnouron/internal/privacy"
+	"github.com/alimikegami/compnouron/pkg/utils"
 	"testing"
 	"time"
 
+	mailerMocks "github.com/alimikegami/compnouron/internal/mocks/mailer"
+	mediaMocks "github.com/alimikegami/compnouron/internal/mocks/media"
+	privacyMocks "github.com/alimikegami/compnouron/internal/mocks/privacy"
 	teamMocks "github.com/alimikegami/compnouron/internal/mocks/team/repository"
+	uowMocks "github.com/alimikegami/compnouron/internal/mocks/unitofwork"
+	userMocks "github.com/alimikegami/compnouron/internal/mocks/user/repository"
 	"github.com/alimikegami/compnouron/internal/team/dto"
 	"github.com/alimikegami/compnouron/internal/team/entity"
+	"github.com/alimikegami/compnouron/internal/unitofwork"
+	userEntity "github.com/alimikegami/compnouron/internal/user/entity"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestCreateTeam(t *testing.T) {
@@ -23,27 +35,47 @@ func TestCreateTeam(t *testing.T) {
 		Description: "Team Technoscape Hackathon 2022",
 		Capacity:    4,
 	}
+	verifiedAt := time.Now()
 	teamMockRepo := teamMocks.NewTeamRepository(t)
-	teamMockRepo.On("CreateTeam", team).Return(createdTeam, nil)
+	userMockRepo := userMocks.NewUserRepository(t)
+	t.Run("success", func(t *testing.T) {
+		userMockRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, VerifiedAt: &verifiedAt}, nil).Once()
+		teamMockRepo.On("CreateTeam", team).Return(createdTeam, nil).Once()
 
-	teamMockRepo.On("AddTeamMember", uint(1), createdTeam.ID, uint(1)).Return(nil)
+		teamMockRepo.On("AddTeamMember", uint(1), createdTeam.ID, entity.TeamRoleOwner).Return(nil).Once()
 
-	testUseCase := CreateNewTeamUseCase(teamMockRepo)
-	err := testUseCase.CreateTeam(1, dto.TeamRequest{
-		Name:        "Team 1",
-		Description: "Team Technoscape Hackathon 2022",
-		Capacity:    4,
+		testUseCase := CreateNewTeamUseCase(teamMockRepo, userMockRepo, policy.CreateNewPolicy(userMockRepo, teamMockRepo), privacyMocks.NewShaper(t), mediaMocks.NewUploader(t), mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
+		err := testUseCase.CreateTeam(1, dto.TeamRequest{
+			Name:        "Team 1",
+			Description: "Team Technoscape Hackathon 2022",
+			Capacity:    4,
+		})
+
+		assert.NoError(t, err)
+		teamMockRepo.AssertExpectations(t)
 	})
 
-	assert.NoError(t, err)
-	teamMockRepo.AssertExpectations(t)
+	t.Run("email-not-verified", func(t *testing.T) {
+		userMockRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1}, nil).Once()
+
+		testUseCase := CreateNewTeamUseCase(teamMockRepo, userMockRepo, policy.CreateNewPolicy(userMockRepo, teamMockRepo), privacyMocks.NewShaper(t), mediaMocks.NewUploader(t), mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
+		err := testUseCase.CreateTeam(1, dto.TeamRequest{
+			Name:        "Team 1",
+			Description: "Team Technoscape Hackathon 2022",
+			Capacity:    4,
+		})
+
+		assert.EqualError(t, err, "email is not verified")
+		userMockRepo.AssertExpectations(t)
+	})
 }
 
 func TestDeleteTeam(t *testing.T) {
 	mockRepo := teamMocks.NewTeamRepository(t)
-	testUseCase := CreateNewTeamUseCase(mockRepo)
+	mockUserRepo := userMocks.NewUserRepository(t)
+	testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), privacyMocks.NewShaper(t), mediaMocks.NewUploader(t), mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
 	t.Run("success", func(t *testing.T) {
-		mockRepo.On("GetTeamLeader", uint(1)).Return(uint(1), nil).Once()
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}, nil).Once()
 		mockRepo.On("DeleteTeam", uint(1)).Return(nil).Once()
 		err := testUseCase.DeleteTeam(uint(1), uint(1))
 		assert.NoError(t, err)
@@ -51,14 +83,15 @@ func TestDeleteTeam(t *testing.T) {
 	})
 
 	t.Run("action-unauthorized", func(t *testing.T) {
-		mockRepo.On("GetTeamLeader", uint(1)).Return(uint(2), nil).Once()
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleStudent}, nil).Once()
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{}, nil).Once()
 		err := testUseCase.DeleteTeam(uint(1), uint(1))
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("repo-error", func(t *testing.T) {
-		mockRepo.On("GetTeamLeader", uint(111)).Return(uint(111), nil).Once()
+		mockRepo.On("GetTeamMember", uint(111), uint(111)).Return(entity.TeamMember{TeamID: 111, UserID: 111, Role: entity.TeamRoleOwner}, nil).Once()
 		mockRepo.On("DeleteTeam", uint(111)).Return(errors.New("no rows affected")).Once()
 		err := testUseCase.DeleteTeam(uint(111), uint(111))
 		assert.Error(t, err)
@@ -68,15 +101,16 @@ func TestDeleteTeam(t *testing.T) {
 
 func TestUpdateTeam(t *testing.T) {
 	mockRepo := teamMocks.NewTeamRepository(t)
+	mockUserRepo := userMocks.NewUserRepository(t)
 	t.Run("success", func(t *testing.T) {
-		mockRepo.On("GetTeamLeader", uint(1)).Return(uint(1), nil).Once()
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}, nil).Once()
 		mockRepo.On("UpdateTeam", entity.Team{
 			ID:          1,
 			Name:        "Team 1",
 			Description: "Team Technoscape Hackathon 2022",
 			Capacity:    4,
 		}).Return(nil).Once()
-		testUseCase := CreateNewTeamUseCase(mockRepo)
+		testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), privacyMocks.NewShaper(t), mediaMocks.NewUploader(t), mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.UpdateTeam(1, dto.TeamRequest{
 			Name:        "Team 1",
 			Description: "Team Technoscape Hackathon 2022",
@@ -87,8 +121,9 @@ func TestUpdateTeam(t *testing.T) {
 	})
 
 	t.Run("action-unauthorized", func(t *testing.T) {
-		mockRepo.On("GetTeamLeader", uint(1)).Return(uint(2), nil).Once()
-		testUseCase := CreateNewTeamUseCase(mockRepo)
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Role: utils.RoleStudent}, nil).Once()
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{}, nil).Once()
+		testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), privacyMocks.NewShaper(t), mediaMocks.NewUploader(t), mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.UpdateTeam(1, dto.TeamRequest{
 			Name:        "Team 1",
 			Description: "Team Technoscape Hackathon 2022",
@@ -99,14 +134,14 @@ func TestUpdateTeam(t *testing.T) {
 	})
 
 	t.Run("unexpected-error", func(t *testing.T) {
-		mockRepo.On("GetTeamLeader", uint(1)).Return(uint(1), nil).Once()
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}, nil).Once()
 		mockRepo.On("UpdateTeam", entity.Team{
 			ID:          1,
 			Name:        "Team 1",
 			Description: "Team Technoscape Hackathon 2022",
 			Capacity:    4,
 		}).Return(errors.New("no affected rows"))
-		testUseCase := CreateNewTeamUseCase(mockRepo)
+		testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), privacyMocks.NewShaper(t), mediaMocks.NewUploader(t), mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
 		err := testUseCase.UpdateTeam(1, dto.TeamRequest{
 			Name:        "Team 1",
 			Description: "Team Technoscape Hackathon 2022",
@@ -119,6 +154,7 @@ func TestUpdateTeam(t *testing.T) {
 
 func TestGetTeamsByUserID(t *testing.T) {
 	mockRepo := teamMocks.NewTeamRepository(t)
+	mockUserRepo := userMocks.NewUserRepository(t)
 	mockRepo.On("GetTeamsByUserID", uint(1)).Return([]entity.Team{
 		{
 			ID:          1,
@@ -137,7 +173,7 @@ func TestGetTeamsByUserID(t *testing.T) {
 			UpdatedAt:   time.Now(),
 		},
 	}, nil)
-	testUseCase := CreateNewTeamUseCase(mockRepo)
+	testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), privacyMocks.NewShaper(t), mediaMocks.NewUploader(t), mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
 	res, err := testUseCase.GetTeamsByUserID(1)
 	assert.NoError(t, err)
 	assert.Len(t, res, 2)
@@ -146,8 +182,9 @@ func TestGetTeamsByUserID(t *testing.T) {
 
 func TestGetTeamsByUserIDErrorOccured(t *testing.T) {
 	mockRepo := teamMocks.NewTeamRepository(t)
+	mockUserRepo := userMocks.NewUserRepository(t)
 	mockRepo.On("GetTeamsByUserID", uint(111)).Return([]entity.Team{}, nil)
-	testUseCase := CreateNewTeamUseCase(mockRepo)
+	testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), privacyMocks.NewShaper(t), mediaMocks.NewUploader(t), mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
 	res, err := testUseCase.GetTeamsByUserID(111)
 	assert.NoError(t, err)
 	assert.Len(t, res, 0)
@@ -156,33 +193,557 @@ func TestGetTeamsByUserIDErrorOccured(t *testing.T) {
 
 func TestGetTeamDetailsByID(t *testing.T) {
 	mockRepo := teamMocks.NewTeamRepository(t)
+	mockUserRepo := userMocks.NewUserRepository(t)
+	mockShaper := privacyMocks.NewShaper(t)
+	mockUploader := mediaMocks.NewUploader(t)
+	mockUploader.On("Image", "logos/1/logo.png").Return(&media.Image{URL: "/uploads/logos/1/logo.png", ThumbnailURL: "/uploads/logos/1/logo_thumb.png"})
 	mockRepo.On("GetTeamByID", uint(1)).Return(entity.Team{
 		ID:          1,
 		Name:        "Team 1",
 		Description: "Team Technoscape Hackathon 2022",
 		Capacity:    4,
+		LogoKey:     "logos/1/logo.png",
 		TeamMembers: []entity.TeamMember{
 			{
-				ID:        1,
-				TeamID:    1,
-				UserID:    1,
-				IsLeader:  1,
+				ID:     1,
+				TeamID: 1,
+				UserID: 1,
+				Role:   entity.TeamRoleOwner,
+				User: userEntity.User{
+					ID:          1,
+					Name:        "Leader",
+					Email:       "leader@gmail.com",
+					PhoneNumber: "08123",
+				},
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
 			},
 			{
-				ID:        2,
-				TeamID:    1,
-				UserID:    2,
-				IsLeader:  0,
+				ID:     2,
+				TeamID: 1,
+				UserID: 2,
+				Role:   entity.TeamRoleMember,
+				User: userEntity.User{
+					ID:                    2,
+					Name:                  "Member",
+					Email:                 "member@gmail.com",
+					PhoneNumber:           "08456",
+					EmailVisibility:       privacy.VisibilityPublic,
+					PhoneNumberVisibility: privacy.VisibilityPrivate,
+				},
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
 			},
 		}}, nil)
 
-	testUseCase := CreateNewTeamUseCase(mockRepo)
-	res, err := testUseCase.GetTeamDetailsByID(uint(1))
-	assert.NoError(t, err)
-	assert.NotEmpty(t, res)
+	testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), mockShaper, mockUploader, mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
+
+	t.Run("anonymous-viewer", func(t *testing.T) {
+		mockShaper.On("Viewer", uint(0)).Return(privacy.Viewer{}, nil).Once()
+
+		res, err := testUseCase.GetTeamDetailsByID(uint(1), uint(0))
+		assert.NoError(t, err)
+		assert.Equal(t, "/uploads/logos/1/logo_thumb.png", res.Logo.ThumbnailURL)
+		assert.Len(t, res.TeamMembers, 2)
+		assert.Equal(t, uint(1), res.TeamMembers[0].IsLeader)
+		assert.Equal(t, "owner", res.TeamMembers[0].Role)
+		assert.Equal(t, "member", res.TeamMembers[1].Role)
+		assert.Empty(t, res.TeamMembers[0].Email)
+		assert.Empty(t, res.TeamMembers[0].PhoneNumber)
+		assert.Equal(t, "member@gmail.com", res.TeamMembers[1].Email)
+		assert.Empty(t, res.TeamMembers[1].PhoneNumber)
+	})
+
+	t.Run("teammate-viewer", func(t *testing.T) {
+		mockShaper.On("Viewer", uint(2)).Return(privacy.Viewer{
+			ID:        2,
+			Teammates: map[uint]bool{1: true},
+		}, nil).Once()
+
+		res, err := testUseCase.GetTeamDetailsByID(uint(1), uint(2))
+		assert.NoError(t, err)
+		assert.Equal(t, "leader@gmail.com", res.TeamMembers[0].Email)
+		assert.Equal(t, "08123", res.TeamMembers[0].PhoneNumber)
+		assert.Equal(t, "08456", res.TeamMembers[1].PhoneNumber)
+	})
+
+	t.Run("private-hidden-from-teammate", func(t *testing.T) {
+		mockShaper.On("Viewer", uint(1)).Return(privacy.Viewer{
+			ID:        1,
+			Teammates: map[uint]bool{2: true},
+		}, nil).Once()
+
+		res, err := testUseCase.GetTeamDetailsByID(uint(1), uint(1))
+		assert.NoError(t, err)
+		assert.Equal(t, "member@gmail.com", res.TeamMembers[1].Email)
+		assert.Empty(t, res.TeamMembers[1].PhoneNumber)
+	})
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUploadTeamLogo(t *testing.T) {
+	mockRepo := teamMocks.NewTeamRepository(t)
+	mockUserRepo := userMocks.NewUserRepository(t)
+	mockUploader := mediaMocks.NewUploader(t)
+	testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), privacyMocks.NewShaper(t), mockUploader, mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
+	data := []byte("image")
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}, nil).Once()
+		mockRepo.On("GetTeamByID", uint(1)).Return(entity.Team{ID: 1, LogoKey: "logos/1/old.png"}, nil).Once()
+		mockUploader.On("Upload", "logos/1", data, media.TeamLogoSpec).Return("logos/1/new.png", nil).Once()
+		mockRepo.On("UpdateTeamLogo", uint(1), "logos/1/new.png").Return(nil).Once()
+		mockUploader.On("Delete", "logos/1/old.png").Return(nil).Once()
+		mockUploader.On("Image", "logos/1/new.png").Return(&media.Image{URL: "/uploads/logos/1/new.png"}).Once()
+
+		res, err := testUseCase.UploadTeamLogo(1, 1, data)
+		assert.NoError(t, err)
+		assert.Equal(t, "/uploads/logos/1/new.png", res.URL)
+	})
+
+	t.Run("action-unauthorized", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2, Role: utils.RoleStudent}, nil).Once()
+		mockRepo.On("GetTeamMember", uint(1), uint(2)).Return(entity.TeamMember{}, nil).Once()
+
+		_, err := testUseCase.UploadTeamLogo(1, 2, data)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid-image", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}, nil).Once()
+		mockRepo.On("GetTeamByID", uint(1)).Return(entity.Team{ID: 1}, nil).Once()
+		mockUploader.On("Upload", "logos/1", data, media.TeamLogoSpec).Return("", errors.New("invalid image")).Once()
+
+		_, err := testUseCase.UploadTeamLogo(1, 1, data)
+		assert.EqualError(t, err, "invalid image")
+	})
+
+	t.Run("update-failed-removes-upload", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}, nil).Once()
+		mockRepo.On("GetTeamByID", uint(1)).Return(entity.Team{ID: 1, LogoKey: "logos/1/old.png"}, nil).Once()
+		mockUploader.On("Upload", "logos/1", data, media.TeamLogoSpec).Return("logos/1/new.png", nil).Once()
+		mockRepo.On("UpdateTeamLogo", uint(1), "logos/1/new.png").Return(errors.New("unexpected DB error")).Once()
+		mockUploader.On("Delete", "logos/1/new.png").Return(nil).Once()
+
+		_, err := testUseCase.UploadTeamLogo(1, 1, data)
+		assert.Error(t, err)
+	})
+
 	mockRepo.AssertExpectations(t)
+	mockUploader.AssertExpectations(t)
+}
+
+func TestDeleteTeamLogo(t *testing.T) {
+	mockRepo := teamMocks.NewTeamRepository(t)
+	mockUserRepo := userMocks.NewUserRepository(t)
+	mockUploader := mediaMocks.NewUploader(t)
+	testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), privacyMocks.NewShaper(t), mockUploader, mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}, nil).Once()
+		mockRepo.On("GetTeamByID", uint(1)).Return(entity.Team{ID: 1, LogoKey: "logos/1/old.png"}, nil).Once()
+		mockRepo.On("UpdateTeamLogo", uint(1), "").Return(nil).Once()
+		mockUploader.On("Delete", "logos/1/old.png").Return(nil).Once()
+
+		err := testUseCase.DeleteTeamLogo(1, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("logo-not-found", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}, nil).Once()
+		mockRepo.On("GetTeamByID", uint(1)).Return(entity.Team{ID: 1}, nil).Once()
+
+		err := testUseCase.DeleteTeamLogo(1, 1)
+		assert.EqualError(t, err, "logo not found")
+	})
+
+	mockRepo.AssertExpectations(t)
+	mockUploader.AssertExpectations(t)
+}
+
+func TestInviteTeamMember(t *testing.T) {
+	mockRepo := teamMocks.NewTeamRepository(t)
+	mockUserRepo := userMocks.NewUserRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), privacyMocks.NewShaper(t), mediaMocks.NewUploader(t), mockMailer, uowMocks.NewUnitOfWork(t))
+	team := entity.Team{ID: 1, Name: "Team 1", Capacity: 4, TeamMembers: []entity.TeamMember{{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}}}
+
+	t.Run("by-user-id", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}, nil).Once()
+		mockRepo.On("GetTeamByID", uint(1)).Return(team, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Name: "Alim Ikegami"}, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2, Email: "asdfa@gmail.com"}, nil).Once()
+		mockRepo.On("HasPendingTeamInvitation", uint(1), uint(2), "").Return(false, nil).Once()
+		mockRepo.On("CreateTeamInvitation", mock.MatchedBy(func(invitation entity.TeamInvitation) bool {
+			return invitation.TeamID == 1 && invitation.InviteeID != nil && *invitation.InviteeID == 2 && invitation.Email == "" && invitation.Status == entity.InvitationStatusPending
+		})).Return(entity.TeamInvitation{ID: 3, TeamID: 1, Status: entity.InvitationStatusPending, ExpiresAt: time.Now().Add(time.Hour)}, nil).Once()
+		mockMailer.On("Send", "asdfa@gmail.com", "You are invited to join Team 1", mock.AnythingOfType("string")).Return(nil).Once()
+
+		res, err := testUseCase.InviteTeamMember(1, 1, dto.TeamInvitationRequest{UserID: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, uint(3), res.ID)
+		assert.Equal(t, entity.InvitationStatusPending, res.Status)
+	})
+
+	t.Run("by-email", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}, nil).Once()
+		mockRepo.On("GetTeamByID", uint(1)).Return(team, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Name: "Alim Ikegami"}, nil).Once()
+		mockRepo.On("HasPendingTeamInvitation", uint(1), uint(0), "friend@gmail.com").Return(false, nil).Once()
+		mockRepo.On("CreateTeamInvitation", mock.MatchedBy(func(invitation entity.TeamInvitation) bool {
+			return invitation.InviteeID == nil && invitation.Email == "friend@gmail.com"
+		})).Return(entity.TeamInvitation{ID: 4, TeamID: 1, Email: "friend@gmail.com", Status: entity.InvitationStatusPending}, nil).Once()
+		mockMailer.On("Send", "friend@gmail.com", "You are invited to join Team 1", mock.AnythingOfType("string")).Return(nil).Once()
+
+		_, err := testUseCase.InviteTeamMember(1, 1, dto.TeamInvitationRequest{Email: " friend@gmail.com "})
+		assert.NoError(t, err)
+	})
+
+	t.Run("send-failed", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}, nil).Once()
+		mockRepo.On("GetTeamByID", uint(1)).Return(team, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Name: "Alim Ikegami"}, nil).Once()
+		mockRepo.On("HasPendingTeamInvitation", uint(1), uint(0), "friend@gmail.com").Return(false, nil).Once()
+		mockRepo.On("CreateTeamInvitation", mock.MatchedBy(func(invitation entity.TeamInvitation) bool {
+			return invitation.Email == "friend@gmail.com"
+		})).Return(entity.TeamInvitation{ID: 5, TeamID: 1, Email: "friend@gmail.com", Status: entity.InvitationStatusPending}, nil).Once()
+		mockMailer.On("Send", "friend@gmail.com", "You are invited to join Team 1", mock.AnythingOfType("string")).Return(errors.New("smtp unavailable")).Once()
+		mockRepo.On("DeleteTeamInvitation", uint(1), uint(5)).Return(nil).Once()
+
+		_, err := testUseCase.InviteTeamMember(1, 1, dto.TeamInvitationRequest{Email: "friend@gmail.com"})
+		assert.EqualError(t, err, "smtp unavailable")
+
+		// the invitation is gone, so the retry goes through
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}, nil).Once()
+		mockRepo.On("GetTeamByID", uint(1)).Return(team, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Name: "Alim Ikegami"}, nil).Once()
+		mockRepo.On("HasPendingTeamInvitation", uint(1), uint(0), "friend@gmail.com").Return(false, nil).Once()
+		mockRepo.On("CreateTeamInvitation", mock.MatchedBy(func(invitation entity.TeamInvitation) bool {
+			return invitation.Email == "friend@gmail.com"
+		})).Return(entity.TeamInvitation{ID: 6, TeamID: 1, Email: "friend@gmail.com", Status: entity.InvitationStatusPending}, nil).Once()
+		mockMailer.On("Send", "friend@gmail.com", "You are invited to join Team 1", mock.AnythingOfType("string")).Return(nil).Once()
+
+		res, err := testUseCase.InviteTeamMember(1, 1, dto.TeamInvitationRequest{Email: "friend@gmail.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, uint(6), res.ID)
+		mockRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("already-a-member", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}, nil).Once()
+		mockRepo.On("GetTeamByID", uint(1)).Return(team, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Name: "Alim Ikegami"}, nil).Twice()
+
+		_, err := testUseCase.InviteTeamMember(1, 1, dto.TeamInvitationRequest{UserID: 1})
+		assert.EqualError(t, err, "already a team member")
+	})
+
+	t.Run("already-sent", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}, nil).Once()
+		mockRepo.On("GetTeamByID", uint(1)).Return(team, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(1)).Return(userEntity.User{ID: 1, Name: "Alim Ikegami"}, nil).Once()
+		mockRepo.On("HasPendingTeamInvitation", uint(1), uint(0), "friend@gmail.com").Return(true, nil).Once()
+
+		_, err := testUseCase.InviteTeamMember(1, 1, dto.TeamInvitationRequest{Email: "friend@gmail.com"})
+		assert.EqualError(t, err, "invitation already sent")
+	})
+
+	t.Run("both-user-id-and-email", func(t *testing.T) {
+		_, err := testUseCase.InviteTeamMember(1, 1, dto.TeamInvitationRequest{UserID: 2, Email: "friend@gmail.com"})
+		assert.EqualError(t, err, "fill either the user ID or the email")
+	})
+
+	t.Run("plain-member", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(2)).Return(entity.TeamMember{TeamID: 1, UserID: 2, Role: entity.TeamRoleMember}, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2, Role: utils.RoleStudent}, nil).Once()
+
+		_, err := testUseCase.InviteTeamMember(1, 2, dto.TeamInvitationRequest{Email: "friend@gmail.com"})
+		assert.EqualError(t, err, "action unauthorized")
+	})
+
+	mockRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockMailer.AssertExpectations(t)
+}
+
+func TestAcceptTeamInvitation(t *testing.T) {
+	mockRepo := teamMocks.NewTeamRepository(t)
+	mockUserRepo := userMocks.NewUserRepository(t)
+	mockUnitOfWork := uowMocks.NewUnitOfWork(t)
+	mockUnitOfWork.On("Do", mock.Anything).Return(func(fn func(unitofwork.Repositories) error) error {
+		return fn(unitofwork.Repositories{Teams: mockRepo})
+	})
+	testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), privacyMocks.NewShaper(t), mediaMocks.NewUploader(t), mailerMocks.NewMailer(t), mockUnitOfWork)
+	verifiedAt := time.Now()
+	inviteeID := uint(2)
+	invitation := entity.TeamInvitation{ID: 3, TeamID: 1, InviteeID: &inviteeID, Status: entity.InvitationStatusPending, ExpiresAt: time.Now().Add(time.Hour)}
+	team := entity.Team{ID: 1, Capacity: 2}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetTeamInvitationByID", uint(3)).Return(invitation, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2}, nil).Once()
+		mockRepo.On("GetTeamForUpdate", uint(1)).Return(team, nil).Once()
+		mockRepo.On("GetTeamMember", uint(1), uint(2)).Return(entity.TeamMember{}, nil).Once()
+		mockRepo.On("CountTeamMembers", uint(1)).Return(int64(1), nil).Once()
+		mockRepo.On("AcceptTeamInvitation", invitation, uint(2)).Return(nil).Once()
+
+		assert.NoError(t, testUseCase.AcceptTeamInvitation(3, 2))
+	})
+
+	t.Run("by-verified-email", func(t *testing.T) {
+		emailInvitation := entity.TeamInvitation{ID: 4, TeamID: 1, Email: "friend@gmail.com", Status: entity.InvitationStatusPending, ExpiresAt: time.Now().Add(time.Hour)}
+		mockRepo.On("GetTeamInvitationByID", uint(4)).Return(emailInvitation, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(5)).Return(userEntity.User{ID: 5, Email: "Friend@gmail.com", VerifiedAt: &verifiedAt}, nil).Once()
+		mockRepo.On("GetTeamForUpdate", uint(1)).Return(team, nil).Once()
+		mockRepo.On("GetTeamMember", uint(1), uint(5)).Return(entity.TeamMember{}, nil).Once()
+		mockRepo.On("CountTeamMembers", uint(1)).Return(int64(1), nil).Once()
+		mockRepo.On("AcceptTeamInvitation", emailInvitation, uint(5)).Return(nil).Once()
+
+		assert.NoError(t, testUseCase.AcceptTeamInvitation(4, 5))
+	})
+
+	t.Run("unverified-email", func(t *testing.T) {
+		emailInvitation := entity.TeamInvitation{ID: 4, TeamID: 1, Email: "friend@gmail.com", Status: entity.InvitationStatusPending, ExpiresAt: time.Now().Add(time.Hour)}
+		mockRepo.On("GetTeamInvitationByID", uint(4)).Return(emailInvitation, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(5)).Return(userEntity.User{ID: 5, Email: "friend@gmail.com"}, nil).Once()
+
+		assert.EqualError(t, testUseCase.AcceptTeamInvitation(4, 5), "action unauthorized")
+	})
+
+	t.Run("team-full", func(t *testing.T) {
+		mockRepo.On("GetTeamInvitationByID", uint(3)).Return(invitation, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2}, nil).Once()
+		mockRepo.On("GetTeamForUpdate", uint(1)).Return(team, nil).Once()
+		mockRepo.On("GetTeamMember", uint(1), uint(2)).Return(entity.TeamMember{}, nil).Once()
+		mockRepo.On("CountTeamMembers", uint(1)).Return(int64(2), nil).Once()
+
+		assert.EqualError(t, testUseCase.AcceptTeamInvitation(3, 2), "The team is full")
+	})
+
+	t.Run("already-a-member", func(t *testing.T) {
+		mockRepo.On("GetTeamInvitationByID", uint(3)).Return(invitation, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2}, nil).Once()
+		mockRepo.On("GetTeamForUpdate", uint(1)).Return(team, nil).Once()
+		mockRepo.On("GetTeamMember", uint(1), uint(2)).Return(entity.TeamMember{TeamID: 1, UserID: 2, Role: entity.TeamRoleMember}, nil).Once()
+
+		assert.EqualError(t, testUseCase.AcceptTeamInvitation(3, 2), "already a team member")
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expired := invitation
+		expired.ExpiresAt = time.Now().Add(-time.Hour)
+		mockRepo.On("GetTeamInvitationByID", uint(3)).Return(expired, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2}, nil).Once()
+		mockRepo.On("UpdateTeamInvitationStatus", uint(3), entity.InvitationStatusExpired).Return(nil).Once()
+
+		assert.EqualError(t, testUseCase.AcceptTeamInvitation(3, 2), "invitation expired")
+	})
+
+	t.Run("already-declined", func(t *testing.T) {
+		declined := invitation
+		declined.Status = entity.InvitationStatusDeclined
+		mockRepo.On("GetTeamInvitationByID", uint(3)).Return(declined, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2}, nil).Once()
+
+		assert.EqualError(t, testUseCase.AcceptTeamInvitation(3, 2), "invitation already answered")
+	})
+
+	mockRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestDeclineTeamInvitation(t *testing.T) {
+	mockRepo := teamMocks.NewTeamRepository(t)
+	mockUserRepo := userMocks.NewUserRepository(t)
+	testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), privacyMocks.NewShaper(t), mediaMocks.NewUploader(t), mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
+	inviteeID := uint(2)
+	mockRepo.On("GetTeamInvitationByID", uint(3)).Return(entity.TeamInvitation{ID: 3, TeamID: 1, InviteeID: &inviteeID, Status: entity.InvitationStatusPending, ExpiresAt: time.Now().Add(time.Hour)}, nil).Once()
+	mockUserRepo.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2}, nil).Once()
+	mockRepo.On("UpdateTeamInvitationStatus", uint(3), entity.InvitationStatusDeclined).Return(nil).Once()
+
+	assert.NoError(t, testUseCase.DeclineTeamInvitation(3, 2))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetReceivedTeamInvitations(t *testing.T) {
+	mockRepo := teamMocks.NewTeamRepository(t)
+	mockUserRepo := userMocks.NewUserRepository(t)
+	testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), privacyMocks.NewShaper(t), mediaMocks.NewUploader(t), mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
+	verifiedAt := time.Now()
+
+	t.Run("verified-email", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2, Email: "asdfa@gmail.com", VerifiedAt: &verifiedAt}, nil).Once()
+		mockRepo.On("GetTeamInvitationsForUser", uint(2), "asdfa@gmail.com").Return([]entity.TeamInvitation{
+			{ID: 3, TeamID: 1, Email: "asdfa@gmail.com", Status: entity.InvitationStatusPending, ExpiresAt: time.Now().Add(-time.Hour), Team: entity.Team{Name: "Team 1"}, Inviter: userEntity.User{Name: "Alim Ikegami"}},
+		}, nil).Once()
+
+		res, err := testUseCase.GetReceivedTeamInvitations(2)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, "Team 1", res[0].TeamName)
+		assert.Equal(t, "Alim Ikegami", res[0].InviterName)
+		assert.Equal(t, entity.InvitationStatusExpired, res[0].Status)
+	})
+
+	t.Run("unverified-email", func(t *testing.T) {
+		mockUserRepo.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2, Email: "asdfa@gmail.com"}, nil).Once()
+		mockRepo.On("GetTeamInvitationsForUser", uint(2), "").Return([]entity.TeamInvitation{}, nil).Once()
+
+		res, err := testUseCase.GetReceivedTeamInvitations(2)
+		assert.NoError(t, err)
+		assert.Len(t, res, 0)
+	})
+
+	mockRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestLeaveTeam(t *testing.T) {
+	mockRepo := teamMocks.NewTeamRepository(t)
+	mockUserRepo := userMocks.NewUserRepository(t)
+	testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), privacyMocks.NewShaper(t), mediaMocks.NewUploader(t), mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(2)).Return(entity.TeamMember{TeamID: 1, UserID: 2, Role: entity.TeamRoleMember}, nil).Once()
+		mockRepo.On("RemoveTeamMember", uint(1), uint(2)).Return(nil).Once()
+
+		assert.NoError(t, testUseCase.LeaveTeam(1, 2))
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}, nil).Once()
+
+		assert.EqualError(t, testUseCase.LeaveTeam(1, 1), "transfer the ownership before leaving")
+	})
+
+	t.Run("not-a-member", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(3)).Return(entity.TeamMember{}, nil).Once()
+
+		assert.EqualError(t, testUseCase.LeaveTeam(1, 3), "not a team member")
+	})
+}
+
+func TestRemoveTeamMember(t *testing.T) {
+	mockRepo := teamMocks.NewTeamRepository(t)
+	mockUserRepo := userMocks.NewUserRepository(t)
+	testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), privacyMocks.NewShaper(t), mediaMocks.NewUploader(t), mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
+	owner := entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}
+	member := entity.TeamMember{TeamID: 1, UserID: 2, Role: entity.TeamRoleMember}
+	coLeader := entity.TeamMember{TeamID: 1, UserID: 3, Role: entity.TeamRoleCoLeader}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(owner, nil).Once()
+		mockRepo.On("GetTeamMember", uint(1), uint(2)).Return(member, nil).Once()
+		mockRepo.On("RemoveTeamMember", uint(1), uint(2)).Return(nil).Once()
+
+		assert.NoError(t, testUseCase.RemoveTeamMember(1, 2, 1))
+	})
+
+	t.Run("co-leader-removes-member", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(3)).Return(coLeader, nil).Once()
+		mockRepo.On("GetTeamMember", uint(1), uint(2)).Return(member, nil).Once()
+		mockRepo.On("RemoveTeamMember", uint(1), uint(2)).Return(nil).Once()
+
+		assert.NoError(t, testUseCase.RemoveTeamMember(1, 2, 3))
+	})
+
+	t.Run("owner-removes-co-leader", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(owner, nil).Twice()
+		mockRepo.On("GetTeamMember", uint(1), uint(3)).Return(coLeader, nil).Once()
+		mockRepo.On("RemoveTeamMember", uint(1), uint(3)).Return(nil).Once()
+
+		assert.NoError(t, testUseCase.RemoveTeamMember(1, 3, 1))
+	})
+
+	t.Run("co-leader-removes-co-leader", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(3)).Return(coLeader, nil).Times(3)
+		mockUserRepo.On("GetUserByID", uint(3)).Return(userEntity.User{ID: 3, Role: utils.RoleStudent}, nil).Once()
+
+		assert.EqualError(t, testUseCase.RemoveTeamMember(1, 3, 3), "action unauthorized")
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(owner, nil).Twice()
+
+		assert.EqualError(t, testUseCase.RemoveTeamMember(1, 1, 1), "the owner can't be removed")
+	})
+
+	t.Run("plain-member", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(2)).Return(member, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2, Role: utils.RoleStudent}, nil).Once()
+
+		assert.EqualError(t, testUseCase.RemoveTeamMember(1, 3, 2), "action unauthorized")
+	})
+}
+
+func TestTransferTeamOwnership(t *testing.T) {
+	mockRepo := teamMocks.NewTeamRepository(t)
+	mockUserRepo := userMocks.NewUserRepository(t)
+	testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), privacyMocks.NewShaper(t), mediaMocks.NewUploader(t), mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
+	owner := entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(owner, nil).Once()
+		mockRepo.On("GetTeamOwner", uint(1)).Return(uint(1), nil).Once()
+		mockRepo.On("GetTeamMember", uint(1), uint(2)).Return(entity.TeamMember{TeamID: 1, UserID: 2, Role: entity.TeamRoleCoLeader}, nil).Once()
+		mockRepo.On("TransferTeamOwnership", uint(1), uint(1), uint(2)).Return(nil).Once()
+
+		assert.NoError(t, testUseCase.TransferTeamOwnership(1, 2, 1))
+	})
+
+	t.Run("already-owner", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(owner, nil).Once()
+		mockRepo.On("GetTeamOwner", uint(1)).Return(uint(1), nil).Once()
+
+		assert.EqualError(t, testUseCase.TransferTeamOwnership(1, 1, 1), "already the team owner")
+	})
+
+	t.Run("not-a-member", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(owner, nil).Once()
+		mockRepo.On("GetTeamOwner", uint(1)).Return(uint(1), nil).Once()
+		mockRepo.On("GetTeamMember", uint(1), uint(3)).Return(entity.TeamMember{}, nil).Once()
+
+		assert.EqualError(t, testUseCase.TransferTeamOwnership(1, 3, 1), "not a team member")
+	})
+
+	t.Run("co-leader", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(2)).Return(entity.TeamMember{TeamID: 1, UserID: 2, Role: entity.TeamRoleCoLeader}, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2, Role: utils.RoleStudent}, nil).Once()
+
+		assert.EqualError(t, testUseCase.TransferTeamOwnership(1, 2, 2), "action unauthorized")
+	})
+}
+
+func TestUpdateTeamMemberRole(t *testing.T) {
+	mockRepo := teamMocks.NewTeamRepository(t)
+	mockUserRepo := userMocks.NewUserRepository(t)
+	testUseCase := CreateNewTeamUseCase(mockRepo, mockUserRepo, policy.CreateNewPolicy(mockUserRepo, mockRepo), privacyMocks.NewShaper(t), mediaMocks.NewUploader(t), mailerMocks.NewMailer(t), uowMocks.NewUnitOfWork(t))
+	owner := entity.TeamMember{TeamID: 1, UserID: 1, Role: entity.TeamRoleOwner}
+
+	t.Run("promote", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(owner, nil).Once()
+		mockRepo.On("GetTeamMember", uint(1), uint(2)).Return(entity.TeamMember{TeamID: 1, UserID: 2, Role: entity.TeamRoleMember}, nil).Once()
+		mockRepo.On("UpdateTeamMemberRole", uint(1), uint(2), entity.TeamRoleCoLeader).Return(nil).Once()
+
+		assert.NoError(t, testUseCase.UpdateTeamMemberRole(1, 2, 1, dto.TeamMemberRoleRequest{Role: "co-leader"}))
+	})
+
+	t.Run("invalid-role", func(t *testing.T) {
+		assert.EqualError(t, testUseCase.UpdateTeamMemberRole(1, 2, 1, dto.TeamMemberRoleRequest{Role: "owner"}), "invalid team role")
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(1)).Return(owner, nil).Twice()
+
+		assert.EqualError(t, testUseCase.UpdateTeamMemberRole(1, 1, 1, dto.TeamMemberRoleRequest{Role: "member"}), "transfer the ownership to change the owner's role")
+	})
+
+	t.Run("co-leader", func(t *testing.T) {
+		mockRepo.On("GetTeamMember", uint(1), uint(3)).Return(entity.TeamMember{TeamID: 1, UserID: 3, Role: entity.TeamRoleCoLeader}, nil).Once()
+		mockUserRepo.On("GetUserByID", uint(3)).Return(userEntity.User{ID: 3, Role: utils.RoleStudent}, nil).Once()
+
+		assert.EqualError(t, testUseCase.UpdateTeamMemberRole(1, 2, 3, dto.TeamMemberRoleRequest{Role: "co-leader"}), "action unauthorized")
+	})
 }
@@ -0,0 +1,416 @@
+package unitofwork_test
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alimikegami/compnouron/internal/policy"
+	recruitmentEntity "github.com/alimikegami/compnouron/internal/recruitment/entity"
+	recruitmentRepo "github.com/alimikegami/compnouron/internal/recruitment/repository"
+	recruitmentUseCase "github.com/alimikegami/compnouron/internal/recruitment/usecase"
+	teamEntity "github.com/alimikegami/compnouron/internal/team/entity"
+	teamRepo "github.com/alimikegami/compnouron/internal/team/repository"
+	teamUseCase "github.com/alimikegami/compnouron/internal/team/usecase"
+	"github.com/alimikegami/compnouron/internal/unitofwork"
+	userEntity "github.com/alimikegami/compnouron/internal/user/entity"
+	userRepo "github.com/alimikegami/compnouron/internal/user/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// The tests below accept many applicants of the same team at once and check
+// that the use cases take the team lock before they count the members, so
+// the team never ends up with more members than its capacity. They don't test
+// the database lock itself: the use cases run against an in-memory store that
+// only stands in for it, the writes of a transaction are visible to the others
+// once it commits, they are dropped when it fails, and GetTeamForUpdate holds
+// the team until the transaction ends. TestAcceptTeamInvitationLocksTeam checks
+// the queries the real repositories send, and
+// TestAcceptRecruitmentApplicationsOnMySQL runs the accepts against a real
+// database.
+
+type memoryStore struct {
+	mu           sync.Mutex
+	teamLocks    map[uint]*sync.Mutex
+	teams        map[uint]teamEntity.Team
+	members      map[uint][]teamEntity.TeamMember
+	applications map[uint]recruitmentEntity.RecruitmentApplication
+	invitations  map[uint]teamEntity.TeamInvitation
+	// mostMembers is the largest number of members each team ever had
+	mostMembers map[uint]int
+}
+
+func newMemoryStore(team teamEntity.Team, ownerID uint) *memoryStore {
+	return &memoryStore{
+		teamLocks:    map[uint]*sync.Mutex{team.ID: {}},
+		teams:        map[uint]teamEntity.Team{team.ID: team},
+		members:      map[uint][]teamEntity.TeamMember{team.ID: {{TeamID: team.ID, UserID: ownerID, Role: teamEntity.TeamRoleOwner}}},
+		applications: map[uint]recruitmentEntity.RecruitmentApplication{},
+		invitations:  map[uint]teamEntity.TeamInvitation{},
+		mostMembers:  map[uint]int{team.ID: 1},
+	}
+}
+
+func (s *memoryStore) memberCount(teamID uint) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.members[teamID])
+}
+
+// memoryTx holds the writes of a transaction until it commits. A nil tx reads
+// and writes the store directly, like the repositories used outside a unit of
+// work.
+type memoryTx struct {
+	store        *memoryStore
+	locked       []*sync.Mutex
+	members      []teamEntity.TeamMember
+	applications []uint
+	invitations  []uint
+}
+
+func (tx *memoryTx) commit() {
+	tx.store.mu.Lock()
+	defer tx.store.mu.Unlock()
+
+	for _, member := range tx.members {
+		tx.store.members[member.TeamID] = append(tx.store.members[member.TeamID], member)
+		if count := len(tx.store.members[member.TeamID]); count > tx.store.mostMembers[member.TeamID] {
+			tx.store.mostMembers[member.TeamID] = count
+		}
+	}
+
+	for _, id := range tx.applications {
+		application := tx.store.applications[id]
+		application.AcceptanceStatus = 1
+		tx.store.applications[id] = application
+	}
+
+	for _, id := range tx.invitations {
+		invitation := tx.store.invitations[id]
+		invitation.Status = teamEntity.InvitationStatusAccepted
+		tx.store.invitations[id] = invitation
+	}
+}
+
+func (tx *memoryTx) unlock() {
+	for _, lock := range tx.locked {
+		lock.Unlock()
+	}
+}
+
+type memoryUnitOfWork struct {
+	store *memoryStore
+}
+
+func (u *memoryUnitOfWork) Do(fn func(repos unitofwork.Repositories) error) error {
+	tx := &memoryTx{store: u.store}
+	defer tx.unlock()
+
+	err := fn(unitofwork.Repositories{
+		Teams:        &memoryTeamRepository{store: u.store, tx: tx},
+		Recruitments: &memoryRecruitmentRepository{store: u.store, tx: tx},
+	})
+	if err != nil {
+		return err
+	}
+
+	tx.commit()
+	return nil
+}
+
+// memoryTeamRepository implements the methods the join flows use, calling any
+// other one panics on the nil embedded interface.
+type memoryTeamRepository struct {
+	teamRepo.TeamRepository
+	store *memoryStore
+	tx    *memoryTx
+}
+
+func (r *memoryTeamRepository) GetTeamForUpdate(teamID uint) (teamEntity.Team, error) {
+	r.store.mu.Lock()
+	lock, ok := r.store.teamLocks[teamID]
+	r.store.mu.Unlock()
+	if !ok {
+		return teamEntity.Team{}, errors.New("record not found")
+	}
+
+	lock.Lock()
+	r.tx.locked = append(r.tx.locked, lock)
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.store.teams[teamID], nil
+}
+
+func (r *memoryTeamRepository) GetTeamMember(teamID uint, userID uint) (teamEntity.TeamMember, error) {
+	r.store.mu.Lock()
+	members := append([]teamEntity.TeamMember{}, r.store.members[teamID]...)
+	r.store.mu.Unlock()
+	if r.tx != nil {
+		members = append(members, r.tx.members...)
+	}
+
+	for _, member := range members {
+		if member.TeamID == teamID && member.UserID == userID {
+			return member, nil
+		}
+	}
+
+	return teamEntity.TeamMember{}, nil
+}
+
+func (r *memoryTeamRepository) CountTeamMembers(teamID uint) (int64, error) {
+	r.store.mu.Lock()
+	count := len(r.store.members[teamID])
+	r.store.mu.Unlock()
+
+	// give the other transactions a chance to interleave between the count
+	// and the write that follows it
+	runtime.Gosched()
+
+	return int64(count), nil
+}
+
+func (r *memoryTeamRepository) AddTeamMember(userID uint, teamID uint, role string) error {
+	r.tx.members = append(r.tx.members, teamEntity.TeamMember{TeamID: teamID, UserID: userID, Role: role})
+	return nil
+}
+
+func (r *memoryTeamRepository) GetTeamInvitationByID(id uint) (teamEntity.TeamInvitation, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	invitation, ok := r.store.invitations[id]
+	if !ok {
+		return teamEntity.TeamInvitation{}, errors.New("record not found")
+	}
+
+	return invitation, nil
+}
+
+func (r *memoryTeamRepository) AcceptTeamInvitation(invitation teamEntity.TeamInvitation, userID uint) error {
+	r.store.mu.Lock()
+	status := r.store.invitations[invitation.ID].Status
+	r.store.mu.Unlock()
+	if status != teamEntity.InvitationStatusPending {
+		return errors.New("no rows affected")
+	}
+
+	r.tx.invitations = append(r.tx.invitations, invitation.ID)
+	return r.AddTeamMember(userID, invitation.TeamID, teamEntity.TeamRoleMember)
+}
+
+type memoryRecruitmentRepository struct {
+	recruitmentRepo.RecruitmentRepository
+	store *memoryStore
+	tx    *memoryTx
+}
+
+func (r *memoryRecruitmentRepository) GetRecruitmentApplicationByID(id uint) (recruitmentEntity.RecruitmentApplication, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	application, ok := r.store.applications[id]
+	if !ok {
+		return recruitmentEntity.RecruitmentApplication{}, errors.New("record not found")
+	}
+
+	return application, nil
+}
+
+func (r *memoryRecruitmentRepository) AcceptRecruitmentApplication(id uint) error {
+	r.store.mu.Lock()
+	status := r.store.applications[id].AcceptanceStatus
+	r.store.mu.Unlock()
+	if status != 0 {
+		return errors.New("no rows affected")
+	}
+
+	for _, accepted := range r.tx.applications {
+		if accepted == id {
+			return errors.New("no rows affected")
+		}
+	}
+
+	r.tx.applications = append(r.tx.applications, id)
+	return nil
+}
+
+type memoryUserRepository struct {
+	userRepo.UserRepository
+}
+
+func (r *memoryUserRepository) GetUserByID(id uint) (userEntity.User, error) {
+	return userEntity.User{ID: id}, nil
+}
+
+// managerPolicy lets every user manage every team, the tests are about
+// capacity, not permissions.
+type managerPolicy struct {
+	policy.Policy
+}
+
+func (p *managerPolicy) CanManageTeam(userID uint, teamID uint) error {
+	return nil
+}
+
+const (
+	testTeamID  = uint(1)
+	testOwnerID = uint(1)
+)
+
+func newRecruitmentUseCase(store *memoryStore) recruitmentUseCase.RecruitmentUseCase {
+	return recruitmentUseCase.CreateNewRecruitmentUseCase(&memoryRecruitmentRepository{store: store}, &memoryTeamRepository{store: store}, &managerPolicy{}, &memoryUnitOfWork{store: store})
+}
+
+func newTeamUseCase(store *memoryStore) teamUseCase.TeamUseCase {
+	return teamUseCase.CreateNewTeamUseCase(&memoryTeamRepository{store: store}, &memoryUserRepository{}, &managerPolicy{}, nil, nil, nil, &memoryUnitOfWork{store: store})
+}
+
+// addApplications adds a pending application for each of the users
+// testOwnerID+1 to testOwnerID+count and returns their IDs.
+func addApplications(store *memoryStore, count int) []uint {
+	var ids []uint
+	for i := 1; i <= count; i++ {
+		id := uint(i)
+		store.applications[id] = recruitmentEntity.RecruitmentApplication{
+			ID:            id,
+			UserID:        testOwnerID + id,
+			RecruitmentID: 1,
+			Recruitment:   recruitmentEntity.Recruitment{ID: 1, TeamID: testTeamID},
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// result counts the outcomes of concurrent accepts by error message.
+type result struct {
+	mu       sync.Mutex
+	outcomes map[string]int
+}
+
+func (r *result) record(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.outcomes == nil {
+		r.outcomes = map[string]int{}
+	}
+
+	if err != nil {
+		r.outcomes[err.Error()]++
+	} else {
+		r.outcomes["accepted"]++
+	}
+}
+
+func TestAcceptRecruitmentApplicationsConcurrently(t *testing.T) {
+	for _, capacity := range []uint{1, 2, 5, 10} {
+		t.Run(fmt.Sprintf("capacity-%d", capacity), func(t *testing.T) {
+			store := newMemoryStore(teamEntity.Team{ID: testTeamID, Capacity: capacity}, testOwnerID)
+			applicationIDs := addApplications(store, 50)
+			ruc := newRecruitmentUseCase(store)
+
+			var wg sync.WaitGroup
+			var res result
+			start := make(chan struct{})
+			for _, id := range applicationIDs {
+				wg.Add(1)
+				go func(id uint) {
+					defer wg.Done()
+					<-start
+					res.record(ruc.AcceptRecruitmentApplication(id, testOwnerID))
+				}(id)
+			}
+			close(start)
+			wg.Wait()
+
+			assert.Equal(t, int(capacity), store.memberCount(testTeamID))
+			assert.Equal(t, int(capacity), store.mostMembers[testTeamID])
+			assert.Equal(t, int(capacity)-1, res.outcomes["accepted"])
+			assert.Equal(t, len(applicationIDs)-int(capacity)+1, res.outcomes["The team is full"])
+
+			accepted := 0
+			for _, application := range store.applications {
+				if application.AcceptanceStatus == 1 {
+					accepted++
+				}
+			}
+			assert.Equal(t, int(capacity)-1, accepted, "an application is only accepted along with its member")
+		})
+	}
+}
+
+func TestAcceptSameRecruitmentApplicationConcurrently(t *testing.T) {
+	store := newMemoryStore(teamEntity.Team{ID: testTeamID, Capacity: 10}, testOwnerID)
+	addApplications(store, 1)
+	ruc := newRecruitmentUseCase(store)
+
+	var wg sync.WaitGroup
+	var res result
+	start := make(chan struct{})
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			res.record(ruc.AcceptRecruitmentApplication(1, testOwnerID))
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, 2, store.memberCount(testTeamID))
+	assert.Equal(t, 1, res.outcomes["accepted"])
+	assert.Equal(t, 19, res.outcomes["already a team member"])
+}
+
+func TestAcceptApplicationsAndInvitationsConcurrently(t *testing.T) {
+	const capacity = 4
+	store := newMemoryStore(teamEntity.Team{ID: testTeamID, Capacity: capacity}, testOwnerID)
+	applicationIDs := addApplications(store, 25)
+	var invitationIDs []uint
+	for i := 1; i <= 25; i++ {
+		id := uint(i)
+		inviteeID := testOwnerID + 100 + id
+		store.invitations[id] = teamEntity.TeamInvitation{
+			ID:        id,
+			TeamID:    testTeamID,
+			InviterID: testOwnerID,
+			InviteeID: &inviteeID,
+			Status:    teamEntity.InvitationStatusPending,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+		invitationIDs = append(invitationIDs, id)
+	}
+	ruc := newRecruitmentUseCase(store)
+	tuc := newTeamUseCase(store)
+
+	var wg sync.WaitGroup
+	var res result
+	start := make(chan struct{})
+	for i := range applicationIDs {
+		wg.Add(2)
+		go func(id uint) {
+			defer wg.Done()
+			<-start
+			res.record(ruc.AcceptRecruitmentApplication(id, testOwnerID))
+		}(applicationIDs[i])
+		go func(id uint) {
+			defer wg.Done()
+			<-start
+			res.record(tuc.AcceptTeamInvitation(id, testOwnerID+100+id))
+		}(invitationIDs[i])
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, capacity, store.memberCount(testTeamID))
+	assert.Equal(t, capacity, store.mostMembers[testTeamID])
+	assert.Equal(t, capacity-1, res.outcomes["accepted"])
+	assert.Equal(t, len(applicationIDs)+len(invitationIDs)-capacity+1, res.outcomes["The team is full"])
+}
@@ -0,0 +1,86 @@
+package unitofwork_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	teamRepo "github.com/alimikegami/compnouron/internal/mocks/team/repository"
+	userRepo "github.com/alimikegami/compnouron/internal/mocks/user/repository"
+	teamEntity "github.com/alimikegami/compnouron/internal/team/entity"
+	teamUseCase "github.com/alimikegami/compnouron/internal/team/usecase"
+	"github.com/alimikegami/compnouron/internal/unitofwork"
+	userEntity "github.com/alimikegami/compnouron/internal/user/entity"
+	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// TestAcceptTeamInvitationLocksTeam accepts an invitation through the real
+// unit of work and repositories, and checks that the team row is selected FOR
+// UPDATE inside the transaction before the members are counted and added.
+func TestAcceptTeamInvitationLocksTeam(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	defer mockedDB.Close()
+
+	inviteeID := uint(2)
+	invitation := teamEntity.TeamInvitation{
+		ID:        3,
+		TeamID:    1,
+		InviterID: 1,
+		InviteeID: &inviteeID,
+		Status:    teamEntity.InvitationStatusPending,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockTeam := teamRepo.NewTeamRepository(t)
+		mockUser := userRepo.NewUserRepository(t)
+		mockTeam.On("GetTeamInvitationByID", uint(3)).Return(invitation, nil).Once()
+		mockUser.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2}, nil).Once()
+		tuc := teamUseCase.CreateNewTeamUseCase(mockTeam, mockUser, nil, nil, nil, nil, unitofwork.CreateNewUnitOfWork(db))
+
+		mockObj.ExpectBegin()
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `teams` WHERE `teams`.`id` = ? ORDER BY `teams`.`id` LIMIT 1 FOR UPDATE")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "capacity"}).AddRow(1, "Team 1", 4))
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `team_members` WHERE team_id = ? AND user_id = ? LIMIT 1")).WithArgs(1, 2).WillReturnRows(sqlmock.NewRows([]string{"team_id", "user_id", "role"}))
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `team_members` WHERE team_id = ?")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(3))
+		mockObj.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `team_invitations` SET `invitee_id`=?,`responded_at`=?,`status`=?,`updated_at`=? WHERE id = ? AND status = ?")).WithArgs(2, utils.AnyTime{}, "accepted", utils.AnyTime{}, 3, "pending").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `team_members` (`team_id`,`user_id`,`role`,`created_at`,`updated_at`) VALUES (?,?,?,?,?)")).WithArgs(1, 2, "member", utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(5, 1))
+		mockObj.ExpectCommit()
+
+		err := tuc.AcceptTeamInvitation(3, 2)
+		assert.NoError(t, err)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+
+	t.Run("full", func(t *testing.T) {
+		mockTeam := teamRepo.NewTeamRepository(t)
+		mockUser := userRepo.NewUserRepository(t)
+		mockTeam.On("GetTeamInvitationByID", uint(3)).Return(invitation, nil).Once()
+		mockUser.On("GetUserByID", uint(2)).Return(userEntity.User{ID: 2}, nil).Once()
+		tuc := teamUseCase.CreateNewTeamUseCase(mockTeam, mockUser, nil, nil, nil, nil, unitofwork.CreateNewUnitOfWork(db))
+
+		mockObj.ExpectBegin()
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `teams` WHERE `teams`.`id` = ? ORDER BY `teams`.`id` LIMIT 1 FOR UPDATE")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "capacity"}).AddRow(1, "Team 1", 4))
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `team_members` WHERE team_id = ? AND user_id = ? LIMIT 1")).WithArgs(1, 2).WillReturnRows(sqlmock.NewRows([]string{"team_id", "user_id", "role"}))
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `team_members` WHERE team_id = ?")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(4))
+		mockObj.ExpectRollback()
+
+		err := tuc.AcceptTeamInvitation(3, 2)
+		assert.EqualError(t, err, "The team is full")
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,101 @@
+package unitofwork_test
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alimikegami/compnouron/db/migration"
+	recruitmentEntity "github.com/alimikegami/compnouron/internal/recruitment/entity"
+	recruitmentRepo "github.com/alimikegami/compnouron/internal/recruitment/repository"
+	recruitmentUseCase "github.com/alimikegami/compnouron/internal/recruitment/usecase"
+	teamEntity "github.com/alimikegami/compnouron/internal/team/entity"
+	teamRepo "github.com/alimikegami/compnouron/internal/team/repository"
+	"github.com/alimikegami/compnouron/internal/unitofwork"
+	userEntity "github.com/alimikegami/compnouron/internal/user/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// TestAcceptRecruitmentApplicationsOnMySQL accepts many applicants of the same
+// team at once against a real MySQL database, so unlike the in-memory tests it
+// checks that the FOR UPDATE lock itself keeps the team within its capacity.
+// It only runs when TEST_MYSQL_DSN is set, e.g.
+// "user:password@tcp(localhost:3306)/compnouron_test?parseTime=True". The
+// database is migrated and the rows the test adds are removed afterwards.
+func TestAcceptRecruitmentApplicationsOnMySQL(t *testing.T) {
+	dsn := os.Getenv("TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("TEST_MYSQL_DSN is not set")
+	}
+
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening the database connection", err)
+	}
+	migration.Migrate(db)
+
+	const capacity = 5
+	const applicants = 30
+	suffix := time.Now().UnixNano()
+
+	var userIDs []uint
+	owner := userEntity.User{Name: "Owner", Email: fmt.Sprintf("owner-%d@compnouron.test", suffix)}
+	assert.NoError(t, db.Create(&owner).Error)
+	userIDs = append(userIDs, owner.ID)
+
+	team := teamEntity.Team{Name: fmt.Sprintf("Team %d", suffix), Description: "Lock test", Capacity: capacity}
+	assert.NoError(t, db.Create(&team).Error)
+	assert.NoError(t, db.Create(&teamEntity.TeamMember{TeamID: team.ID, UserID: owner.ID, Role: teamEntity.TeamRoleOwner}).Error)
+
+	recruitment := recruitmentEntity.Recruitment{Role: "Backend", Description: "Lock test", TeamID: team.ID}
+	assert.NoError(t, db.Create(&recruitment).Error)
+
+	t.Cleanup(func() {
+		db.Where("team_id = ?", team.ID).Delete(&teamEntity.TeamMember{})
+		db.Where("recruitment_id = ?", recruitment.ID).Delete(&recruitmentEntity.RecruitmentApplication{})
+		db.Delete(&recruitmentEntity.Recruitment{}, recruitment.ID)
+		db.Delete(&teamEntity.Team{}, team.ID)
+		db.Delete(&userEntity.User{}, userIDs)
+	})
+
+	var applicationIDs []uint
+	for i := 0; i < applicants; i++ {
+		applicant := userEntity.User{Name: "Applicant", Email: fmt.Sprintf("applicant-%d-%d@compnouron.test", i, suffix)}
+		assert.NoError(t, db.Create(&applicant).Error)
+		userIDs = append(userIDs, applicant.ID)
+
+		application := recruitmentEntity.RecruitmentApplication{UserID: applicant.ID, RecruitmentID: recruitment.ID}
+		assert.NoError(t, db.Create(&application).Error)
+		applicationIDs = append(applicationIDs, application.ID)
+	}
+
+	ruc := recruitmentUseCase.CreateNewRecruitmentUseCase(recruitmentRepo.CreateNewRecruitmentRepository(db), teamRepo.CreateNewTeamRepository(db), &managerPolicy{}, unitofwork.CreateNewUnitOfWork(db))
+
+	var wg sync.WaitGroup
+	var res result
+	start := make(chan struct{})
+	for _, id := range applicationIDs {
+		wg.Add(1)
+		go func(id uint) {
+			defer wg.Done()
+			<-start
+			res.record(ruc.AcceptRecruitmentApplication(id, owner.ID))
+		}(id)
+	}
+	close(start)
+	wg.Wait()
+	t.Log(res.outcomes)
+
+	var memberCount int64
+	assert.NoError(t, db.Model(&teamEntity.TeamMember{}).Where("team_id = ?", team.ID).Count(&memberCount).Error)
+	assert.LessOrEqual(t, memberCount, int64(capacity))
+
+	var accepted int64
+	assert.NoError(t, db.Model(&recruitmentEntity.RecruitmentApplication{}).Where("recruitment_id = ? AND acceptance_status = ?", recruitment.ID, 1).Count(&accepted).Error)
+	assert.Equal(t, memberCount-1, accepted, "an application is only accepted along with its member")
+	assert.Equal(t, res.outcomes["accepted"], int(accepted))
+}
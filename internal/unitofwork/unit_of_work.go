@@ -0,0 +1,45 @@
+// Package unitofwork runs a group of repository writes in one database
+// transaction, so they are either all applied or none is.
+//
+// Writes that must not exceed the capacity of a team lock the team row first
+// with TeamRepository.GetTeamForUpdate and only then count the members. The
+// lock is held until the transaction ends, so concurrent writes to the same
+// team run one after the other and each one sees the members the previous
+// one added.
+package unitofwork
+
+import (
+	recruitmentRepo "github.com/alimikegami/compnouron/internal/recruitment/repository"
+	teamRepo "github.com/alimikegami/compnouron/internal/team/repository"
+	"gorm.io/gorm"
+)
+
+// Repositories are bound to the transaction of the unit of work. They must not
+// be used once the function given to Do has returned.
+type Repositories struct {
+	Teams        teamRepo.TeamRepository
+	Recruitments recruitmentRepo.RecruitmentRepository
+}
+
+type UnitOfWork interface {
+	// Do runs fn in a transaction, which is committed when fn returns nil and
+	// rolled back otherwise. The error of fn is returned as is.
+	Do(fn func(repos Repositories) error) error
+}
+
+type UnitOfWorkImpl struct {
+	db *gorm.DB
+}
+
+func CreateNewUnitOfWork(db *gorm.DB) UnitOfWork {
+	return &UnitOfWorkImpl{db: db}
+}
+
+func (u *UnitOfWorkImpl) Do(fn func(repos Repositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(Repositories{
+			Teams:        teamRepo.CreateNewTeamRepository(tx),
+			Recruitments: recruitmentRepo.CreateNewRecruitmentRepository(tx),
+		})
+	})
+}
@@ -0,0 +1,66 @@
+package unitofwork
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alimikegami/compnouron/internal/team/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestDo(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	uow := CreateNewUnitOfWork(db)
+
+	defer mockedDB.Close()
+
+	t.Run("commit", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "capacity"}).AddRow(1, "Team 1", 4)
+		mockObj.ExpectBegin()
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `teams` WHERE `teams`.`id` = ? ORDER BY `teams`.`id` LIMIT 1 FOR UPDATE")).WithArgs(1).WillReturnRows(rows)
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `team_members`")).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockObj.ExpectCommit()
+
+		err := uow.Do(func(repos Repositories) error {
+			_, err := repos.Teams.GetTeamForUpdate(1)
+			if err != nil {
+				return err
+			}
+
+			return repos.Teams.AddTeamMember(2, 1, entity.TeamRoleMember)
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "capacity"}).AddRow(1, "Team 1", 4)
+		mockObj.ExpectBegin()
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `teams` WHERE `teams`.`id` = ? ORDER BY `teams`.`id` LIMIT 1 FOR UPDATE")).WithArgs(1).WillReturnRows(rows)
+		mockObj.ExpectRollback()
+
+		err := uow.Do(func(repos Repositories) error {
+			_, err := repos.Teams.GetTeamForUpdate(1)
+			if err != nil {
+				return err
+			}
+
+			return errors.New("The team is full")
+		})
+		assert.EqualError(t, err, "The team is full")
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+}
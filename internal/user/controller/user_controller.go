@@ -1,12 +1,19 @@
 package controller
 
 import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/alimikegami/compnouron/internal/media"
 	"github.com/alimikegami/compnouron/internal/user/dto"
 	"github.com/alimikegami/compnouron/internal/user/usecase"
+	"github.com/alimikegami/compnouron/pkg/password"
 	"github.com/alimikegami/compnouron/pkg/response"
 	"github.com/alimikegami/compnouron/pkg/utils"
 	"github.com/labstack/echo/v4"
@@ -21,9 +28,45 @@ type UserController struct {
 func (uc *UserController) InitializeUserRoute(config middleware.JWTConfig) {
 	uc.router.POST("/users", uc.CreateUser)
 	uc.router.POST("/users/login", uc.Login)
+	uc.router.POST("/users/login/2fa", uc.LoginWithTwoFactor)
+	uc.router.GET("/users/oidc/:provider/login", uc.StartOIDCLogin)
+	uc.router.GET("/users/oidc/:provider/callback", uc.CompleteOIDCLogin)
+	uc.router.POST("/users/token/refresh", uc.RefreshToken)
+	uc.router.POST("/users/logout", uc.Logout)
+	uc.router.GET("/users/verify", uc.VerifyEmail)
+	uc.router.POST("/users/verify/resend", uc.ResendVerificationEmail, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.POST("/users/password/forgot", uc.ForgotPassword)
+	uc.router.POST("/users/password/reset", uc.ResetPassword)
+	uc.router.GET("/users/me", uc.GetUserDetails, utils.JWTWithScope(config, utils.ScopeProfileRead))
+	uc.router.PUT("/users/me", uc.UpdateUser, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.DELETE("/users/me", uc.DeleteAccount, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.GET("/users/me/export", uc.ExportUserData, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.POST("/users/me/2fa/setup", uc.SetupTwoFactor, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.POST("/users/me/2fa/enable", uc.EnableTwoFactor, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.POST("/users/me/2fa/disable", uc.DisableTwoFactor, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.PUT("/users/me/privacy", uc.UpdatePrivacySettings, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.PUT("/users/me/avatar", uc.UploadAvatar, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.DELETE("/users/me/avatar", uc.DeleteAvatar, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.PUT("/users/me/password", uc.ChangePassword, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.GET("/users/me/sessions", uc.GetSessions, middleware.JWTWithConfig(config))
+	uc.router.DELETE("/users/me/sessions", uc.RevokeOtherSessions, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.DELETE("/users/me/sessions/:id", uc.RevokeSession, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.GET("/users/me/tokens", uc.GetPersonalAccessTokens, middleware.JWTWithConfig(config))
+	uc.router.POST("/users/me/tokens", uc.CreatePersonalAccessToken, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.DELETE("/users/me/tokens/:id", uc.RevokePersonalAccessToken, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.POST("/users/me/skills", uc.AddUserSkill, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.DELETE("/users/me/skills/:id", uc.RemoveUserSkill, middleware.JWTWithConfig(config), utils.ForbidImpersonation)
+	uc.router.GET("/users/search", uc.SearchUsers, middleware.JWTWithConfig(config))
+	uc.router.PUT("/users/:id/role", uc.UpdateUserRole, middleware.JWTWithConfig(config), utils.RequireRole(utils.RoleAdmin))
+	uc.router.GET("/users/lockouts", uc.GetActiveLockouts, middleware.JWTWithConfig(config), utils.RequireRole(utils.RoleAdmin))
+	uc.router.POST("/users/:id/unlock", uc.UnlockUser, middleware.JWTWithConfig(config), utils.RequireRole(utils.RoleAdmin))
+	uc.router.POST("/users/:id/impersonate", uc.ImpersonateUser, middleware.JWTWithConfig(config), utils.RequireRole(utils.RoleAdmin))
+	uc.router.GET("/users/impersonations", uc.GetImpersonations, middleware.JWTWithConfig(config), utils.RequireRole(utils.RoleAdmin))
+	uc.router.DELETE("/users/me/impersonation", uc.EndImpersonation, middleware.JWTWithConfig(config))
 	uc.router.GET("/users/:id/competitions", uc.GetCompetitionsData)
-	uc.router.GET("/users/competitions/registrations", uc.GetCompetitionRegistrationHistory, middleware.JWTWithConfig(config))
-	uc.router.GET("/users/recruitments/applications", uc.GetRecruitmentApplicationHistory, middleware.JWTWithConfig(config))
+	uc.router.GET("/users/:id/achievements", uc.GetAchievements)
+	uc.router.GET("/users/competitions/registrations", uc.GetCompetitionRegistrationHistory, utils.JWTWithScope(config, utils.ScopeRegistrationsRead))
+	uc.router.GET("/users/recruitments/applications", uc.GetRecruitmentApplicationHistory, utils.JWTWithScope(config, utils.ScopeRecruitmentsRead))
 }
 
 // CreateUser godoc
@@ -34,12 +77,1333 @@ func (uc *UserController) InitializeUserRoute(config middleware.JWTConfig) {
 // @Produce      json
 // @Param data body dto.UserRegistrationRequest true "Request Body"
 // @Success      201  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response{data=[]password.Violation}
+// @Failure      500  {object}  response.Response
+// @Router       /users [post]
+func (uc *UserController) CreateUser(c echo.Context) error {
+	u := new(dto.UserRegistrationRequest)
+	if err := c.Bind(u); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	err := uc.userUC.CreateUser(u)
+	if err != nil {
+		fmt.Println(err)
+		if violationErr, ok := err.(*password.ViolationError); ok {
+			return c.JSON(http.StatusBadRequest, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    violationErr.Violations,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusCreated, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// Login godoc
+// @Summary      Login
+// @Description  Given the credentials, authenticate the credentials and returns the JWT token if the credentials matched the record in the database. When the user has two-factor authentication enabled, a challenge token for /users/login/2fa is returned instead. Repeated failures slow down and then temporarily lock the account and the client's IP address
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param data body dto.Credential true "Request Body"
+// @Success      200  {object}   response.Response{data=dto.TokenResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      429  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/login [post]
+func (uc *UserController) Login(c echo.Context) error {
+	credential := new(dto.Credential)
+	if err := c.Bind(credential); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	tokens, err := uc.userUC.Login(credential, c.RealIP(), c.Request().UserAgent())
+	if err != nil {
+		var statusCode int
+		fmt.Println(err)
+		if err.Error() == "credentials dont match" {
+			statusCode = 403
+		} else if err.Error() == "too many login attempts, try again later" {
+			statusCode = http.StatusTooManyRequests
+		} else {
+			statusCode = 500
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    tokens,
+	})
+}
+
+// LoginWithTwoFactor godoc
+// @Summary      Complete a two-factor login
+// @Description  Given the challenge token returned by the login and a code from the authenticator app or an unused recovery code, returns the JWT token
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param data body dto.TwoFactorLoginRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=dto.TokenResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      429  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/login/2fa [post]
+func (uc *UserController) LoginWithTwoFactor(c echo.Context) error {
+	loginRequest := new(dto.TwoFactorLoginRequest)
+	if err := c.Bind(loginRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	tokens, err := uc.userUC.LoginWithTwoFactor(*loginRequest, c.RealIP(), c.Request().UserAgent())
+	if err != nil {
+		var statusCode int
+		fmt.Println(err)
+		if err.Error() == "invalid challenge token" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "invalid two-factor code" {
+			statusCode = http.StatusForbidden
+		} else if err.Error() == "too many login attempts, try again later" {
+			statusCode = http.StatusTooManyRequests
+		} else {
+			statusCode = 500
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    tokens,
+	})
+}
+
+// oidcFlowCookie keeps the state of a login with an identity provider between
+// the redirect to the provider and the callback
+const oidcFlowCookie = "oidc_flow"
+
+// StartOIDCLogin godoc
+// @Summary      Login with an identity provider
+// @Description  Redirect to the sign in page of the given OpenID Connect provider. The provider redirects back to /users/oidc/{provider}/callback
+// @Tags         Users
+// @Param provider path string true "Identity provider"
+// @Success      302
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/oidc/{provider}/login [get]
+func (uc *UserController) StartOIDCLogin(c echo.Context) error {
+	provider := c.Param("provider")
+	authorization, err := uc.userUC.StartOIDCLogin(provider)
+	if err != nil {
+		fmt.Println(err)
+		if err.Error() == "unknown identity provider" {
+			return c.JSON(http.StatusNotFound, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	c.SetCookie(&http.Cookie{
+		Name:     oidcFlowCookie,
+		Value:    authorization.FlowState,
+		Path:     "/users/oidc/" + provider,
+		MaxAge:   600,
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+	return c.Redirect(http.StatusFound, authorization.AuthorizationURL)
+}
+
+// CompleteOIDCLogin godoc
+// @Summary      Finish a login with an identity provider
+// @Description  Handle the redirect back from the OpenID Connect provider and return the JWT token, or a challenge token when the user has two-factor authentication enabled. The provider's account is linked to the user with the same verified email, and a new user is created when there is none
+// @Tags         Users
+// @Produce      json
+// @Param provider path string true "Identity provider"
+// @Param code query string true "Authorization code"
+// @Param state query string true "State"
+// @Success      200  {object}   response.Response{data=dto.TokenResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/oidc/{provider}/callback [get]
+func (uc *UserController) CompleteOIDCLogin(c echo.Context) error {
+	provider := c.Param("provider")
+	callbackRequest := new(dto.OIDCCallbackRequest)
+	if err := c.Bind(callbackRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	// the flow state only ever comes from the cookie set by StartOIDCLogin, so a
+	// callback URL crafted by someone else can't finish their login in this browser
+	callbackRequest.FlowState = ""
+	if cookie, err := c.Cookie(oidcFlowCookie); err == nil {
+		callbackRequest.FlowState = cookie.Value
+	}
+	c.SetCookie(&http.Cookie{
+		Name:     oidcFlowCookie,
+		Path:     "/users/oidc/" + provider,
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+	tokens, err := uc.userUC.CompleteOIDCLogin(provider, *callbackRequest, c.RealIP(), c.Request().UserAgent())
+	if err != nil {
+		var statusCode int
+		fmt.Println(err)
+		if err.Error() == "unknown identity provider" {
+			statusCode = http.StatusNotFound
+		} else if err.Error() == "invalid login state" {
+			statusCode = http.StatusBadRequest
+		} else if err.Error() == "identity provider login failed" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "the identity provider has not verified this email" || err.Error() == "verify the email of your account before signing in with this provider" {
+			statusCode = http.StatusForbidden
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    tokens,
+	})
+}
+
+// RefreshToken godoc
+// @Summary      Refresh the access token
+// @Description  Given a refresh token, revoke it and return a new access token along with a new refresh token. Presenting a refresh token that has already been used revokes every token issued from the same login
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param data body dto.RefreshTokenRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=dto.TokenResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/token/refresh [post]
+func (uc *UserController) RefreshToken(c echo.Context) error {
+	refreshTokenRequest := new(dto.RefreshTokenRequest)
+	if err := c.Bind(refreshTokenRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	tokens, err := uc.userUC.RefreshToken(refreshTokenRequest.RefreshToken)
+	if err != nil {
+		fmt.Println(err)
+		if err.Error() == "invalid refresh token" || err.Error() == "refresh token reused" || err.Error() == "refresh token expired" {
+			return c.JSON(http.StatusUnauthorized, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    tokens,
+	})
+}
+
+// Logout godoc
+// @Summary      Logout
+// @Description  Given a refresh token, revoke it together with every token issued from the same login
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param data body dto.RefreshTokenRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/logout [post]
+func (uc *UserController) Logout(c echo.Context) error {
+	refreshTokenRequest := new(dto.RefreshTokenRequest)
+	if err := c.Bind(refreshTokenRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	err := uc.userUC.Logout(refreshTokenRequest.RefreshToken)
+	if err != nil {
+		fmt.Println(err)
+		if err.Error() == "invalid refresh token" {
+			return c.JSON(http.StatusUnauthorized, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// VerifyEmail godoc
+// @Summary      Verify email address
+// @Description  Given the verification token that was emailed to the user, mark the user's email address as verified
+// @Tags         Users
+// @Produce      json
+// @Param        token     query      string     true  "verification token"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/verify [get]
+func (uc *UserController) VerifyEmail(c echo.Context) error {
+	token := c.QueryParam("token")
+	err := uc.userUC.VerifyEmail(token)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "invalid verification token" {
+			statusCode = http.StatusBadRequest
+		} else if err.Error() == "email already verified" {
+			statusCode = http.StatusConflict
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// ResendVerificationEmail godoc
+// @Summary      Resend the verification email
+// @Description  Given the user ID on the JWT Token, send a new verification email to that user
+// @Tags         Users
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/verify/resend [post]
+func (uc *UserController) ResendVerificationEmail(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	err := uc.userUC.ResendVerificationEmail(userID)
+	if err != nil {
+		fmt.Println(err)
+		if err.Error() == "email already verified" {
+			return c.JSON(http.StatusConflict, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// ForgotPassword godoc
+// @Summary      Request a password reset
+// @Description  Given the email address, send a one-time password reset link to that address if it belongs to a registered user. The response is the same whether or not the address is registered
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param data body dto.ForgotPasswordRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/password/forgot [post]
+func (uc *UserController) ForgotPassword(c echo.Context) error {
+	forgotPasswordRequest := new(dto.ForgotPasswordRequest)
+	if err := c.Bind(forgotPasswordRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	err := uc.userUC.ForgotPassword(forgotPasswordRequest.Email)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// ResetPassword godoc
+// @Summary      Reset password
+// @Description  Given the reset token that was emailed to the user and a new password, replace the user's password and sign the user out of every device
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param data body dto.ResetPasswordRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response{data=[]password.Violation}
+// @Failure      500  {object}  response.Response
+// @Router       /users/password/reset [post]
+func (uc *UserController) ResetPassword(c echo.Context) error {
+	resetPasswordRequest := new(dto.ResetPasswordRequest)
+	if err := c.Bind(resetPasswordRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	err := uc.userUC.ResetPassword(*resetPasswordRequest)
+	if err != nil {
+		fmt.Println(err)
+		if violationErr, ok := err.(*password.ViolationError); ok {
+			return c.JSON(http.StatusBadRequest, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    violationErr.Violations,
+			})
+		}
+		if err.Error() == "invalid reset token" || err.Error() == "fill your new password" {
+			return c.JSON(http.StatusBadRequest, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// GetUserDetails godoc
+// @Summary      Get the profile of the logged in user
+// @Description  Given the user ID on the JWT Token, returns the profile of that user along with the user's skills
+// @Tags         Users
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Success      200  {object}   response.Response{data=dto.UserDetailsResponse,status=string,message=string}
+// @Failure      500  {object}  response.Response
+// @Router       /users/me [get]
+func (uc *UserController) GetUserDetails(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	result, err := uc.userUC.GetUserDetails(userID)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    result,
+	})
+}
+
+// UpdateUser godoc
+// @Summary      Update the profile of the logged in user
+// @Description  Given the request body and the user ID on the JWT Token, update that user's profile. Changing the email address marks it as unverified and sends a new verification email
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param data body dto.UserUpdateRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/me [put]
+func (uc *UserController) UpdateUser(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	user := new(dto.UserUpdateRequest)
+	if err := c.Bind(user); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	err := uc.userUC.UpdateUser(userID, *user)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "fill your name and email" {
+			statusCode = http.StatusBadRequest
+		} else if err.Error() == "email is already registered" {
+			statusCode = http.StatusConflict
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// UpdatePrivacySettings godoc
+// @Summary      Change who may see the logged in user's contact details
+// @Description  Set the visibility of the email and the phone number to public, team, organizers or private, whether the user shows up in talent search and whether the user is looking for a team. A field left empty keeps its current value
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param data body dto.PrivacySettingsRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/me/privacy [put]
+func (uc *UserController) UpdatePrivacySettings(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	privacySettingsRequest := new(dto.PrivacySettingsRequest)
+	if err := c.Bind(privacySettingsRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	err := uc.userUC.UpdatePrivacySettings(userID, *privacySettingsRequest)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "invalid visibility" {
+			statusCode = http.StatusBadRequest
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// SearchUsers godoc
+// @Summary      Find users by skill, institution and availability
+// @Description  Returns the public profiles of the users matching the filters, leaving out users who opted out of search. Contact details are redacted according to each user's privacy settings
+// @Tags         Users
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param skills query string false "comma separated skill IDs"
+// @Param match query string false "any (default) or all of the skills"
+// @Param institutionID query int false "institution ID"
+// @Param available query bool false "only users looking for a team"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Success      200  {object}   response.Response{data=[]dto.UserProfileResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/search [get]
+func (uc *UserController) SearchUsers(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	userSearchRequest, err := userSearchParams(c)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	result, err := uc.userUC.SearchUsers(userID, userSearchRequest)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "invalid offset" {
+			statusCode = http.StatusBadRequest
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    result,
+	})
+}
+
+// userSearchParams reads the talent search filters from the query string.
+func userSearchParams(c echo.Context) (dto.UserSearchRequest, error) {
+	var request dto.UserSearchRequest
+	if skills := c.QueryParam("skills"); skills != "" {
+		for _, skill := range strings.Split(skills, ",") {
+			skillID, err := strconv.ParseUint(strings.TrimSpace(skill), 10, 64)
+			if err != nil {
+				return dto.UserSearchRequest{}, errors.New("invalid skills")
+			}
+			request.SkillIDs = append(request.SkillIDs, uint(skillID))
+		}
+	}
+
+	if match := c.QueryParam("match"); match == "all" {
+		request.MatchAllSkills = true
+	} else if match != "" && match != "any" {
+		return dto.UserSearchRequest{}, errors.New("invalid match")
+	}
+
+	if institutionID := c.QueryParam("institutionID"); institutionID != "" {
+		id, err := strconv.ParseUint(institutionID, 10, 64)
+		if err != nil {
+			return dto.UserSearchRequest{}, errors.New("invalid institution")
+		}
+		request.InstitutionID = uint(id)
+	}
+
+	if available := c.QueryParam("available"); available != "" {
+		availableOnly, err := strconv.ParseBool(available)
+		if err != nil {
+			return dto.UserSearchRequest{}, errors.New("invalid availability")
+		}
+		request.AvailableOnly = availableOnly
+	}
+
+	if limit := c.QueryParam("limit"); limit != "" {
+		limitInt, err := strconv.Atoi(limit)
+		if err != nil {
+			return dto.UserSearchRequest{}, err
+		}
+		request.Limit = limitInt
+	}
+
+	if offset := c.QueryParam("offset"); offset != "" {
+		offsetInt, err := strconv.Atoi(offset)
+		if err != nil {
+			return dto.UserSearchRequest{}, err
+		}
+		request.Offset = offsetInt
+	}
+
+	return request, nil
+}
+
+// ChangePassword godoc
+// @Summary      Change the password of the logged in user
+// @Description  Given the current and the new password, replace the user's password and sign the user out of every device
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param data body dto.PasswordChangeRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response{data=[]password.Violation}
+// @Failure      403  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/me/password [put]
+func (uc *UserController) ChangePassword(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	passwordChangeRequest := new(dto.PasswordChangeRequest)
+	if err := c.Bind(passwordChangeRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	err := uc.userUC.ChangePassword(userID, *passwordChangeRequest)
+	if err != nil {
+		fmt.Println(err)
+		if violationErr, ok := err.(*password.ViolationError); ok {
+			return c.JSON(http.StatusBadRequest, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    violationErr.Violations,
+			})
+		}
+		var statusCode int
+		if err.Error() == "fill your new password" {
+			statusCode = http.StatusBadRequest
+		} else if err.Error() == "wrong password" {
+			statusCode = http.StatusForbidden
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// ExportUserData godoc
+// @Summary      Export the data of the logged in user
+// @Description  Stream a ZIP archive of JSON documents with everything stored about the user on the JWT Token: profile, skills, team memberships, competition registrations, recruitment applications, organized competitions, sessions, lockout events, discussion threads, replies, mentions, personal access tokens, linked identities, achievements, team invitations and the actions admins took while impersonating the user
+// @Tags         Users
+// @Produce      application/zip
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Success      200  {file}  file
+// @Failure      500  {object}  response.Response
+// @Router       /users/me/export [get]
+func (uc *UserController) ExportUserData(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	export, err := uc.userUC.ExportUserData(userID)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, "application/zip")
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="compnouron-export.zip"`)
+	c.Response().WriteHeader(http.StatusOK)
+	return writeUserDataArchive(c.Response(), export)
+}
+
+// writeUserDataArchive writes every part of the export as its own JSON document
+// straight into w, so the archive is never held in memory as a whole.
+func writeUserDataArchive(w io.Writer, export dto.UserDataExport) error {
+	documents := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"skills.json", export.Skills},
+		{"team_memberships.json", export.TeamMemberships},
+		{"competition_registrations.json", export.CompetitionRegistrations},
+		{"recruitment_applications.json", export.RecruitmentApplications},
+		{"organized_competitions.json", export.OrganizedCompetitions},
+		{"sessions.json", export.Sessions},
+		{"lockout_events.json", export.LockoutEvents},
+		{"threads.json", export.Threads},
+		{"replies.json", export.Replies},
+		{"mentions.json", export.Mentions},
+		{"personal_access_tokens.json", export.PersonalAccessTokens},
+		{"identities.json", export.Identities},
+		{"achievements.json", export.Achievements},
+		{"invitations_sent.json", export.InvitationsSent},
+		{"invitations_received.json", export.InvitationsReceived},
+		{"impersonation_actions.json", export.ImpersonationActions},
+	}
+
+	archive := zip.NewWriter(w)
+	for _, document := range documents {
+		file, err := archive.Create(document.name)
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(document.data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// DeleteAccount godoc
+// @Summary      Delete the account of the logged in user
+// @Description  Given the user's password, anonymize the personal data of the user on the JWT Token. The competitions and applications the user took part in are kept, the user leaves their teams. An owner has to transfer the ownership first
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param data body dto.AccountDeletionRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/me [delete]
+func (uc *UserController) DeleteAccount(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	deletionRequest := new(dto.AccountDeletionRequest)
+	if err := c.Bind(deletionRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	err := uc.userUC.DeleteAccount(userID, *deletionRequest)
+	if err != nil {
+		fmt.Println(err)
+		if err.Error() == "wrong password" {
+			return c.JSON(http.StatusForbidden, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		if err.Error() == "transfer the ownership of your teams before deleting the account" {
+			return c.JSON(http.StatusConflict, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// SetupTwoFactor godoc
+// @Summary      Start two-factor authentication enrollment
+// @Description  Generate a new TOTP secret for the user on the JWT Token and return it along with the otpauth provisioning URI to show as a QR code. Two-factor authentication stays disabled until it is confirmed with a code
+// @Tags         Users
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Success      200  {object}   response.Response{data=dto.TwoFactorSetupResponse,status=string,message=string}
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/me/2fa/setup [post]
+func (uc *UserController) SetupTwoFactor(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	setup, err := uc.userUC.SetupTwoFactor(userID)
+	if err != nil {
+		fmt.Println(err)
+		if err.Error() == "two-factor authentication is already enabled" {
+			return c.JSON(http.StatusConflict, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    setup,
+	})
+}
+
+// EnableTwoFactor godoc
+// @Summary      Enable two-factor authentication
+// @Description  Given a code from the authenticator app, enable two-factor authentication for the user on the JWT Token and return the one-time recovery codes. The recovery codes are only shown once
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param data body dto.TwoFactorCodeRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=dto.RecoveryCodesResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/me/2fa/enable [post]
+func (uc *UserController) EnableTwoFactor(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	codeRequest := new(dto.TwoFactorCodeRequest)
+	if err := c.Bind(codeRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	recoveryCodes, err := uc.userUC.EnableTwoFactor(userID, *codeRequest)
+	if err != nil {
+		var statusCode int
+		fmt.Println(err)
+		if err.Error() == "two-factor authentication is not set up" || err.Error() == "invalid two-factor code" {
+			statusCode = http.StatusBadRequest
+		} else if err.Error() == "two-factor authentication is already enabled" {
+			statusCode = http.StatusConflict
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    recoveryCodes,
+	})
+}
+
+// DisableTwoFactor godoc
+// @Summary      Disable two-factor authentication
+// @Description  Given the user's password and a code from the authenticator app or a recovery code, disable two-factor authentication for the user on the JWT Token and discard the recovery codes
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param data body dto.TwoFactorDisableRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/me/2fa/disable [post]
+func (uc *UserController) DisableTwoFactor(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	disableRequest := new(dto.TwoFactorDisableRequest)
+	if err := c.Bind(disableRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	err := uc.userUC.DisableTwoFactor(userID, *disableRequest)
+	if err != nil {
+		var statusCode int
+		fmt.Println(err)
+		if err.Error() == "two-factor authentication is not enabled" {
+			statusCode = http.StatusBadRequest
+		} else if err.Error() == "wrong password" || err.Error() == "invalid two-factor code" {
+			statusCode = http.StatusForbidden
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// GetSessions godoc
+// @Summary      List the active sessions of the logged in user
+// @Description  Returns every device the user on the JWT Token is still signed in on, most recently used first. The session of the current request is marked as current
+// @Tags         Users
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Success      200  {object}   response.Response{data=[]dto.SessionResponse,status=string,message=string}
+// @Failure      500  {object}  response.Response
+// @Router       /users/me/sessions [get]
+func (uc *UserController) GetSessions(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	result, err := uc.userUC.GetSessions(userID, utils.GetSessionID(c))
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    result,
+	})
+}
+
+// RevokeOtherSessions godoc
+// @Summary      Sign out of every other device
+// @Description  Revokes every session of the user on the JWT Token except the one of the current request
+// @Tags         Users
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      500  {object}  response.Response
+// @Router       /users/me/sessions [delete]
+func (uc *UserController) RevokeOtherSessions(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	err := uc.userUC.RevokeOtherSessions(userID, utils.GetSessionID(c))
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// RevokeSession godoc
+// @Summary      Sign out of a device
+// @Description  Given the session ID on the path parameter, revoke that session of the user on the JWT Token. Its access and refresh tokens stop working immediately
+// @Tags         Users
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Session ID"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/me/sessions/{id} [delete]
+func (uc *UserController) RevokeSession(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	err = uc.userUC.RevokeSession(userID, uint(sessionID))
+	if err != nil {
+		fmt.Println(err)
+		if err.Error() == "session not found" {
+			return c.JSON(http.StatusNotFound, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// CreatePersonalAccessToken godoc
+// @Summary      Create a personal access token
+// @Description  Given the request body, create a personal access token for the user on the JWT Token. The token is only returned once. Scripts send it as a Bearer token and can only call the endpoints its scopes allow
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param data body dto.PersonalAccessTokenRequest true "Request Body"
+// @Success      201  {object}   response.Response{data=dto.CreatedPersonalAccessTokenResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/me/tokens [post]
+func (uc *UserController) CreatePersonalAccessToken(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	tokenRequest := new(dto.PersonalAccessTokenRequest)
+	if err := c.Bind(tokenRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	result, err := uc.userUC.CreatePersonalAccessToken(userID, *tokenRequest)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "fill the token name" || err.Error() == "fill the token scopes" || err.Error() == "invalid scope" || err.Error() == "invalid token lifetime" {
+			statusCode = http.StatusBadRequest
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusCreated, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    result,
+	})
+}
+
+// GetPersonalAccessTokens godoc
+// @Summary      List the personal access tokens of the logged in user
+// @Description  Returns the tokens of the user on the JWT Token that have not been revoked, without the tokens themselves
+// @Tags         Users
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Success      200  {object}   response.Response{data=[]dto.PersonalAccessTokenResponse,status=string,message=string}
+// @Failure      500  {object}  response.Response
+// @Router       /users/me/tokens [get]
+func (uc *UserController) GetPersonalAccessTokens(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	result, err := uc.userUC.GetPersonalAccessTokens(userID)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    result,
+	})
+}
+
+// RevokePersonalAccessToken godoc
+// @Summary      Revoke a personal access token
+// @Description  Given the token ID on the path parameter, revoke that personal access token of the user on the JWT Token
+// @Tags         Users
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Token ID"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/me/tokens/{id} [delete]
+func (uc *UserController) RevokePersonalAccessToken(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	tokenID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	err = uc.userUC.RevokePersonalAccessToken(userID, uint(tokenID))
+	if err != nil {
+		fmt.Println(err)
+		if err.Error() == "token not found" {
+			return c.JSON(http.StatusNotFound, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// AddUserSkill godoc
+// @Summary      Add a skill to the logged in user
+// @Description  Given the request body and the user ID on the JWT Token, add a catalog skill to that user or update its proficiency. Unknown skill names are added to the catalog
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param data body dto.UserSkillRequest true "Request Body"
+// @Success      201  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/me/skills [post]
+func (uc *UserController) AddUserSkill(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	skill := new(dto.UserSkillRequest)
+	if err := c.Bind(skill); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	err := uc.userUC.AddUserSkill(userID, *skill)
+	if err != nil {
+		fmt.Println(err)
+		if err.Error() == "fill the skill name" || err.Error() == "proficiency must be between 1 and 5" {
+			return c.JSON(http.StatusBadRequest, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusCreated, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// RemoveUserSkill godoc
+// @Summary      Remove a skill from the logged in user
+// @Description  Given the skill ID on the path parameter, remove that skill from the user on the JWT Token
+// @Tags         Users
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "Skill ID"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
 // @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
 // @Failure      500  {object}  response.Response
-// @Router       /users [post]
-func (uc *UserController) CreateUser(c echo.Context) error {
-	u := new(dto.UserRegistrationRequest)
-	if err := c.Bind(u); err != nil {
+// @Router       /users/me/skills/{id} [delete]
+func (uc *UserController) RemoveUserSkill(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	skillID := c.Param("id")
+	skillIDUint, err := strconv.ParseUint(skillID, 10, 32)
+	if err != nil {
 		fmt.Println(err)
 		return c.JSON(http.StatusBadRequest, response.Response{
 			Status:  "error",
@@ -47,36 +1411,59 @@ func (uc *UserController) CreateUser(c echo.Context) error {
 			Data:    nil,
 		})
 	}
-	err := uc.userUC.CreateUser(u)
+	err = uc.userUC.RemoveUserSkill(userID, uint(skillIDUint))
 	if err != nil {
 		fmt.Println(err)
+		if err.Error() == "skill not found" {
+			return c.JSON(http.StatusNotFound, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, response.Response{
 			Status:  "error",
 			Message: err.Error(),
 			Data:    nil,
 		})
 	}
-	return c.JSON(http.StatusCreated, response.Response{
+	return c.JSON(http.StatusOK, response.Response{
 		Status:  "success",
 		Message: nil,
 		Data:    nil,
 	})
 }
 
-// Login godoc
-// @Summary      Login
-// @Description  Given the credentials, authenticate the credentials and returns the JWT token if the credentials matched the record in the database
+// UpdateUserRole godoc
+// @Summary      Change a user's role
+// @Description  Given the user ID on the path parameter and the role on the request body, change the role of that user. Only admins can call this endpoint
 // @Tags         Users
 // @Accept       json
 // @Produce      json
-// @Param data body dto.Credential true "Request Body"
-// @Success      200  {object}   response.Response{data=dto.TokenResponse,status=string,message=string}
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "User ID"
+// @Param data body dto.RoleRequest true "Request Body"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
 // @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      403  {object}  response.Response
 // @Failure      500  {object}  response.Response
-// @Router       /users/login [post]
-func (uc *UserController) Login(c echo.Context) error {
-	credential := new(dto.Credential)
-	if err := c.Bind(credential); err != nil {
+// @Router       /users/{id}/role [put]
+func (uc *UserController) UpdateUserRole(c echo.Context) error {
+	adminID, _ := utils.GetUserDetails(c)
+	userID := c.Param("id")
+	userIDUint, err := strconv.ParseUint(userID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	roleRequest := new(dto.RoleRequest)
+	if err := c.Bind(roleRequest); err != nil {
 		fmt.Println(err)
 		return c.JSON(http.StatusBadRequest, response.Response{
 			Status:  "error",
@@ -84,14 +1471,168 @@ func (uc *UserController) Login(c echo.Context) error {
 			Data:    nil,
 		})
 	}
-	token, err := uc.userUC.Login(credential)
+	err = uc.userUC.UpdateUserRole(adminID, uint(userIDUint), roleRequest.Role)
 	if err != nil {
+		fmt.Println(err)
 		var statusCode int
+		if err.Error() == "invalid role" || err.Error() == "can't change your own role" {
+			statusCode = http.StatusBadRequest
+		} else if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// GetActiveLockouts godoc
+// @Summary      Get the active login lockouts
+// @Description  Returns the accounts and IP addresses that are currently locked because of repeated failed logins. Only admins can call this endpoint
+// @Tags         Users
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Success      200  {object}   response.Response{data=[]dto.LockoutEventResponse,status=string,message=string}
+// @Failure      401  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/lockouts [get]
+func (uc *UserController) GetActiveLockouts(c echo.Context) error {
+	adminID, _ := utils.GetUserDetails(c)
+	lockouts, err := uc.userUC.GetActiveLockouts(adminID)
+	if err != nil {
 		fmt.Println(err)
-		if err.Error() == "credentials dont match" {
-			statusCode = 403
+		if err.Error() == "action unauthorized" {
+			return c.JSON(http.StatusUnauthorized, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    lockouts,
+	})
+}
+
+// UnlockUser godoc
+// @Summary      Unlock a locked account
+// @Description  Given the user ID on the path parameter, clear the failed logins of that user's account so they can log in again. Only admins can call this endpoint
+// @Tags         Users
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "User ID"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/{id}/unlock [post]
+func (uc *UserController) UnlockUser(c echo.Context) error {
+	adminID, _ := utils.GetUserDetails(c)
+	userID := c.Param("id")
+	userIDUint, err := strconv.ParseUint(userID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	err = uc.userUC.UnlockUser(adminID, uint(userIDUint))
+	if err != nil {
+		fmt.Println(err)
+		if err.Error() == "action unauthorized" {
+			return c.JSON(http.StatusUnauthorized, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// ImpersonateUser godoc
+// @Summary      Impersonate a user
+// @Description  Given the user ID on the path parameter, returns a short-lived token that lets the admin on the JWT Token see the API as that user, to reproduce what they see. The token names the admin as its actor, every request made with it is recorded and it can't be used to change the credentials or the account of the user. Admins can't be impersonated. Only admins can call this endpoint
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param id path int true "User ID"
+// @Param data body dto.ImpersonationRequest true "Request Body"
+// @Success      201  {object}   response.Response{data=dto.ImpersonationTokenResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/{id}/impersonate [post]
+func (uc *UserController) ImpersonateUser(c echo.Context) error {
+	adminID, _ := utils.GetUserDetails(c)
+	userID := c.Param("id")
+	userIDUint, err := strconv.ParseUint(userID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	impersonationRequest := new(dto.ImpersonationRequest)
+	if err := c.Bind(impersonationRequest); err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	result, err := uc.userUC.ImpersonateUser(adminID, utils.GetSessionID(c), uint(userIDUint), *impersonationRequest, c.RealIP())
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "fill the impersonation reason" {
+			statusCode = http.StatusBadRequest
+		} else if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "admins can't be impersonated" {
+			statusCode = http.StatusForbidden
+		} else if err.Error() == "record not found" {
+			statusCode = http.StatusNotFound
 		} else {
-			statusCode = 500
+			statusCode = http.StatusInternalServerError
 		}
 		return c.JSON(statusCode, response.Response{
 			Status:  "error",
@@ -99,13 +1640,82 @@ func (uc *UserController) Login(c echo.Context) error {
 			Data:    nil,
 		})
 	}
+	return c.JSON(http.StatusCreated, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    result,
+	})
+}
+
+// EndImpersonation godoc
+// @Summary      End an impersonation
+// @Description  Revokes the impersonation token on the JWT Token before it expires
+// @Tags         Users
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/me/impersonation [delete]
+func (uc *UserController) EndImpersonation(c echo.Context) error {
+	if !utils.IsImpersonating(c) {
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: "not impersonating",
+			Data:    nil,
+		})
+	}
+	err := uc.userUC.EndImpersonation(utils.GetImpersonationID(c))
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
+// GetImpersonations godoc
+// @Summary      Get the impersonation audit log
+// @Description  Returns every impersonation, latest first, with the requests made during it. Only admins can call this endpoint
+// @Tags         Users
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Success      200  {object}   response.Response{data=[]dto.ImpersonationResponse,status=string,message=string}
+// @Failure      401  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/impersonations [get]
+func (uc *UserController) GetImpersonations(c echo.Context) error {
+	adminID, _ := utils.GetUserDetails(c)
+	impersonations, err := uc.userUC.GetImpersonations(adminID)
+	if err != nil {
+		fmt.Println(err)
+		if err.Error() == "action unauthorized" {
+			return c.JSON(http.StatusUnauthorized, response.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    nil,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
 	return c.JSON(http.StatusOK, response.Response{
 		Status:  "success",
 		Message: nil,
-		Data: dto.TokenResponse{
-			Token:     token,
-			TokenType: "JWT",
-		},
+		Data:    impersonations,
 	})
 }
 
@@ -172,6 +1782,43 @@ func (uc *UserController) GetCompetitionsData(c echo.Context) error {
 	})
 }
 
+// GetAchievements godoc
+// @Summary      Get the achievement portfolio of a user
+// @Description  Given the user ID on the path parameter, returns the published competition results the user took part in, on their own or as a team member
+// @Tags         Users
+// @Produce      json
+// @Param id path int true "User ID"
+// @Success      200  {object}   response.Response{data=[]dto.AchievementResponse,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/{id}/achievements [get]
+func (uc *UserController) GetAchievements(c echo.Context) error {
+	userID := c.Param("id")
+	userIDUint, err := strconv.ParseUint(userID, 10, 32)
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusBadRequest, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	result, err := uc.userUC.GetAchievements(uint(userIDUint))
+	if err != nil {
+		fmt.Println(err)
+		return c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    result,
+	})
+}
+
 // GetRecruitmentApplicationHistory godoc
 // @Summary      Get the history recruitment application histories of a user
 // @Description  Given the user ID on the JWT Token, returns the recruitment application histories of that user
@@ -199,6 +1846,106 @@ func (uc *UserController) GetRecruitmentApplicationHistory(c echo.Context) error
 	})
 }
 
+// UploadAvatar godoc
+// @Summary      Upload avatar
+// @Description  Replaces the avatar of the logged in user with the uploaded image and returns its URLs, a thumbnail is generated as well
+// @Tags         Users
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Param image formData file true "JPEG, PNG or GIF image"
+// @Success      200  {object}   response.Response{data=media.Image,status=string,message=string}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      413  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/me/avatar [put]
+func (uc *UserController) UploadAvatar(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+	data, err := utils.ReadUploadedFile(c, "image", media.MaxUploadBytes)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "file too large" {
+			statusCode = http.StatusRequestEntityTooLarge
+		} else {
+			statusCode = http.StatusBadRequest
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	result, err := uc.userUC.UploadAvatar(userID, data)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "file too large" {
+			statusCode = http.StatusRequestEntityTooLarge
+		} else if err.Error() == "unsupported image type" || err.Error() == "invalid image" || err.Error() == "image dimensions too large" {
+			statusCode = http.StatusBadRequest
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    result,
+	})
+}
+
+// DeleteAvatar godoc
+// @Summary      Delete avatar
+// @Description  Removes the avatar of the logged in user
+// @Tags         Users
+// @Produce      json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer"
+// @Success      200  {object}   response.Response{data=string,status=string,message=string}
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      500  {object}  response.Response
+// @Router       /users/me/avatar [delete]
+func (uc *UserController) DeleteAvatar(c echo.Context) error {
+	userID, _ := utils.GetUserDetails(c)
+
+	err := uc.userUC.DeleteAvatar(userID)
+	if err != nil {
+		fmt.Println(err)
+		var statusCode int
+		if err.Error() == "action unauthorized" {
+			statusCode = http.StatusUnauthorized
+		} else if err.Error() == "avatar not found" {
+			statusCode = http.StatusNotFound
+		} else {
+			statusCode = http.StatusInternalServerError
+		}
+		return c.JSON(statusCode, response.Response{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    nil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Response{
+		Status:  "success",
+		Message: nil,
+		Data:    nil,
+	})
+}
+
 func CreateNewUserController(e *echo.Echo, userUC usecase.UserUseCase) *UserController {
 	return &UserController{router: e, userUC: userUC}
 }
@@ -1,19 +1,25 @@
 package controller
 
 import (
+	"archive/zip"
 	"bytes"
 	"encoding/json"
 	"errors"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	compDto "github.com/alimikegami/compnouron/internal/competition/dto"
+	"github.com/alimikegami/compnouron/internal/media"
 	mocks "github.com/alimikegami/compnouron/internal/mocks/user/usecase"
 	"github.com/alimikegami/compnouron/internal/user/dto"
+	"github.com/alimikegami/compnouron/pkg/password"
 	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestCreateUser(t *testing.T) {
@@ -24,7 +30,7 @@ func TestCreateUser(t *testing.T) {
 		PhoneNumber:       "081111111111",
 		Password:          "asdfasfas",
 		SchoolInstitution: "Udayana University",
-		Skills: []dto.SkillRequest{
+		Skills: []dto.UserSkillRequest{
 			{
 				Name: "Node.J",
 			},
@@ -38,7 +44,7 @@ func TestCreateUser(t *testing.T) {
 		PhoneNumber:       "081111111111",
 		Password:          "asdfasfas",
 		SchoolInstitution: "Udayana University",
-		Skills: []dto.SkillRequest{
+		Skills: []dto.UserSkillRequest{
 			{
 				Name: "Node.J",
 			},
@@ -76,7 +82,7 @@ func TestCreateUserDuplicateEmail(t *testing.T) {
 		PhoneNumber:       "081111111111",
 		Password:          "asdfasfas",
 		SchoolInstitution: "Udayana University",
-		Skills: []dto.SkillRequest{
+		Skills: []dto.UserSkillRequest{
 			{
 				Name: "Node.J",
 			},
@@ -90,7 +96,7 @@ func TestCreateUserDuplicateEmail(t *testing.T) {
 		PhoneNumber:       "081111111111",
 		Password:          "asdfasfas",
 		SchoolInstitution: "Udayana University",
-		Skills: []dto.SkillRequest{
+		Skills: []dto.UserSkillRequest{
 			{
 				Name: "Node.J",
 			},
@@ -125,7 +131,11 @@ func TestLogin(t *testing.T) {
 	mockUseCase.On("Login", &dto.Credential{
 		Email:    "sdafsfa@gmail.com",
 		Password: "asdfasfas",
-	}).Return("sdafasfasfsafasdfasdfasfasfasdf", nil)
+	}, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(dto.TokenResponse{
+		Token:        "sdafasfasfsafasdfasdfasfasfasdf",
+		TokenType:    "JWT",
+		RefreshToken: "qwerqwerqwerqwerqwer",
+	}, nil)
 
 	// construct request body
 	reqBody := dto.Credential{
@@ -161,7 +171,7 @@ func TestLoginWrongCredentials(t *testing.T) {
 	mockUseCase.On("Login", &dto.Credential{
 		Email:    "sdafsfa@gmail.com",
 		Password: "asdfasfas1",
-	}).Return("", errors.New("credentials dont match"))
+	}, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(dto.TokenResponse{}, errors.New("credentials dont match"))
 
 	// construct request body
 	reqBody := dto.Credential{
@@ -192,6 +202,209 @@ func TestLoginWrongCredentials(t *testing.T) {
 	mockUseCase.AssertExpectations(t)
 }
 
+func TestLoginTooManyAttempts(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	mockUseCase.On("Login", &dto.Credential{
+		Email:    "sdafsfa@gmail.com",
+		Password: "asdfasfas1",
+	}, "10.0.0.1", mock.AnythingOfType("string")).Return(dto.TokenResponse{}, errors.New("too many login attempts, try again later"))
+
+	reqBody := dto.Credential{
+		Email:    "sdafsfa@gmail.com",
+		Password: "asdfasfas1",
+	}
+
+	jsonReqBody, err := json.Marshal(&reqBody)
+	assert.NoError(t, err, "No marshaling error")
+
+	req, err := http.NewRequest(http.MethodPost, "/users/login", bytes.NewBuffer(jsonReqBody))
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	req.RemoteAddr = "10.0.0.1:51234"
+
+	assert.NoError(t, err, "No request error")
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	testUserController := UserController{
+		router: e,
+		userUC: mockUseCase,
+	}
+
+	testUserController.Login(c)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestLoginWithTwoFactor(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		reqBody := dto.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "123456"}
+		mockUseCase.On("LoginWithTwoFactor", reqBody, "10.0.0.1", mock.AnythingOfType("string")).Return(dto.TokenResponse{
+			Token:        "sdafasfasfsafasdfasdfasfasfasdf",
+			TokenType:    "JWT",
+			RefreshToken: "qwerqwerqwerqwerqwer",
+		}, nil).Once()
+		jsonReqBody, err := json.Marshal(&reqBody)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPost, "/users/login/2fa", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		req.RemoteAddr = "10.0.0.1:51234"
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.LoginWithTwoFactor(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid-code", func(t *testing.T) {
+		reqBody := dto.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "000000"}
+		mockUseCase.On("LoginWithTwoFactor", reqBody, "10.0.0.1", mock.AnythingOfType("string")).Return(dto.TokenResponse{}, errors.New("invalid two-factor code")).Once()
+		jsonReqBody, err := json.Marshal(&reqBody)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPost, "/users/login/2fa", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		req.RemoteAddr = "10.0.0.1:51234"
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.LoginWithTwoFactor(c)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid-challenge-token", func(t *testing.T) {
+		reqBody := dto.TwoFactorLoginRequest{ChallengeToken: "expired", Code: "123456"}
+		mockUseCase.On("LoginWithTwoFactor", reqBody, "10.0.0.1", mock.AnythingOfType("string")).Return(dto.TokenResponse{}, errors.New("invalid challenge token")).Once()
+		jsonReqBody, err := json.Marshal(&reqBody)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPost, "/users/login/2fa", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		req.RemoteAddr = "10.0.0.1:51234"
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.LoginWithTwoFactor(c)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestStartOIDCLogin(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("StartOIDCLogin", "google").Return(dto.OIDCAuthorization{
+			AuthorizationURL: "https://accounts.google.com/o/oauth2/v2/auth?state=state",
+			FlowState:        "encrypted",
+		}, nil).Once()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/oidc/:provider/login")
+		c.SetParamNames("provider")
+		c.SetParamValues("google")
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.StartOIDCLogin(c)
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "https://accounts.google.com/o/oauth2/v2/auth?state=state", rec.Header().Get("Location"))
+		assert.Contains(t, rec.Header().Get("Set-Cookie"), "oidc_flow=encrypted")
+		assert.Contains(t, rec.Header().Get("Set-Cookie"), "HttpOnly")
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("unknown-provider", func(t *testing.T) {
+		mockUseCase.On("StartOIDCLogin", "facebook").Return(dto.OIDCAuthorization{}, errors.New("unknown identity provider")).Once()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/oidc/:provider/login")
+		c.SetParamNames("provider")
+		c.SetParamValues("facebook")
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.StartOIDCLogin(c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestCompleteOIDCLogin(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("CompleteOIDCLogin", "google", dto.OIDCCallbackRequest{Code: "code", State: "state", FlowState: "encrypted"}, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(dto.TokenResponse{
+			Token:        "sdafasfasfsafasdfasdfasfasfasdf",
+			TokenType:    "JWT",
+			RefreshToken: "qwerqwerqwerqwerqwer",
+		}, nil).Once()
+		req, err := http.NewRequest(http.MethodGet, "/users/oidc/google/callback?code=code&state=state", nil)
+		assert.NoError(t, err, "No request error")
+		req.AddCookie(&http.Cookie{Name: "oidc_flow", Value: "encrypted"})
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/oidc/:provider/callback")
+		c.SetParamNames("provider")
+		c.SetParamValues("google")
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.CompleteOIDCLogin(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("flow-state-only-from-cookie", func(t *testing.T) {
+		mockUseCase.On("CompleteOIDCLogin", "google", dto.OIDCCallbackRequest{Code: "code", State: "state"}, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(dto.TokenResponse{}, errors.New("invalid login state")).Once()
+		req, err := http.NewRequest(http.MethodGet, "/users/oidc/google/callback?code=code&state=state&FlowState=encrypted", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/oidc/:provider/callback")
+		c.SetParamNames("provider")
+		c.SetParamValues("google")
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.CompleteOIDCLogin(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
 func TestGetRecruitmentApplicationHistory(t *testing.T) {
 	mockUseCase := mocks.NewUserUseCase(t)
 	// setup the endpoint
@@ -209,7 +422,7 @@ func TestGetRecruitmentApplicationHistory(t *testing.T) {
 		e := echo.New()
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
-		token := utils.CreateJWTToken(1, "gmail@gmail.com")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		// setup controller/handler
 		userController := UserController{
@@ -230,7 +443,7 @@ func TestGetRecruitmentApplicationHistory(t *testing.T) {
 		e := echo.New()
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
-		token := utils.CreateJWTToken(1, "gmail@gmail.com")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		// setup controller/handler
 		userController := UserController{
@@ -262,7 +475,7 @@ func TestGetCompetitionRegistrationHistory(t *testing.T) {
 		e := echo.New()
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
-		token := utils.CreateJWTToken(1, "gmail@gmail.com")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		// setup controller/handler
 		userController := UserController{
@@ -283,7 +496,7 @@ func TestGetCompetitionRegistrationHistory(t *testing.T) {
 		e := echo.New()
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
-		token := utils.CreateJWTToken(1, "gmail@gmail.com")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
 		c.Set("user", token)
 		// setup controller/handler
 		userController := UserController{
@@ -353,3 +566,1327 @@ func TestGetCompetitionsData(t *testing.T) {
 		mockUseCase.AssertExpectations(t)
 	})
 }
+
+func TestRefreshToken(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("RefreshToken", "qwerqwerqwerqwerqwer").Return(dto.TokenResponse{
+			Token:        "sdafasfasfsafasdfasdfasfasfasdf",
+			TokenType:    "JWT",
+			RefreshToken: "zxcvzxcvzxcvzxcvzxcv",
+		}, nil).Once()
+		jsonReqBody, err := json.Marshal(&dto.RefreshTokenRequest{RefreshToken: "qwerqwerqwerqwerqwer"})
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPost, "/users/token/refresh", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.RefreshToken(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("token-reused", func(t *testing.T) {
+		mockUseCase.On("RefreshToken", "qwerqwerqwerqwerqwer").Return(dto.TokenResponse{}, errors.New("refresh token reused")).Once()
+		jsonReqBody, err := json.Marshal(&dto.RefreshTokenRequest{RefreshToken: "qwerqwerqwerqwerqwer"})
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPost, "/users/token/refresh", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.RefreshToken(c)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	mockUseCase.On("Logout", "qwerqwerqwerqwerqwer").Return(nil).Once()
+	jsonReqBody, err := json.Marshal(&dto.RefreshTokenRequest{RefreshToken: "qwerqwerqwerqwerqwer"})
+	assert.NoError(t, err, "No marshaling error")
+	req, err := http.NewRequest(http.MethodPost, "/users/logout", bytes.NewBuffer(jsonReqBody))
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	assert.NoError(t, err, "No request error")
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	userController := UserController{
+		router: e,
+		userUC: mockUseCase,
+	}
+
+	userController.Logout(c)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestVerifyEmail(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("VerifyEmail", "verification-token").Return(nil).Once()
+		req, err := http.NewRequest(http.MethodGet, "/users/verify?token=verification-token", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.VerifyEmail(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid-token", func(t *testing.T) {
+		mockUseCase.On("VerifyEmail", "verification-token").Return(errors.New("invalid verification token")).Once()
+		req, err := http.NewRequest(http.MethodGet, "/users/verify?token=verification-token", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.VerifyEmail(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestForgotPassword(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	mockUseCase.On("ForgotPassword", "asdfa@gmail.com").Return(nil).Once()
+	jsonReqBody, err := json.Marshal(&dto.ForgotPasswordRequest{Email: "asdfa@gmail.com"})
+	assert.NoError(t, err, "No marshaling error")
+	req, err := http.NewRequest(http.MethodPost, "/users/password/forgot", bytes.NewBuffer(jsonReqBody))
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	assert.NoError(t, err, "No request error")
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	userController := UserController{
+		router: e,
+		userUC: mockUseCase,
+	}
+
+	userController.ForgotPassword(c)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestResetPassword(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	resetPasswordRequest := dto.ResetPasswordRequest{Token: "reset-token", Password: "newpassword"}
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("ResetPassword", resetPasswordRequest).Return(nil).Once()
+		jsonReqBody, err := json.Marshal(&resetPasswordRequest)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPost, "/users/password/reset", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.ResetPassword(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid-token", func(t *testing.T) {
+		mockUseCase.On("ResetPassword", resetPasswordRequest).Return(errors.New("invalid reset token")).Once()
+		jsonReqBody, err := json.Marshal(&resetPasswordRequest)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPost, "/users/password/reset", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.ResetPassword(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestUpdateUserRole(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("UpdateUserRole", uint(1), uint(2), "organizer").Return(nil).Once()
+		jsonReqBody, err := json.Marshal(&dto.RoleRequest{Role: "organizer"})
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPut, "/", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/:id/role")
+		c.SetParamNames("id")
+		c.SetParamValues("2")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleAdmin, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.UpdateUserRole(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid-role", func(t *testing.T) {
+		mockUseCase.On("UpdateUserRole", uint(1), uint(2), "superuser").Return(errors.New("invalid role")).Once()
+		jsonReqBody, err := json.Marshal(&dto.RoleRequest{Role: "superuser"})
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPut, "/", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/:id/role")
+		c.SetParamNames("id")
+		c.SetParamValues("2")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleAdmin, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.UpdateUserRole(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("role-middleware-rejects-non-admin", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPut, "/", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleOrganizer, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		utils.RequireRole(utils.RoleAdmin)(userController.UpdateUserRole)(c)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
+func TestUnlockUser(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("UnlockUser", uint(1), uint(2)).Return(nil).Once()
+		req, err := http.NewRequest(http.MethodPost, "/", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/:id/unlock")
+		c.SetParamNames("id")
+		c.SetParamValues("2")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleAdmin, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.UnlockUser(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("not-admin", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/:id/unlock")
+		c.SetParamNames("id")
+		c.SetParamValues("2")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		utils.RequireRole(utils.RoleAdmin)(userController.UnlockUser)(c)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
+func TestGetActiveLockouts(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	mockUseCase.On("GetActiveLockouts", uint(1)).Return([]dto.LockoutEventResponse{
+		{
+			ID:        1,
+			Email:     "sdafsfa@gmail.com",
+			IPAddress: "10.0.0.1",
+			Scope:     "account",
+		},
+	}, nil).Once()
+	req, err := http.NewRequest(http.MethodGet, "/users/lockouts", nil)
+	assert.NoError(t, err, "No request error")
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleAdmin, 1)
+	c.Set("user", token)
+	userController := UserController{
+		router: e,
+		userUC: mockUseCase,
+	}
+
+	userController.GetActiveLockouts(c)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGetUserDetails(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	mockUseCase.On("GetUserDetails", uint(1)).Return(dto.UserDetailsResponse{
+		ID:    1,
+		Name:  "Alim Ikegami",
+		Email: "gmail@gmail.com",
+		Skills: []dto.UserSkillResponse{
+			{
+				ID:          1,
+				Name:        "Node.js",
+				Proficiency: 3,
+			},
+		},
+	}, nil).Once()
+	req, err := http.NewRequest(http.MethodGet, "/users/me", nil)
+	assert.NoError(t, err, "No request error")
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+	c.Set("user", token)
+	userController := UserController{
+		router: e,
+		userUC: mockUseCase,
+	}
+
+	userController.GetUserDetails(c)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestUpdateUser(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	userUpdateRequest := dto.UserUpdateRequest{
+		Name:              "Alim Ikegami",
+		Email:             "taken@gmail.com",
+		PhoneNumber:       "081111111111",
+		SchoolInstitution: "Udayana University",
+	}
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("UpdateUser", uint(1), userUpdateRequest).Return(nil).Once()
+		jsonReqBody, err := json.Marshal(&userUpdateRequest)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPut, "/users/me", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.UpdateUser(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("email-taken", func(t *testing.T) {
+		mockUseCase.On("UpdateUser", uint(1), userUpdateRequest).Return(errors.New("email is already registered")).Once()
+		jsonReqBody, err := json.Marshal(&userUpdateRequest)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPut, "/users/me", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.UpdateUser(c)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestChangePassword(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	passwordChangeRequest := dto.PasswordChangeRequest{OldPassword: "wrong", NewPassword: "newpassword"}
+	mockUseCase.On("ChangePassword", uint(1), passwordChangeRequest).Return(errors.New("wrong password")).Once()
+	jsonReqBody, err := json.Marshal(&passwordChangeRequest)
+	assert.NoError(t, err, "No marshaling error")
+	req, err := http.NewRequest(http.MethodPut, "/users/me/password", bytes.NewBuffer(jsonReqBody))
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	assert.NoError(t, err, "No request error")
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+	c.Set("user", token)
+	userController := UserController{
+		router: e,
+		userUC: mockUseCase,
+	}
+
+	userController.ChangePassword(c)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestChangePasswordPolicyViolation(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	passwordChangeRequest := dto.PasswordChangeRequest{OldPassword: "asdfasfas", NewPassword: "short"}
+	mockUseCase.On("ChangePassword", uint(1), passwordChangeRequest).Return(&password.ViolationError{Violations: []password.Violation{
+		{Code: "too_short", Message: "must be at least 8 characters long"},
+	}}).Once()
+	jsonReqBody, err := json.Marshal(&passwordChangeRequest)
+	assert.NoError(t, err, "No marshaling error")
+	req, err := http.NewRequest(http.MethodPut, "/users/me/password", bytes.NewBuffer(jsonReqBody))
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	assert.NoError(t, err, "No request error")
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+	c.Set("user", token)
+	userController := UserController{
+		router: e,
+		userUC: mockUseCase,
+	}
+
+	userController.ChangePassword(c)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"too_short"`)
+	assert.Contains(t, rec.Body.String(), "password does not meet the policy")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestImpersonateUser(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	impersonationRequest := dto.ImpersonationRequest{Reason: "ticket #42"}
+	jsonReqBody, err := json.Marshal(&impersonationRequest)
+	assert.NoError(t, err, "No marshaling error")
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("ImpersonateUser", uint(1), uint(7), uint(2), impersonationRequest, mock.AnythingOfType("string")).Return(dto.ImpersonationTokenResponse{ImpersonationID: 3, Token: "token", TokenType: "JWT"}, nil).Once()
+		req, err := http.NewRequest(http.MethodPost, "/", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/:id/impersonate")
+		c.SetParamNames("id")
+		c.SetParamValues("2")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleAdmin, 7)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.ImpersonateUser(c)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("admin-subject", func(t *testing.T) {
+		mockUseCase.On("ImpersonateUser", uint(1), uint(7), uint(4), impersonationRequest, mock.AnythingOfType("string")).Return(dto.ImpersonationTokenResponse{}, errors.New("admins can't be impersonated")).Once()
+		req, err := http.NewRequest(http.MethodPost, "/", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/:id/impersonate")
+		c.SetParamNames("id")
+		c.SetParamValues("4")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleAdmin, 7)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.ImpersonateUser(c)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func impersonationToken() *jwt.Token {
+	token := utils.CreateJWTToken(2, "asdfa@gmail.com", utils.RoleStudent, 0)
+	claims := token.Claims.(*utils.JwtCustomClaims)
+	claims.Actor = &utils.Actor{ID: 1, Email: "gmail@gmail.com"}
+	claims.ImpersonationID = 3
+	return token
+}
+
+func TestEndImpersonation(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("EndImpersonation", uint(3)).Return(nil).Once()
+		req, err := http.NewRequest(http.MethodDelete, "/users/me/impersonation", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", impersonationToken())
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.EndImpersonation(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("not-impersonating", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodDelete, "/users/me/impersonation", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(2, "asdfa@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.EndImpersonation(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestChangePasswordWhileImpersonating(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	passwordChangeRequest := dto.PasswordChangeRequest{OldPassword: "asdfasfas", NewPassword: "Tr4vel-Lamp-Quiet"}
+	jsonReqBody, err := json.Marshal(&passwordChangeRequest)
+	assert.NoError(t, err, "No marshaling error")
+	req, err := http.NewRequest(http.MethodPut, "/users/me/password", bytes.NewBuffer(jsonReqBody))
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	assert.NoError(t, err, "No request error")
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", impersonationToken())
+	userController := UserController{
+		router: e,
+		userUC: mockUseCase,
+	}
+
+	utils.ForbidImpersonation(userController.ChangePassword)(c)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "not allowed while impersonating")
+}
+
+func TestExportUserData(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	mockUseCase.On("ExportUserData", uint(1)).Return(dto.UserDataExport{
+		Profile:  dto.UserProfileExport{ID: 1, Name: "Alim Ikegami", Email: "gmail@gmail.com"},
+		Skills:   []dto.UserSkillResponse{{ID: 1, Name: "Node.js", Proficiency: 3}},
+		Sessions: []dto.UserSessionExport{{ID: 2, UserAgent: "Mozilla/5.0", IPAddress: "10.0.0.1"}},
+	}, nil).Once()
+	req, err := http.NewRequest(http.MethodGet, "/users/me/export", nil)
+	assert.NoError(t, err, "No request error")
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+	c.Set("user", token)
+	userController := UserController{
+		router: e,
+		userUC: mockUseCase,
+	}
+
+	err = userController.ExportUserData(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/zip", rec.Header().Get(echo.HeaderContentType))
+
+	archive, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	assert.NoError(t, err)
+	assert.Len(t, archive.File, 17)
+
+	profileFile, err := archive.Open("profile.json")
+	assert.NoError(t, err)
+	var profile dto.UserProfileExport
+	assert.NoError(t, json.NewDecoder(profileFile).Decode(&profile))
+	assert.Equal(t, "gmail@gmail.com", profile.Email)
+
+	sessionsFile, err := archive.Open("sessions.json")
+	assert.NoError(t, err)
+	var sessions []dto.UserSessionExport
+	assert.NoError(t, json.NewDecoder(sessionsFile).Decode(&sessions))
+	assert.Equal(t, "10.0.0.1", sessions[0].IPAddress)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestDeleteAccount(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		reqBody := dto.AccountDeletionRequest{Password: "asdfasfas"}
+		mockUseCase.On("DeleteAccount", uint(1), reqBody).Return(nil).Once()
+		jsonReqBody, err := json.Marshal(&reqBody)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodDelete, "/users/me", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.DeleteAccount(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("wrong-password", func(t *testing.T) {
+		reqBody := dto.AccountDeletionRequest{Password: "wrong"}
+		mockUseCase.On("DeleteAccount", uint(1), reqBody).Return(errors.New("wrong password")).Once()
+		jsonReqBody, err := json.Marshal(&reqBody)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodDelete, "/users/me", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.DeleteAccount(c)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("team-owner", func(t *testing.T) {
+		reqBody := dto.AccountDeletionRequest{Password: "asdfasfas"}
+		mockUseCase.On("DeleteAccount", uint(1), reqBody).Return(errors.New("transfer the ownership of your teams before deleting the account")).Once()
+		jsonReqBody, err := json.Marshal(&reqBody)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodDelete, "/users/me", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.DeleteAccount(c)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestSetupTwoFactor(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("SetupTwoFactor", uint(1)).Return(dto.TwoFactorSetupResponse{
+			Secret:          "JBSWY3DPEHPK3PXP",
+			ProvisioningURI: "otpauth://totp/Compnouron:gmail@gmail.com?secret=JBSWY3DPEHPK3PXP",
+		}, nil).Once()
+		req, err := http.NewRequest(http.MethodPost, "/users/me/2fa/setup", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.SetupTwoFactor(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "otpauth://totp/")
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("already-enabled", func(t *testing.T) {
+		mockUseCase.On("SetupTwoFactor", uint(1)).Return(dto.TwoFactorSetupResponse{}, errors.New("two-factor authentication is already enabled")).Once()
+		req, err := http.NewRequest(http.MethodPost, "/users/me/2fa/setup", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.SetupTwoFactor(c)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestEnableTwoFactor(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		reqBody := dto.TwoFactorCodeRequest{Code: "123456"}
+		mockUseCase.On("EnableTwoFactor", uint(1), reqBody).Return(dto.RecoveryCodesResponse{RecoveryCodes: []string{"abcde-fghij"}}, nil).Once()
+		jsonReqBody, err := json.Marshal(&reqBody)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPost, "/users/me/2fa/enable", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.EnableTwoFactor(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "abcde-fghij")
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid-code", func(t *testing.T) {
+		reqBody := dto.TwoFactorCodeRequest{Code: "000000"}
+		mockUseCase.On("EnableTwoFactor", uint(1), reqBody).Return(dto.RecoveryCodesResponse{}, errors.New("invalid two-factor code")).Once()
+		jsonReqBody, err := json.Marshal(&reqBody)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPost, "/users/me/2fa/enable", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.EnableTwoFactor(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	reqBody := dto.TwoFactorDisableRequest{Password: "asdfasfas", Code: "000000"}
+	mockUseCase.On("DisableTwoFactor", uint(1), reqBody).Return(errors.New("invalid two-factor code")).Once()
+	jsonReqBody, err := json.Marshal(&reqBody)
+	assert.NoError(t, err, "No marshaling error")
+	req, err := http.NewRequest(http.MethodPost, "/users/me/2fa/disable", bytes.NewBuffer(jsonReqBody))
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	assert.NoError(t, err, "No request error")
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+	c.Set("user", token)
+	userController := UserController{
+		router: e,
+		userUC: mockUseCase,
+	}
+
+	userController.DisableTwoFactor(c)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestAddUserSkill(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		reqBody := dto.UserSkillRequest{Name: "Golang", Proficiency: 4}
+		mockUseCase.On("AddUserSkill", uint(1), reqBody).Return(nil).Once()
+		jsonReqBody, err := json.Marshal(&reqBody)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPost, "/users/me/skills", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.AddUserSkill(c)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid-proficiency", func(t *testing.T) {
+		reqBody := dto.UserSkillRequest{Name: "Golang", Proficiency: 9}
+		mockUseCase.On("AddUserSkill", uint(1), reqBody).Return(errors.New("proficiency must be between 1 and 5")).Once()
+		jsonReqBody, err := json.Marshal(&reqBody)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPost, "/users/me/skills", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.AddUserSkill(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestRemoveUserSkill(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("RemoveUserSkill", uint(1), uint(2)).Return(nil).Once()
+		req, err := http.NewRequest(http.MethodDelete, "/", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/me/skills/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("2")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.RemoveUserSkill(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockUseCase.On("RemoveUserSkill", uint(1), uint(2)).Return(errors.New("skill not found")).Once()
+		req, err := http.NewRequest(http.MethodDelete, "/", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/me/skills/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("2")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.RemoveUserSkill(c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestGetSessions(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	mockUseCase.On("GetSessions", uint(1), uint(7)).Return([]dto.SessionResponse{
+		{ID: 7, UserAgent: "Mozilla/5.0", IPAddress: "10.0.0.1", Current: true},
+	}, nil).Once()
+	req, err := http.NewRequest(http.MethodGet, "/users/me/sessions", nil)
+	assert.NoError(t, err, "No request error")
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 7)
+	c.Set("user", token)
+	userController := UserController{
+		router: e,
+		userUC: mockUseCase,
+	}
+
+	userController.GetSessions(c)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestSearchUsers(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("SearchUsers", uint(1), dto.UserSearchRequest{SkillIDs: []uint{1, 2}, MatchAllSkills: true, InstitutionID: 3, AvailableOnly: true, Limit: 20}).Return([]dto.UserProfileResponse{
+			{ID: 2, Name: "Alim Ikegami", AvailableForTeams: true},
+		}, nil).Once()
+		req, err := http.NewRequest(http.MethodGet, "/users/search?skills=1,2&match=all&institutionID=3&available=true&limit=20", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.SearchUsers(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid-skills", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/users/search?skills=go", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.SearchUsers(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("invalid-match", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/users/search?skills=1&match=some", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.SearchUsers(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestGetAchievements(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("GetAchievements", uint(2)).Return([]dto.AchievementResponse{
+			{CompetitionID: 1, CompetitionName: "Technoscape Hackathon 2022", Rank: 1, AwardTitle: "Champion"},
+		}, nil).Once()
+		req, err := http.NewRequest(http.MethodGet, "/users/2/achievements", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/:id/achievements")
+		c.SetParamNames("id")
+		c.SetParamValues("2")
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.GetAchievements(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid-id", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/users/abc/achievements", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/:id/achievements")
+		c.SetParamNames("id")
+		c.SetParamValues("abc")
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.GetAchievements(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestRevokeOtherSessions(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	mockUseCase.On("RevokeOtherSessions", uint(1), uint(7)).Return(nil).Once()
+	req, err := http.NewRequest(http.MethodDelete, "/users/me/sessions", nil)
+	assert.NoError(t, err, "No request error")
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 7)
+	c.Set("user", token)
+	userController := UserController{
+		router: e,
+		userUC: mockUseCase,
+	}
+
+	userController.RevokeOtherSessions(c)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestRevokeSession(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("RevokeSession", uint(1), uint(8)).Return(nil).Once()
+		req, err := http.NewRequest(http.MethodDelete, "/", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/me/sessions/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("8")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 7)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.RevokeSession(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockUseCase.On("RevokeSession", uint(1), uint(8)).Return(errors.New("session not found")).Once()
+		req, err := http.NewRequest(http.MethodDelete, "/", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/me/sessions/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("8")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 7)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.RevokeSession(c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid-id", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodDelete, "/", nil)
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/users/me/sessions/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("abc")
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 7)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.RevokeSession(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestCreatePersonalAccessToken(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	t.Run("success", func(t *testing.T) {
+		tokenRequest := dto.PersonalAccessTokenRequest{
+			Name:   "union bot",
+			Scopes: []string{utils.ScopeRegistrationsRead},
+		}
+		mockUseCase.On("CreatePersonalAccessToken", uint(1), tokenRequest).Return(dto.CreatedPersonalAccessTokenResponse{
+			PersonalAccessTokenResponse: dto.PersonalAccessTokenResponse{ID: 4, Name: "union bot", Scopes: []string{utils.ScopeRegistrationsRead}},
+			Token:                       "cpat_token",
+		}, nil).Once()
+		jsonReqBody, err := json.Marshal(&tokenRequest)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPost, "/users/me/tokens", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.CreatePersonalAccessToken(c)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid-scope", func(t *testing.T) {
+		tokenRequest := dto.PersonalAccessTokenRequest{
+			Name:   "union bot",
+			Scopes: []string{"users:delete"},
+		}
+		mockUseCase.On("CreatePersonalAccessToken", uint(1), tokenRequest).Return(dto.CreatedPersonalAccessTokenResponse{}, errors.New("invalid scope")).Once()
+		jsonReqBody, err := json.Marshal(&tokenRequest)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPost, "/users/me/tokens", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.CreatePersonalAccessToken(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestRevokePersonalAccessToken(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	mockUseCase.On("RevokePersonalAccessToken", uint(1), uint(4)).Return(errors.New("token not found")).Once()
+	req, err := http.NewRequest(http.MethodDelete, "/", nil)
+	assert.NoError(t, err, "No request error")
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/users/me/tokens/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("4")
+	token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+	c.Set("user", token)
+	userController := UserController{
+		router: e,
+		userUC: mockUseCase,
+	}
+
+	userController.RevokePersonalAccessToken(c)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestUpdatePrivacySettings(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	privacySettingsRequest := dto.PrivacySettingsRequest{
+		EmailVisibility:       "public",
+		PhoneNumberVisibility: "friends",
+	}
+	t.Run("invalid-visibility", func(t *testing.T) {
+		mockUseCase.On("UpdatePrivacySettings", uint(1), privacySettingsRequest).Return(errors.New("invalid visibility")).Once()
+		jsonReqBody, err := json.Marshal(&privacySettingsRequest)
+		assert.NoError(t, err, "No marshaling error")
+		req, err := http.NewRequest(http.MethodPut, "/users/me/privacy", bytes.NewBuffer(jsonReqBody))
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		assert.NoError(t, err, "No request error")
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		token := utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1)
+		c.Set("user", token)
+		userController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		userController.UpdatePrivacySettings(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestUploadAvatar(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+	data := []byte("image")
+	newRequest := func() *http.Request {
+		body := new(bytes.Buffer)
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("image", "avatar.jpg")
+		assert.NoError(t, err)
+		part.Write(data)
+		writer.Close()
+		req := httptest.NewRequest(http.MethodPut, "/users/me/avatar", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("UploadAvatar", uint(1), data).Return(&media.Image{URL: "/uploads/avatars/1/a.jpg", ThumbnailURL: "/uploads/avatars/1/a_thumb.jpg"}, nil).Once()
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(), rec)
+		c.Set("user", utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1))
+		testUserController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		testUserController.UploadAvatar(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "/uploads/avatars/1/a_thumb.jpg")
+	})
+
+	t.Run("unsupported-image-type", func(t *testing.T) {
+		mockUseCase.On("UploadAvatar", uint(1), data).Return(nil, errors.New("unsupported image type")).Once()
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(), rec)
+		c.Set("user", utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1))
+		testUserController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		testUserController.UploadAvatar(c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("file-too-large", func(t *testing.T) {
+		body := new(bytes.Buffer)
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("image", "avatar.jpg")
+		assert.NoError(t, err)
+		part.Write(make([]byte, media.MaxUploadBytes+1))
+		writer.Close()
+		req := httptest.NewRequest(http.MethodPut, "/users/me/avatar", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1))
+		testUserController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		testUserController.UploadAvatar(c)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestDeleteAvatar(t *testing.T) {
+	mockUseCase := mocks.NewUserUseCase(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockUseCase.On("DeleteAvatar", uint(1)).Return(nil).Once()
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodDelete, "/users/me/avatar", nil), rec)
+		c.Set("user", utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1))
+		testUserController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		testUserController.DeleteAvatar(c)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("avatar-not-found", func(t *testing.T) {
+		mockUseCase.On("DeleteAvatar", uint(1)).Return(errors.New("avatar not found")).Once()
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodDelete, "/users/me/avatar", nil), rec)
+		c.Set("user", utils.CreateJWTToken(1, "gmail@gmail.com", utils.RoleStudent, 1))
+		testUserController := UserController{
+			router: e,
+			userUC: mockUseCase,
+		}
+
+		testUserController.DeleteAvatar(c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	mockUseCase.AssertExpectations(t)
+}
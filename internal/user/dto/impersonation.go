@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+type ImpersonationRequest struct {
+	// Reason is kept in the audit log, e.g. the support ticket being handled
+	Reason string `json:"reason"`
+}
+
+// ImpersonationTokenResponse carries an access token for the impersonated
+// user. It can't be refreshed, a new impersonation has to be started once it
+// expires.
+type ImpersonationTokenResponse struct {
+	ImpersonationID uint      `json:"impersonationID"`
+	Token           string    `json:"token"`
+	TokenType       string    `json:"tokenType"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+}
+
+type ImpersonationActionResponse struct {
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Status    int       `json:"status"`
+	IPAddress string    `json:"ipAddress"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ImpersonationResponse struct {
+	ID        uint                          `json:"id"`
+	ActorID   uint                          `json:"actorID"`
+	UserID    uint                          `json:"userID"`
+	Reason    string                        `json:"reason"`
+	IPAddress string                        `json:"ipAddress"`
+	ExpiresAt time.Time                     `json:"expiresAt"`
+	EndedAt   *time.Time                    `json:"endedAt"`
+	CreatedAt time.Time                     `json:"createdAt"`
+	Actions   []ImpersonationActionResponse `json:"actions"`
+}
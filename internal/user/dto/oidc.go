@@ -0,0 +1,16 @@
+package dto
+
+// OIDCAuthorization is where to send the user to sign in with an identity
+// provider. FlowState has to come back with the callback and is kept in a
+// cookie, never in the URL.
+type OIDCAuthorization struct {
+	AuthorizationURL string
+	FlowState        string
+}
+
+type OIDCCallbackRequest struct {
+	Code      string `query:"code"`
+	State     string `query:"state"`
+	Error     string `query:"error"`
+	FlowState string
+}
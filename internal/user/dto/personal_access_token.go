@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+type PersonalAccessTokenRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// ExpiresInDays is optional, the token never expires when it is 0
+	ExpiresInDays int `json:"expiresInDays"`
+}
+
+type PersonalAccessTokenResponse struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// CreatedPersonalAccessTokenResponse is the only response that contains the
+// token itself, it cannot be retrieved again later.
+type CreatedPersonalAccessTokenResponse struct {
+	PersonalAccessTokenResponse
+	Token string `json:"token"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+type PrivacySettingsRequest struct {
+	EmailVisibility       string `json:"emailVisibility"`
+	PhoneNumberVisibility string `json:"phoneNumberVisibility"`
+	Searchable            *bool  `json:"searchable"`
+	AvailableForTeams     *bool  `json:"availableForTeams"`
+}
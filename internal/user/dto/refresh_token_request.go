@@ -0,0 +1,5 @@
+package dto
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+type SessionResponse struct {
+	ID         uint      `json:"id"`
+	UserAgent  string    `json:"userAgent"`
+	IPAddress  string    `json:"ipAddress"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	Current    bool      `json:"current"`
+}
@@ -1,6 +1,12 @@
 package dto
 
+// TokenResponse carries either the issued tokens or, when the user has
+// two-factor authentication enabled, the challenge token to send to the second
+// login step.
 type TokenResponse struct {
-	Token     string `json:"token"`
-	TokenType string `json:"tokenType"`
+	Token             string `json:"token,omitempty"`
+	TokenType         string `json:"tokenType,omitempty"`
+	RefreshToken      string `json:"refreshToken,omitempty"`
+	TwoFactorRequired bool   `json:"twoFactorRequired,omitempty"`
+	ChallengeToken    string `json:"challengeToken,omitempty"`
 }
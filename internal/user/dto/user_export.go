@@ -0,0 +1,144 @@
+package dto
+
+import (
+	"time"
+
+	dtoComp "github.com/alimikegami/compnouron/internal/competition/dto"
+)
+
+type UserProfileExport struct {
+	ID                uint       `json:"id"`
+	Name              string     `json:"name"`
+	Email             string     `json:"email"`
+	PhoneNumber       string     `json:"phoneNumber"`
+	SchoolInstitution string     `json:"schoolInstitution"`
+	Role              string     `json:"role"`
+	VerifiedAt        *time.Time `json:"verifiedAt"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+}
+
+type UserTeamMembershipExport struct {
+	TeamID   uint      `json:"teamID"`
+	TeamName string    `json:"teamName"`
+	IsLeader bool      `json:"isLeader"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
+type UserSessionExport struct {
+	ID         uint       `json:"id"`
+	UserAgent  string     `json:"userAgent"`
+	IPAddress  string     `json:"ipAddress"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastSeenAt time.Time  `json:"lastSeenAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+}
+
+type UserLockoutEventExport struct {
+	ID          uint       `json:"id"`
+	Email       string     `json:"email"`
+	IPAddress   string     `json:"ipAddress"`
+	Scope       string     `json:"scope"`
+	LockedUntil time.Time  `json:"lockedUntil"`
+	UnlockedAt  *time.Time `json:"unlockedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// UserThreadExport is a thread the user started, MentionedUserIDs are the
+// members the user mentioned in it.
+type UserThreadExport struct {
+	ID               uint       `json:"id"`
+	TeamID           uint       `json:"teamID"`
+	Title            string     `json:"title"`
+	Body             string     `json:"body"`
+	MentionedUserIDs []uint     `json:"mentionedUserIDs"`
+	EditedAt         *time.Time `json:"editedAt"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+// UserReplyExport is a reply the user posted, MentionedUserIDs are the members
+// the user mentioned in it.
+type UserReplyExport struct {
+	ID               uint       `json:"id"`
+	ThreadID         uint       `json:"threadID"`
+	Body             string     `json:"body"`
+	MentionedUserIDs []uint     `json:"mentionedUserIDs"`
+	EditedAt         *time.Time `json:"editedAt"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+// UserMentionExport is a place where another member mentioned the user, in a
+// thread or, when ReplyID is set, in one of its replies.
+type UserMentionExport struct {
+	ID        uint      `json:"id"`
+	ThreadID  uint      `json:"threadID"`
+	ReplyID   *uint     `json:"replyID"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// UserPersonalAccessTokenExport is the metadata of a personal access token,
+// the token itself is never stored.
+type UserPersonalAccessTokenExport struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type UserIdentityExport struct {
+	ID        uint      `json:"id"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type UserTeamInvitationExport struct {
+	ID          uint       `json:"id"`
+	TeamID      uint       `json:"teamID"`
+	TeamName    string     `json:"teamName"`
+	InviterID   uint       `json:"inviterID"`
+	InviteeID   *uint      `json:"inviteeID"`
+	Email       string     `json:"email"`
+	Status      string     `json:"status"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	RespondedAt *time.Time `json:"respondedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// UserImpersonationActionExport is a request an admin made while acting as
+// the user.
+type UserImpersonationActionExport struct {
+	ImpersonationID uint      `json:"impersonationID"`
+	ActorID         uint      `json:"actorID"`
+	Method          string    `json:"method"`
+	Path            string    `json:"path"`
+	Status          int       `json:"status"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// UserDataExport holds everything stored about a user. Every field becomes its
+// own JSON document in the export archive.
+type UserDataExport struct {
+	Profile                  UserProfileExport
+	Skills                   []UserSkillResponse
+	TeamMemberships          []UserTeamMembershipExport
+	CompetitionRegistrations []UserCompetitionHistory
+	RecruitmentApplications  []UserRecruitmentApplicationHistory
+	OrganizedCompetitions    []dtoComp.CompetitionResponse
+	Sessions                 []UserSessionExport
+	LockoutEvents            []UserLockoutEventExport
+	Threads                  []UserThreadExport
+	Replies                  []UserReplyExport
+	Mentions                 []UserMentionExport
+	PersonalAccessTokens     []UserPersonalAccessTokenExport
+	Identities               []UserIdentityExport
+	Achievements             []AchievementResponse
+	InvitationsSent          []UserTeamInvitationExport
+	InvitationsReceived      []UserTeamInvitationExport
+	ImpersonationActions     []UserImpersonationActionExport
+}
@@ -1,14 +1,31 @@
 package dto
 
-type SkillRequest struct {
-	Name string `json:"name"`
+type UserSkillRequest struct {
+	Name        string `json:"name"`
+	Proficiency uint   `json:"proficiency"`
 }
 
 type UserRegistrationRequest struct {
-	Name              string         `json:"name"`
-	Email             string         `json:"email"`
-	PhoneNumber       string         `json:"phoneNumber"`
-	Password          string         `json:"password"`
-	SchoolInstitution string         `json:"schoolInstitution"`
-	Skills            []SkillRequest `json:"skills"`
+	Name              string             `json:"name"`
+	Email             string             `json:"email"`
+	PhoneNumber       string             `json:"phoneNumber"`
+	Password          string             `json:"password"`
+	SchoolInstitution string             `json:"schoolInstitution"`
+	Skills            []UserSkillRequest `json:"skills"`
+}
+
+type UserUpdateRequest struct {
+	Name              string `json:"name"`
+	Email             string `json:"email"`
+	PhoneNumber       string `json:"phoneNumber"`
+	SchoolInstitution string `json:"schoolInstitution"`
+}
+
+type PasswordChangeRequest struct {
+	OldPassword string `json:"oldPassword"`
+	NewPassword string `json:"newPassword"`
+}
+
+type AccountDeletionRequest struct {
+	Password string `json:"password"`
 }
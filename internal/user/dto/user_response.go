@@ -1,18 +1,44 @@
 package dto
 
-type SkillResponse struct {
-	ID     uint   `json:"id"`
-	Name   string `json:"name"`
-	UserID uint   `json:"userID"`
+import (
+	"time"
+
+	"github.com/alimikegami/compnouron/internal/media"
+)
+
+type UserSkillResponse struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Proficiency uint   `json:"proficiency"`
 }
 
 type UserDetailsResponse struct {
-	ID                uint            `json:"id"`
-	Name              string          `json:"name"`
-	Email             string          `json:"email"`
-	PhoneNumber       string          `json:"phoneNumber"`
-	SchoolInstitution string          `json:"schoolInstitution"`
-	Skills            []SkillResponse `json:"skills"`
+	ID                    uint                `json:"id"`
+	Name                  string              `json:"name"`
+	Email                 string              `json:"email"`
+	PhoneNumber           string              `json:"phoneNumber"`
+	SchoolInstitution     string              `json:"schoolInstitution"`
+	Role                  string              `json:"role"`
+	EmailVerified         bool                `json:"emailVerified"`
+	TwoFactorEnabled      bool                `json:"twoFactorEnabled"`
+	EmailVisibility       string              `json:"emailVisibility"`
+	PhoneNumberVisibility string              `json:"phoneNumberVisibility"`
+	Searchable            bool                `json:"searchable"`
+	AvailableForTeams     bool                `json:"availableForTeams"`
+	InstitutionID         *uint               `json:"institutionID"`
+	InstitutionName       string              `json:"institutionName,omitempty"`
+	Avatar                *media.Image        `json:"avatar"`
+	Skills                []UserSkillResponse `json:"skills"`
+}
+
+type LockoutEventResponse struct {
+	ID          uint      `json:"id"`
+	UserID      *uint     `json:"userID"`
+	Email       string    `json:"email"`
+	IPAddress   string    `json:"ipAddress"`
+	Scope       string    `json:"scope"`
+	LockedUntil time.Time `json:"lockedUntil"`
+	CreatedAt   time.Time `json:"createdAt"`
 }
 
 type UserCompetitionHistory struct {
@@ -22,6 +48,18 @@ type UserCompetitionHistory struct {
 	AcceptanceStatus          uint   `json:"acceptanceStatus"`
 }
 
+// AchievementResponse is a published competition result credited to the user.
+// TeamName is set when the result was won as a team.
+type AchievementResponse struct {
+	CompetitionID   uint      `json:"competitionID"`
+	CompetitionName string    `json:"competitionName"`
+	Level           string    `json:"level"`
+	Rank            uint      `json:"rank"`
+	AwardTitle      string    `json:"awardTitle"`
+	TeamName        string    `json:"teamName,omitempty"`
+	PublishedAt     time.Time `json:"publishedAt"`
+}
+
 type UserRecruitmentApplicationHistory struct {
 	RecruitmentApplicationID uint   `json:"id"`
 	RecruitmentID            uint   `json:"recruitmentID"`
@@ -0,0 +1,27 @@
+package dto
+
+import "github.com/alimikegami/compnouron/internal/media"
+
+type UserSearchRequest struct {
+	SkillIDs       []uint
+	MatchAllSkills bool
+	InstitutionID  uint
+	AvailableOnly  bool
+	Limit          int
+	Offset         int
+}
+
+// UserProfileResponse is what other users see of a user. Email and phone
+// number are empty when the user's privacy settings hide them from the viewer.
+type UserProfileResponse struct {
+	ID                uint                `json:"id"`
+	Name              string              `json:"name"`
+	Email             string              `json:"email,omitempty"`
+	PhoneNumber       string              `json:"phoneNumber,omitempty"`
+	SchoolInstitution string              `json:"schoolInstitution"`
+	InstitutionID     *uint               `json:"institutionID"`
+	InstitutionName   string              `json:"institutionName,omitempty"`
+	AvailableForTeams bool                `json:"availableForTeams"`
+	Avatar            *media.Image        `json:"avatar"`
+	Skills            []UserSkillResponse `json:"skills"`
+}
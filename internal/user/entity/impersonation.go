@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+// Impersonation is an admin acting as another user for support, ActorID being
+// the admin and UserID the user. It is tied to the login session of the admin,
+// so signing the admin out ends it too.
+type Impersonation struct {
+	ID        uint   `gorm:"primaryKey"`
+	ActorID   uint   `gorm:"not null;index"`
+	UserID    uint   `gorm:"not null;index"`
+	SessionID uint   `gorm:"not null"`
+	Reason    string `gorm:"not null"`
+	IPAddress string `gorm:"not null"`
+	ExpiresAt time.Time
+	EndedAt   *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Actions   []ImpersonationAction
+}
+
+// ImpersonationAction is one request made with an impersonation token. The
+// admin and the user are kept on every action, so a row still reads on its
+// own when it is exported or the impersonation is gone.
+type ImpersonationAction struct {
+	ID              uint   `gorm:"primaryKey"`
+	ImpersonationID uint   `gorm:"not null;index"`
+	ActorID         uint   `gorm:"not null;index"`
+	UserID          uint   `gorm:"not null;index"`
+	Method          string `gorm:"not null"`
+	Path            string `gorm:"not null"`
+	Status          int    `gorm:"not null"`
+	IPAddress       string `gorm:"not null"`
+	CreatedAt       time.Time
+}
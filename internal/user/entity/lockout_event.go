@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+const (
+	LockoutScopeAccount = "account"
+	LockoutScopeIP      = "ip"
+)
+
+// LockoutEvent records that repeated failed logins locked an account or an IP
+// address. UserID is nil when the email doesn't belong to any user.
+type LockoutEvent struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      *uint  `gorm:"index"`
+	Email       string `gorm:"not null"`
+	IPAddress   string `gorm:"not null"`
+	Scope       string `gorm:"not null"`
+	LockedUntil time.Time
+	UnlockedAt  *time.Time
+	UnlockedBy  *uint
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// PersonalAccessToken is a long-lived token a user creates for scripts and
+// bots. Only the hash of the token is stored.
+type PersonalAccessToken struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	Name      string `gorm:"not null"`
+	TokenHash string `gorm:"type:varchar(64);unique;not null"`
+	// Scopes is the space separated list of scopes granted to the token
+	Scopes     string `gorm:"not null"`
+	LastUsedAt *time.Time
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	User       User
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type RecoveryCode struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	CodeHash  string `gorm:"not null;index"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	User      User
+}
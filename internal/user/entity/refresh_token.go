@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type RefreshToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null"`
+	TokenHash string    `gorm:"unique;not null"`
+	FamilyID  string    `gorm:"not null;index"`
+	ExpiresAt time.Time `gorm:"not null"`
+	RevokedAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	User      User
+}
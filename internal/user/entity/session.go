@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// Session is one login of a user on a device. Access tokens carry the session
+// ID, and the refresh tokens issued for the login share its FamilyID, so
+// revoking the session signs that device out for good.
+type Session struct {
+	ID         uint      `gorm:"primaryKey"`
+	UserID     uint      `gorm:"not null;index"`
+	FamilyID   string    `gorm:"unique;not null"`
+	UserAgent  string    `gorm:"not null"`
+	IPAddress  string    `gorm:"not null"`
+	LastSeenAt time.Time `gorm:"not null"`
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	User       User
+}
@@ -2,6 +2,8 @@ package entity
 
 import (
 	"time"
+
+	institutionEntity "github.com/alimikegami/compnouron/internal/institution/entity"
 )
 
 type User struct {
@@ -11,7 +13,30 @@ type User struct {
 	PhoneNumber       string `gorm:"not null"`
 	Password          string `gorm:"not null"`
 	SchoolInstitution string `gorm:"not null"`
-	Skills            []Skill
-	CreatedAt         time.Time
-	UpdatedAt         time.Time
+	VerifiedAt        *time.Time
+	Role              string `gorm:"not null;default:student"`
+	AnonymizedAt      *time.Time
+	// who may see the email and the phone number, see the privacy package
+	EmailVisibility       string `gorm:"not null;default:team"`
+	PhoneNumberVisibility string `gorm:"not null;default:team"`
+	// Searchable lets the user opt out of talent search, AvailableForTeams
+	// tells team leaders the user is looking for a team
+	Searchable        bool `gorm:"not null;default:true"`
+	AvailableForTeams bool `gorm:"not null;default:false"`
+	// InstitutionID is the verified affiliation, it is only set while the
+	// verified email is on one of the institution's domains
+	InstitutionID *uint
+	Institution   *institutionEntity.Institution
+	// TwoFactorSecret is the TOTP secret encrypted with utils.EncryptSecret. It
+	// is set when enrollment starts and only used once TwoFactorEnabledAt is set.
+	TwoFactorSecret    string
+	TwoFactorEnabledAt *time.Time
+	// TwoFactorLastStep is the time step of the last accepted code, so the same
+	// code can't be used twice
+	TwoFactorLastStep int64 `gorm:"not null;default:0"`
+	// AvatarKey is the storage key of the avatar, see the media package
+	AvatarKey string
+	Skills    []UserSkill
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// UserIdentity links a user to an account at an OpenID Connect provider.
+type UserIdentity struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	Provider  string `gorm:"size:191;not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Subject   string `gorm:"size:191;not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Email     string `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	User      User
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	skillEntity "github.com/alimikegami/compnouron/internal/skill/entity"
+)
+
+const (
+	MinSkillProficiency = 1
+	MaxSkillProficiency = 5
+)
+
+type UserSkill struct {
+	UserID      uint `gorm:"primaryKey;autoIncrement:false"`
+	SkillID     uint `gorm:"primaryKey;autoIncrement:false"`
+	Proficiency uint `gorm:"not null;default:1"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Skill       skillEntity.Skill
+}
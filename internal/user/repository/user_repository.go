@@ -1,14 +1,84 @@
 package repository
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
+	teamEntity "github.com/alimikegami/compnouron/internal/team/entity"
 	"github.com/alimikegami/compnouron/internal/user/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository interface {
 	CreateUser(user entity.User) (uint, error)
 	GetUserByEmail(email string) *entity.User
-	AddUserSkills(skill []entity.Skill) error
+	GetUserByID(id uint) (entity.User, error)
+	GetUserWithSkillsByID(id uint) (entity.User, error)
+	UpdateUser(user entity.User) error
+	UpdateUserEmail(id uint, email string) error
+	AddUserSkills(skills []entity.UserSkill) error
+	DeleteUserSkill(userID uint, skillID uint) error
+	VerifyUserEmail(id uint) error
+	UpdateUserRole(id uint, role string) error
+	UpdateUserInstitution(id uint, institutionID uint) error
+	UpdateUserPrivacySettings(id uint, emailVisibility string, phoneNumberVisibility string, searchable bool, availableForTeams bool) error
+	SearchUsers(filter UserSearchFilter, limit int, offset int) ([]entity.User, error)
+	UpdateUserAvatar(id uint, avatarKey string) error
+	CreateRefreshToken(refreshToken entity.RefreshToken) error
+	GetRefreshTokenByHash(tokenHash string) (entity.RefreshToken, error)
+	RevokeRefreshToken(id uint) error
+	RevokeRefreshTokenFamily(familyID string) error
+	CreateSession(session entity.Session) (uint, error)
+	GetSessionByID(id uint) (entity.Session, error)
+	GetSessionByFamilyID(familyID string) (entity.Session, error)
+	GetActiveSessions(userID uint, lastSeenAfter time.Time) ([]entity.Session, error)
+	GetSessionsByUserID(userID uint) ([]entity.Session, error)
+	TouchSession(id uint) error
+	RevokeSession(userID uint, id uint) error
+	RevokeUserSessions(userID uint, exceptSessionID uint) error
+	CreatePersonalAccessToken(token entity.PersonalAccessToken) (uint, error)
+	GetPersonalAccessTokens(userID uint) ([]entity.PersonalAccessToken, error)
+	GetAllPersonalAccessTokens(userID uint) ([]entity.PersonalAccessToken, error)
+	GetPersonalAccessTokenByHash(tokenHash string) (entity.PersonalAccessToken, error)
+	TouchPersonalAccessToken(id uint) error
+	RevokePersonalAccessToken(userID uint, id uint) error
+	RevokeUserPersonalAccessTokens(userID uint) error
+	UpdateUserPassword(id uint, password string) error
+	CreatePasswordResetToken(passwordResetToken entity.PasswordResetToken) error
+	GetPasswordResetTokenByHash(tokenHash string) (entity.PasswordResetToken, error)
+	UsePasswordResetToken(id uint) error
+	InvalidateUserPasswordResetTokens(userID uint) error
+	AnonymizeUser(id uint) error
+	CreateLockoutEvent(lockoutEvent entity.LockoutEvent) error
+	GetActiveLockoutEvents() ([]entity.LockoutEvent, error)
+	GetLockoutEventsByUserID(userID uint) ([]entity.LockoutEvent, error)
+	UnlockLockoutEvents(userID uint, unlockedBy uint) error
+	CreateImpersonation(impersonation entity.Impersonation) (uint, error)
+	GetImpersonationByID(id uint) (entity.Impersonation, error)
+	GetImpersonations() ([]entity.Impersonation, error)
+	EndImpersonation(id uint) error
+	CreateImpersonationAction(action entity.ImpersonationAction) error
+	GetImpersonationActionsByUserID(userID uint) ([]entity.ImpersonationAction, error)
+	SetTwoFactorSecret(id uint, secret string) error
+	EnableTwoFactor(id uint, step int64, recoveryCodes []entity.RecoveryCode) error
+	DisableTwoFactor(id uint) error
+	UseTwoFactorStep(id uint, step int64) error
+	UseRecoveryCode(userID uint, codeHash string) error
+	GetUserIdentity(provider string, subject string) *entity.UserIdentity
+	GetUserIdentitiesByUserID(userID uint) ([]entity.UserIdentity, error)
+	CreateUserIdentity(userIdentity entity.UserIdentity) error
+	CreateUserWithIdentity(user entity.User, userIdentity entity.UserIdentity) (uint, error)
+}
+
+// UserSearchFilter narrows a talent search. Zero values don't filter.
+type UserSearchFilter struct {
+	SkillIDs []uint
+	// MatchAllSkills requires every skill in SkillIDs instead of any of them
+	MatchAllSkills bool
+	InstitutionID  uint
+	AvailableOnly  bool
 }
 
 type userRepositoryImpl struct {
@@ -24,11 +94,28 @@ func (ur *userRepositoryImpl) CreateUser(user entity.User) (uint, error) {
 	return user.ID, nil
 }
 
-func (ur *userRepositoryImpl) AddUserSkills(skill []entity.Skill) error {
-	result := ur.db.Create(&skill)
+// AddUserSkills inserts the given skills, updating the proficiency of the ones
+// the user already has.
+func (ur *userRepositoryImpl) AddUserSkills(skills []entity.UserSkill) error {
+	result := ur.db.Omit("Skill").Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"proficiency", "updated_at"}),
+	}).Create(&skills)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (ur *userRepositoryImpl) DeleteUserSkill(userID uint, skillID uint) error {
+	result := ur.db.Where("user_id = ? AND skill_id = ?", userID, skillID).Delete(&entity.UserSkill{})
 	if result.Error != nil {
 		return result.Error
 	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
 	return nil
 }
 
@@ -39,6 +126,766 @@ func (ur *userRepositoryImpl) GetUserByEmail(email string) *entity.User {
 	return &user
 }
 
+func (ur *userRepositoryImpl) GetUserByID(id uint) (entity.User, error) {
+	var user entity.User
+	result := ur.db.First(&user, id)
+	if result.Error != nil {
+		return entity.User{}, result.Error
+	}
+
+	return user, nil
+}
+
+func (ur *userRepositoryImpl) GetUserWithSkillsByID(id uint) (entity.User, error) {
+	var user entity.User
+	result := ur.db.Preload("Skills.Skill").Preload("Institution").First(&user, id)
+	if result.Error != nil {
+		return entity.User{}, result.Error
+	}
+
+	return user, nil
+}
+
+// SearchUsers returns the verified users who haven't opted out of search and
+// match the filter, with their skills and institution.
+func (ur *userRepositoryImpl) SearchUsers(filter UserSearchFilter, limit int, offset int) ([]entity.User, error) {
+	var users []entity.User
+	query := ur.db.Preload("Skills.Skill").Preload("Institution").Where("searchable = ? AND verified_at IS NOT NULL AND anonymized_at IS NULL", true)
+	if len(filter.SkillIDs) > 0 {
+		skillQuery := ur.db.Model(&entity.UserSkill{}).Select("user_id").Where("skill_id IN ?", filter.SkillIDs).Group("user_id")
+		if filter.MatchAllSkills {
+			skillQuery = skillQuery.Having("COUNT(DISTINCT skill_id) = ?", len(filter.SkillIDs))
+		}
+		query = query.Where("id IN (?)", skillQuery)
+	}
+
+	if filter.InstitutionID != 0 {
+		query = query.Where("institution_id = ?", filter.InstitutionID)
+	}
+
+	if filter.AvailableOnly {
+		query = query.Where("available_for_teams = ?", true)
+	}
+
+	result := query.Order("id").Limit(limit).Offset(offset).Find(&users)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return users, nil
+}
+
+func (ur *userRepositoryImpl) UpdateUser(user entity.User) error {
+	result := ur.db.Model(&user).Where("id = ?", user.ID).Updates(user)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// UpdateUserEmail also clears verified_at and the institution affiliation, so
+// the new address has to be verified before the user can create anything again.
+func (ur *userRepositoryImpl) UpdateUserEmail(id uint, email string) error {
+	result := ur.db.Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{"email": email, "verified_at": nil, "institution_id": nil})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) VerifyUserEmail(id uint) error {
+	result := ur.db.Model(&entity.User{}).Where("id = ? AND verified_at IS NULL", id).Update("verified_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) UpdateUserRole(id uint, role string) error {
+	result := ur.db.Model(&entity.User{}).Where("id = ?", id).Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) UpdateUserInstitution(id uint, institutionID uint) error {
+	result := ur.db.Model(&entity.User{}).Where("id = ?", id).Update("institution_id", institutionID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) UpdateUserPrivacySettings(id uint, emailVisibility string, phoneNumberVisibility string, searchable bool, availableForTeams bool) error {
+	result := ur.db.Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"email_visibility":        emailVisibility,
+		"phone_number_visibility": phoneNumberVisibility,
+		"searchable":              searchable,
+		"available_for_teams":     availableForTeams,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) UpdateUserAvatar(id uint, avatarKey string) error {
+	result := ur.db.Model(&entity.User{}).Where("id = ?", id).Update("avatar_key", avatarKey)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) CreateRefreshToken(refreshToken entity.RefreshToken) error {
+	result := ur.db.Create(&refreshToken)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) GetRefreshTokenByHash(tokenHash string) (entity.RefreshToken, error) {
+	var refreshToken entity.RefreshToken
+	result := ur.db.First(&refreshToken, "token_hash = ?", tokenHash)
+	if result.Error != nil {
+		return entity.RefreshToken{}, result.Error
+	}
+
+	return refreshToken, nil
+}
+
+func (ur *userRepositoryImpl) RevokeRefreshToken(id uint) error {
+	result := ur.db.Model(&entity.RefreshToken{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+// RevokeRefreshTokenFamily also ends the session the family was issued for.
+func (ur *userRepositoryImpl) RevokeRefreshTokenFamily(familyID string) error {
+	return ur.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&entity.RefreshToken{}).Where("family_id = ? AND revoked_at IS NULL", familyID).Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Model(&entity.Session{}).Where("family_id = ? AND revoked_at IS NULL", familyID).Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return nil
+	})
+}
+
+func (ur *userRepositoryImpl) CreateSession(session entity.Session) (uint, error) {
+	result := ur.db.Create(&session)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return session.ID, nil
+}
+
+func (ur *userRepositoryImpl) GetSessionByID(id uint) (entity.Session, error) {
+	var session entity.Session
+	result := ur.db.First(&session, id)
+	if result.Error != nil {
+		return entity.Session{}, result.Error
+	}
+
+	return session, nil
+}
+
+func (ur *userRepositoryImpl) GetSessionByFamilyID(familyID string) (entity.Session, error) {
+	var session entity.Session
+	result := ur.db.Where("family_id = ?", familyID).First(&session)
+	if result.Error != nil {
+		return entity.Session{}, result.Error
+	}
+
+	return session, nil
+}
+
+func (ur *userRepositoryImpl) GetActiveSessions(userID uint, lastSeenAfter time.Time) ([]entity.Session, error) {
+	var sessions []entity.Session
+	result := ur.db.Where("user_id = ? AND revoked_at IS NULL AND last_seen_at > ?", userID, lastSeenAfter).Order("last_seen_at DESC").Find(&sessions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return sessions, nil
+}
+
+// GetSessionsByUserID returns every session of the user, the revoked and
+// expired ones included.
+func (ur *userRepositoryImpl) GetSessionsByUserID(userID uint) ([]entity.Session, error) {
+	var sessions []entity.Session
+	result := ur.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&sessions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return sessions, nil
+}
+
+func (ur *userRepositoryImpl) TouchSession(id uint) error {
+	result := ur.db.Model(&entity.Session{}).Where("id = ?", id).Update("last_seen_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// RevokeSession ends one of the user's sessions along with its refresh tokens.
+func (ur *userRepositoryImpl) RevokeSession(userID uint, id uint) error {
+	return ur.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&entity.Session{}).Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected != 1 {
+			return errors.New("no rows affected")
+		}
+
+		result = tx.Model(&entity.RefreshToken{}).Where("family_id IN (?) AND revoked_at IS NULL", tx.Model(&entity.Session{}).Select("family_id").Where("id = ?", id)).Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return nil
+	})
+}
+
+// RevokeUserSessions ends every session of the user except exceptSessionID,
+// which is 0 to end all of them.
+func (ur *userRepositoryImpl) RevokeUserSessions(userID uint, exceptSessionID uint) error {
+	return ur.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&entity.Session{}).Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, exceptSessionID).Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Model(&entity.RefreshToken{}).Where("user_id = ? AND family_id NOT IN (?) AND revoked_at IS NULL", userID, tx.Model(&entity.Session{}).Select("family_id").Where("id = ?", exceptSessionID)).Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return nil
+	})
+}
+
+func (ur *userRepositoryImpl) CreatePersonalAccessToken(token entity.PersonalAccessToken) (uint, error) {
+	result := ur.db.Create(&token)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return token.ID, nil
+}
+
+func (ur *userRepositoryImpl) GetPersonalAccessTokens(userID uint) ([]entity.PersonalAccessToken, error) {
+	var tokens []entity.PersonalAccessToken
+	result := ur.db.Where("user_id = ? AND revoked_at IS NULL", userID).Order("created_at DESC").Find(&tokens)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return tokens, nil
+}
+
+// GetAllPersonalAccessTokens returns every token of the user, the revoked
+// ones included.
+func (ur *userRepositoryImpl) GetAllPersonalAccessTokens(userID uint) ([]entity.PersonalAccessToken, error) {
+	var tokens []entity.PersonalAccessToken
+	result := ur.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&tokens)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return tokens, nil
+}
+
+func (ur *userRepositoryImpl) GetPersonalAccessTokenByHash(tokenHash string) (entity.PersonalAccessToken, error) {
+	var token entity.PersonalAccessToken
+	result := ur.db.Preload("User").Where("token_hash = ?", tokenHash).First(&token)
+	if result.Error != nil {
+		return entity.PersonalAccessToken{}, result.Error
+	}
+
+	return token, nil
+}
+
+func (ur *userRepositoryImpl) TouchPersonalAccessToken(id uint) error {
+	result := ur.db.Model(&entity.PersonalAccessToken{}).Where("id = ?", id).Update("last_used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) RevokePersonalAccessToken(userID uint, id uint) error {
+	result := ur.db.Model(&entity.PersonalAccessToken{}).Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) RevokeUserPersonalAccessTokens(userID uint) error {
+	result := ur.db.Model(&entity.PersonalAccessToken{}).Where("user_id = ? AND revoked_at IS NULL", userID).Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) UpdateUserPassword(id uint, password string) error {
+	result := ur.db.Model(&entity.User{}).Where("id = ?", id).Update("password", password)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) CreatePasswordResetToken(passwordResetToken entity.PasswordResetToken) error {
+	result := ur.db.Create(&passwordResetToken)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) GetPasswordResetTokenByHash(tokenHash string) (entity.PasswordResetToken, error) {
+	var passwordResetToken entity.PasswordResetToken
+	result := ur.db.First(&passwordResetToken, "token_hash = ?", tokenHash)
+	if result.Error != nil {
+		return entity.PasswordResetToken{}, result.Error
+	}
+
+	return passwordResetToken, nil
+}
+
+func (ur *userRepositoryImpl) UsePasswordResetToken(id uint) error {
+	result := ur.db.Model(&entity.PasswordResetToken{}).Where("id = ? AND used_at IS NULL", id).Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) InvalidateUserPasswordResetTokens(userID uint) error {
+	result := ur.db.Model(&entity.PasswordResetToken{}).Where("user_id = ? AND used_at IS NULL", userID).Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// AnonymizeUser replaces the user's personal data with placeholders and removes
+// what only makes sense for an active account. The row itself is kept, so the
+// competitions and applications that reference it stay intact. The user leaves
+// the teams they are a member of the same way LeaveTeam does: the owner row is
+// never removed, and the registrations and recruitments stay with the team.
+func (ur *userRepositoryImpl) AnonymizeUser(id uint) error {
+	return ur.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&entity.User{}).Where("id = ? AND anonymized_at IS NULL", id).Updates(map[string]interface{}{
+			"name":                  "Deleted User",
+			"email":                 fmt.Sprintf("deleted-user-%d@compnouron.invalid", id),
+			"phone_number":          "",
+			"password":              "",
+			"school_institution":    "",
+			"verified_at":           nil,
+			"institution_id":        nil,
+			"two_factor_secret":     "",
+			"two_factor_enabled_at": nil,
+			"avatar_key":            "",
+			"anonymized_at":         now,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected != 1 {
+			return errors.New("no rows affected")
+		}
+
+		result = tx.Where("user_id = ?", id).Delete(&entity.UserSkill{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Where("user_id = ?", id).Delete(&entity.RecoveryCode{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Where("user_id = ?", id).Delete(&entity.UserIdentity{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Where("user_id = ? AND role <> ?", id, teamEntity.TeamRoleOwner).Delete(&teamEntity.TeamMember{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// the events keep the email and IP address the logins came from
+		result = tx.Where("user_id = ?", id).Delete(&entity.LockoutEvent{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Model(&entity.RefreshToken{}).Where("user_id = ? AND revoked_at IS NULL", id).Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Model(&entity.Session{}).Where("user_id = ? AND revoked_at IS NULL", id).Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Model(&entity.Session{}).Where("user_id = ?", id).Updates(map[string]interface{}{
+			"ip_address": "",
+			"user_agent": "",
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Model(&entity.PersonalAccessToken{}).Where("user_id = ? AND revoked_at IS NULL", id).Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Model(&entity.PasswordResetToken{}).Where("user_id = ? AND used_at IS NULL", id).Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return nil
+	})
+}
+
+func (ur *userRepositoryImpl) CreateLockoutEvent(lockoutEvent entity.LockoutEvent) error {
+	result := ur.db.Create(&lockoutEvent)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) GetActiveLockoutEvents() ([]entity.LockoutEvent, error) {
+	var lockoutEvents []entity.LockoutEvent
+	result := ur.db.Where("unlocked_at IS NULL AND locked_until > ?", time.Now()).Order("created_at DESC").Find(&lockoutEvents)
+	if result.Error != nil {
+		return []entity.LockoutEvent{}, result.Error
+	}
+
+	return lockoutEvents, nil
+}
+
+func (ur *userRepositoryImpl) GetLockoutEventsByUserID(userID uint) ([]entity.LockoutEvent, error) {
+	var lockoutEvents []entity.LockoutEvent
+	result := ur.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&lockoutEvents)
+	if result.Error != nil {
+		return []entity.LockoutEvent{}, result.Error
+	}
+
+	return lockoutEvents, nil
+}
+
+func (ur *userRepositoryImpl) UnlockLockoutEvents(userID uint, unlockedBy uint) error {
+	result := ur.db.Model(&entity.LockoutEvent{}).Where("user_id = ? AND scope = ? AND unlocked_at IS NULL", userID, entity.LockoutScopeAccount).Updates(map[string]interface{}{
+		"unlocked_at": time.Now(),
+		"unlocked_by": unlockedBy,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) CreateImpersonation(impersonation entity.Impersonation) (uint, error) {
+	result := ur.db.Create(&impersonation)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return impersonation.ID, nil
+}
+
+func (ur *userRepositoryImpl) GetImpersonationByID(id uint) (entity.Impersonation, error) {
+	var impersonation entity.Impersonation
+	result := ur.db.First(&impersonation, id)
+	if result.Error != nil {
+		return entity.Impersonation{}, result.Error
+	}
+
+	return impersonation, nil
+}
+
+// GetImpersonations returns every impersonation, latest first, along with the
+// requests made during it.
+func (ur *userRepositoryImpl) GetImpersonations() ([]entity.Impersonation, error) {
+	var impersonations []entity.Impersonation
+	result := ur.db.Preload("Actions").Order("created_at DESC").Find(&impersonations)
+	if result.Error != nil {
+		return []entity.Impersonation{}, result.Error
+	}
+
+	return impersonations, nil
+}
+
+func (ur *userRepositoryImpl) EndImpersonation(id uint) error {
+	result := ur.db.Model(&entity.Impersonation{}).Where("id = ? AND ended_at IS NULL", id).Update("ended_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) CreateImpersonationAction(action entity.ImpersonationAction) error {
+	result := ur.db.Create(&action)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetImpersonationActionsByUserID returns the requests admins made as the user,
+// in the order they were made.
+func (ur *userRepositoryImpl) GetImpersonationActionsByUserID(userID uint) ([]entity.ImpersonationAction, error) {
+	var actions []entity.ImpersonationAction
+	result := ur.db.Where("user_id = ?", userID).Order("created_at, id").Find(&actions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return actions, nil
+}
+
+// SetTwoFactorSecret stores the secret of an enrollment that hasn't been
+// confirmed yet. It fails once two-factor authentication is enabled.
+func (ur *userRepositoryImpl) SetTwoFactorSecret(id uint, secret string) error {
+	result := ur.db.Model(&entity.User{}).Where("id = ? AND two_factor_enabled_at IS NULL", id).Update("two_factor_secret", secret)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+// EnableTwoFactor confirms the enrollment and replaces the user's recovery
+// codes. step is the time step of the code that confirmed it.
+func (ur *userRepositoryImpl) EnableTwoFactor(id uint, step int64, recoveryCodes []entity.RecoveryCode) error {
+	return ur.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.User{}).Where("id = ? AND two_factor_enabled_at IS NULL", id).Updates(map[string]interface{}{
+			"two_factor_enabled_at": time.Now(),
+			"two_factor_last_step":  step,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected != 1 {
+			return errors.New("no rows affected")
+		}
+
+		result = tx.Where("user_id = ?", id).Delete(&entity.RecoveryCode{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Create(&recoveryCodes)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return nil
+	})
+}
+
+func (ur *userRepositoryImpl) DisableTwoFactor(id uint) error {
+	return ur.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"two_factor_secret":     "",
+			"two_factor_enabled_at": nil,
+			"two_factor_last_step":  0,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Where("user_id = ?", id).Delete(&entity.RecoveryCode{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return nil
+	})
+}
+
+// UseTwoFactorStep records step as the last accepted one. It fails when a code
+// from the same or a later step has already been accepted, so a code that has
+// been used can't be replayed.
+func (ur *userRepositoryImpl) UseTwoFactorStep(id uint, step int64) error {
+	result := ur.db.Model(&entity.User{}).Where("id = ? AND two_factor_last_step < ?", id, step).Update("two_factor_last_step", step)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) UseRecoveryCode(userID uint, codeHash string) error {
+	result := ur.db.Model(&entity.RecoveryCode{}).Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+func (ur *userRepositoryImpl) GetUserIdentity(provider string, subject string) *entity.UserIdentity {
+	var userIdentity entity.UserIdentity
+	ur.db.First(&userIdentity, "provider = ? AND subject = ?", provider, subject)
+	if userIdentity.ID == 0 {
+		return nil
+	}
+
+	return &userIdentity
+}
+
+func (ur *userRepositoryImpl) GetUserIdentitiesByUserID(userID uint) ([]entity.UserIdentity, error) {
+	var userIdentities []entity.UserIdentity
+	result := ur.db.Where("user_id = ?", userID).Order("created_at").Find(&userIdentities)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return userIdentities, nil
+}
+
+func (ur *userRepositoryImpl) CreateUserIdentity(userIdentity entity.UserIdentity) error {
+	result := ur.db.Create(&userIdentity)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// CreateUserWithIdentity creates a user that signed up through an identity
+// provider together with the link to that provider's account.
+func (ur *userRepositoryImpl) CreateUserWithIdentity(user entity.User, userIdentity entity.UserIdentity) (uint, error) {
+	err := ur.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Create(&user)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		userIdentity.UserID = user.ID
+		result = tx.Create(&userIdentity)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return user.ID, nil
+}
+
 func CreateNewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepositoryImpl{db: db}
 }
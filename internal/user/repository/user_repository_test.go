@@ -30,7 +30,7 @@ func TestCreateUser(t *testing.T) {
 	defer mockedDB.Close()
 
 	mockObj.ExpectBegin()
-	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`name`,`email`,`phone_number`,`password`,`school_institution`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?)")).WithArgs("Alim Ikegami", "sdafsfa@gmail.com", "081111111111", "asdfasfas", "Udayana University", utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(1, 1))
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`name`,`email`,`phone_number`,`password`,`school_institution`,`verified_at`,`role`,`anonymized_at`,`email_visibility`,`phone_number_visibility`,`searchable`,`available_for_teams`,`institution_id`,`two_factor_secret`,`two_factor_enabled_at`,`two_factor_last_step`,`avatar_key`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)")).WithArgs("Alim Ikegami", "sdafsfa@gmail.com", "081111111111", "asdfasfas", "Udayana University", nil, "student", nil, "team", "team", true, false, nil, "", nil, 0, "", utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(1, 1))
 	mockObj.ExpectCommit()
 
 	userID, err := userRepo.CreateUser(entity.User{
@@ -39,6 +39,7 @@ func TestCreateUser(t *testing.T) {
 		PhoneNumber:       "081111111111",
 		Password:          "asdfasfas",
 		SchoolInstitution: "Udayana University",
+		Role:              "student",
 	})
 	assert.NoError(t, err)
 	assert.Equal(t, userID, uint(1))
@@ -60,7 +61,7 @@ func TestCreateUserUnexpectedDBError(t *testing.T) {
 	defer mockedDB.Close()
 
 	mockObj.ExpectBegin()
-	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`name`,`email`,`phone_number`,`password`,`school_institution`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?)")).WithArgs("Alim Ikegami", "sdafsfa@gmail.com", "081111111111", "asdfasfas", "Udayana University", utils.AnyTime{}, utils.AnyTime{}).WillReturnError(errors.New("unexpected DB error"))
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`name`,`email`,`phone_number`,`password`,`school_institution`,`verified_at`,`role`,`anonymized_at`,`email_visibility`,`phone_number_visibility`,`searchable`,`available_for_teams`,`institution_id`,`two_factor_secret`,`two_factor_enabled_at`,`two_factor_last_step`,`avatar_key`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)")).WithArgs("Alim Ikegami", "sdafsfa@gmail.com", "081111111111", "asdfasfas", "Udayana University", nil, "student", nil, "team", "team", true, false, nil, "", nil, 0, "", utils.AnyTime{}, utils.AnyTime{}).WillReturnError(errors.New("unexpected DB error"))
 	mockObj.ExpectCommit()
 
 	userID, err := userRepo.CreateUser(entity.User{
@@ -131,13 +132,14 @@ func TestAddUserSkills(t *testing.T) {
 	defer mockedDB.Close()
 
 	mockObj.ExpectBegin()
-	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `skills` (`name`,`user_id`,`created_at`,`updated_at`) VALUES (?,?,?,?)")).WithArgs("Node.Js", 1, utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(1, 1))
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_skills` (`user_id`,`skill_id`,`proficiency`,`created_at`,`updated_at`) VALUES (?,?,?,?,?) ON DUPLICATE KEY UPDATE `proficiency`=VALUES(`proficiency`),`updated_at`=VALUES(`updated_at`)")).WithArgs(1, 1, 3, utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(1, 1))
 	mockObj.ExpectCommit()
 
-	err = userRepo.AddUserSkills([]entity.Skill{
+	err = userRepo.AddUserSkills([]entity.UserSkill{
 		{
-			Name:   "Node.Js",
-			UserID: 1,
+			UserID:      1,
+			SkillID:     1,
+			Proficiency: 3,
 		},
 	})
 	assert.NoError(t, err)
@@ -159,14 +161,922 @@ func TestAddUserSkillsErrorDB(t *testing.T) {
 	defer mockedDB.Close()
 
 	mockObj.ExpectBegin()
-	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `skills` (`name`,`user_id`,`created_at`,`updated_at`) VALUES (?,?,?,?)")).WithArgs("Node.Js", 1, utils.AnyTime{}, utils.AnyTime{}).WillReturnError(errors.New("error occured"))
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_skills` (`user_id`,`skill_id`,`proficiency`,`created_at`,`updated_at`) VALUES (?,?,?,?,?) ON DUPLICATE KEY UPDATE `proficiency`=VALUES(`proficiency`),`updated_at`=VALUES(`updated_at`)")).WithArgs(1, 1, 3, utils.AnyTime{}, utils.AnyTime{}).WillReturnError(errors.New("error occured"))
 	mockObj.ExpectCommit()
 
-	err = userRepo.AddUserSkills([]entity.Skill{
+	err = userRepo.AddUserSkills([]entity.UserSkill{
 		{
-			Name:   "Node.Js",
-			UserID: 1,
+			UserID:      1,
+			SkillID:     1,
+			Proficiency: 3,
 		},
 	})
 	assert.Error(t, err)
 }
+
+func TestRevokeRefreshToken(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `refresh_tokens` SET `revoked_at`=?,`updated_at`=? WHERE id = ? AND revoked_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		err = userRepo.RevokeRefreshToken(1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("already-revoked", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `refresh_tokens` SET `revoked_at`=?,`updated_at`=? WHERE id = ? AND revoked_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectCommit()
+
+		err = userRepo.RevokeRefreshToken(1)
+		assert.Error(t, err)
+	})
+}
+
+func TestUsePasswordResetToken(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `password_reset_tokens` SET `used_at`=?,`updated_at`=? WHERE id = ? AND used_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		err = userRepo.UsePasswordResetToken(1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("already-used", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `password_reset_tokens` SET `used_at`=?,`updated_at`=? WHERE id = ? AND used_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectCommit()
+
+		err = userRepo.UsePasswordResetToken(1)
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateUserEmail(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `email`=?,`institution_id`=?,`verified_at`=?,`updated_at`=? WHERE id = ?")).WithArgs("new@gmail.com", nil, nil, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mockObj.ExpectCommit()
+
+	err = userRepo.UpdateUserEmail(1, "new@gmail.com")
+	assert.NoError(t, err)
+}
+
+func TestUpdateUserPrivacySettings(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `available_for_teams`=?,`email_visibility`=?,`phone_number_visibility`=?,`searchable`=?,`updated_at`=? WHERE id = ?")).WithArgs(true, "public", "private", false, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		err := userRepo.UpdateUserPrivacySettings(1, "public", "private", false, true)
+		assert.NoError(t, err)
+	})
+
+	t.Run("no-rows-affected", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `available_for_teams`=?,`email_visibility`=?,`phone_number_visibility`=?,`searchable`=?,`updated_at`=? WHERE id = ?")).WithArgs(true, "public", "private", false, utils.AnyTime{}, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectCommit()
+
+		err := userRepo.UpdateUserPrivacySettings(9, "public", "private", false, true)
+		assert.EqualError(t, err, "no rows affected")
+	})
+}
+
+func TestSearchUsers(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("any-skill", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "searchable"}).AddRow(2, "Alim Ikegami", true)
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE (searchable = ? AND verified_at IS NOT NULL AND anonymized_at IS NULL) AND id IN (SELECT `user_id` FROM `user_skills` WHERE skill_id IN (?,?) GROUP BY `user_id`) ORDER BY id LIMIT 10")).WithArgs(true, 1, 2).WillReturnRows(rows)
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_skills` WHERE `user_skills`.`user_id` = ?")).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"user_id", "skill_id", "proficiency"}).AddRow(2, 1, 3))
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `skills` WHERE `skills`.`id` = ?")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Go"))
+
+		users, err := userRepo.SearchUsers(UserSearchFilter{SkillIDs: []uint{1, 2}}, 10, 0)
+		assert.NoError(t, err)
+		assert.Len(t, users, 1)
+		assert.Equal(t, "Go", users[0].Skills[0].Skill.Name)
+	})
+
+	t.Run("all-skills", func(t *testing.T) {
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE (searchable = ? AND verified_at IS NOT NULL AND anonymized_at IS NULL) AND id IN (SELECT `user_id` FROM `user_skills` WHERE skill_id IN (?,?) GROUP BY `user_id` HAVING COUNT(DISTINCT skill_id) = ?) AND institution_id = ? AND available_for_teams = ? ORDER BY id LIMIT 10 OFFSET 20")).WithArgs(true, 1, 2, 2, 3, true).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		users, err := userRepo.SearchUsers(UserSearchFilter{SkillIDs: []uint{1, 2}, MatchAllSkills: true, InstitutionID: 3, AvailableOnly: true}, 10, 20)
+		assert.NoError(t, err)
+		assert.Empty(t, users)
+	})
+}
+
+func TestDeleteUserSkill(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `user_skills` WHERE user_id = ? AND skill_id = ?")).WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		err = userRepo.DeleteUserSkill(1, 2)
+		assert.NoError(t, err)
+	})
+
+	t.Run("other-users-skill", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `user_skills` WHERE user_id = ? AND skill_id = ?")).WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectCommit()
+
+		err = userRepo.DeleteUserSkill(1, 2)
+		assert.Error(t, err)
+	})
+}
+
+func TestRevokeSession(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `sessions` SET `revoked_at`=?,`updated_at`=? WHERE id = ? AND user_id = ? AND revoked_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `refresh_tokens` SET `revoked_at`=?,`updated_at`=? WHERE family_id IN (SELECT `family_id` FROM `sessions` WHERE id = ?) AND revoked_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		err = userRepo.RevokeSession(1, 2)
+		assert.NoError(t, err)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `sessions` SET `revoked_at`=?,`updated_at`=? WHERE id = ? AND user_id = ? AND revoked_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 2, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectRollback()
+
+		err = userRepo.RevokeSession(1, 2)
+		assert.EqualError(t, err, "no rows affected")
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `sessions` SET `revoked_at`=?,`updated_at`=? WHERE user_id = ? AND id <> ? AND revoked_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 1, 2).WillReturnResult(sqlmock.NewResult(0, 3))
+	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `refresh_tokens` SET `revoked_at`=?,`updated_at`=? WHERE user_id = ? AND family_id NOT IN (SELECT `family_id` FROM `sessions` WHERE id = ?) AND revoked_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 1, 2).WillReturnResult(sqlmock.NewResult(0, 3))
+	mockObj.ExpectCommit()
+
+	err = userRepo.RevokeUserSessions(1, 2)
+	assert.NoError(t, err)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestRevokeUserPersonalAccessTokens(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `personal_access_tokens` SET `revoked_at`=?,`updated_at`=? WHERE user_id = ? AND revoked_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 2))
+	mockObj.ExpectCommit()
+
+	err = userRepo.RevokeUserPersonalAccessTokens(1)
+	assert.NoError(t, err)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestGetActiveSessions(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	since := time.Now().Add(-time.Hour)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "family_id", "user_agent", "ip_address", "last_seen_at"}).
+		AddRow(2, 1, "family", "Mozilla/5.0", "10.0.0.1", time.Now())
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `sessions` WHERE user_id = ? AND revoked_at IS NULL AND last_seen_at > ? ORDER BY last_seen_at DESC")).WithArgs(1, since).WillReturnRows(rows)
+
+	sessions, err := userRepo.GetActiveSessions(1, since)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 1)
+	assert.Equal(t, "Mozilla/5.0", sessions[0].UserAgent)
+}
+
+func TestGetSessionsByUserID(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "family_id", "user_agent", "ip_address", "last_seen_at", "revoked_at"}).
+		AddRow(3, 1, "family-2", "Mozilla/5.0", "10.0.0.1", time.Now(), nil).
+		AddRow(2, 1, "family-1", "curl/7.79.1", "10.0.0.2", time.Now(), time.Now())
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `sessions` WHERE user_id = ? ORDER BY created_at DESC")).WithArgs(1).WillReturnRows(rows)
+
+	sessions, err := userRepo.GetSessionsByUserID(1)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.NotNil(t, sessions[1].RevokedAt)
+}
+
+func TestCreatePersonalAccessToken(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `personal_access_tokens` (`user_id`,`name`,`token_hash`,`scopes`,`last_used_at`,`expires_at`,`revoked_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?)")).WithArgs(1, "union bot", "hash", "registrations:read recruitments:read", nil, nil, nil, utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(4, 1))
+	mockObj.ExpectCommit()
+
+	id, err := userRepo.CreatePersonalAccessToken(entity.PersonalAccessToken{
+		UserID:    1,
+		Name:      "union bot",
+		TokenHash: "hash",
+		Scopes:    "registrations:read recruitments:read",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), id)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestRevokePersonalAccessToken(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `personal_access_tokens` SET `revoked_at`=?,`updated_at`=? WHERE id = ? AND user_id = ? AND revoked_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 4, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		err = userRepo.RevokePersonalAccessToken(1, 4)
+		assert.NoError(t, err)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `personal_access_tokens` SET `revoked_at`=?,`updated_at`=? WHERE id = ? AND user_id = ? AND revoked_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 4, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectCommit()
+
+		err = userRepo.RevokePersonalAccessToken(1, 4)
+		assert.EqualError(t, err, "no rows affected")
+	})
+}
+
+func TestAnonymizeUser(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `anonymized_at`=?,`avatar_key`=?,`email`=?,`institution_id`=?,`name`=?,`password`=?,`phone_number`=?,`school_institution`=?,`two_factor_enabled_at`=?,`two_factor_secret`=?,`verified_at`=?,`updated_at`=? WHERE id = ? AND anonymized_at IS NULL")).WithArgs(utils.AnyTime{}, "", "deleted-user-1@compnouron.invalid", nil, "Deleted User", "", "", "", nil, "", nil, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `user_skills` WHERE user_id = ?")).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `recovery_codes` WHERE user_id = ?")).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `user_identities` WHERE user_id = ?")).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `team_members` WHERE user_id = ? AND role <> ?")).WithArgs(1, "owner").WillReturnResult(sqlmock.NewResult(0, 2))
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `lockout_events` WHERE user_id = ?")).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `refresh_tokens` SET `revoked_at`=?,`updated_at`=? WHERE user_id = ? AND revoked_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `sessions` SET `revoked_at`=?,`updated_at`=? WHERE user_id = ? AND revoked_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `sessions` SET `ip_address`=?,`user_agent`=?,`updated_at`=? WHERE user_id = ?")).WithArgs("", "", utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 3))
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `personal_access_tokens` SET `revoked_at`=?,`updated_at`=? WHERE user_id = ? AND revoked_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `password_reset_tokens` SET `used_at`=?,`updated_at`=? WHERE user_id = ? AND used_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectCommit()
+
+		err = userRepo.AnonymizeUser(1)
+		assert.NoError(t, err)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+
+	t.Run("already-anonymized", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `anonymized_at`=?,`avatar_key`=?,`email`=?,`institution_id`=?,`name`=?,`password`=?,`phone_number`=?,`school_institution`=?,`two_factor_enabled_at`=?,`two_factor_secret`=?,`verified_at`=?,`updated_at`=? WHERE id = ? AND anonymized_at IS NULL")).WithArgs(utils.AnyTime{}, "", "deleted-user-1@compnouron.invalid", nil, "Deleted User", "", "", "", nil, "", nil, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectRollback()
+
+		err = userRepo.AnonymizeUser(1)
+		assert.EqualError(t, err, "no rows affected")
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+}
+
+func TestUnlockLockoutEvents(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `lockout_events` SET `unlocked_at`=?,`unlocked_by`=?,`updated_at`=? WHERE user_id = ? AND scope = ? AND unlocked_at IS NULL")).WithArgs(utils.AnyTime{}, 1, utils.AnyTime{}, 2, "account").WillReturnResult(sqlmock.NewResult(0, 1))
+	mockObj.ExpectCommit()
+
+	err = userRepo.UnlockLockoutEvents(2, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestGetActiveLockoutEvents(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "email", "ip_address", "scope"}).AddRow(1, 2, "asdfa@gmail.com", "10.0.0.1", "account")
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `lockout_events` WHERE unlocked_at IS NULL AND locked_until > ? ORDER BY created_at DESC")).WithArgs(utils.AnyTime{}).WillReturnRows(rows)
+
+	lockoutEvents, err := userRepo.GetActiveLockoutEvents()
+	assert.NoError(t, err)
+	assert.Len(t, lockoutEvents, 1)
+	assert.Equal(t, uint(2), *lockoutEvents[0].UserID)
+}
+
+func TestGetLockoutEventsByUserID(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "email", "ip_address", "scope", "unlocked_at"}).
+		AddRow(2, 1, "asdfa@gmail.com", "10.0.0.1", "account", nil).
+		AddRow(1, 1, "asdfa@gmail.com", "10.0.0.1", "account", time.Now())
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `lockout_events` WHERE user_id = ? ORDER BY created_at DESC")).WithArgs(1).WillReturnRows(rows)
+
+	lockoutEvents, err := userRepo.GetLockoutEventsByUserID(1)
+	assert.NoError(t, err)
+	assert.Len(t, lockoutEvents, 2)
+	assert.NotNil(t, lockoutEvents[1].UnlockedAt)
+}
+
+func TestCreateImpersonation(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	expiresAt := time.Now().Add(15 * time.Minute)
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `impersonations` (`actor_id`,`user_id`,`session_id`,`reason`,`ip_address`,`expires_at`,`ended_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?)")).WithArgs(1, 2, 7, "ticket #42", "10.0.0.1", expiresAt, nil, utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(3, 1))
+	mockObj.ExpectCommit()
+
+	id, err := userRepo.CreateImpersonation(entity.Impersonation{ActorID: 1, UserID: 2, SessionID: 7, Reason: "ticket #42", IPAddress: "10.0.0.1", ExpiresAt: expiresAt})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), id)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestGetImpersonations(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "actor_id", "user_id", "reason"}).AddRow(3, 1, 2, "ticket #42")
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `impersonations` ORDER BY created_at DESC")).WillReturnRows(rows)
+	actionRows := sqlmock.NewRows([]string{"id", "impersonation_id", "method", "path", "status"}).AddRow(1, 3, "GET", "/users/competitions/registrations", 200)
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `impersonation_actions` WHERE `impersonation_actions`.`impersonation_id` = ?")).WithArgs(3).WillReturnRows(actionRows)
+
+	impersonations, err := userRepo.GetImpersonations()
+	assert.NoError(t, err)
+	assert.Len(t, impersonations, 1)
+	assert.Equal(t, "/users/competitions/registrations", impersonations[0].Actions[0].Path)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestEndImpersonation(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `impersonations` SET `ended_at`=?,`updated_at`=? WHERE id = ? AND ended_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		assert.NoError(t, userRepo.EndImpersonation(3))
+	})
+
+	t.Run("already-ended", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `impersonations` SET `ended_at`=?,`updated_at`=? WHERE id = ? AND ended_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 3).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectCommit()
+
+		assert.EqualError(t, userRepo.EndImpersonation(3), "no rows affected")
+	})
+}
+
+func TestCreateImpersonationAction(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `impersonation_actions` (`impersonation_id`,`actor_id`,`user_id`,`method`,`path`,`status`,`ip_address`,`created_at`) VALUES (?,?,?,?,?,?,?,?)")).WithArgs(3, 1, 2, "GET", "/users/competitions/registrations", 200, "10.0.0.1", utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(1, 1))
+	mockObj.ExpectCommit()
+
+	err = userRepo.CreateImpersonationAction(entity.ImpersonationAction{ImpersonationID: 3, ActorID: 1, UserID: 2, Method: "GET", Path: "/users/competitions/registrations", Status: 200, IPAddress: "10.0.0.1"})
+	assert.NoError(t, err)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestEnableTwoFactor(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `two_factor_enabled_at`=?,`two_factor_last_step`=?,`updated_at`=? WHERE id = ? AND two_factor_enabled_at IS NULL")).WithArgs(utils.AnyTime{}, 55135680, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `recovery_codes` WHERE user_id = ?")).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `recovery_codes` (`user_id`,`code_hash`,`used_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?),(?,?,?,?,?)")).WithArgs(1, "hash-1", nil, utils.AnyTime{}, utils.AnyTime{}, 1, "hash-2", nil, utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(1, 2))
+		mockObj.ExpectCommit()
+
+		err = userRepo.EnableTwoFactor(1, 55135680, []entity.RecoveryCode{
+			{UserID: 1, CodeHash: "hash-1"},
+			{UserID: 1, CodeHash: "hash-2"},
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+
+	t.Run("already-enabled", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `two_factor_enabled_at`=?,`two_factor_last_step`=?,`updated_at`=? WHERE id = ? AND two_factor_enabled_at IS NULL")).WithArgs(utils.AnyTime{}, 55135680, utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectRollback()
+
+		err = userRepo.EnableTwoFactor(1, 55135680, []entity.RecoveryCode{{UserID: 1, CodeHash: "hash-1"}})
+		assert.EqualError(t, err, "no rows affected")
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+}
+
+func TestUseTwoFactorStep(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `two_factor_last_step`=?,`updated_at`=? WHERE id = ? AND two_factor_last_step < ?")).WithArgs(55135681, utils.AnyTime{}, 1, 55135681).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		err = userRepo.UseTwoFactorStep(1, 55135681)
+		assert.NoError(t, err)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `two_factor_last_step`=?,`updated_at`=? WHERE id = ? AND two_factor_last_step < ?")).WithArgs(55135681, utils.AnyTime{}, 1, 55135681).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectCommit()
+
+		err = userRepo.UseTwoFactorStep(1, 55135681)
+		assert.EqualError(t, err, "no rows affected")
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `recovery_codes` SET `used_at`=?,`updated_at`=? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL")).WithArgs(utils.AnyTime{}, utils.AnyTime{}, 1, "hash-1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mockObj.ExpectCommit()
+
+	err = userRepo.UseRecoveryCode(1, "hash-1")
+	assert.EqualError(t, err, "no rows affected")
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestGetUserIdentity(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("found", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "user_id", "provider", "subject", "email"}).AddRow(1, 2, "google", "110169484474386276334", "alim@student.unud.ac.id")
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_identities` WHERE provider = ? AND subject = ? ORDER BY `user_identities`.`id` LIMIT 1")).WithArgs("google", "110169484474386276334").WillReturnRows(rows)
+
+		userIdentity := userRepo.GetUserIdentity("google", "110169484474386276334")
+		assert.NotNil(t, userIdentity)
+		assert.Equal(t, uint(2), userIdentity.UserID)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_identities` WHERE provider = ? AND subject = ? ORDER BY `user_identities`.`id` LIMIT 1")).WithArgs("google", "1").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		userIdentity := userRepo.GetUserIdentity("google", "1")
+		assert.Nil(t, userIdentity)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+}
+
+func TestCreateUserWithIdentity(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	verifiedAt := time.Now()
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`name`,`email`,`phone_number`,`password`,`school_institution`,`verified_at`,`role`,`anonymized_at`,`email_visibility`,`phone_number_visibility`,`searchable`,`available_for_teams`,`institution_id`,`two_factor_secret`,`two_factor_enabled_at`,`two_factor_last_step`,`avatar_key`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)")).WithArgs("Alim Ikegami", "alim@student.unud.ac.id", "", "", "", utils.AnyTime{}, "student", nil, "team", "team", true, false, nil, "", nil, 0, "", utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(3, 1))
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_identities` (`user_id`,`provider`,`subject`,`email`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?)")).WithArgs(3, "google", "110169484474386276334", "alim@student.unud.ac.id", utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(1, 1))
+	mockObj.ExpectCommit()
+
+	userID, err := userRepo.CreateUserWithIdentity(entity.User{
+		Name:       "Alim Ikegami",
+		Email:      "alim@student.unud.ac.id",
+		Role:       "student",
+		VerifiedAt: &verifiedAt,
+	}, entity.UserIdentity{
+		Provider: "google",
+		Subject:  "110169484474386276334",
+		Email:    "alim@student.unud.ac.id",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), userID)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestUpdateUserAvatar(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	t.Run("success", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `avatar_key`=?,`updated_at`=? WHERE id = ?")).WithArgs("avatars/1/a.jpg", utils.AnyTime{}, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		err := userRepo.UpdateUserAvatar(1, "avatars/1/a.jpg")
+		assert.NoError(t, err)
+	})
+
+	t.Run("no-rows-affected", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `avatar_key`=?,`updated_at`=? WHERE id = ?")).WithArgs("", utils.AnyTime{}, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectCommit()
+
+		err := userRepo.UpdateUserAvatar(9, "")
+		assert.EqualError(t, err, "no rows affected")
+	})
+}
+
+func TestGetAllPersonalAccessTokens(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "name", "token_hash", "scopes", "revoked_at"}).
+		AddRow(2, 1, "CI", "hash-2", "registrations:read", nil).
+		AddRow(1, 1, "Old bot", "hash-1", "teams:read", time.Now())
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `personal_access_tokens` WHERE user_id = ? ORDER BY created_at DESC")).WithArgs(1).WillReturnRows(rows)
+
+	tokens, err := userRepo.GetAllPersonalAccessTokens(1)
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 2)
+	assert.NotNil(t, tokens[1].RevokedAt)
+}
+
+func TestGetImpersonationActionsByUserID(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "impersonation_id", "actor_id", "user_id", "method", "path", "status", "ip_address"}).
+		AddRow(1, 3, 2, 1, "GET", "/users/me", 200, "10.0.0.1")
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `impersonation_actions` WHERE user_id = ? ORDER BY created_at, id")).WithArgs(1).WillReturnRows(rows)
+
+	actions, err := userRepo.GetImpersonationActionsByUserID(1)
+	assert.NoError(t, err)
+	assert.Len(t, actions, 1)
+	assert.Equal(t, "/users/me", actions[0].Path)
+}
+
+func TestGetUserIdentitiesByUserID(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	userRepo := CreateNewUserRepository(db)
+
+	defer mockedDB.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "provider", "subject", "email"}).
+		AddRow(1, 1, "google", "1234", "asdfa@gmail.com")
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_identities` WHERE user_id = ? ORDER BY created_at")).WithArgs(1).WillReturnRows(rows)
+
+	userIdentities, err := userRepo.GetUserIdentitiesByUserID(1)
+	assert.NoError(t, err)
+	assert.Len(t, userIdentities, 1)
+	assert.Equal(t, "google", userIdentities[0].Provider)
+}
@@ -1,62 +1,612 @@
 package usecase
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"errors"
+	"github.com/alimikegami/compnouron/internal/policy"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	entityComp "github.com/alimikegami/compnouron/internal/competition/entity"
+	discussionEntity "github.com/alimikegami/compnouron/internal/discussion/entity"
+	institutionEntity "github.com/alimikegami/compnouron/internal/institution/entity"
+	"github.com/alimikegami/compnouron/internal/media"
 	competitionRepo "github.com/alimikegami/compnouron/internal/mocks/competition/repository"
+	discussionRepo "github.com/alimikegami/compnouron/internal/mocks/discussion/repository"
+	institutionRepo "github.com/alimikegami/compnouron/internal/mocks/institution/repository"
+	guardMocks "github.com/alimikegami/compnouron/internal/mocks/loginguard"
+	mailerMocks "github.com/alimikegami/compnouron/internal/mocks/mailer"
+	mediaMocks "github.com/alimikegami/compnouron/internal/mocks/media"
+	oidcMocks "github.com/alimikegami/compnouron/internal/mocks/oidc"
+	privacyMocks "github.com/alimikegami/compnouron/internal/mocks/privacy"
 	recruitmentRepo "github.com/alimikegami/compnouron/internal/mocks/recruitment/repository"
+	skillRepo "github.com/alimikegami/compnouron/internal/mocks/skill/repository"
+	teamRepo "github.com/alimikegami/compnouron/internal/mocks/team/repository"
 	userRepo "github.com/alimikegami/compnouron/internal/mocks/user/repository"
+	"github.com/alimikegami/compnouron/internal/privacy"
 	entityRec "github.com/alimikegami/compnouron/internal/recruitment/entity"
+	skillEntity "github.com/alimikegami/compnouron/internal/skill/entity"
+	entityTeam "github.com/alimikegami/compnouron/internal/team/entity"
 	"github.com/alimikegami/compnouron/internal/user/dto"
 	"github.com/alimikegami/compnouron/internal/user/entity"
+	userRepository "github.com/alimikegami/compnouron/internal/user/repository"
+	"github.com/alimikegami/compnouron/pkg/keyring"
+	"github.com/alimikegami/compnouron/pkg/loginguard"
+	"github.com/alimikegami/compnouron/pkg/oidc"
+	"github.com/alimikegami/compnouron/pkg/oidc/oidctest"
+	"github.com/alimikegami/compnouron/pkg/password"
+	"github.com/alimikegami/compnouron/pkg/totp"
+	"github.com/alimikegami/compnouron/pkg/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
+func newTestKeyRing() keyring.Ring {
+	options := keyring.DefaultOptions
+	options.Algorithm = keyring.AlgorithmEdDSA
+	kr, err := keyring.CreateNewRing(keyring.CreateNewMemoryStore(), options)
+	if err != nil {
+		panic(err)
+	}
+	return kr
+}
+
+var testKeyRing = newTestKeyRing()
+
+// testPasswordChecker accepts any non-empty password, tests of the policy
+// itself build a checker with password.DefaultPolicy
+var testPasswordChecker = password.CreateNewChecker(password.Policy{MinLength: 1}, nil)
+
+// testEncryptionKey seals the TOTP secrets and the OIDC flow state in tests
+const testEncryptionKey = "0123456789abcdef0123456789abcdef"
+
 func TestLogin(t *testing.T) {
 	mockRepo := userRepo.NewUserRepository(t)
 	mockCompetition := competitionRepo.NewCompetitionRepository(t)
 	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	user := &entity.User{
+		ID:                1,
+		Name:              "Alim Ikegami",
+		Email:             "asdfa@gmail.com",
+		PhoneNumber:       "081111111111",
+		SchoolInstitution: "Udayana University",
+		Password:          "$2a$10$YefQPq3c5H7OalTHNFgo8Ob7Sxjc8F.fI3.ePvHOhOYCkqOGrFhm6",
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}
 	t.Run("success", func(t *testing.T) {
-		mockRepo.On("GetUserByEmail", "asdfa@gmail.com").Return(&entity.User{
-			ID:                1,
-			Name:              "Alim Ikegami",
-			Email:             "asdfa@gmail.com",
-			PhoneNumber:       "081111111111",
-			SchoolInstitution: "Udayana University",
-			Password:          "$2a$10$YefQPq3c5H7OalTHNFgo8Ob7Sxjc8F.fI3.ePvHOhOYCkqOGrFhm6",
-			CreatedAt:         time.Now(),
-			UpdatedAt:         time.Now(),
-		}).Once()
-		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment)
+		mockGuard.On("Check", "asdfa@gmail.com", "10.0.0.1").Return(nil).Once()
+		mockRepo.On("GetUserByEmail", "asdfa@gmail.com").Return(user).Once()
+		mockGuard.On("Succeed", "asdfa@gmail.com", "10.0.0.1").Return(nil).Once()
+		mockRepo.On("CreateSession", mock.MatchedBy(func(session entity.Session) bool {
+			return session.UserID == user.ID && session.UserAgent == "Mozilla/5.0" && session.IPAddress == "10.0.0.1" && session.FamilyID != ""
+		})).Return(uint(7), nil).Once()
+		mockRepo.On("CreateRefreshToken", mock.AnythingOfType("entity.RefreshToken")).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
 		token, err := testUseCase.Login(&dto.Credential{
 			Email:    "asdfa@gmail.com",
 			Password: "asdfasfas",
-		})
+		}, "10.0.0.1", "Mozilla/5.0")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token.Token)
+		assert.NotEmpty(t, token.RefreshToken)
+		accessToken, err := utils.ParseJWTToken(testKeyRing, token.Token)
 		assert.NoError(t, err)
-		assert.NotNil(t, token)
+		assert.Equal(t, uint(7), accessToken.Claims.(*utils.JwtCustomClaims).SessionID)
 		mockRepo.AssertExpectations(t)
+		mockGuard.AssertExpectations(t)
 	})
 
 	t.Run("user-not-found", func(t *testing.T) {
+		mockGuard.On("Check", "asdfa@gmail.com", "10.0.0.1").Return(nil).Once()
 		mockRepo.On("GetUserByEmail", "asdfa@gmail.com").Return(nil).Once()
-		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment)
+		mockGuard.On("Fail", "asdfa@gmail.com", "10.0.0.1").Return(loginguard.Lockout{}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
 		token, err := testUseCase.Login(&dto.Credential{
 			Email:    "asdfa@gmail.com",
 			Password: "asdfasfas",
-		})
-		assert.Error(t, err)
-		assert.Equal(t, token, "")
+		}, "10.0.0.1", "Mozilla/5.0")
+		assert.EqualError(t, err, "credentials dont match")
+		assert.Empty(t, token)
+		mockRepo.AssertExpectations(t)
+		mockGuard.AssertExpectations(t)
+	})
+
+	t.Run("account-locked-out", func(t *testing.T) {
+		lockedUntil := time.Now().Add(15 * time.Minute)
+		mockGuard.On("Check", "asdfa@gmail.com", "10.0.0.1").Return(nil).Once()
+		mockRepo.On("GetUserByEmail", "asdfa@gmail.com").Return(user).Once()
+		mockGuard.On("Fail", "asdfa@gmail.com", "10.0.0.1").Return(loginguard.Lockout{Account: true, LockedUntil: lockedUntil}, nil).Once()
+		mockRepo.On("CreateLockoutEvent", entity.LockoutEvent{
+			UserID:      &user.ID,
+			Email:       "asdfa@gmail.com",
+			IPAddress:   "10.0.0.1",
+			Scope:       entity.LockoutScopeAccount,
+			LockedUntil: lockedUntil,
+		}).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.Login(&dto.Credential{
+			Email:    "asdfa@gmail.com",
+			Password: "wrong",
+		}, "10.0.0.1", "Mozilla/5.0")
+		assert.EqualError(t, err, "credentials dont match")
+		mockRepo.AssertExpectations(t)
+		mockGuard.AssertExpectations(t)
+	})
+
+	t.Run("too-many-attempts", func(t *testing.T) {
+		mockGuard.On("Check", "asdfa@gmail.com", "10.0.0.1").Return(loginguard.ErrTooManyAttempts).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.Login(&dto.Credential{
+			Email:    "asdfa@gmail.com",
+			Password: "asdfasfas",
+		}, "10.0.0.1", "Mozilla/5.0")
+		assert.ErrorIs(t, err, loginguard.ErrTooManyAttempts)
+		mockGuard.AssertExpectations(t)
+	})
+
+	t.Run("two-factor-required", func(t *testing.T) {
+		enabledAt := time.Now()
+		twoFactorUser := *user
+		twoFactorUser.TwoFactorEnabledAt = &enabledAt
+		mockGuard.On("Check", "asdfa@gmail.com", "10.0.0.1").Return(nil).Once()
+		mockRepo.On("GetUserByEmail", "asdfa@gmail.com").Return(&twoFactorUser).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		token, err := testUseCase.Login(&dto.Credential{
+			Email:    "asdfa@gmail.com",
+			Password: "asdfasfas",
+		}, "10.0.0.1", "Mozilla/5.0")
+		assert.NoError(t, err)
+		assert.True(t, token.TwoFactorRequired)
+		assert.Empty(t, token.Token)
+		claims, err := utils.ParsePurposeToken(utils.TwoFactorLoginPurpose, token.ChallengeToken)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), claims.ID)
+		mockRepo.AssertExpectations(t)
+		mockGuard.AssertExpectations(t)
+	})
+}
+
+func TestLoginWithTwoFactor(t *testing.T) {
+	t.Setenv("ENCRYPTION_KEY", testEncryptionKey)
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	secret, _ := totp.GenerateSecret()
+	encryptedSecret, _ := utils.EncryptSecret(secret)
+	enabledAt := time.Now()
+	user := entity.User{
+		ID:                 1,
+		Email:              "asdfa@gmail.com",
+		Role:               utils.RoleStudent,
+		TwoFactorSecret:    encryptedSecret,
+		TwoFactorEnabledAt: &enabledAt,
+	}
+	challengeToken, _ := utils.CreateSignedPurposeToken(utils.TwoFactorLoginPurpose, 1, "asdfa@gmail.com", time.Minute)
+
+	t.Run("success", func(t *testing.T) {
+		code, _ := totp.Code(secret, totp.Step(time.Now()))
+		mockRepo.On("GetUserByID", uint(1)).Return(user, nil).Once()
+		mockGuard.On("Check", "asdfa@gmail.com", "10.0.0.1").Return(nil).Once()
+		mockRepo.On("UseTwoFactorStep", uint(1), mock.AnythingOfType("int64")).Return(nil).Once()
+		mockGuard.On("Succeed", "asdfa@gmail.com", "10.0.0.1").Return(nil).Once()
+		mockRepo.On("CreateSession", mock.AnythingOfType("entity.Session")).Return(uint(7), nil).Once()
+		mockRepo.On("CreateRefreshToken", mock.AnythingOfType("entity.RefreshToken")).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		token, err := testUseCase.LoginWithTwoFactor(dto.TwoFactorLoginRequest{ChallengeToken: challengeToken, Code: code}, "10.0.0.1", "Mozilla/5.0")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token.Token)
+		assert.NotEmpty(t, token.RefreshToken)
+		mockRepo.AssertExpectations(t)
+		mockGuard.AssertExpectations(t)
+	})
+
+	t.Run("recovery-code", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(user, nil).Once()
+		mockGuard.On("Check", "asdfa@gmail.com", "10.0.0.1").Return(nil).Once()
+		mockRepo.On("UseRecoveryCode", uint(1), utils.HashToken("abcdefghij")).Return(nil).Once()
+		mockGuard.On("Succeed", "asdfa@gmail.com", "10.0.0.1").Return(nil).Once()
+		mockRepo.On("CreateSession", mock.AnythingOfType("entity.Session")).Return(uint(7), nil).Once()
+		mockRepo.On("CreateRefreshToken", mock.AnythingOfType("entity.RefreshToken")).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		token, err := testUseCase.LoginWithTwoFactor(dto.TwoFactorLoginRequest{ChallengeToken: challengeToken, Code: "ABCDE-FGHIJ"}, "10.0.0.1", "Mozilla/5.0")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token.Token)
+		mockRepo.AssertExpectations(t)
+		mockGuard.AssertExpectations(t)
+	})
+
+	t.Run("replayed-code", func(t *testing.T) {
+		code, _ := totp.Code(secret, totp.Step(time.Now()))
+		mockRepo.On("GetUserByID", uint(1)).Return(user, nil).Once()
+		mockGuard.On("Check", "asdfa@gmail.com", "10.0.0.1").Return(nil).Once()
+		mockRepo.On("UseTwoFactorStep", uint(1), mock.AnythingOfType("int64")).Return(errors.New("no rows affected")).Once()
+		mockGuard.On("Fail", "asdfa@gmail.com", "10.0.0.1").Return(loginguard.Lockout{}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.LoginWithTwoFactor(dto.TwoFactorLoginRequest{ChallengeToken: challengeToken, Code: code}, "10.0.0.1", "Mozilla/5.0")
+		assert.EqualError(t, err, "invalid two-factor code")
+		mockRepo.AssertExpectations(t)
+		mockGuard.AssertExpectations(t)
+	})
+
+	t.Run("invalid-challenge-token", func(t *testing.T) {
+		accessToken, _ := utils.CreateSignedJWTToken(testKeyRing, 1, "asdfa@gmail.com", utils.RoleStudent, 7)
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.LoginWithTwoFactor(dto.TwoFactorLoginRequest{ChallengeToken: accessToken, Code: "123456"}, "10.0.0.1", "Mozilla/5.0")
+		assert.EqualError(t, err, "invalid challenge token")
+	})
+}
+
+func TestStartOIDCLogin(t *testing.T) {
+	t.Setenv("ENCRYPTION_KEY", testEncryptionKey)
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	mockProvider := oidcMocks.NewProvider(t)
+	providers := map[string]oidc.Provider{"google": mockProvider}
+
+	t.Run("success", func(t *testing.T) {
+		mockProvider.On("AuthCodeURL", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return("https://accounts.google.com/o/oauth2/v2/auth?state=state", nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, providers, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		authorization, err := testUseCase.StartOIDCLogin("google")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://accounts.google.com/o/oauth2/v2/auth?state=state", authorization.AuthorizationURL)
+
+		// the code verifier only travels inside the encrypted flow state
+		verifier := mockProvider.Calls[len(mockProvider.Calls)-1].Arguments.String(2)
+		assert.NotContains(t, authorization.FlowState, verifier)
+		flowState, err := utils.DecryptSecret(authorization.FlowState)
+		assert.NoError(t, err)
+		assert.Contains(t, flowState, verifier)
+		mockProvider.AssertExpectations(t)
+	})
+
+	t.Run("unknown-provider", func(t *testing.T) {
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, providers, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.StartOIDCLogin("facebook")
+		assert.EqualError(t, err, "unknown identity provider")
+	})
+}
+
+// TestCompleteOIDCLogin walks through the whole login against a local identity
+// provider.
+func TestCompleteOIDCLogin(t *testing.T) {
+	t.Setenv("ENCRYPTION_KEY", testEncryptionKey)
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	identity := oidc.Identity{
+		Subject:       "110169484474386276334",
+		Email:         "alim@student.unud.ac.id",
+		EmailVerified: true,
+		Name:          "Alim Ikegami",
+	}
+	server := oidctest.NewServer("compnouron", "secret", identity)
+	defer server.Close()
+	providers := map[string]oidc.Provider{
+		"campus": oidc.CreateNewProvider(oidc.ProviderConfig{
+			Name:         "campus",
+			IssuerURL:    server.URL,
+			ClientID:     "compnouron",
+			ClientSecret: "secret",
+			RedirectURL:  "http://localhost:1323/users/oidc/campus/callback",
+		}, server.Client()),
+	}
+	testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, providers, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+	login := func() (dto.OIDCCallbackRequest, error) {
+		authorization, err := testUseCase.StartOIDCLogin("campus")
+		if err != nil {
+			return dto.OIDCCallbackRequest{}, err
+		}
+		code, state, err := server.Authorize(authorization.AuthorizationURL)
+		return dto.OIDCCallbackRequest{Code: code, State: state, FlowState: authorization.FlowState}, err
+	}
+
+	t.Run("new-user", func(t *testing.T) {
+		server.SetIdentity(identity)
+		callback, err := login()
+		assert.NoError(t, err)
+		mockRepo.On("GetUserIdentity", "campus", "110169484474386276334").Return(nil).Once()
+		mockRepo.On("GetUserByEmail", "alim@student.unud.ac.id").Return(&entity.User{}).Once()
+		mockInstitution.On("GetInstitutionByDomains", []string{"student.unud.ac.id", "unud.ac.id", "ac.id"}).Return(institutionEntity.Institution{ID: 2, Name: "Udayana University"}, nil).Once()
+		mockRepo.On("CreateUserWithIdentity", mock.MatchedBy(func(user entity.User) bool {
+			return user.Name == "Alim Ikegami" && user.Password == "" && user.Role == utils.RoleStudent && user.VerifiedAt != nil && user.InstitutionID != nil && *user.InstitutionID == 2
+		}), entity.UserIdentity{Provider: "campus", Subject: "110169484474386276334", Email: "alim@student.unud.ac.id"}).Return(uint(3), nil).Once()
+		mockRepo.On("CreateSession", mock.AnythingOfType("entity.Session")).Return(uint(7), nil).Once()
+		mockRepo.On("CreateRefreshToken", mock.MatchedBy(func(refreshToken entity.RefreshToken) bool {
+			return refreshToken.UserID == 3
+		})).Return(nil).Once()
+		token, err := testUseCase.CompleteOIDCLogin("campus", callback, "10.0.0.1", "Mozilla/5.0")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token.Token)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("link-existing-user", func(t *testing.T) {
+		server.SetIdentity(identity)
+		callback, err := login()
+		assert.NoError(t, err)
+		mockRepo.On("GetUserIdentity", "campus", "110169484474386276334").Return(nil).Once()
+		mockRepo.On("GetUserByEmail", "alim@student.unud.ac.id").Return(&entity.User{ID: 1, Email: "alim@student.unud.ac.id"}).Once()
+		mockRepo.On("CreateUserIdentity", entity.UserIdentity{UserID: 1, Provider: "campus", Subject: "110169484474386276334", Email: "alim@student.unud.ac.id"}).Return(nil).Once()
+		mockRepo.On("VerifyUserEmail", uint(1)).Return(nil).Once()
+		mockInstitution.On("GetInstitutionByDomains", []string{"student.unud.ac.id", "unud.ac.id", "ac.id"}).Return(institutionEntity.Institution{}, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("CreateSession", mock.AnythingOfType("entity.Session")).Return(uint(7), nil).Once()
+		mockRepo.On("CreateRefreshToken", mock.AnythingOfType("entity.RefreshToken")).Return(nil).Once()
+		token, err := testUseCase.CompleteOIDCLogin("campus", callback, "10.0.0.1", "Mozilla/5.0")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token.Token)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unverified-password-account", func(t *testing.T) {
+		server.SetIdentity(identity)
+		callback, err := login()
+		assert.NoError(t, err)
+		mockRepo.On("GetUserIdentity", "campus", "110169484474386276334").Return(nil).Once()
+		mockRepo.On("GetUserByEmail", "alim@student.unud.ac.id").Return(&entity.User{ID: 1, Email: "alim@student.unud.ac.id", Password: "$2a$10$hashed"}).Once()
+		_, err = testUseCase.CompleteOIDCLogin("campus", callback, "10.0.0.1", "Mozilla/5.0")
+		assert.EqualError(t, err, "verify the email of your account before signing in with this provider")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("linked-user-with-two-factor", func(t *testing.T) {
+		server.SetIdentity(identity)
+		callback, err := login()
+		assert.NoError(t, err)
+		enabledAt := time.Now()
+		mockRepo.On("GetUserIdentity", "campus", "110169484474386276334").Return(&entity.UserIdentity{UserID: 1}).Once()
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Email: "alim@student.unud.ac.id", TwoFactorEnabledAt: &enabledAt}, nil).Once()
+		token, err := testUseCase.CompleteOIDCLogin("campus", callback, "10.0.0.1", "Mozilla/5.0")
+		assert.NoError(t, err)
+		assert.True(t, token.TwoFactorRequired)
+		assert.Empty(t, token.Token)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unverified-email", func(t *testing.T) {
+		unverified := identity
+		unverified.Subject = "2"
+		unverified.EmailVerified = false
+		server.SetIdentity(unverified)
+		callback, err := login()
+		assert.NoError(t, err)
+		mockRepo.On("GetUserIdentity", "campus", "2").Return(nil).Once()
+		_, err = testUseCase.CompleteOIDCLogin("campus", callback, "10.0.0.1", "Mozilla/5.0")
+		assert.EqualError(t, err, "the identity provider has not verified this email")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("state-mismatch", func(t *testing.T) {
+		callback, err := login()
+		assert.NoError(t, err)
+		callback.State = "forged"
+		_, err = testUseCase.CompleteOIDCLogin("campus", callback, "10.0.0.1", "Mozilla/5.0")
+		assert.EqualError(t, err, "invalid login state")
+	})
+
+	t.Run("flow-from-another-login", func(t *testing.T) {
+		callback, err := login()
+		assert.NoError(t, err)
+		other, err := login()
+		assert.NoError(t, err)
+		callback.FlowState = other.FlowState
+		_, err = testUseCase.CompleteOIDCLogin("campus", callback, "10.0.0.1", "Mozilla/5.0")
+		assert.EqualError(t, err, "invalid login state")
+	})
+}
+
+func TestGetActiveLockouts(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		mockRepo.On("GetActiveLockoutEvents").Return([]entity.LockoutEvent{
+			{
+				ID:        1,
+				Email:     "asdfa@gmail.com",
+				IPAddress: "10.0.0.1",
+				Scope:     entity.LockoutScopeAccount,
+			},
+		}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		res, err := testUseCase.GetActiveLockouts(1)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("action-unauthorized", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(2)).Return(entity.User{ID: 2, Role: utils.RoleStudent}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.GetActiveLockouts(2)
+		assert.EqualError(t, err, "action unauthorized")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUnlockUser(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		mockRepo.On("GetUserByID", uint(2)).Return(entity.User{ID: 2, Email: "asdfa@gmail.com", Role: utils.RoleStudent}, nil).Once()
+		mockGuard.On("Unlock", "asdfa@gmail.com").Return(nil).Once()
+		mockRepo.On("UnlockLockoutEvents", uint(2), uint(1)).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.UnlockUser(1, 2)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockGuard.AssertExpectations(t)
+	})
+
+	t.Run("action-unauthorized", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(2)).Return(entity.User{ID: 2, Role: utils.RoleStudent}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.UnlockUser(2, 3)
+		assert.EqualError(t, err, "action unauthorized")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestImpersonateUser(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Email: "admin@gmail.com", Role: utils.RoleAdmin}, nil).Twice()
+		mockRepo.On("GetUserByID", uint(2)).Return(entity.User{ID: 2, Email: "asdfa@gmail.com", Role: utils.RoleStudent}, nil).Once()
+		mockRepo.On("CreateImpersonation", mock.MatchedBy(func(impersonation entity.Impersonation) bool {
+			return impersonation.ActorID == 1 && impersonation.UserID == 2 && impersonation.SessionID == 7 && impersonation.Reason == "ticket #42"
+		})).Return(uint(3), nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		res, err := testUseCase.ImpersonateUser(1, 7, 2, dto.ImpersonationRequest{Reason: " ticket #42 "}, "10.0.0.1")
+		assert.NoError(t, err)
+		assert.Equal(t, uint(3), res.ImpersonationID)
+		token, err := utils.ParseJWTToken(testKeyRing, res.Token)
+		assert.NoError(t, err)
+		claims := token.Claims.(*utils.JwtCustomClaims)
+		assert.Equal(t, uint(2), claims.ID)
+		assert.Equal(t, &utils.Actor{ID: 1, Email: "admin@gmail.com"}, claims.Actor)
+		assert.Equal(t, uint(3), claims.ImpersonationID)
+		assert.Equal(t, uint(0), claims.SessionID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("admin-subject", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Email: "admin@gmail.com", Role: utils.RoleAdmin}, nil).Twice()
+		mockRepo.On("GetUserByID", uint(4)).Return(entity.User{ID: 4, Email: "other@gmail.com", Role: utils.RoleAdmin}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.ImpersonateUser(1, 7, 4, dto.ImpersonationRequest{Reason: "ticket #42"}, "10.0.0.1")
+		assert.EqualError(t, err, "admins can't be impersonated")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("missing-reason", func(t *testing.T) {
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.ImpersonateUser(1, 7, 2, dto.ImpersonationRequest{Reason: " "}, "10.0.0.1")
+		assert.EqualError(t, err, "fill the impersonation reason")
+	})
+}
+
+func TestValidateImpersonation(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	t.Run("active", func(t *testing.T) {
+		mockRepo.On("GetImpersonationByID", uint(3)).Return(entity.Impersonation{ID: 3, ActorID: 1, UserID: 2, SessionID: 7, ExpiresAt: time.Now().Add(time.Minute)}, nil).Once()
+		mockRepo.On("GetSessionByID", uint(7)).Return(entity.Session{ID: 7, UserID: 1, LastSeenAt: time.Now()}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		assert.NoError(t, testUseCase.ValidateImpersonation(3, 1, 2))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("ended", func(t *testing.T) {
+		endedAt := time.Now()
+		mockRepo.On("GetImpersonationByID", uint(3)).Return(entity.Impersonation{ID: 3, ActorID: 1, UserID: 2, SessionID: 7, ExpiresAt: time.Now().Add(time.Minute), EndedAt: &endedAt}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		assert.EqualError(t, testUseCase.ValidateImpersonation(3, 1, 2), "impersonation ended")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("other-user", func(t *testing.T) {
+		mockRepo.On("GetImpersonationByID", uint(3)).Return(entity.Impersonation{ID: 3, ActorID: 1, UserID: 2, SessionID: 7, ExpiresAt: time.Now().Add(time.Minute)}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		assert.EqualError(t, testUseCase.ValidateImpersonation(3, 1, 5), "impersonation ended")
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("admin-logged-out", func(t *testing.T) {
+		revokedAt := time.Now()
+		mockRepo.On("GetImpersonationByID", uint(3)).Return(entity.Impersonation{ID: 3, ActorID: 1, UserID: 2, SessionID: 7, ExpiresAt: time.Now().Add(time.Minute)}, nil).Once()
+		mockRepo.On("GetSessionByID", uint(7)).Return(entity.Session{ID: 7, UserID: 1, RevokedAt: &revokedAt}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		assert.EqualError(t, testUseCase.ValidateImpersonation(3, 1, 2), "session revoked")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRecordImpersonatedRequest(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	mockRepo.On("CreateImpersonationAction", entity.ImpersonationAction{
+		ImpersonationID: 3,
+		ActorID:         1,
+		UserID:          2,
+		Method:          "GET",
+		Path:            "/users/competitions/registrations",
+		Status:          200,
+		IPAddress:       "10.0.0.1",
+	}).Return(nil).Once()
+	testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+	err := testUseCase.RecordImpersonatedRequest(3, 1, 2, "GET", "/users/competitions/registrations", 200, "10.0.0.1")
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
 }
 
 func TestGetCompetitionsData(t *testing.T) {
 	mockRepo := userRepo.NewUserRepository(t)
 	mockCompetition := competitionRepo.NewCompetitionRepository(t)
 	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
 	t.Run("success", func(t *testing.T) {
 		mockCompetition.On("GetCompetitionByUserID", uint(1)).Return([]entityComp.Competition{
 			{
@@ -74,7 +624,7 @@ func TestGetCompetitionsData(t *testing.T) {
 				UserID:                   1,
 			},
 		}, nil).Once()
-		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment)
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
 		res, err := testUseCase.GetCompetitionsData(uint(1))
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
@@ -83,7 +633,7 @@ func TestGetCompetitionsData(t *testing.T) {
 
 	t.Run("unexpected-error", func(t *testing.T) {
 		mockCompetition.On("GetCompetitionByUserID", uint(1)).Return([]entityComp.Competition{}, errors.New("unexpected error")).Once()
-		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment)
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
 		res, err := testUseCase.GetCompetitionsData(uint(1))
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -94,6 +644,11 @@ func TestGetCompetitionRegistrationHistory(t *testing.T) {
 	mockRepo := userRepo.NewUserRepository(t)
 	mockCompetition := competitionRepo.NewCompetitionRepository(t)
 	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
 	t.Run("success", func(t *testing.T) {
 		mockCompetition.On("GetCompetitionRegistrationByUserID", uint(1)).Return([]entityComp.CompetitionRegistration{
 			{
@@ -106,7 +661,7 @@ func TestGetCompetitionRegistrationHistory(t *testing.T) {
 				UserID:           1,
 			},
 		}, nil).Once()
-		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment)
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
 		res, err := testUseCase.GetCompetitionRegistrationHistory(uint(1))
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
@@ -115,7 +670,7 @@ func TestGetCompetitionRegistrationHistory(t *testing.T) {
 
 	t.Run("unexpected-error", func(t *testing.T) {
 		mockCompetition.On("GetCompetitionRegistrationByUserID", uint(1)).Return([]entityComp.CompetitionRegistration{}, errors.New("unexpected error")).Once()
-		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment)
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
 		res, err := testUseCase.GetCompetitionRegistrationHistory(uint(1))
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -127,6 +682,11 @@ func TestGetRecruitmentApplicationHistory(t *testing.T) {
 	mockRepo := userRepo.NewUserRepository(t)
 	mockCompetition := competitionRepo.NewCompetitionRepository(t)
 	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
 	t.Run("success", func(t *testing.T) {
 		mockRecruitment.On("GetRecruitmentApplicationByUserID", uint(1)).Return([]entityRec.RecruitmentApplication{
 			{
@@ -138,7 +698,7 @@ func TestGetRecruitmentApplicationHistory(t *testing.T) {
 				UpdatedAt:        time.Now(),
 			},
 		}, nil).Once()
-		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment)
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
 		res, err := testUseCase.GetRecruitmentApplicationHistory(uint(1))
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
@@ -147,10 +707,1496 @@ func TestGetRecruitmentApplicationHistory(t *testing.T) {
 
 	t.Run("unexpected-error", func(t *testing.T) {
 		mockRecruitment.On("GetRecruitmentApplicationByUserID", uint(1)).Return([]entityRec.RecruitmentApplication{}, errors.New("unexpected error")).Once()
-		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment)
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
 		res, err := testUseCase.GetRecruitmentApplicationHistory(uint(1))
 		assert.Error(t, err)
 		assert.Empty(t, res)
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestRefreshToken(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	revokedAt := time.Now()
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetRefreshTokenByHash", utils.HashToken("refresh-token")).Return(entity.RefreshToken{
+			ID:        1,
+			UserID:    1,
+			TokenHash: utils.HashToken("refresh-token"),
+			FamilyID:  "family",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil).Once()
+		mockRepo.On("RevokeRefreshToken", uint(1)).Return(nil).Once()
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{
+			ID:    1,
+			Email: "asdfa@gmail.com",
+		}, nil).Once()
+		mockRepo.On("GetSessionByFamilyID", "family").Return(entity.Session{ID: 7, UserID: 1, FamilyID: "family"}, nil).Once()
+		mockRepo.On("TouchSession", uint(7)).Return(nil).Once()
+		mockRepo.On("CreateRefreshToken", mock.MatchedBy(func(refreshToken entity.RefreshToken) bool {
+			return refreshToken.FamilyID == "family" && refreshToken.UserID == 1
+		})).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		token, err := testUseCase.RefreshToken("refresh-token")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token.Token)
+		assert.NotEqual(t, "refresh-token", token.RefreshToken)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("reused-token-revokes-family", func(t *testing.T) {
+		mockRepo.On("GetRefreshTokenByHash", utils.HashToken("refresh-token")).Return(entity.RefreshToken{
+			ID:        1,
+			UserID:    1,
+			TokenHash: utils.HashToken("refresh-token"),
+			FamilyID:  "family",
+			ExpiresAt: time.Now().Add(time.Hour),
+			RevokedAt: &revokedAt,
+		}, nil).Once()
+		mockRepo.On("RevokeRefreshTokenFamily", "family").Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		token, err := testUseCase.RefreshToken("refresh-token")
+		assert.EqualError(t, err, "refresh token reused")
+		assert.Empty(t, token)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("revoked-session", func(t *testing.T) {
+		mockRepo.On("GetRefreshTokenByHash", utils.HashToken("refresh-token")).Return(entity.RefreshToken{
+			ID:        1,
+			UserID:    1,
+			TokenHash: utils.HashToken("refresh-token"),
+			FamilyID:  "family",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil).Once()
+		mockRepo.On("RevokeRefreshToken", uint(1)).Return(nil).Once()
+		mockRepo.On("GetSessionByFamilyID", "family").Return(entity.Session{ID: 7, UserID: 1, FamilyID: "family", RevokedAt: &revokedAt}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		token, err := testUseCase.RefreshToken("refresh-token")
+		assert.EqualError(t, err, "invalid refresh token")
+		assert.Empty(t, token)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("expired-token", func(t *testing.T) {
+		mockRepo.On("GetRefreshTokenByHash", utils.HashToken("refresh-token")).Return(entity.RefreshToken{
+			ID:        1,
+			UserID:    1,
+			TokenHash: utils.HashToken("refresh-token"),
+			FamilyID:  "family",
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		token, err := testUseCase.RefreshToken("refresh-token")
+		assert.EqualError(t, err, "refresh token expired")
+		assert.Empty(t, token)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown-token", func(t *testing.T) {
+		mockRepo.On("GetRefreshTokenByHash", utils.HashToken("unknown")).Return(entity.RefreshToken{}, errors.New("record not found")).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		token, err := testUseCase.RefreshToken("unknown")
+		assert.EqualError(t, err, "invalid refresh token")
+		assert.Empty(t, token)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	mockRepo.On("GetRefreshTokenByHash", utils.HashToken("refresh-token")).Return(entity.RefreshToken{
+		ID:       1,
+		UserID:   1,
+		FamilyID: "family",
+	}, nil).Once()
+	mockRepo.On("RevokeRefreshTokenFamily", "family").Return(nil).Once()
+	testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+	err := testUseCase.Logout("refresh-token")
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetSessions(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	mockRepo.On("GetActiveSessions", uint(1), mock.AnythingOfType("time.Time")).Return([]entity.Session{
+		{ID: 7, UserID: 1, UserAgent: "Mozilla/5.0", IPAddress: "10.0.0.1"},
+		{ID: 8, UserID: 1, UserAgent: "curl/7.81.0", IPAddress: "10.0.0.2"},
+	}, nil).Once()
+	testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+	sessions, err := testUseCase.GetSessions(1, 8)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.False(t, sessions[0].Current)
+	assert.True(t, sessions[1].Current)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRevokeSession(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("RevokeSession", uint(1), uint(7)).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.RevokeSession(1, 7)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockRepo.On("RevokeSession", uint(1), uint(7)).Return(errors.New("no rows affected")).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.RevokeSession(1, 7)
+		assert.EqualError(t, err, "session not found")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRevokeOtherSessions(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	mockRepo.On("RevokeUserSessions", uint(1), uint(7)).Return(nil).Once()
+	testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+	err := testUseCase.RevokeOtherSessions(1, 7)
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestValidateSession(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	revokedAt := time.Now()
+	t.Run("active", func(t *testing.T) {
+		mockRepo.On("GetSessionByID", uint(7)).Return(entity.Session{ID: 7, UserID: 1, LastSeenAt: time.Now()}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.ValidateSession(1, 7)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("touches-idle-session", func(t *testing.T) {
+		mockRepo.On("GetSessionByID", uint(7)).Return(entity.Session{ID: 7, UserID: 1, LastSeenAt: time.Now().Add(-time.Hour)}, nil).Once()
+		mockRepo.On("TouchSession", uint(7)).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.ValidateSession(1, 7)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		mockRepo.On("GetSessionByID", uint(7)).Return(entity.Session{ID: 7, UserID: 1, LastSeenAt: time.Now(), RevokedAt: &revokedAt}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.ValidateSession(1, 7)
+		assert.EqualError(t, err, "session revoked")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("other-user", func(t *testing.T) {
+		mockRepo.On("GetSessionByID", uint(7)).Return(entity.Session{ID: 7, UserID: 2, LastSeenAt: time.Now()}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.ValidateSession(1, 7)
+		assert.EqualError(t, err, "session revoked")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestCreatePersonalAccessToken(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("CreatePersonalAccessToken", mock.MatchedBy(func(token entity.PersonalAccessToken) bool {
+			return token.UserID == 1 && token.Name == "union bot" && token.Scopes == "registrations:read recruitments:read" && token.ExpiresAt != nil && len(token.TokenHash) == 64
+		})).Return(uint(4), nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		token, err := testUseCase.CreatePersonalAccessToken(1, dto.PersonalAccessTokenRequest{
+			Name:          "union bot",
+			Scopes:        []string{utils.ScopeRegistrationsRead, utils.ScopeRecruitmentsRead},
+			ExpiresInDays: 90,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, uint(4), token.ID)
+		assert.True(t, strings.HasPrefix(token.Token, utils.PersonalAccessTokenPrefix))
+		assert.Equal(t, []string{utils.ScopeRegistrationsRead, utils.ScopeRecruitmentsRead}, token.Scopes)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid-scope", func(t *testing.T) {
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.CreatePersonalAccessToken(1, dto.PersonalAccessTokenRequest{
+			Name:   "union bot",
+			Scopes: []string{"users:delete"},
+		})
+		assert.EqualError(t, err, "invalid scope")
+	})
+
+	t.Run("missing-scopes", func(t *testing.T) {
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.CreatePersonalAccessToken(1, dto.PersonalAccessTokenRequest{Name: "union bot"})
+		assert.EqualError(t, err, "fill the token scopes")
+	})
+
+	t.Run("missing-name", func(t *testing.T) {
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.CreatePersonalAccessToken(1, dto.PersonalAccessTokenRequest{
+			Name:   " ",
+			Scopes: []string{utils.ScopeRegistrationsRead},
+		})
+		assert.EqualError(t, err, "fill the token name")
+	})
+
+	t.Run("lifetime-too-long", func(t *testing.T) {
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.CreatePersonalAccessToken(1, dto.PersonalAccessTokenRequest{
+			Name:          "union bot",
+			Scopes:        []string{utils.ScopeRegistrationsRead},
+			ExpiresInDays: 1000,
+		})
+		assert.EqualError(t, err, "invalid token lifetime")
+	})
+}
+
+func TestRevokePersonalAccessToken(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("RevokePersonalAccessToken", uint(1), uint(4)).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.RevokePersonalAccessToken(1, 4)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockRepo.On("RevokePersonalAccessToken", uint(1), uint(4)).Return(errors.New("no rows affected")).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.RevokePersonalAccessToken(1, 4)
+		assert.EqualError(t, err, "token not found")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestAuthenticatePersonalAccessToken(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	tokenHash := utils.HashToken("cpat_token")
+	owner := entity.User{ID: 1, Email: "asdfa@gmail.com", Role: utils.RoleStudent}
+	past := time.Now().Add(-time.Hour)
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetPersonalAccessTokenByHash", tokenHash).Return(entity.PersonalAccessToken{ID: 4, UserID: 1, Scopes: "registrations:read", User: owner}, nil).Once()
+		mockRepo.On("TouchPersonalAccessToken", uint(4)).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		claims, err := testUseCase.AuthenticatePersonalAccessToken("cpat_token")
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), claims.ID)
+		assert.Equal(t, utils.RoleStudent, claims.Role)
+		assert.Equal(t, []string{utils.ScopeRegistrationsRead}, claims.Scopes)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		mockRepo.On("GetPersonalAccessTokenByHash", tokenHash).Return(entity.PersonalAccessToken{ID: 4, UserID: 1, RevokedAt: &past, User: owner}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.AuthenticatePersonalAccessToken("cpat_token")
+		assert.EqualError(t, err, "invalid access token")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		mockRepo.On("GetPersonalAccessTokenByHash", tokenHash).Return(entity.PersonalAccessToken{ID: 4, UserID: 1, ExpiresAt: &past, User: owner}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.AuthenticatePersonalAccessToken("cpat_token")
+		assert.EqualError(t, err, "invalid access token")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		mockRepo.On("GetPersonalAccessTokenByHash", tokenHash).Return(entity.PersonalAccessToken{}, gorm.ErrRecordNotFound).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.AuthenticatePersonalAccessToken("cpat_token")
+		assert.EqualError(t, err, "invalid access token")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestCreateUser(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("CreateUser", mock.AnythingOfType("entity.User")).Return(uint(1), nil).Once()
+		mockSkill.On("FindOrCreateSkill", "Node.Js").Return(skillEntity.Skill{ID: 3, Name: "Node.js", Slug: "node.js"}, nil).Once()
+		mockRepo.On("AddUserSkills", []entity.UserSkill{
+			{
+				UserID:      1,
+				SkillID:     3,
+				Proficiency: 4,
+			},
+		}).Return(nil).Once()
+		mockMailer.On("Send", "asdfa@gmail.com", "Verify your Compnouron account", mock.AnythingOfType("string")).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.CreateUser(&dto.UserRegistrationRequest{
+			Name:              "Alim Ikegami",
+			Email:             "asdfa@gmail.com",
+			PhoneNumber:       "081111111111",
+			Password:          "asdfasfas",
+			SchoolInstitution: "Udayana University",
+			Skills: []dto.UserSkillRequest{
+				{
+					Name:        "Node.Js",
+					Proficiency: 4,
+				},
+			},
+		})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockSkill.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("invalid-proficiency", func(t *testing.T) {
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.CreateUser(&dto.UserRegistrationRequest{
+			Name:     "Alim Ikegami",
+			Email:    "asdfa@gmail.com",
+			Password: "asdfasfas",
+			Skills: []dto.UserSkillRequest{
+				{
+					Name:        "Node.Js",
+					Proficiency: 6,
+				},
+			},
+		})
+		assert.EqualError(t, err, "proficiency must be between 1 and 5")
+	})
+
+	t.Run("no-skills", func(t *testing.T) {
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.CreateUser(&dto.UserRegistrationRequest{
+			Name:     "Alim Ikegami",
+			Email:    "asdfa@gmail.com",
+			Password: "asdfasfas",
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("password-policy", func(t *testing.T) {
+		checker := password.CreateNewChecker(password.DefaultPolicy, nil)
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), checker)
+		err := testUseCase.CreateUser(&dto.UserRegistrationRequest{
+			Name:     "Alim Ikegami",
+			Email:    "asdfa@gmail.com",
+			Password: "",
+			Skills: []dto.UserSkillRequest{
+				{
+					Name:        "Node.Js",
+					Proficiency: 4,
+				},
+			},
+		})
+		assert.EqualError(t, err, "password does not meet the policy")
+		assert.Len(t, err.(*password.ViolationError).Violations, 4)
+	})
+}
+
+func TestVerifyEmail(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	token, err := utils.CreateSignedPurposeToken(utils.EmailVerificationPurpose, 1, "asdfa@gmail.com", time.Hour)
+	assert.NoError(t, err)
+	verifiedAt := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Email: "asdfa@gmail.com"}, nil).Once()
+		mockRepo.On("VerifyUserEmail", uint(1)).Return(nil).Once()
+		mockInstitution.On("GetInstitutionByDomains", []string{"gmail.com"}).Return(institutionEntity.Institution{ID: 5}, nil).Once()
+		mockRepo.On("UpdateUserInstitution", uint(1), uint(5)).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.VerifyEmail(token)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("already-verified", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Email: "asdfa@gmail.com", VerifiedAt: &verifiedAt}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.VerifyEmail(token)
+		assert.EqualError(t, err, "email already verified")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("email-changed", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Email: "another@gmail.com"}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.VerifyEmail(token)
+		assert.EqualError(t, err, "invalid verification token")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("access-token-rejected", func(t *testing.T) {
+		accessToken, err := utils.CreateSignedJWTToken(testKeyRing, 1, "asdfa@gmail.com", utils.RoleStudent, 7)
+		assert.NoError(t, err)
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err = testUseCase.VerifyEmail(accessToken)
+		assert.EqualError(t, err, "invalid verification token")
+	})
+}
+
+func TestForgotPassword(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetUserByEmail", "asdfa@gmail.com").Return(&entity.User{ID: 1, Email: "asdfa@gmail.com"}).Once()
+		mockRepo.On("CreatePasswordResetToken", mock.MatchedBy(func(passwordResetToken entity.PasswordResetToken) bool {
+			return passwordResetToken.UserID == 1 && passwordResetToken.TokenHash != "" && passwordResetToken.ExpiresAt.After(time.Now())
+		})).Return(nil).Once()
+		mockMailer.On("Send", "asdfa@gmail.com", "Reset your Compnouron password", mock.AnythingOfType("string")).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.ForgotPassword("asdfa@gmail.com")
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("unknown-email", func(t *testing.T) {
+		mockRepo.On("GetUserByEmail", "unknown@gmail.com").Return(&entity.User{}).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.ForgotPassword("unknown@gmail.com")
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	usedAt := time.Now()
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetPasswordResetTokenByHash", utils.HashToken("reset-token")).Return(entity.PasswordResetToken{
+			ID:        1,
+			UserID:    1,
+			TokenHash: utils.HashToken("reset-token"),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil).Once()
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Name: "Alim Ikegami", Email: "asdfa@gmail.com"}, nil).Once()
+		mockRepo.On("UsePasswordResetToken", uint(1)).Return(nil).Once()
+		mockRepo.On("UpdateUserPassword", uint(1), mock.MatchedBy(func(password string) bool {
+			return bcrypt.CompareHashAndPassword([]byte(password), []byte("newpassword")) == nil
+		})).Return(nil).Once()
+		mockRepo.On("RevokeUserSessions", uint(1), uint(0)).Return(nil).Once()
+		mockRepo.On("RevokeUserPersonalAccessTokens", uint(1)).Return(nil).Once()
+		mockRepo.On("InvalidateUserPasswordResetTokens", uint(1)).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.ResetPassword(dto.ResetPasswordRequest{Token: "reset-token", Password: "newpassword"})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("used-token", func(t *testing.T) {
+		mockRepo.On("GetPasswordResetTokenByHash", utils.HashToken("reset-token")).Return(entity.PasswordResetToken{
+			ID:        1,
+			UserID:    1,
+			TokenHash: utils.HashToken("reset-token"),
+			ExpiresAt: time.Now().Add(time.Hour),
+			UsedAt:    &usedAt,
+		}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.ResetPassword(dto.ResetPasswordRequest{Token: "reset-token", Password: "newpassword"})
+		assert.EqualError(t, err, "invalid reset token")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("expired-token", func(t *testing.T) {
+		mockRepo.On("GetPasswordResetTokenByHash", utils.HashToken("reset-token")).Return(entity.PasswordResetToken{
+			ID:        1,
+			UserID:    1,
+			TokenHash: utils.HashToken("reset-token"),
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.ResetPassword(dto.ResetPasswordRequest{Token: "reset-token", Password: "newpassword"})
+		assert.EqualError(t, err, "invalid reset token")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("empty-password", func(t *testing.T) {
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.ResetPassword(dto.ResetPasswordRequest{Token: "reset-token"})
+		assert.EqualError(t, err, "fill your new password")
+	})
+
+	t.Run("weak-password-keeps-token", func(t *testing.T) {
+		mockRepo.On("GetPasswordResetTokenByHash", utils.HashToken("reset-token")).Return(entity.PasswordResetToken{
+			ID:        1,
+			UserID:    1,
+			TokenHash: utils.HashToken("reset-token"),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil).Once()
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Name: "Alim Ikegami", Email: "asdfa@gmail.com"}, nil).Once()
+		checker := password.CreateNewChecker(password.DefaultPolicy, nil)
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), checker)
+		err := testUseCase.ResetPassword(dto.ResetPasswordRequest{Token: "reset-token", Password: "Ikegami2022"})
+		assert.EqualError(t, err, "password does not meet the policy")
+		assert.Equal(t, "contains_personal_info", err.(*password.ViolationError).Violations[0].Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdatePrivacySettings(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, EmailVisibility: "team", PhoneNumberVisibility: "organizers", Searchable: true}, nil).Once()
+		mockRepo.On("UpdateUserPrivacySettings", uint(1), "public", "organizers", true, false).Return(nil).Once()
+		err := testUseCase.UpdatePrivacySettings(1, dto.PrivacySettingsRequest{EmailVisibility: "public"})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("opt-out-of-search", func(t *testing.T) {
+		searchable := false
+		availableForTeams := true
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, EmailVisibility: "team", PhoneNumberVisibility: "team", Searchable: true}, nil).Once()
+		mockRepo.On("UpdateUserPrivacySettings", uint(1), "team", "team", false, true).Return(nil).Once()
+		err := testUseCase.UpdatePrivacySettings(1, dto.PrivacySettingsRequest{Searchable: &searchable, AvailableForTeams: &availableForTeams})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid-visibility", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, EmailVisibility: "team", PhoneNumberVisibility: "team"}, nil).Once()
+		err := testUseCase.UpdatePrivacySettings(1, dto.PrivacySettingsRequest{PhoneNumberVisibility: "friends"})
+		assert.EqualError(t, err, "invalid visibility")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestSearchUsers(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	mockShaper := privacyMocks.NewShaper(t)
+	mockUploader := mediaMocks.NewUploader(t)
+	testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), mockShaper, mockGuard, nil, testKeyRing, mockUploader, testPasswordChecker)
+	institutionID := uint(3)
+	users := []entity.User{
+		{
+			ID:                    2,
+			Name:                  "Alim Ikegami",
+			Email:                 "alim@gmail.com",
+			PhoneNumber:           "081111111111",
+			EmailVisibility:       "public",
+			PhoneNumberVisibility: "team",
+			AvailableForTeams:     true,
+			AvatarKey:             "avatars/2/a.png",
+			InstitutionID:         &institutionID,
+			Institution:           &institutionEntity.Institution{ID: 3, Name: "Udayana University"},
+			Skills:                []entity.UserSkill{{UserID: 2, SkillID: 1, Proficiency: 3, Skill: skillEntity.Skill{ID: 1, Name: "Go"}}},
+		},
+		{
+			ID:                    3,
+			Name:                  "Budi",
+			Email:                 "budi@gmail.com",
+			PhoneNumber:           "082222222222",
+			EmailVisibility:       "team",
+			PhoneNumberVisibility: "team",
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		filter := userRepository.UserSearchFilter{SkillIDs: []uint{1, 2}, MatchAllSkills: true, InstitutionID: 3, AvailableOnly: true}
+		mockRepo.On("SearchUsers", filter, 10, 0).Return(users, nil).Once()
+		mockShaper.On("Viewer", uint(1)).Return(privacy.Viewer{ID: 1, Teammates: map[uint]bool{3: true}}, nil).Once()
+		mockUploader.On("Image", "avatars/2/a.png").Return(&media.Image{URL: "/uploads/avatars/2/a.png", ThumbnailURL: "/uploads/avatars/2/a_thumb.png"}).Once()
+		mockUploader.On("Image", "").Return((*media.Image)(nil)).Once()
+		profiles, err := testUseCase.SearchUsers(1, dto.UserSearchRequest{SkillIDs: []uint{1, 2}, MatchAllSkills: true, InstitutionID: 3, AvailableOnly: true})
+		assert.NoError(t, err)
+		assert.Len(t, profiles, 2)
+		assert.Equal(t, "alim@gmail.com", profiles[0].Email)
+		assert.Empty(t, profiles[0].PhoneNumber)
+		assert.Equal(t, "Udayana University", profiles[0].InstitutionName)
+		assert.Equal(t, "Go", profiles[0].Skills[0].Name)
+		assert.Equal(t, "/uploads/avatars/2/a_thumb.png", profiles[0].Avatar.ThumbnailURL)
+		assert.Equal(t, "budi@gmail.com", profiles[1].Email)
+		assert.Nil(t, profiles[1].Avatar)
+		assert.Equal(t, "082222222222", profiles[1].PhoneNumber)
+	})
+
+	t.Run("limit-clamped", func(t *testing.T) {
+		mockRepo.On("SearchUsers", userRepository.UserSearchFilter{}, 50, 0).Return([]entity.User{}, nil).Once()
+		mockShaper.On("Viewer", uint(1)).Return(privacy.Viewer{ID: 1}, nil).Once()
+		profiles, err := testUseCase.SearchUsers(1, dto.UserSearchRequest{Limit: 500})
+		assert.NoError(t, err)
+		assert.Empty(t, profiles)
+	})
+
+	t.Run("invalid-offset", func(t *testing.T) {
+		_, err := testUseCase.SearchUsers(1, dto.UserSearchRequest{Offset: -1})
+		assert.EqualError(t, err, "invalid offset")
+	})
+}
+
+func TestGetAchievements(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+	publishedAt := time.Now().Add(-time.Hour)
+	t.Run("success", func(t *testing.T) {
+		mockCompetition.On("GetAchievementsByUserID", uint(2), mock.AnythingOfType("time.Time")).Return([]entityComp.Achievement{
+			{
+				ID:     1,
+				UserID: 2,
+				CompetitionResult: entityComp.CompetitionResult{
+					CompetitionID: 1,
+					Rank:          1,
+					AwardTitle:    "Champion",
+					Competition:   entityComp.Competition{ID: 1, Name: "Technoscape Hackathon 2022", Level: "University Student", ResultsPublishAt: &publishedAt},
+					CompetitionRegistration: entityComp.CompetitionRegistration{
+						ID:     4,
+						TeamID: 6,
+						Team:   entityTeam.Team{ID: 6, Name: "Ikegami"},
+					},
+				},
+			},
+		}, nil).Once()
+		achievements, err := testUseCase.GetAchievements(2)
+		assert.NoError(t, err)
+		assert.Equal(t, []dto.AchievementResponse{
+			{CompetitionID: 1, CompetitionName: "Technoscape Hackathon 2022", Level: "University Student", Rank: 1, AwardTitle: "Champion", TeamName: "Ikegami", PublishedAt: publishedAt},
+		}, achievements)
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockCompetition.On("GetAchievementsByUserID", uint(2), mock.AnythingOfType("time.Time")).Return(nil, errors.New("unexpected error")).Once()
+		_, err := testUseCase.GetAchievements(2)
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateUserRole(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		mockRepo.On("UpdateUserRole", uint(2), utils.RoleOrganizer).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.UpdateUserRole(1, 2, utils.RoleOrganizer)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("not-admin", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Role: utils.RoleOrganizer}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.UpdateUserRole(1, 2, utils.RoleAdmin)
+		assert.EqualError(t, err, "action unauthorized")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid-role", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.UpdateUserRole(1, 2, "superuser")
+		assert.EqualError(t, err, "invalid role")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("own-role", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Role: utils.RoleAdmin}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.UpdateUserRole(1, 1, utils.RoleStudent)
+		assert.EqualError(t, err, "can't change your own role")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetUserDetails(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	verifiedAt := time.Now()
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetUserWithSkillsByID", uint(1)).Return(entity.User{
+			ID:                1,
+			Name:              "Alim Ikegami",
+			Email:             "asdfa@gmail.com",
+			PhoneNumber:       "081111111111",
+			SchoolInstitution: "Udayana University",
+			Role:              utils.RoleStudent,
+			VerifiedAt:        &verifiedAt,
+			Skills: []entity.UserSkill{
+				{
+					UserID:      1,
+					SkillID:     3,
+					Proficiency: 4,
+					Skill:       skillEntity.Skill{ID: 3, Name: "Node.js", Slug: "node.js"},
+				},
+			},
+		}, nil).Once()
+		mockUploader := mediaMocks.NewUploader(t)
+		mockUploader.On("Image", "").Return((*media.Image)(nil)).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mockUploader, testPasswordChecker)
+		res, err := testUseCase.GetUserDetails(1)
+		assert.NoError(t, err)
+		assert.Nil(t, res.Avatar)
+		assert.Equal(t, "asdfa@gmail.com", res.Email)
+		assert.True(t, res.EmailVerified)
+		assert.Equal(t, []dto.UserSkillResponse{{ID: 3, Name: "Node.js", Proficiency: 4}}, res.Skills)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockRepo.On("GetUserWithSkillsByID", uint(1)).Return(entity.User{}, errors.New("unexpected error")).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		res, err := testUseCase.GetUserDetails(1)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdateUser(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	t.Run("same-email", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Email: "asdfa@gmail.com"}, nil).Once()
+		mockRepo.On("UpdateUser", entity.User{
+			ID:                1,
+			Name:              "Alim",
+			PhoneNumber:       "081111111111",
+			SchoolInstitution: "Udayana University",
+		}).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.UpdateUser(1, dto.UserUpdateRequest{
+			Name:              "Alim",
+			Email:             "asdfa@gmail.com",
+			PhoneNumber:       "081111111111",
+			SchoolInstitution: "Udayana University",
+		})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("email-changed", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Email: "asdfa@gmail.com"}, nil).Once()
+		mockRepo.On("GetUserByEmail", "new@gmail.com").Return(&entity.User{}).Once()
+		mockRepo.On("UpdateUser", mock.AnythingOfType("entity.User")).Return(nil).Once()
+		mockRepo.On("UpdateUserEmail", uint(1), "new@gmail.com").Return(nil).Once()
+		mockMailer.On("Send", "new@gmail.com", "Verify your Compnouron account", mock.AnythingOfType("string")).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.UpdateUser(1, dto.UserUpdateRequest{
+			Name:  "Alim",
+			Email: "new@gmail.com",
+		})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("email-taken", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Email: "asdfa@gmail.com"}, nil).Once()
+		mockRepo.On("GetUserByEmail", "taken@gmail.com").Return(&entity.User{ID: 2, Email: "taken@gmail.com"}).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.UpdateUser(1, dto.UserUpdateRequest{
+			Name:  "Alim",
+			Email: "taken@gmail.com",
+		})
+		assert.EqualError(t, err, "email is already registered")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestChangePassword(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	user := entity.User{
+		ID:       1,
+		Email:    "asdfa@gmail.com",
+		Password: "$2a$10$YefQPq3c5H7OalTHNFgo8Ob7Sxjc8F.fI3.ePvHOhOYCkqOGrFhm6",
+	}
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(user, nil).Once()
+		mockRepo.On("UpdateUserPassword", uint(1), mock.MatchedBy(func(password string) bool {
+			return bcrypt.CompareHashAndPassword([]byte(password), []byte("newpassword")) == nil
+		})).Return(nil).Once()
+		mockRepo.On("RevokeUserSessions", uint(1), uint(0)).Return(nil).Once()
+		mockRepo.On("RevokeUserPersonalAccessTokens", uint(1)).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.ChangePassword(1, dto.PasswordChangeRequest{OldPassword: "asdfasfas", NewPassword: "newpassword"})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong-password", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(user, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.ChangePassword(1, dto.PasswordChangeRequest{OldPassword: "wrong", NewPassword: "newpassword"})
+		assert.EqualError(t, err, "wrong password")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("breached-password", func(t *testing.T) {
+		// range file of the SHA-1 of "Password123", see the password package
+		sum := sha1.Sum([]byte("Password123"))
+		hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, hash[:5]+".txt"), []byte(hash[5:]+":42\n"), 0o600)
+		assert.NoError(t, err)
+		checker := password.CreateNewChecker(password.DefaultPolicy, password.CreateNewRangeDirectory(dir))
+
+		mockRepo.On("GetUserByID", uint(1)).Return(user, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), checker)
+		err = testUseCase.ChangePassword(1, dto.PasswordChangeRequest{OldPassword: "asdfasfas", NewPassword: "Password123"})
+		assert.EqualError(t, err, "password does not meet the policy")
+		assert.Equal(t, "breached", err.(*password.ViolationError).Violations[0].Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestExportUserData(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockDiscussion := discussionRepo.NewDiscussionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	// expectAccountSections sets up the sections about the account itself,
+	// each test below fills the one it checks
+	expectAccountSections := func(tokens []entity.PersonalAccessToken, userIdentities []entity.UserIdentity, achievements []entityComp.Achievement, sent []entityTeam.TeamInvitation, received []entityTeam.TeamInvitation, actions []entity.ImpersonationAction) {
+		mockRepo.On("GetAllPersonalAccessTokens", uint(1)).Return(tokens, nil).Once()
+		mockRepo.On("GetUserIdentitiesByUserID", uint(1)).Return(userIdentities, nil).Once()
+		mockCompetition.On("GetAchievementsByUserID", uint(1), mock.AnythingOfType("time.Time")).Return(achievements, nil).Once()
+		mockTeam.On("GetTeamInvitationsByInviterID", uint(1)).Return(sent, nil).Once()
+		mockTeam.On("GetTeamInvitationsForUser", uint(1), "asdfa@gmail.com").Return(received, nil).Once()
+		mockRepo.On("GetImpersonationActionsByUserID", uint(1)).Return(actions, nil).Once()
+	}
+	// expectOtherSections sets up everything else with empty sections
+	expectOtherSections := func() {
+		verifiedAt := time.Now()
+		mockRepo.On("GetUserWithSkillsByID", uint(1)).Return(entity.User{ID: 1, Email: "asdfa@gmail.com", VerifiedAt: &verifiedAt}, nil).Once()
+		mockTeam.On("GetTeamMembershipsByUserID", uint(1)).Return([]entityTeam.TeamMember{}, nil).Once()
+		mockCompetition.On("GetCompetitionRegistrationByUserID", uint(1)).Return([]entityComp.CompetitionRegistration{}, nil).Once()
+		mockRecruitment.On("GetRecruitmentApplicationByUserID", uint(1)).Return([]entityRec.RecruitmentApplication{}, nil).Once()
+		mockCompetition.On("GetCompetitionByUserID", uint(1)).Return([]entityComp.Competition{}, nil).Once()
+		mockRepo.On("GetSessionsByUserID", uint(1)).Return([]entity.Session{}, nil).Once()
+		mockRepo.On("GetLockoutEventsByUserID", uint(1)).Return([]entity.LockoutEvent{}, nil).Once()
+		mockDiscussion.On("GetThreadsByAuthorID", uint(1)).Return([]discussionEntity.Thread{}, nil).Once()
+		mockDiscussion.On("GetRepliesByAuthorID", uint(1)).Return([]discussionEntity.Reply{}, nil).Once()
+		mockDiscussion.On("GetMentionsByUserID", uint(1)).Return([]discussionEntity.Mention{}, nil).Once()
+	}
+	testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, mockDiscussion, mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetUserWithSkillsByID", uint(1)).Return(entity.User{
+			ID:    1,
+			Name:  "Alim Ikegami",
+			Email: "asdfa@gmail.com",
+			Skills: []entity.UserSkill{
+				{
+					UserID:      1,
+					SkillID:     3,
+					Proficiency: 4,
+					Skill:       skillEntity.Skill{ID: 3, Name: "Node.js", Slug: "node.js"},
+				},
+			},
+		}, nil).Once()
+		mockTeam.On("GetTeamMembershipsByUserID", uint(1)).Return([]entityTeam.TeamMember{
+			{
+				ID:     1,
+				TeamID: 2,
+				UserID: 1,
+				Role:   entityTeam.TeamRoleOwner,
+				Team:   entityTeam.Team{ID: 2, Name: "Team 1"},
+			},
+		}, nil).Once()
+		mockCompetition.On("GetCompetitionRegistrationByUserID", uint(1)).Return([]entityComp.CompetitionRegistration{
+			{
+				ID:            1,
+				CompetitionID: 1,
+				UserID:        1,
+				Competition:   entityComp.Competition{ID: 1, Name: "Techoscape"},
+			},
+		}, nil).Once()
+		mockRecruitment.On("GetRecruitmentApplicationByUserID", uint(1)).Return([]entityRec.RecruitmentApplication{}, nil).Once()
+		mockCompetition.On("GetCompetitionByUserID", uint(1)).Return([]entityComp.Competition{}, nil).Once()
+		mockRepo.On("GetSessionsByUserID", uint(1)).Return([]entity.Session{
+			{ID: 2, UserID: 1, UserAgent: "Mozilla/5.0", IPAddress: "10.0.0.1"},
+		}, nil).Once()
+		userID := uint(1)
+		mockRepo.On("GetLockoutEventsByUserID", uint(1)).Return([]entity.LockoutEvent{
+			{ID: 4, UserID: &userID, Email: "asdfa@gmail.com", IPAddress: "10.0.0.1", Scope: entity.LockoutScopeAccount},
+		}, nil).Once()
+		replyID := uint(8)
+		mockDiscussion.On("GetThreadsByAuthorID", uint(1)).Return([]discussionEntity.Thread{
+			{ID: 5, TeamID: 2, AuthorID: 1, Title: "Practice", Body: "Tomorrow at 7 @Ani", Mentions: []discussionEntity.Mention{{ID: 1, ThreadID: 5, UserID: 4}}},
+		}, nil).Once()
+		mockDiscussion.On("GetRepliesByAuthorID", uint(1)).Return([]discussionEntity.Reply{
+			{ID: 9, ThreadID: 6, AuthorID: 1, Body: "See you there"},
+		}, nil).Once()
+		mockDiscussion.On("GetMentionsByUserID", uint(1)).Return([]discussionEntity.Mention{
+			{ID: 3, ThreadID: 5, ReplyID: &replyID, UserID: 1},
+		}, nil).Once()
+		mockRepo.On("GetAllPersonalAccessTokens", uint(1)).Return([]entity.PersonalAccessToken{}, nil).Once()
+		mockRepo.On("GetUserIdentitiesByUserID", uint(1)).Return([]entity.UserIdentity{}, nil).Once()
+		mockCompetition.On("GetAchievementsByUserID", uint(1), mock.AnythingOfType("time.Time")).Return([]entityComp.Achievement{}, nil).Once()
+		mockTeam.On("GetTeamInvitationsByInviterID", uint(1)).Return([]entityTeam.TeamInvitation{}, nil).Once()
+		// the email isn't verified, only the invitations to the user ID count
+		mockTeam.On("GetTeamInvitationsForUser", uint(1), "").Return([]entityTeam.TeamInvitation{}, nil).Once()
+		mockRepo.On("GetImpersonationActionsByUserID", uint(1)).Return([]entity.ImpersonationAction{}, nil).Once()
+		res, err := testUseCase.ExportUserData(1)
+		assert.NoError(t, err)
+		assert.Equal(t, "asdfa@gmail.com", res.Profile.Email)
+		assert.Equal(t, []dto.UserSkillResponse{{ID: 3, Name: "Node.js", Proficiency: 4}}, res.Skills)
+		assert.Equal(t, []dto.UserTeamMembershipExport{{TeamID: 2, TeamName: "Team 1", IsLeader: true, Role: "owner"}}, res.TeamMemberships)
+		assert.Len(t, res.CompetitionRegistrations, 1)
+		assert.NotNil(t, res.RecruitmentApplications)
+		assert.NotNil(t, res.OrganizedCompetitions)
+		assert.Equal(t, []dto.UserSessionExport{{ID: 2, UserAgent: "Mozilla/5.0", IPAddress: "10.0.0.1"}}, res.Sessions)
+		assert.Equal(t, []dto.UserLockoutEventExport{{ID: 4, Email: "asdfa@gmail.com", IPAddress: "10.0.0.1", Scope: "account"}}, res.LockoutEvents)
+		assert.Equal(t, []dto.UserThreadExport{{ID: 5, TeamID: 2, Title: "Practice", Body: "Tomorrow at 7 @Ani", MentionedUserIDs: []uint{4}}}, res.Threads)
+		assert.Equal(t, []dto.UserReplyExport{{ID: 9, ThreadID: 6, Body: "See you there", MentionedUserIDs: []uint{}}}, res.Replies)
+		assert.Equal(t, []dto.UserMentionExport{{ID: 3, ThreadID: 5, ReplyID: &replyID}}, res.Mentions)
+		assert.NotNil(t, res.PersonalAccessTokens)
+		assert.NotNil(t, res.Identities)
+		assert.NotNil(t, res.Achievements)
+		assert.NotNil(t, res.InvitationsSent)
+		assert.NotNil(t, res.InvitationsReceived)
+		assert.NotNil(t, res.ImpersonationActions)
+		mockDiscussion.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+		mockTeam.AssertExpectations(t)
+		mockCompetition.AssertExpectations(t)
+		mockRecruitment.AssertExpectations(t)
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockRepo.On("GetUserWithSkillsByID", uint(1)).Return(entity.User{ID: 1}, nil).Once()
+		mockTeam.On("GetTeamMembershipsByUserID", uint(1)).Return([]entityTeam.TeamMember{}, errors.New("unexpected error")).Once()
+		_, err := testUseCase.ExportUserData(1)
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+		mockTeam.AssertExpectations(t)
+	})
+
+	t.Run("personal-access-tokens", func(t *testing.T) {
+		revokedAt := time.Now()
+		expectOtherSections()
+		expectAccountSections([]entity.PersonalAccessToken{
+			{ID: 2, UserID: 1, Name: "CI", TokenHash: "hash-2", Scopes: "registrations:read teams:read"},
+			{ID: 1, UserID: 1, Name: "Old bot", TokenHash: "hash-1", Scopes: "teams:read", RevokedAt: &revokedAt},
+		}, []entity.UserIdentity{}, []entityComp.Achievement{}, []entityTeam.TeamInvitation{}, []entityTeam.TeamInvitation{}, []entity.ImpersonationAction{})
+		res, err := testUseCase.ExportUserData(1)
+		assert.NoError(t, err)
+		// metadata only, the hash of the token stays out of the export
+		assert.Equal(t, []dto.UserPersonalAccessTokenExport{
+			{ID: 2, Name: "CI", Scopes: []string{"registrations:read", "teams:read"}},
+			{ID: 1, Name: "Old bot", Scopes: []string{"teams:read"}, RevokedAt: &revokedAt},
+		}, res.PersonalAccessTokens)
+	})
+
+	t.Run("identities", func(t *testing.T) {
+		expectOtherSections()
+		expectAccountSections([]entity.PersonalAccessToken{}, []entity.UserIdentity{
+			{ID: 1, UserID: 1, Provider: "google", Subject: "1234", Email: "asdfa@gmail.com"},
+		}, []entityComp.Achievement{}, []entityTeam.TeamInvitation{}, []entityTeam.TeamInvitation{}, []entity.ImpersonationAction{})
+		res, err := testUseCase.ExportUserData(1)
+		assert.NoError(t, err)
+		assert.Equal(t, []dto.UserIdentityExport{{ID: 1, Provider: "google", Subject: "1234", Email: "asdfa@gmail.com"}}, res.Identities)
+	})
+
+	t.Run("achievements", func(t *testing.T) {
+		publishedAt := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+		expectOtherSections()
+		expectAccountSections([]entity.PersonalAccessToken{}, []entity.UserIdentity{}, []entityComp.Achievement{
+			{
+				ID:     1,
+				UserID: 1,
+				CompetitionResult: entityComp.CompetitionResult{
+					CompetitionID: 1,
+					Rank:          1,
+					AwardTitle:    "Champion",
+					Competition:   entityComp.Competition{ID: 1, Name: "Techoscape", Level: "National", ResultsPublishAt: &publishedAt},
+				},
+			},
+		}, []entityTeam.TeamInvitation{}, []entityTeam.TeamInvitation{}, []entity.ImpersonationAction{})
+		res, err := testUseCase.ExportUserData(1)
+		assert.NoError(t, err)
+		assert.Equal(t, []dto.AchievementResponse{{CompetitionID: 1, CompetitionName: "Techoscape", Level: "National", Rank: 1, AwardTitle: "Champion", PublishedAt: publishedAt}}, res.Achievements)
+	})
+
+	t.Run("invitations", func(t *testing.T) {
+		inviteeID := uint(4)
+		expectOtherSections()
+		expectAccountSections([]entity.PersonalAccessToken{}, []entity.UserIdentity{}, []entityComp.Achievement{}, []entityTeam.TeamInvitation{
+			{ID: 3, TeamID: 2, InviterID: 1, InviteeID: &inviteeID, Status: entityTeam.InvitationStatusAccepted, Team: entityTeam.Team{ID: 2, Name: "Team 1"}},
+		}, []entityTeam.TeamInvitation{
+			{ID: 5, TeamID: 6, InviterID: 7, Email: "asdfa@gmail.com", Status: entityTeam.InvitationStatusPending, ExpiresAt: time.Now().Add(-time.Hour), Team: entityTeam.Team{ID: 6, Name: "Team 2"}},
+		}, []entity.ImpersonationAction{})
+		res, err := testUseCase.ExportUserData(1)
+		assert.NoError(t, err)
+		assert.Len(t, res.InvitationsSent, 1)
+		assert.Equal(t, "Team 1", res.InvitationsSent[0].TeamName)
+		assert.Equal(t, &inviteeID, res.InvitationsSent[0].InviteeID)
+		assert.Len(t, res.InvitationsReceived, 1)
+		assert.Equal(t, uint(7), res.InvitationsReceived[0].InviterID)
+		assert.Equal(t, entityTeam.InvitationStatusExpired, res.InvitationsReceived[0].Status)
+	})
+
+	t.Run("impersonation-actions", func(t *testing.T) {
+		expectOtherSections()
+		expectAccountSections([]entity.PersonalAccessToken{}, []entity.UserIdentity{}, []entityComp.Achievement{}, []entityTeam.TeamInvitation{}, []entityTeam.TeamInvitation{}, []entity.ImpersonationAction{
+			{ID: 1, ImpersonationID: 3, ActorID: 2, UserID: 1, Method: "GET", Path: "/users/me", Status: 200, IPAddress: "10.0.0.1"},
+		})
+		res, err := testUseCase.ExportUserData(1)
+		assert.NoError(t, err)
+		assert.Equal(t, []dto.UserImpersonationActionExport{{ImpersonationID: 3, ActorID: 2, Method: "GET", Path: "/users/me", Status: 200}}, res.ImpersonationActions)
+	})
+
+	mockRepo.AssertExpectations(t)
+	mockCompetition.AssertExpectations(t)
+	mockTeam.AssertExpectations(t)
+}
+
+func TestDeleteAccount(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	user := entity.User{
+		ID:       1,
+		Email:    "asdfa@gmail.com",
+		Password: "$2a$10$YefQPq3c5H7OalTHNFgo8Ob7Sxjc8F.fI3.ePvHOhOYCkqOGrFhm6",
+	}
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(user, nil).Once()
+		mockTeam.On("GetTeamMembershipsByUserID", uint(1)).Return([]entityTeam.TeamMember{{TeamID: 3, UserID: 1, Role: entityTeam.TeamRoleMember}}, nil).Once()
+		mockRepo.On("AnonymizeUser", uint(1)).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.DeleteAccount(1, dto.AccountDeletionRequest{Password: "asdfasfas"})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("removes-avatar", func(t *testing.T) {
+		withAvatar := user
+		withAvatar.AvatarKey = "avatars/1/a.png"
+		mockRepo.On("GetUserByID", uint(1)).Return(withAvatar, nil).Once()
+		mockTeam.On("GetTeamMembershipsByUserID", uint(1)).Return([]entityTeam.TeamMember{{TeamID: 3, UserID: 1, Role: entityTeam.TeamRoleMember}}, nil).Once()
+		mockRepo.On("AnonymizeUser", uint(1)).Return(nil).Once()
+		mockUploader := mediaMocks.NewUploader(t)
+		mockUploader.On("Delete", "avatars/1/a.png").Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mockUploader, testPasswordChecker)
+		err := testUseCase.DeleteAccount(1, dto.AccountDeletionRequest{Password: "asdfasfas"})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockUploader.AssertExpectations(t)
+	})
+
+	t.Run("wrong-password", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(user, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.DeleteAccount(1, dto.AccountDeletionRequest{Password: "wrong"})
+		assert.EqualError(t, err, "wrong password")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("team-owner", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(user, nil).Once()
+		mockTeam.On("GetTeamMembershipsByUserID", uint(1)).Return([]entityTeam.TeamMember{{TeamID: 3, UserID: 1, Role: entityTeam.TeamRoleMember}, {TeamID: 4, UserID: 1, Role: entityTeam.TeamRoleOwner}}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.DeleteAccount(1, dto.AccountDeletionRequest{Password: "asdfasfas"})
+		assert.EqualError(t, err, "transfer the ownership of your teams before deleting the account")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUploadAvatar(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	mockUploader := mediaMocks.NewUploader(t)
+	testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mockUploader, testPasswordChecker)
+	data := []byte("image")
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, AvatarKey: "avatars/1/old.jpg"}, nil).Once()
+		mockUploader.On("Upload", "avatars/1", data, media.AvatarSpec).Return("avatars/1/new.jpg", nil).Once()
+		mockRepo.On("UpdateUserAvatar", uint(1), "avatars/1/new.jpg").Return(nil).Once()
+		mockUploader.On("Delete", "avatars/1/old.jpg").Return(nil).Once()
+		mockUploader.On("Image", "avatars/1/new.jpg").Return(&media.Image{URL: "/uploads/avatars/1/new.jpg", ThumbnailURL: "/uploads/avatars/1/new_thumb.jpg"}).Once()
+		res, err := testUseCase.UploadAvatar(1, data)
+		assert.NoError(t, err)
+		assert.Equal(t, "/uploads/avatars/1/new_thumb.jpg", res.ThumbnailURL)
+	})
+
+	t.Run("file-too-large", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1}, nil).Once()
+		mockUploader.On("Upload", "avatars/1", data, media.AvatarSpec).Return("", errors.New("file too large")).Once()
+		_, err := testUseCase.UploadAvatar(1, data)
+		assert.EqualError(t, err, "file too large")
+	})
+
+	t.Run("update-failed-removes-upload", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1}, nil).Once()
+		mockUploader.On("Upload", "avatars/1", data, media.AvatarSpec).Return("avatars/1/new.jpg", nil).Once()
+		mockRepo.On("UpdateUserAvatar", uint(1), "avatars/1/new.jpg").Return(errors.New("unexpected DB error")).Once()
+		mockUploader.On("Delete", "avatars/1/new.jpg").Return(nil).Once()
+		_, err := testUseCase.UploadAvatar(1, data)
+		assert.Error(t, err)
+	})
+
+	mockRepo.AssertExpectations(t)
+	mockUploader.AssertExpectations(t)
+}
+
+func TestDeleteAvatar(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	mockUploader := mediaMocks.NewUploader(t)
+	testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mockUploader, testPasswordChecker)
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, AvatarKey: "avatars/1/old.jpg"}, nil).Once()
+		mockRepo.On("UpdateUserAvatar", uint(1), "").Return(nil).Once()
+		mockUploader.On("Delete", "avatars/1/old.jpg").Return(nil).Once()
+		err := testUseCase.DeleteAvatar(1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("avatar-not-found", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1}, nil).Once()
+		err := testUseCase.DeleteAvatar(1)
+		assert.EqualError(t, err, "avatar not found")
+	})
+
+	mockRepo.AssertExpectations(t)
+	mockUploader.AssertExpectations(t)
+}
+
+func TestAddUserSkill(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	t.Run("success", func(t *testing.T) {
+		mockSkill.On("FindOrCreateSkill", "golang").Return(skillEntity.Skill{ID: 2, Name: "Go", Slug: "go"}, nil).Once()
+		mockRepo.On("AddUserSkills", []entity.UserSkill{
+			{
+				UserID:      1,
+				SkillID:     2,
+				Proficiency: 1,
+			},
+		}).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.AddUserSkill(1, dto.UserSkillRequest{Name: "golang"})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockSkill.AssertExpectations(t)
+	})
+
+	t.Run("empty-name", func(t *testing.T) {
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.AddUserSkill(1, dto.UserSkillRequest{Name: "  "})
+		assert.EqualError(t, err, "fill the skill name")
+	})
+
+	t.Run("unexpected-error", func(t *testing.T) {
+		mockSkill.On("FindOrCreateSkill", "golang").Return(skillEntity.Skill{}, errors.New("unexpected error")).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.AddUserSkill(1, dto.UserSkillRequest{Name: "golang", Proficiency: 2})
+		assert.Error(t, err)
+		mockSkill.AssertExpectations(t)
+	})
+}
+
+func TestRemoveUserSkill(t *testing.T) {
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("DeleteUserSkill", uint(1), uint(2)).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.RemoveUserSkill(1, 2)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockRepo.On("DeleteUserSkill", uint(1), uint(2)).Return(errors.New("no rows affected")).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.RemoveUserSkill(1, 2)
+		assert.EqualError(t, err, "skill not found")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestSetupTwoFactor(t *testing.T) {
+	t.Setenv("ENCRYPTION_KEY", testEncryptionKey)
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, Email: "asdfa@gmail.com"}, nil).Once()
+		mockRepo.On("SetTwoFactorSecret", uint(1), mock.AnythingOfType("string")).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		setup, err := testUseCase.SetupTwoFactor(1)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, setup.Secret)
+		assert.Contains(t, setup.ProvisioningURI, "secret="+setup.Secret)
+
+		// the secret is stored encrypted
+		storedSecret := mockRepo.Calls[len(mockRepo.Calls)-1].Arguments.String(1)
+		assert.NotEqual(t, setup.Secret, storedSecret)
+		decryptedSecret, err := utils.DecryptSecret(storedSecret)
+		assert.NoError(t, err)
+		assert.Equal(t, setup.Secret, decryptedSecret)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("already-enabled", func(t *testing.T) {
+		enabledAt := time.Now()
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1, TwoFactorEnabledAt: &enabledAt}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.SetupTwoFactor(1)
+		assert.EqualError(t, err, "two-factor authentication is already enabled")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestEnableTwoFactor(t *testing.T) {
+	t.Setenv("ENCRYPTION_KEY", testEncryptionKey)
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	secret, _ := totp.GenerateSecret()
+	encryptedSecret, _ := utils.EncryptSecret(secret)
+	user := entity.User{ID: 1, TwoFactorSecret: encryptedSecret}
+
+	t.Run("success", func(t *testing.T) {
+		code, _ := totp.Code(secret, totp.Step(time.Now()))
+		mockRepo.On("GetUserByID", uint(1)).Return(user, nil).Once()
+		mockRepo.On("EnableTwoFactor", uint(1), mock.AnythingOfType("int64"), mock.AnythingOfType("[]entity.RecoveryCode")).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		recoveryCodes, err := testUseCase.EnableTwoFactor(1, dto.TwoFactorCodeRequest{Code: code})
+		assert.NoError(t, err)
+		assert.Len(t, recoveryCodes.RecoveryCodes, 10)
+
+		storedCodes := mockRepo.Calls[len(mockRepo.Calls)-1].Arguments.Get(2).([]entity.RecoveryCode)
+		assert.Equal(t, utils.HashToken(strings.ReplaceAll(recoveryCodes.RecoveryCodes[0], "-", "")), storedCodes[0].CodeHash)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid-code", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(user, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.EnableTwoFactor(1, dto.TwoFactorCodeRequest{Code: "000000x"})
+		assert.EqualError(t, err, "invalid two-factor code")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("not-set-up", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(entity.User{ID: 1}, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		_, err := testUseCase.EnableTwoFactor(1, dto.TwoFactorCodeRequest{Code: "123456"})
+		assert.EqualError(t, err, "two-factor authentication is not set up")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	t.Setenv("ENCRYPTION_KEY", testEncryptionKey)
+	mockRepo := userRepo.NewUserRepository(t)
+	mockCompetition := competitionRepo.NewCompetitionRepository(t)
+	mockRecruitment := recruitmentRepo.NewRecruitmentRepository(t)
+	mockTeam := teamRepo.NewTeamRepository(t)
+	mockSkill := skillRepo.NewSkillRepository(t)
+	mockInstitution := institutionRepo.NewInstitutionRepository(t)
+	mockMailer := mailerMocks.NewMailer(t)
+	mockGuard := guardMocks.NewGuard(t)
+	secret, _ := totp.GenerateSecret()
+	encryptedSecret, _ := utils.EncryptSecret(secret)
+	enabledAt := time.Now()
+	user := entity.User{
+		ID:                 1,
+		Password:           "$2a$10$YefQPq3c5H7OalTHNFgo8Ob7Sxjc8F.fI3.ePvHOhOYCkqOGrFhm6",
+		TwoFactorSecret:    encryptedSecret,
+		TwoFactorEnabledAt: &enabledAt,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		code, _ := totp.Code(secret, totp.Step(time.Now()))
+		mockRepo.On("GetUserByID", uint(1)).Return(user, nil).Once()
+		mockRepo.On("UseTwoFactorStep", uint(1), mock.AnythingOfType("int64")).Return(nil).Once()
+		mockRepo.On("DisableTwoFactor", uint(1)).Return(nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.DisableTwoFactor(1, dto.TwoFactorDisableRequest{Password: "asdfasfas", Code: code})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong-password", func(t *testing.T) {
+		mockRepo.On("GetUserByID", uint(1)).Return(user, nil).Once()
+		testUseCase := CreateNewUserUseCase(mockRepo, mockCompetition, mockRecruitment, mockTeam, mockSkill, mockInstitution, discussionRepo.NewDiscussionRepository(t), mockMailer, policy.CreateNewPolicy(mockRepo, nil), privacyMocks.NewShaper(t), mockGuard, nil, testKeyRing, mediaMocks.NewUploader(t), testPasswordChecker)
+		err := testUseCase.DisableTwoFactor(1, dto.TwoFactorDisableRequest{Password: "wrong", Code: "123456"})
+		assert.EqualError(t, err, "wrong password")
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -1,44 +1,147 @@
 package usecase
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	compRepo "github.com/alimikegami/compnouron/internal/competition/repository"
+	discussionEntity "github.com/alimikegami/compnouron/internal/discussion/entity"
+	discussionRepo "github.com/alimikegami/compnouron/internal/discussion/repository"
+	institutionRepo "github.com/alimikegami/compnouron/internal/institution/repository"
 	recRepo "github.com/alimikegami/compnouron/internal/recruitment/repository"
+	skillRepo "github.com/alimikegami/compnouron/internal/skill/repository"
+	teamEntity "github.com/alimikegami/compnouron/internal/team/entity"
+	teamRepo "github.com/alimikegami/compnouron/internal/team/repository"
 
 	dtoComp "github.com/alimikegami/compnouron/internal/competition/dto"
+	"github.com/alimikegami/compnouron/internal/media"
+	"github.com/alimikegami/compnouron/internal/policy"
+	"github.com/alimikegami/compnouron/internal/privacy"
 	"github.com/alimikegami/compnouron/internal/user/dto"
 	"github.com/alimikegami/compnouron/internal/user/entity"
 	"github.com/alimikegami/compnouron/internal/user/repository"
 
+	"github.com/alimikegami/compnouron/pkg/keyring"
+	"github.com/alimikegami/compnouron/pkg/loginguard"
+	"github.com/alimikegami/compnouron/pkg/mailer"
+	"github.com/alimikegami/compnouron/pkg/oidc"
+	"github.com/alimikegami/compnouron/pkg/password"
+	"github.com/alimikegami/compnouron/pkg/totp"
 	"github.com/alimikegami/compnouron/pkg/utils"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 type UserUseCase interface {
 	CreateUser(user *dto.UserRegistrationRequest) error
-	Login(credential *dto.Credential) (string, error)
+	Login(credential *dto.Credential, ipAddress string, userAgent string) (dto.TokenResponse, error)
+	LoginWithTwoFactor(request dto.TwoFactorLoginRequest, ipAddress string, userAgent string) (dto.TokenResponse, error)
+	StartOIDCLogin(provider string) (dto.OIDCAuthorization, error)
+	CompleteOIDCLogin(provider string, request dto.OIDCCallbackRequest, ipAddress string, userAgent string) (dto.TokenResponse, error)
+	RefreshToken(refreshToken string) (dto.TokenResponse, error)
+	Logout(refreshToken string) error
+	GetSessions(userID uint, currentSessionID uint) ([]dto.SessionResponse, error)
+	RevokeSession(userID uint, sessionID uint) error
+	RevokeOtherSessions(userID uint, currentSessionID uint) error
+	ValidateSession(userID uint, sessionID uint) error
+	CreatePersonalAccessToken(userID uint, request dto.PersonalAccessTokenRequest) (dto.CreatedPersonalAccessTokenResponse, error)
+	GetPersonalAccessTokens(userID uint) ([]dto.PersonalAccessTokenResponse, error)
+	RevokePersonalAccessToken(userID uint, tokenID uint) error
+	AuthenticatePersonalAccessToken(token string) (utils.JwtCustomClaims, error)
+	VerifyEmail(token string) error
+	ResendVerificationEmail(userID uint) error
+	ForgotPassword(email string) error
+	ResetPassword(request dto.ResetPasswordRequest) error
+	UpdateUserRole(adminID uint, userID uint, role string) error
+	GetActiveLockouts(adminID uint) ([]dto.LockoutEventResponse, error)
+	UnlockUser(adminID uint, userID uint) error
+	ImpersonateUser(adminID uint, adminSessionID uint, userID uint, request dto.ImpersonationRequest, ipAddress string) (dto.ImpersonationTokenResponse, error)
+	ValidateImpersonation(impersonationID uint, actorID uint, userID uint) error
+	EndImpersonation(impersonationID uint) error
+	RecordImpersonatedRequest(impersonationID uint, actorID uint, userID uint, method string, path string, status int, ipAddress string) error
+	GetImpersonations(adminID uint) ([]dto.ImpersonationResponse, error)
+	GetUserDetails(userID uint) (dto.UserDetailsResponse, error)
+	UpdateUser(userID uint, user dto.UserUpdateRequest) error
+	UpdatePrivacySettings(userID uint, request dto.PrivacySettingsRequest) error
+	SearchUsers(viewerID uint, request dto.UserSearchRequest) ([]dto.UserProfileResponse, error)
+	ChangePassword(userID uint, request dto.PasswordChangeRequest) error
+	AddUserSkill(userID uint, skill dto.UserSkillRequest) error
+	RemoveUserSkill(userID uint, skillID uint) error
+	ExportUserData(userID uint) (dto.UserDataExport, error)
+	DeleteAccount(userID uint, request dto.AccountDeletionRequest) error
+	SetupTwoFactor(userID uint) (dto.TwoFactorSetupResponse, error)
+	EnableTwoFactor(userID uint, request dto.TwoFactorCodeRequest) (dto.RecoveryCodesResponse, error)
+	DisableTwoFactor(userID uint, request dto.TwoFactorDisableRequest) error
 	GetCompetitionRegistrationHistory(userID uint) ([]dto.UserCompetitionHistory, error)
 	GetRecruitmentApplicationHistory(userID uint) ([]dto.UserRecruitmentApplicationHistory, error)
 	GetCompetitionsData(userID uint) ([]dtoComp.CompetitionResponse, error)
+	GetAchievements(userID uint) ([]dto.AchievementResponse, error)
+	UploadAvatar(userID uint, data []byte) (*media.Image, error)
+	DeleteAvatar(userID uint) error
 }
 
+const (
+	refreshTokenLifetime       = 30 * 24 * time.Hour
+	verificationTokenLifetime  = 24 * time.Hour
+	passwordResetTokenLifetime = time.Hour
+	twoFactorChallengeLifetime = 5 * time.Minute
+	oidcFlowLifetime           = 10 * time.Minute
+	impersonationLifetime      = 15 * time.Minute
+	recoveryCodeCount          = 10
+	// last_seen_at is only written when it is older than this, so a burst of
+	// requests does not turn into a burst of updates
+	sessionTouchInterval = time.Minute
+	// personal access tokens may not be valid for longer than this
+	personalAccessTokenMaxDays = 365
+	defaultSearchLimit         = 10
+	maxSearchLimit             = 50
+)
+
 type UserUseCaseImpl struct {
 	ur repository.UserRepository
 	cr compRepo.CompetitionRepository
 	rr recRepo.RecruitmentRepository
+	tr teamRepo.TeamRepository
+	sr skillRepo.SkillRepository
+	ir institutionRepo.InstitutionRepository
+	dr discussionRepo.DiscussionRepository
+	m  mailer.Mailer
+	p  policy.Policy
+	s  privacy.Shaper
+	lg loginguard.Guard
+	op map[string]oidc.Provider
+	kr keyring.Ring
+	mu media.Uploader
+	pc password.Checker
 }
 
-func CreateNewUserUseCase(ur repository.UserRepository, cr compRepo.CompetitionRepository, rr recRepo.RecruitmentRepository) UserUseCase {
-	return &UserUseCaseImpl{ur: ur, cr: cr, rr: rr}
+func CreateNewUserUseCase(ur repository.UserRepository, cr compRepo.CompetitionRepository, rr recRepo.RecruitmentRepository, tr teamRepo.TeamRepository, sr skillRepo.SkillRepository, ir institutionRepo.InstitutionRepository, dr discussionRepo.DiscussionRepository, m mailer.Mailer, p policy.Policy, s privacy.Shaper, lg loginguard.Guard, op map[string]oidc.Provider, kr keyring.Ring, mu media.Uploader, pc password.Checker) UserUseCase {
+	return &UserUseCaseImpl{ur: ur, cr: cr, rr: rr, tr: tr, sr: sr, ir: ir, dr: dr, m: m, p: p, s: s, lg: lg, op: op, kr: kr, mu: mu, pc: pc}
 }
 
 func (us *UserUseCaseImpl) CreateUser(user *dto.UserRegistrationRequest) error {
-	var skills []entity.Skill
 	if len(user.Skills) == 0 {
 		return errors.New("fill your skills")
 	}
+
+	err := us.pc.Check(user.Password, user.Email, user.Name)
+	if err != nil {
+		return err
+	}
+
+	skills, err := us.toUserSkills(user.Skills)
+	if err != nil {
+		return err
+	}
+
 	hash, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return err
@@ -50,6 +153,7 @@ func (us *UserUseCaseImpl) CreateUser(user *dto.UserRegistrationRequest) error {
 		Password:          string(hash),
 		PhoneNumber:       user.PhoneNumber,
 		SchoolInstitution: user.SchoolInstitution,
+		Role:              utils.RoleStudent,
 	}
 
 	userID, err := us.ur.CreateUser(userEntity)
@@ -57,48 +161,1710 @@ func (us *UserUseCaseImpl) CreateUser(user *dto.UserRegistrationRequest) error {
 		return err
 	}
 
+	for i := range skills {
+		skills[i].UserID = userID
+	}
+
+	err = us.ur.AddUserSkills(skills)
+	if err != nil {
+		return err
+	}
+
+	return us.sendVerificationEmail(userID, user.Email)
+}
+
+func (us *UserUseCaseImpl) sendVerificationEmail(userID uint, email string) error {
+	token, err := utils.CreateSignedPurposeToken(utils.EmailVerificationPurpose, userID, email, verificationTokenLifetime)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Open the link below to verify your email address. The link expires in 24 hours.\n\n%s/users/verify?token=%s", os.Getenv("APP_URL"), token)
+	return us.m.Send(email, "Verify your Compnouron account", body)
+}
+
+func (us *UserUseCaseImpl) VerifyEmail(token string) error {
+	claims, err := utils.ParsePurposeToken(utils.EmailVerificationPurpose, token)
+	if err != nil {
+		return errors.New("invalid verification token")
+	}
+
+	user, err := us.ur.GetUserByID(claims.ID)
+	if err != nil {
+		return errors.New("invalid verification token")
+	}
+
+	// the token is bound to the address it was sent to, so it stops working
+	// once the user changes their email
+	if user.Email != claims.Email {
+		return errors.New("invalid verification token")
+	}
+
+	if user.VerifiedAt != nil {
+		return errors.New("email already verified")
+	}
+
+	err = us.ur.VerifyUserEmail(user.ID)
+	if err != nil {
+		return err
+	}
+
+	return us.affiliateInstitution(user.ID, user.Email)
+}
+
+// institutionIDForEmail returns the ID of the institution owning the domain of
+// the email address, or nil when no institution owns it.
+func (us *UserUseCaseImpl) institutionIDForEmail(email string) (*uint, error) {
+	institution, err := us.ir.GetInstitutionByDomains(institutionRepo.EmailDomains(email))
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &institution.ID, nil
+}
+
+// affiliateInstitution must only be called once the email address has been
+// verified, since owning the address is what proves the affiliation.
+func (us *UserUseCaseImpl) affiliateInstitution(userID uint, email string) error {
+	institutionID, err := us.institutionIDForEmail(email)
+	if err != nil || institutionID == nil {
+		return err
+	}
+
+	return us.ur.UpdateUserInstitution(userID, *institutionID)
+}
+
+func (us *UserUseCaseImpl) ResendVerificationEmail(userID uint) error {
+	user, err := us.ur.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.VerifiedAt != nil {
+		return errors.New("email already verified")
+	}
+
+	return us.sendVerificationEmail(user.ID, user.Email)
+}
+
+func (us *UserUseCaseImpl) ForgotPassword(email string) error {
+	// unknown addresses are ignored so the endpoint can't be used to find out
+	// which emails are registered
+	user := us.ur.GetUserByEmail(email)
+	if user == nil || user.ID == 0 {
+		return nil
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	err = us.ur.CreatePasswordResetToken(entity.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(passwordResetTokenLifetime),
+	})
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Open the link below to choose a new password. The link expires in 1 hour and can only be used once. If you did not ask for a password reset, you can ignore this email.\n\n%s/users/password/reset?token=%s", os.Getenv("APP_URL"), token)
+	return us.m.Send(user.Email, "Reset your Compnouron password", body)
+}
+
+func (us *UserUseCaseImpl) ResetPassword(request dto.ResetPasswordRequest) error {
+	if request.Password == "" {
+		return errors.New("fill your new password")
+	}
+
+	storedToken, err := us.ur.GetPasswordResetTokenByHash(utils.HashToken(request.Token))
+	if err != nil {
+		return errors.New("invalid reset token")
+	}
+
+	if storedToken.UsedAt != nil || time.Now().After(storedToken.ExpiresAt) {
+		return errors.New("invalid reset token")
+	}
+
+	// checked before the token is redeemed, so the user can retry with a
+	// better password using the same link
+	user, err := us.ur.GetUserByID(storedToken.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = us.pc.Check(request.Password, user.Email, user.Name)
+	if err != nil {
+		return err
+	}
+
+	err = us.ur.UsePasswordResetToken(storedToken.ID)
+	if err != nil {
+		// the token was redeemed by a concurrent request
+		return errors.New("invalid reset token")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	err = us.ur.UpdateUserPassword(storedToken.UserID, string(hash))
+	if err != nil {
+		return err
+	}
+
+	// whoever knew the old password may still be logged in or hold a personal
+	// access token made with it, so sign out every device, revoke the tokens
+	// and drop any other reset links that are still outstanding
+	err = us.ur.RevokeUserSessions(storedToken.UserID, 0)
+	if err != nil {
+		return err
+	}
+
+	err = us.ur.RevokeUserPersonalAccessTokens(storedToken.UserID)
+	if err != nil {
+		return err
+	}
+
+	return us.ur.InvalidateUserPasswordResetTokens(storedToken.UserID)
+}
+
+func (us *UserUseCaseImpl) UpdateUserRole(adminID uint, userID uint, role string) error {
+	err := us.p.CanAssignRoles(adminID)
+	if err != nil {
+		return err
+	}
+
+	if !utils.IsValidRole(role) {
+		return errors.New("invalid role")
+	}
+
+	// keeps an admin from locking themselves out of the admin endpoints
+	if adminID == userID {
+		return errors.New("can't change your own role")
+	}
+
+	return us.ur.UpdateUserRole(userID, role)
+}
+
+func (us *UserUseCaseImpl) GetUserDetails(userID uint) (dto.UserDetailsResponse, error) {
+	user, err := us.ur.GetUserWithSkillsByID(userID)
+	if err != nil {
+		return dto.UserDetailsResponse{}, err
+	}
+
+	userDetails := dto.UserDetailsResponse{
+		ID:                    user.ID,
+		Name:                  user.Name,
+		Email:                 user.Email,
+		PhoneNumber:           user.PhoneNumber,
+		SchoolInstitution:     user.SchoolInstitution,
+		Role:                  user.Role,
+		EmailVerified:         user.VerifiedAt != nil,
+		TwoFactorEnabled:      user.TwoFactorEnabledAt != nil,
+		EmailVisibility:       user.EmailVisibility,
+		PhoneNumberVisibility: user.PhoneNumberVisibility,
+		Searchable:            user.Searchable,
+		AvailableForTeams:     user.AvailableForTeams,
+		InstitutionID:         user.InstitutionID,
+		Avatar:                us.mu.Image(user.AvatarKey),
+		Skills:                []dto.UserSkillResponse{},
+	}
+	if user.Institution != nil {
+		userDetails.InstitutionName = user.Institution.Name
+	}
+
 	for _, skill := range user.Skills {
-		skills = append(skills, entity.Skill{
-			Name:   skill.Name,
-			UserID: userID,
+		userDetails.Skills = append(userDetails.Skills, dto.UserSkillResponse{
+			ID:          skill.SkillID,
+			Name:        skill.Skill.Name,
+			Proficiency: skill.Proficiency,
 		})
 	}
 
-	err = us.ur.AddUserSkills(skills)
-	return err
+	return userDetails, nil
 }
 
-func (us *UserUseCaseImpl) Login(credential *dto.Credential) (string, error) {
-	user := us.ur.GetUserByEmail(credential.Email)
-	if user == nil {
-		return "", fmt.Errorf("credentials dont match")
+func (us *UserUseCaseImpl) UpdateUser(userID uint, user dto.UserUpdateRequest) error {
+	if user.Name == "" || user.Email == "" {
+		return errors.New("fill your name and email")
+	}
+
+	currentUser, err := us.ur.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	emailChanged := user.Email != currentUser.Email
+	if emailChanged {
+		existingUser := us.ur.GetUserByEmail(user.Email)
+		if existingUser != nil && existingUser.ID != 0 {
+			return errors.New("email is already registered")
+		}
 	}
-	err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(credential.Password))
+
+	err = us.ur.UpdateUser(entity.User{
+		ID:                userID,
+		Name:              user.Name,
+		PhoneNumber:       user.PhoneNumber,
+		SchoolInstitution: user.SchoolInstitution,
+	})
 	if err != nil {
-		return "", fmt.Errorf("credentials dont match")
+		return err
 	}
-	token, err := utils.CreateSignedJWTToken(user.ID, user.Email)
+
+	if !emailChanged {
+		return nil
+	}
+
+	// the new address is unverified until the user opens the link sent to it
+	err = us.ur.UpdateUserEmail(userID, user.Email)
 	if err != nil {
-		fmt.Println(err)
-		return "", nil
+		return err
 	}
 
-	return token, nil
+	return us.sendVerificationEmail(userID, user.Email)
 }
 
-func (us *UserUseCaseImpl) GetCompetitionsData(userID uint) ([]dtoComp.CompetitionResponse, error) {
-	var createdComps []dtoComp.CompetitionResponse
-	comps, err := us.cr.GetCompetitionByUserID(userID)
-	for _, comp := range comps {
-		createdComps = append(createdComps, dtoComp.CompetitionResponse{
-			ID:            comp.ID,
-			Name:          comp.Name,
-			ContactPerson: comp.ContactPerson,
-			IsTeam:        comp.IsTeam,
-			Level:         comp.Level,
+// UpdatePrivacySettings changes who may see the user's email and phone number,
+// and whether the user shows up in talent search. A setting left empty keeps
+// its current value.
+func (us *UserUseCaseImpl) UpdatePrivacySettings(userID uint, request dto.PrivacySettingsRequest) error {
+	user, err := us.ur.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	emailVisibility := user.EmailVisibility
+	if emailVisibility == "" {
+		emailVisibility = privacy.DefaultVisibility
+	}
+	if request.EmailVisibility != "" {
+		emailVisibility = request.EmailVisibility
+	}
+
+	phoneNumberVisibility := user.PhoneNumberVisibility
+	if phoneNumberVisibility == "" {
+		phoneNumberVisibility = privacy.DefaultVisibility
+	}
+	if request.PhoneNumberVisibility != "" {
+		phoneNumberVisibility = request.PhoneNumberVisibility
+	}
+
+	if !privacy.IsValidVisibility(emailVisibility) || !privacy.IsValidVisibility(phoneNumberVisibility) {
+		return errors.New("invalid visibility")
+	}
+
+	searchable := user.Searchable
+	if request.Searchable != nil {
+		searchable = *request.Searchable
+	}
+
+	availableForTeams := user.AvailableForTeams
+	if request.AvailableForTeams != nil {
+		availableForTeams = *request.AvailableForTeams
+	}
+
+	return us.ur.UpdateUserPrivacySettings(userID, emailVisibility, phoneNumberVisibility, searchable, availableForTeams)
+}
+
+// SearchUsers finds users by skill, institution and availability. Users who
+// opted out of search are left out, and contact details are redacted
+// according to how the viewer relates to each user.
+func (us *UserUseCaseImpl) SearchUsers(viewerID uint, request dto.UserSearchRequest) ([]dto.UserProfileResponse, error) {
+	if request.Offset < 0 {
+		return nil, errors.New("invalid offset")
+	}
+
+	limit := request.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	} else if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	users, err := us.ur.SearchUsers(repository.UserSearchFilter{
+		SkillIDs:       request.SkillIDs,
+		MatchAllSkills: request.MatchAllSkills,
+		InstitutionID:  request.InstitutionID,
+		AvailableOnly:  request.AvailableOnly,
+	}, limit, request.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	viewer, err := us.s.Viewer(viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := []dto.UserProfileResponse{}
+	for _, user := range users {
+		relationship := viewer.RelationshipTo(user.ID)
+		profile := dto.UserProfileResponse{
+			ID:                user.ID,
+			Name:              user.Name,
+			Email:             relationship.Email(user),
+			PhoneNumber:       relationship.PhoneNumber(user),
+			SchoolInstitution: user.SchoolInstitution,
+			InstitutionID:     user.InstitutionID,
+			AvailableForTeams: user.AvailableForTeams,
+			Avatar:            us.mu.Image(user.AvatarKey),
+			Skills:            []dto.UserSkillResponse{},
+		}
+		if user.Institution != nil {
+			profile.InstitutionName = user.Institution.Name
+		}
+
+		for _, skill := range user.Skills {
+			profile.Skills = append(profile.Skills, dto.UserSkillResponse{
+				ID:          skill.SkillID,
+				Name:        skill.Skill.Name,
+				Proficiency: skill.Proficiency,
+			})
+		}
+
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+func (us *UserUseCaseImpl) ChangePassword(userID uint, request dto.PasswordChangeRequest) error {
+	if request.NewPassword == "" {
+		return errors.New("fill your new password")
+	}
+
+	user, err := us.ur.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.OldPassword))
+	if err != nil {
+		return errors.New("wrong password")
+	}
+
+	err = us.pc.Check(request.NewPassword, user.Email, user.Name)
+	if err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(request.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	err = us.ur.UpdateUserPassword(userID, string(hash))
+	if err != nil {
+		return err
+	}
+
+	err = us.ur.RevokeUserSessions(userID, 0)
+	if err != nil {
+		return err
+	}
+
+	return us.ur.RevokeUserPersonalAccessTokens(userID)
+}
+
+func (us *UserUseCaseImpl) ExportUserData(userID uint) (dto.UserDataExport, error) {
+	user, err := us.ur.GetUserWithSkillsByID(userID)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	export := dto.UserDataExport{
+		Profile: dto.UserProfileExport{
+			ID:                user.ID,
+			Name:              user.Name,
+			Email:             user.Email,
+			PhoneNumber:       user.PhoneNumber,
+			SchoolInstitution: user.SchoolInstitution,
+			Role:              user.Role,
+			VerifiedAt:        user.VerifiedAt,
+			CreatedAt:         user.CreatedAt,
+			UpdatedAt:         user.UpdatedAt,
+		},
+		Skills:                   []dto.UserSkillResponse{},
+		TeamMemberships:          []dto.UserTeamMembershipExport{},
+		CompetitionRegistrations: []dto.UserCompetitionHistory{},
+		RecruitmentApplications:  []dto.UserRecruitmentApplicationHistory{},
+		OrganizedCompetitions:    []dtoComp.CompetitionResponse{},
+		Sessions:                 []dto.UserSessionExport{},
+		LockoutEvents:            []dto.UserLockoutEventExport{},
+		Threads:                  []dto.UserThreadExport{},
+		Replies:                  []dto.UserReplyExport{},
+		Mentions:                 []dto.UserMentionExport{},
+		PersonalAccessTokens:     []dto.UserPersonalAccessTokenExport{},
+		Identities:               []dto.UserIdentityExport{},
+		InvitationsSent:          []dto.UserTeamInvitationExport{},
+		InvitationsReceived:      []dto.UserTeamInvitationExport{},
+		ImpersonationActions:     []dto.UserImpersonationActionExport{},
+	}
+
+	for _, skill := range user.Skills {
+		export.Skills = append(export.Skills, dto.UserSkillResponse{
+			ID:          skill.SkillID,
+			Name:        skill.Skill.Name,
+			Proficiency: skill.Proficiency,
 		})
 	}
-	return createdComps, err
+
+	teamMembers, err := us.tr.GetTeamMembershipsByUserID(userID)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	for _, teamMember := range teamMembers {
+		export.TeamMemberships = append(export.TeamMemberships, dto.UserTeamMembershipExport{
+			TeamID:   teamMember.TeamID,
+			TeamName: teamMember.Team.Name,
+			IsLeader: teamMember.Role == teamEntity.TeamRoleOwner,
+			Role:     teamMember.Role,
+			JoinedAt: teamMember.CreatedAt,
+		})
+	}
+
+	registrations, err := us.GetCompetitionRegistrationHistory(userID)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+	export.CompetitionRegistrations = append(export.CompetitionRegistrations, registrations...)
+
+	applications, err := us.GetRecruitmentApplicationHistory(userID)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+	export.RecruitmentApplications = append(export.RecruitmentApplications, applications...)
+
+	competitions, err := us.GetCompetitionsData(userID)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+	export.OrganizedCompetitions = append(export.OrganizedCompetitions, competitions...)
+
+	sessions, err := us.ur.GetSessionsByUserID(userID)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	for _, session := range sessions {
+		export.Sessions = append(export.Sessions, dto.UserSessionExport{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			RevokedAt:  session.RevokedAt,
+		})
+	}
+
+	lockoutEvents, err := us.ur.GetLockoutEventsByUserID(userID)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	for _, lockoutEvent := range lockoutEvents {
+		export.LockoutEvents = append(export.LockoutEvents, dto.UserLockoutEventExport{
+			ID:          lockoutEvent.ID,
+			Email:       lockoutEvent.Email,
+			IPAddress:   lockoutEvent.IPAddress,
+			Scope:       lockoutEvent.Scope,
+			LockedUntil: lockoutEvent.LockedUntil,
+			UnlockedAt:  lockoutEvent.UnlockedAt,
+			CreatedAt:   lockoutEvent.CreatedAt,
+		})
+	}
+
+	threads, err := us.dr.GetThreadsByAuthorID(userID)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	for _, thread := range threads {
+		export.Threads = append(export.Threads, dto.UserThreadExport{
+			ID:               thread.ID,
+			TeamID:           thread.TeamID,
+			Title:            thread.Title,
+			Body:             thread.Body,
+			MentionedUserIDs: mentionedUserIDs(thread.Mentions),
+			EditedAt:         thread.EditedAt,
+			CreatedAt:        thread.CreatedAt,
+		})
+	}
+
+	replies, err := us.dr.GetRepliesByAuthorID(userID)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	for _, reply := range replies {
+		export.Replies = append(export.Replies, dto.UserReplyExport{
+			ID:               reply.ID,
+			ThreadID:         reply.ThreadID,
+			Body:             reply.Body,
+			MentionedUserIDs: mentionedUserIDs(reply.Mentions),
+			EditedAt:         reply.EditedAt,
+			CreatedAt:        reply.CreatedAt,
+		})
+	}
+
+	mentions, err := us.dr.GetMentionsByUserID(userID)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	for _, mention := range mentions {
+		export.Mentions = append(export.Mentions, dto.UserMentionExport{
+			ID:        mention.ID,
+			ThreadID:  mention.ThreadID,
+			ReplyID:   mention.ReplyID,
+			CreatedAt: mention.CreatedAt,
+		})
+	}
+
+	tokens, err := us.ur.GetAllPersonalAccessTokens(userID)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	for _, token := range tokens {
+		export.PersonalAccessTokens = append(export.PersonalAccessTokens, dto.UserPersonalAccessTokenExport{
+			ID:         token.ID,
+			Name:       token.Name,
+			Scopes:     strings.Fields(token.Scopes),
+			LastUsedAt: token.LastUsedAt,
+			ExpiresAt:  token.ExpiresAt,
+			RevokedAt:  token.RevokedAt,
+			CreatedAt:  token.CreatedAt,
+		})
+	}
+
+	userIdentities, err := us.ur.GetUserIdentitiesByUserID(userID)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	for _, userIdentity := range userIdentities {
+		export.Identities = append(export.Identities, dto.UserIdentityExport{
+			ID:        userIdentity.ID,
+			Provider:  userIdentity.Provider,
+			Subject:   userIdentity.Subject,
+			Email:     userIdentity.Email,
+			CreatedAt: userIdentity.CreatedAt,
+		})
+	}
+
+	export.Achievements, err = us.GetAchievements(userID)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	sentInvitations, err := us.tr.GetTeamInvitationsByInviterID(userID)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	for _, invitation := range sentInvitations {
+		export.InvitationsSent = append(export.InvitationsSent, toUserTeamInvitationExport(invitation))
+	}
+
+	// invitations to the email address only belong to the user once it is
+	// verified, as on the list of received invitations
+	email := ""
+	if user.VerifiedAt != nil {
+		email = user.Email
+	}
+
+	receivedInvitations, err := us.tr.GetTeamInvitationsForUser(userID, email)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	for _, invitation := range receivedInvitations {
+		export.InvitationsReceived = append(export.InvitationsReceived, toUserTeamInvitationExport(invitation))
+	}
+
+	actions, err := us.ur.GetImpersonationActionsByUserID(userID)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	for _, action := range actions {
+		export.ImpersonationActions = append(export.ImpersonationActions, dto.UserImpersonationActionExport{
+			ImpersonationID: action.ImpersonationID,
+			ActorID:         action.ActorID,
+			Method:          action.Method,
+			Path:            action.Path,
+			Status:          action.Status,
+			CreatedAt:       action.CreatedAt,
+		})
+	}
+
+	return export, nil
+}
+
+func toUserTeamInvitationExport(invitation teamEntity.TeamInvitation) dto.UserTeamInvitationExport {
+	return dto.UserTeamInvitationExport{
+		ID:          invitation.ID,
+		TeamID:      invitation.TeamID,
+		TeamName:    invitation.Team.Name,
+		InviterID:   invitation.InviterID,
+		InviteeID:   invitation.InviteeID,
+		Email:       invitation.Email,
+		Status:      invitation.CurrentStatus(),
+		ExpiresAt:   invitation.ExpiresAt,
+		RespondedAt: invitation.RespondedAt,
+		CreatedAt:   invitation.CreatedAt,
+	}
+}
+
+func mentionedUserIDs(mentions []discussionEntity.Mention) []uint {
+	userIDs := []uint{}
+	for _, mention := range mentions {
+		userIDs = append(userIDs, mention.UserID)
+	}
+
+	return userIDs
+}
+
+// DeleteAccount anonymizes the user instead of deleting the row, because the
+// teams, competitions and applications the user took part in still refer to it.
+// As with leaving a team, the owner has to hand the ownership over first, so
+// no team is left without one.
+func (us *UserUseCaseImpl) DeleteAccount(userID uint, request dto.AccountDeletionRequest) error {
+	user, err := us.ur.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password))
+	if err != nil {
+		return errors.New("wrong password")
+	}
+
+	memberships, err := us.tr.GetTeamMembershipsByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, membership := range memberships {
+		if membership.Role == teamEntity.TeamRoleOwner {
+			return errors.New("transfer the ownership of your teams before deleting the account")
+		}
+	}
+
+	err = us.ur.AnonymizeUser(userID)
+	if err != nil {
+		return err
+	}
+
+	// the account is gone either way, a leftover avatar file only wastes space
+	if user.AvatarKey != "" {
+		us.mu.Delete(user.AvatarKey)
+	}
+
+	return nil
+}
+
+func (us *UserUseCaseImpl) UploadAvatar(userID uint, data []byte) (*media.Image, error) {
+	user, err := us.ur.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := us.mu.Upload("avatars/"+strconv.FormatUint(uint64(userID), 10), data, media.AvatarSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	err = us.ur.UpdateUserAvatar(userID, key)
+	if err != nil {
+		us.mu.Delete(key)
+		return nil, err
+	}
+
+	// the new avatar is already saved, a leftover old file only wastes space
+	if user.AvatarKey != "" {
+		us.mu.Delete(user.AvatarKey)
+	}
+
+	return us.mu.Image(key), nil
+}
+
+func (us *UserUseCaseImpl) DeleteAvatar(userID uint) error {
+	user, err := us.ur.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.AvatarKey == "" {
+		return errors.New("avatar not found")
+	}
+
+	err = us.ur.UpdateUserAvatar(userID, "")
+	if err != nil {
+		return err
+	}
+
+	return us.mu.Delete(user.AvatarKey)
+}
+
+// SetupTwoFactor starts enrollment by generating a new secret. Two-factor
+// authentication stays disabled until EnableTwoFactor confirms a code from it.
+func (us *UserUseCaseImpl) SetupTwoFactor(userID uint) (dto.TwoFactorSetupResponse, error) {
+	user, err := us.ur.GetUserByID(userID)
+	if err != nil {
+		return dto.TwoFactorSetupResponse{}, err
+	}
+
+	if user.TwoFactorEnabledAt != nil {
+		return dto.TwoFactorSetupResponse{}, errors.New("two-factor authentication is already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return dto.TwoFactorSetupResponse{}, err
+	}
+
+	encryptedSecret, err := utils.EncryptSecret(secret)
+	if err != nil {
+		return dto.TwoFactorSetupResponse{}, err
+	}
+
+	err = us.ur.SetTwoFactorSecret(userID, encryptedSecret)
+	if err != nil {
+		return dto.TwoFactorSetupResponse{}, err
+	}
+
+	return dto.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, "Compnouron", user.Email),
+	}, nil
+}
+
+// EnableTwoFactor confirms enrollment with a code from the authenticator app and
+// returns the recovery codes. They are only shown this once.
+func (us *UserUseCaseImpl) EnableTwoFactor(userID uint, request dto.TwoFactorCodeRequest) (dto.RecoveryCodesResponse, error) {
+	user, err := us.ur.GetUserByID(userID)
+	if err != nil {
+		return dto.RecoveryCodesResponse{}, err
+	}
+
+	if user.TwoFactorEnabledAt != nil {
+		return dto.RecoveryCodesResponse{}, errors.New("two-factor authentication is already enabled")
+	}
+
+	if user.TwoFactorSecret == "" {
+		return dto.RecoveryCodesResponse{}, errors.New("two-factor authentication is not set up")
+	}
+
+	secret, err := utils.DecryptSecret(user.TwoFactorSecret)
+	if err != nil {
+		return dto.RecoveryCodesResponse{}, err
+	}
+
+	step, ok := totp.Validate(secret, request.Code, time.Now())
+	if !ok {
+		return dto.RecoveryCodesResponse{}, errors.New("invalid two-factor code")
+	}
+
+	codes := []string{}
+	recoveryCodes := []entity.RecoveryCode{}
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return dto.RecoveryCodesResponse{}, err
+		}
+
+		codes = append(codes, code)
+		recoveryCodes = append(recoveryCodes, entity.RecoveryCode{
+			UserID:   userID,
+			CodeHash: utils.HashToken(normalizeRecoveryCode(code)),
+		})
+	}
+
+	err = us.ur.EnableTwoFactor(userID, step, recoveryCodes)
+	if err != nil {
+		return dto.RecoveryCodesResponse{}, err
+	}
+
+	return dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (us *UserUseCaseImpl) DisableTwoFactor(userID uint, request dto.TwoFactorDisableRequest) error {
+	user, err := us.ur.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.TwoFactorEnabledAt == nil {
+		return errors.New("two-factor authentication is not enabled")
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password))
+	if err != nil {
+		return errors.New("wrong password")
+	}
+
+	err = us.verifyTwoFactorCode(user, request.Code)
+	if err != nil {
+		return err
+	}
+
+	return us.ur.DisableTwoFactor(userID)
+}
+
+// verifyTwoFactorCode accepts either a TOTP code that hasn't been used yet or
+// an unused recovery code, which is then spent.
+func (us *UserUseCaseImpl) verifyTwoFactorCode(user entity.User, code string) error {
+	secret, err := utils.DecryptSecret(user.TwoFactorSecret)
+	if err != nil {
+		return err
+	}
+
+	step, ok := totp.Validate(secret, code, time.Now())
+	if ok {
+		err = us.ur.UseTwoFactorStep(user.ID, step)
+		if err != nil {
+			return errors.New("invalid two-factor code")
+		}
+		return nil
+	}
+
+	err = us.ur.UseRecoveryCode(user.ID, utils.HashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return errors.New("invalid two-factor code")
+	}
+
+	return nil
+}
+
+// generateRecoveryCode returns a code such as "k3xq7-mz2pa".
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 7)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(b))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
+
+func (us *UserUseCaseImpl) AddUserSkill(userID uint, skill dto.UserSkillRequest) error {
+	skills, err := us.toUserSkills([]dto.UserSkillRequest{skill})
+	if err != nil {
+		return err
+	}
+	skills[0].UserID = userID
+
+	return us.ur.AddUserSkills(skills)
+}
+
+// toUserSkills resolves the free-text skill names against the skill catalog,
+// adding the names it doesn't know yet. The caller sets the user ID.
+func (us *UserUseCaseImpl) toUserSkills(skills []dto.UserSkillRequest) ([]entity.UserSkill, error) {
+	var userSkills []entity.UserSkill
+	for _, skill := range skills {
+		if strings.TrimSpace(skill.Name) == "" {
+			return nil, errors.New("fill the skill name")
+		}
+
+		proficiency := skill.Proficiency
+		if proficiency == 0 {
+			proficiency = entity.MinSkillProficiency
+		}
+		if proficiency > entity.MaxSkillProficiency {
+			return nil, errors.New("proficiency must be between 1 and 5")
+		}
+
+		catalogSkill, err := us.sr.FindOrCreateSkill(skill.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		userSkills = append(userSkills, entity.UserSkill{
+			SkillID:     catalogSkill.ID,
+			Proficiency: proficiency,
+		})
+	}
+
+	return userSkills, nil
+}
+
+func (us *UserUseCaseImpl) RemoveUserSkill(userID uint, skillID uint) error {
+	err := us.ur.DeleteUserSkill(userID, skillID)
+	if err != nil && err.Error() == "no rows affected" {
+		return errors.New("skill not found")
+	}
+
+	return err
+}
+
+func (us *UserUseCaseImpl) Login(credential *dto.Credential, ipAddress string, userAgent string) (dto.TokenResponse, error) {
+	err := us.lg.Check(credential.Email, ipAddress)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	user := us.ur.GetUserByEmail(credential.Email)
+	if user == nil {
+		return dto.TokenResponse{}, us.loginFailed(credential.Email, ipAddress, nil, errors.New("credentials dont match"))
+	}
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(credential.Password))
+	if err != nil {
+		return dto.TokenResponse{}, us.loginFailed(credential.Email, ipAddress, user, errors.New("credentials dont match"))
+	}
+
+	// the failures counted so far are only reset once the second step passes
+	if user.TwoFactorEnabledAt != nil {
+		return us.twoFactorChallenge(*user)
+	}
+
+	err = us.lg.Succeed(credential.Email, ipAddress)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	return us.startSession(*user, ipAddress, userAgent)
+}
+
+func (us *UserUseCaseImpl) twoFactorChallenge(user entity.User) (dto.TokenResponse, error) {
+	challengeToken, err := utils.CreateSignedPurposeToken(utils.TwoFactorLoginPurpose, user.ID, user.Email, twoFactorChallengeLifetime)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	return dto.TokenResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    challengeToken,
+	}, nil
+}
+
+// LoginWithTwoFactor is the second login step. It takes the challenge token
+// returned by Login along with a TOTP code or an unused recovery code.
+func (us *UserUseCaseImpl) LoginWithTwoFactor(request dto.TwoFactorLoginRequest, ipAddress string, userAgent string) (dto.TokenResponse, error) {
+	claims, err := utils.ParsePurposeToken(utils.TwoFactorLoginPurpose, request.ChallengeToken)
+	if err != nil {
+		return dto.TokenResponse{}, errors.New("invalid challenge token")
+	}
+
+	user, err := us.ur.GetUserByID(claims.ID)
+	if err != nil || user.Email != claims.Email || user.TwoFactorEnabledAt == nil {
+		return dto.TokenResponse{}, errors.New("invalid challenge token")
+	}
+
+	err = us.lg.Check(user.Email, ipAddress)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	err = us.verifyTwoFactorCode(user, request.Code)
+	if err != nil {
+		if err.Error() == "invalid two-factor code" {
+			return dto.TokenResponse{}, us.loginFailed(user.Email, ipAddress, &user, err)
+		}
+		return dto.TokenResponse{}, err
+	}
+
+	err = us.lg.Succeed(user.Email, ipAddress)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	return us.startSession(user, ipAddress, userAgent)
+}
+
+// oidcFlow is what the callback needs to finish a login started by
+// StartOIDCLogin. It is kept encrypted on the client between the two requests.
+type oidcFlow struct {
+	Provider  string `json:"provider"`
+	State     string `json:"state"`
+	Nonce     string `json:"nonce"`
+	Verifier  string `json:"verifier"`
+	ExpiresAt int64  `json:"expiresAt"`
+}
+
+func (us *UserUseCaseImpl) StartOIDCLogin(provider string) (dto.OIDCAuthorization, error) {
+	p, ok := us.op[provider]
+	if !ok {
+		return dto.OIDCAuthorization{}, errors.New("unknown identity provider")
+	}
+
+	flow := oidcFlow{
+		Provider:  provider,
+		ExpiresAt: time.Now().Add(oidcFlowLifetime).Unix(),
+	}
+	var err error
+	flow.State, err = utils.GenerateRandomToken(16)
+	if err != nil {
+		return dto.OIDCAuthorization{}, err
+	}
+	flow.Nonce, err = utils.GenerateRandomToken(16)
+	if err != nil {
+		return dto.OIDCAuthorization{}, err
+	}
+	flow.Verifier, err = oidc.GenerateCodeVerifier()
+	if err != nil {
+		return dto.OIDCAuthorization{}, err
+	}
+
+	authorizationURL, err := p.AuthCodeURL(flow.State, flow.Nonce, flow.Verifier)
+	if err != nil {
+		return dto.OIDCAuthorization{}, err
+	}
+
+	encodedFlow, err := json.Marshal(flow)
+	if err != nil {
+		return dto.OIDCAuthorization{}, err
+	}
+
+	flowState, err := utils.EncryptSecret(string(encodedFlow))
+	if err != nil {
+		return dto.OIDCAuthorization{}, err
+	}
+
+	return dto.OIDCAuthorization{
+		AuthorizationURL: authorizationURL,
+		FlowState:        flowState,
+	}, nil
+}
+
+// CompleteOIDCLogin handles the redirect back from the identity provider. The
+// provider's account is linked to the user with the same verified email, and a
+// new user is created when there is none. Such users have no password until
+// they reset it. Accounts that have a password but no verified email are not
+// linked.
+func (us *UserUseCaseImpl) CompleteOIDCLogin(provider string, request dto.OIDCCallbackRequest, ipAddress string, userAgent string) (dto.TokenResponse, error) {
+	p, ok := us.op[provider]
+	if !ok {
+		return dto.TokenResponse{}, errors.New("unknown identity provider")
+	}
+
+	if request.Error != "" {
+		return dto.TokenResponse{}, errors.New("identity provider login failed")
+	}
+
+	var flow oidcFlow
+	decryptedFlow, err := utils.DecryptSecret(request.FlowState)
+	if err == nil {
+		err = json.Unmarshal([]byte(decryptedFlow), &flow)
+	}
+	if err != nil || flow.Provider != provider || subtle.ConstantTimeCompare([]byte(flow.State), []byte(request.State)) != 1 || time.Now().Unix() > flow.ExpiresAt {
+		return dto.TokenResponse{}, errors.New("invalid login state")
+	}
+
+	identity, err := p.Exchange(request.Code, flow.Verifier, flow.Nonce)
+	if err != nil {
+		return dto.TokenResponse{}, errors.New("identity provider login failed")
+	}
+
+	user, err := us.linkOIDCIdentity(provider, identity)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	if user.TwoFactorEnabledAt != nil {
+		return us.twoFactorChallenge(user)
+	}
+
+	return us.startSession(user, ipAddress, userAgent)
+}
+
+func (us *UserUseCaseImpl) linkOIDCIdentity(provider string, identity oidc.Identity) (entity.User, error) {
+	userIdentity := us.ur.GetUserIdentity(provider, identity.Subject)
+	if userIdentity != nil {
+		return us.ur.GetUserByID(userIdentity.UserID)
+	}
+
+	// linking by email is only safe when the provider vouches for the address
+	if !identity.EmailVerified || identity.Email == "" {
+		return entity.User{}, errors.New("the identity provider has not verified this email")
+	}
+
+	newIdentity := entity.UserIdentity{
+		Provider: provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	}
+
+	user := us.ur.GetUserByEmail(identity.Email)
+	if user != nil && user.ID != 0 {
+		// anyone could have signed up with this address and picked the
+		// password, so an unverified password account is never taken over
+		if user.VerifiedAt == nil && user.Password != "" {
+			return entity.User{}, errors.New("verify the email of your account before signing in with this provider")
+		}
+
+		newIdentity.UserID = user.ID
+		err := us.ur.CreateUserIdentity(newIdentity)
+		if err != nil {
+			return entity.User{}, err
+		}
+
+		if user.VerifiedAt == nil {
+			err = us.ur.VerifyUserEmail(user.ID)
+			if err != nil {
+				return entity.User{}, err
+			}
+
+			err = us.affiliateInstitution(user.ID, user.Email)
+			if err != nil {
+				return entity.User{}, err
+			}
+		}
+
+		return *user, nil
+	}
+
+	name := identity.Name
+	if name == "" {
+		name = strings.Split(identity.Email, "@")[0]
+	}
+	institutionID, err := us.institutionIDForEmail(identity.Email)
+	if err != nil {
+		return entity.User{}, err
+	}
+	verifiedAt := time.Now()
+	newUser := entity.User{
+		Name:          name,
+		Email:         identity.Email,
+		Role:          utils.RoleStudent,
+		VerifiedAt:    &verifiedAt,
+		InstitutionID: institutionID,
+	}
+
+	userID, err := us.ur.CreateUserWithIdentity(newUser, newIdentity)
+	if err != nil {
+		return entity.User{}, err
+	}
+	newUser.ID = userID
+
+	return newUser, nil
+}
+
+// loginFailed counts the failed attempt and records a lockout event for every
+// key the attempt has locked. It returns cause unless recording the attempt
+// fails.
+func (us *UserUseCaseImpl) loginFailed(email string, ipAddress string, user *entity.User, cause error) error {
+	lockout, err := us.lg.Fail(email, ipAddress)
+	if err != nil {
+		return err
+	}
+
+	var userID *uint
+	if user != nil && user.ID != 0 {
+		userID = &user.ID
+	}
+
+	scopes := []string{}
+	if lockout.Account {
+		scopes = append(scopes, entity.LockoutScopeAccount)
+	}
+	if lockout.IP {
+		scopes = append(scopes, entity.LockoutScopeIP)
+	}
+	for _, scope := range scopes {
+		err = us.ur.CreateLockoutEvent(entity.LockoutEvent{
+			UserID:      userID,
+			Email:       email,
+			IPAddress:   ipAddress,
+			Scope:       scope,
+			LockedUntil: lockout.LockedUntil,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return cause
+}
+
+func (us *UserUseCaseImpl) GetActiveLockouts(adminID uint) ([]dto.LockoutEventResponse, error) {
+	err := us.p.CanManageLockouts(adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	lockoutEvents, err := us.ur.GetActiveLockoutEvents()
+	if err != nil {
+		return nil, err
+	}
+
+	lockouts := []dto.LockoutEventResponse{}
+	for _, lockoutEvent := range lockoutEvents {
+		lockouts = append(lockouts, dto.LockoutEventResponse{
+			ID:          lockoutEvent.ID,
+			UserID:      lockoutEvent.UserID,
+			Email:       lockoutEvent.Email,
+			IPAddress:   lockoutEvent.IPAddress,
+			Scope:       lockoutEvent.Scope,
+			LockedUntil: lockoutEvent.LockedUntil,
+			CreatedAt:   lockoutEvent.CreatedAt,
+		})
+	}
+
+	return lockouts, nil
+}
+
+func (us *UserUseCaseImpl) UnlockUser(adminID uint, userID uint) error {
+	err := us.p.CanManageLockouts(adminID)
+	if err != nil {
+		return err
+	}
+
+	user, err := us.ur.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	err = us.lg.Unlock(user.Email)
+	if err != nil {
+		return err
+	}
+
+	return us.ur.UnlockLockoutEvents(userID, adminID)
+}
+
+// ImpersonateUser lets an admin act as the user to see what they see. The
+// returned token is marked with the admin as its actor, every request made
+// with it is recorded, and it stops working when the admin logs out.
+func (us *UserUseCaseImpl) ImpersonateUser(adminID uint, adminSessionID uint, userID uint, request dto.ImpersonationRequest, ipAddress string) (dto.ImpersonationTokenResponse, error) {
+	request.Reason = strings.TrimSpace(request.Reason)
+	if request.Reason == "" {
+		return dto.ImpersonationTokenResponse{}, errors.New("fill the impersonation reason")
+	}
+
+	admin, err := us.ur.GetUserByID(adminID)
+	if err != nil {
+		return dto.ImpersonationTokenResponse{}, err
+	}
+
+	user, err := us.ur.GetUserByID(userID)
+	if err != nil {
+		return dto.ImpersonationTokenResponse{}, err
+	}
+
+	if user.AnonymizedAt != nil {
+		return dto.ImpersonationTokenResponse{}, errors.New("record not found")
+	}
+
+	err = us.p.CanImpersonate(adminID, user.Role)
+	if err != nil {
+		return dto.ImpersonationTokenResponse{}, err
+	}
+
+	expiresAt := time.Now().Add(impersonationLifetime)
+	impersonationID, err := us.ur.CreateImpersonation(entity.Impersonation{
+		ActorID:   adminID,
+		UserID:    userID,
+		SessionID: adminSessionID,
+		Reason:    request.Reason,
+		IPAddress: ipAddress,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return dto.ImpersonationTokenResponse{}, err
+	}
+
+	token, err := utils.CreateSignedImpersonationToken(us.kr, user.ID, user.Email, user.Role, utils.Actor{ID: admin.ID, Email: admin.Email}, impersonationID, expiresAt)
+	if err != nil {
+		return dto.ImpersonationTokenResponse{}, err
+	}
+
+	return dto.ImpersonationTokenResponse{
+		ImpersonationID: impersonationID,
+		Token:           token,
+		TokenType:       "JWT",
+		ExpiresAt:       expiresAt,
+	}, nil
+}
+
+// ValidateImpersonation is ValidateSession for impersonation tokens: the
+// impersonation must not have been ended and the admin must still be logged
+// in.
+func (us *UserUseCaseImpl) ValidateImpersonation(impersonationID uint, actorID uint, userID uint) error {
+	impersonation, err := us.ur.GetImpersonationByID(impersonationID)
+	if err != nil || impersonation.ActorID != actorID || impersonation.UserID != userID || impersonation.EndedAt != nil || time.Now().After(impersonation.ExpiresAt) {
+		return errors.New("impersonation ended")
+	}
+
+	return us.ValidateSession(actorID, impersonation.SessionID)
+}
+
+func (us *UserUseCaseImpl) EndImpersonation(impersonationID uint) error {
+	return us.ur.EndImpersonation(impersonationID)
+}
+
+func (us *UserUseCaseImpl) RecordImpersonatedRequest(impersonationID uint, actorID uint, userID uint, method string, path string, status int, ipAddress string) error {
+	return us.ur.CreateImpersonationAction(entity.ImpersonationAction{
+		ImpersonationID: impersonationID,
+		ActorID:         actorID,
+		UserID:          userID,
+		Method:          method,
+		Path:            path,
+		Status:          status,
+		IPAddress:       ipAddress,
+	})
+}
+
+func (us *UserUseCaseImpl) GetImpersonations(adminID uint) ([]dto.ImpersonationResponse, error) {
+	err := us.p.CanAuditImpersonations(adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	impersonations, err := us.ur.GetImpersonations()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []dto.ImpersonationResponse{}
+	for _, impersonation := range impersonations {
+		actions := []dto.ImpersonationActionResponse{}
+		for _, action := range impersonation.Actions {
+			actions = append(actions, dto.ImpersonationActionResponse{
+				Method:    action.Method,
+				Path:      action.Path,
+				Status:    action.Status,
+				IPAddress: action.IPAddress,
+				CreatedAt: action.CreatedAt,
+			})
+		}
+
+		responses = append(responses, dto.ImpersonationResponse{
+			ID:        impersonation.ID,
+			ActorID:   impersonation.ActorID,
+			UserID:    impersonation.UserID,
+			Reason:    impersonation.Reason,
+			IPAddress: impersonation.IPAddress,
+			ExpiresAt: impersonation.ExpiresAt,
+			EndedAt:   impersonation.EndedAt,
+			CreatedAt: impersonation.CreatedAt,
+			Actions:   actions,
+		})
+	}
+
+	return responses, nil
+}
+
+func (us *UserUseCaseImpl) RefreshToken(refreshToken string) (dto.TokenResponse, error) {
+	storedToken, err := us.ur.GetRefreshTokenByHash(utils.HashToken(refreshToken))
+	if err != nil {
+		return dto.TokenResponse{}, errors.New("invalid refresh token")
+	}
+
+	// a token that has already been rotated or revoked is being presented again,
+	// so assume it leaked and revoke every token descended from the same login
+	if storedToken.RevokedAt != nil {
+		err = us.ur.RevokeRefreshTokenFamily(storedToken.FamilyID)
+		if err != nil {
+			return dto.TokenResponse{}, err
+		}
+		return dto.TokenResponse{}, errors.New("refresh token reused")
+	}
+
+	if time.Now().After(storedToken.ExpiresAt) {
+		return dto.TokenResponse{}, errors.New("refresh token expired")
+	}
+
+	err = us.ur.RevokeRefreshToken(storedToken.ID)
+	if err != nil {
+		// another request rotated this token first
+		err = us.ur.RevokeRefreshTokenFamily(storedToken.FamilyID)
+		if err != nil {
+			return dto.TokenResponse{}, err
+		}
+		return dto.TokenResponse{}, errors.New("refresh token reused")
+	}
+
+	session, err := us.ur.GetSessionByFamilyID(storedToken.FamilyID)
+	if err != nil || session.RevokedAt != nil {
+		return dto.TokenResponse{}, errors.New("invalid refresh token")
+	}
+
+	err = us.ur.TouchSession(session.ID)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	user, err := us.ur.GetUserByID(storedToken.UserID)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	return us.issueTokens(user, session)
+}
+
+func (us *UserUseCaseImpl) Logout(refreshToken string) error {
+	storedToken, err := us.ur.GetRefreshTokenByHash(utils.HashToken(refreshToken))
+	if err != nil {
+		return errors.New("invalid refresh token")
+	}
+
+	return us.ur.RevokeRefreshTokenFamily(storedToken.FamilyID)
+}
+
+// startSession records a new login of the user and issues its first tokens.
+// The session shares its ID with the refresh token family of the login.
+func (us *UserUseCaseImpl) startSession(user entity.User, ipAddress string, userAgent string) (dto.TokenResponse, error) {
+	familyID, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	session := entity.Session{
+		UserID:     user.ID,
+		FamilyID:   familyID,
+		UserAgent:  userAgent,
+		IPAddress:  ipAddress,
+		LastSeenAt: time.Now(),
+	}
+	session.ID, err = us.ur.CreateSession(session)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	return us.issueTokens(user, session)
+}
+
+func (us *UserUseCaseImpl) GetSessions(userID uint, currentSessionID uint) ([]dto.SessionResponse, error) {
+	sessions, err := us.ur.GetActiveSessions(userID, time.Now().Add(-refreshTokenLifetime))
+	if err != nil {
+		return nil, err
+	}
+
+	sessionResponses := []dto.SessionResponse{}
+	for _, session := range sessions {
+		sessionResponses = append(sessionResponses, dto.SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+
+	return sessionResponses, nil
+}
+
+func (us *UserUseCaseImpl) RevokeSession(userID uint, sessionID uint) error {
+	err := us.ur.RevokeSession(userID, sessionID)
+	if err != nil && err.Error() == "no rows affected" {
+		return errors.New("session not found")
+	}
+
+	return err
+}
+
+func (us *UserUseCaseImpl) RevokeOtherSessions(userID uint, currentSessionID uint) error {
+	return us.ur.RevokeUserSessions(userID, currentSessionID)
+}
+
+// ValidateSession is called for every authenticated request to reject access
+// tokens whose session has been revoked.
+func (us *UserUseCaseImpl) ValidateSession(userID uint, sessionID uint) error {
+	session, err := us.ur.GetSessionByID(sessionID)
+	if err != nil || session.UserID != userID || session.RevokedAt != nil {
+		return errors.New("session revoked")
+	}
+
+	if time.Since(session.LastSeenAt) > sessionTouchInterval {
+		return us.ur.TouchSession(session.ID)
+	}
+
+	return nil
+}
+
+func (us *UserUseCaseImpl) CreatePersonalAccessToken(userID uint, request dto.PersonalAccessTokenRequest) (dto.CreatedPersonalAccessTokenResponse, error) {
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" {
+		return dto.CreatedPersonalAccessTokenResponse{}, errors.New("fill the token name")
+	}
+
+	if len(request.Scopes) == 0 {
+		return dto.CreatedPersonalAccessTokenResponse{}, errors.New("fill the token scopes")
+	}
+
+	for _, scope := range request.Scopes {
+		if !utils.IsValidScope(scope) {
+			return dto.CreatedPersonalAccessTokenResponse{}, errors.New("invalid scope")
+		}
+	}
+
+	if request.ExpiresInDays < 0 || request.ExpiresInDays > personalAccessTokenMaxDays {
+		return dto.CreatedPersonalAccessTokenResponse{}, errors.New("invalid token lifetime")
+	}
+
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return dto.CreatedPersonalAccessTokenResponse{}, err
+	}
+	token := utils.PersonalAccessTokenPrefix + secret
+
+	personalAccessToken := entity.PersonalAccessToken{
+		UserID:    userID,
+		Name:      request.Name,
+		TokenHash: utils.HashToken(token),
+		Scopes:    strings.Join(request.Scopes, " "),
+	}
+	if request.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, request.ExpiresInDays)
+		personalAccessToken.ExpiresAt = &expiresAt
+	}
+
+	personalAccessToken.ID, err = us.ur.CreatePersonalAccessToken(personalAccessToken)
+	if err != nil {
+		return dto.CreatedPersonalAccessTokenResponse{}, err
+	}
+	personalAccessToken.CreatedAt = time.Now()
+
+	return dto.CreatedPersonalAccessTokenResponse{
+		PersonalAccessTokenResponse: toPersonalAccessTokenResponse(personalAccessToken),
+		Token:                       token,
+	}, nil
+}
+
+func toPersonalAccessTokenResponse(token entity.PersonalAccessToken) dto.PersonalAccessTokenResponse {
+	return dto.PersonalAccessTokenResponse{
+		ID:         token.ID,
+		Name:       token.Name,
+		Scopes:     strings.Fields(token.Scopes),
+		LastUsedAt: token.LastUsedAt,
+		ExpiresAt:  token.ExpiresAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
+
+func (us *UserUseCaseImpl) GetPersonalAccessTokens(userID uint) ([]dto.PersonalAccessTokenResponse, error) {
+	tokens, err := us.ur.GetPersonalAccessTokens(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenResponses := []dto.PersonalAccessTokenResponse{}
+	for _, token := range tokens {
+		tokenResponses = append(tokenResponses, toPersonalAccessTokenResponse(token))
+	}
+
+	return tokenResponses, nil
+}
+
+func (us *UserUseCaseImpl) RevokePersonalAccessToken(userID uint, tokenID uint) error {
+	err := us.ur.RevokePersonalAccessToken(userID, tokenID)
+	if err != nil && err.Error() == "no rows affected" {
+		return errors.New("token not found")
+	}
+
+	return err
+}
+
+// AuthenticatePersonalAccessToken returns the claims a request made with the
+// given personal access token is handled with.
+func (us *UserUseCaseImpl) AuthenticatePersonalAccessToken(token string) (utils.JwtCustomClaims, error) {
+	storedToken, err := us.ur.GetPersonalAccessTokenByHash(utils.HashToken(token))
+	if err != nil || storedToken.RevokedAt != nil || (storedToken.ExpiresAt != nil && time.Now().After(*storedToken.ExpiresAt)) {
+		return utils.JwtCustomClaims{}, errors.New("invalid access token")
+	}
+
+	if storedToken.LastUsedAt == nil || time.Since(*storedToken.LastUsedAt) > sessionTouchInterval {
+		err = us.ur.TouchPersonalAccessToken(storedToken.ID)
+		if err != nil {
+			return utils.JwtCustomClaims{}, err
+		}
+	}
+
+	return utils.JwtCustomClaims{
+		ID:     storedToken.User.ID,
+		Email:  storedToken.User.Email,
+		Role:   storedToken.User.Role,
+		Scopes: strings.Fields(storedToken.Scopes),
+	}, nil
+}
+
+func (us *UserUseCaseImpl) issueTokens(user entity.User, session entity.Session) (dto.TokenResponse, error) {
+	token, err := utils.CreateSignedJWTToken(us.kr, user.ID, user.Email, user.Role, session.ID)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	refreshToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	err = us.ur.CreateRefreshToken(entity.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(refreshToken),
+		FamilyID:  session.FamilyID,
+		ExpiresAt: time.Now().Add(refreshTokenLifetime),
+	})
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	return dto.TokenResponse{
+		Token:        token,
+		TokenType:    "JWT",
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (us *UserUseCaseImpl) GetCompetitionsData(userID uint) ([]dtoComp.CompetitionResponse, error) {
+	var createdComps []dtoComp.CompetitionResponse
+	comps, err := us.cr.GetCompetitionByUserID(userID)
+	for _, comp := range comps {
+		createdComps = append(createdComps, dtoComp.CompetitionResponse{
+			ID:            comp.ID,
+			Name:          comp.Name,
+			ContactPerson: comp.ContactPerson,
+			IsTeam:        comp.IsTeam,
+			Level:         comp.Level,
+		})
+	}
+	return createdComps, err
+}
+
+// GetAchievements returns the user's portfolio: the published results of the
+// competitions the user took part in, on their own or as a team member.
+// Results under embargo are left out.
+func (us *UserUseCaseImpl) GetAchievements(userID uint) ([]dto.AchievementResponse, error) {
+	achievements, err := us.cr.GetAchievementsByUserID(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	achievementsResponse := []dto.AchievementResponse{}
+	for _, achievement := range achievements {
+		result := achievement.CompetitionResult
+		achievementResponse := dto.AchievementResponse{
+			CompetitionID:   result.CompetitionID,
+			CompetitionName: result.Competition.Name,
+			Level:           result.Competition.Level,
+			Rank:            result.Rank,
+			AwardTitle:      result.AwardTitle,
+			TeamName:        result.CompetitionRegistration.Team.Name,
+		}
+		if result.Competition.ResultsPublishAt != nil {
+			achievementResponse.PublishedAt = *result.Competition.ResultsPublishAt
+		}
+
+		achievementsResponse = append(achievementsResponse, achievementResponse)
+	}
+
+	return achievementsResponse, nil
 }
 
 func (us *UserUseCaseImpl) GetCompetitionRegistrationHistory(userID uint) ([]dto.UserCompetitionHistory, error) {
@@ -0,0 +1,146 @@
+// Package imaging validates uploaded images and prepares them for storage.
+// Every image is decoded and encoded again, which drops EXIF and any other
+// metadata the original file carried, such as the location a photo was taken.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	// registers the GIF decoder with image.Decode
+	_ "image/gif"
+)
+
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+	ContentTypeGIF  = "image/gif"
+
+	// MaxPixels bounds the decoded size, so a small file that expands into a
+	// huge bitmap can't exhaust memory
+	MaxPixels = 25000000
+
+	jpegQuality = 90
+)
+
+// Image is an encoded image ready to be stored.
+type Image struct {
+	Data        []byte
+	ContentType string
+	Extension   string
+}
+
+// Decode checks that data is a JPEG, PNG or GIF of at most maxBytes and
+// decodes it. The format is sniffed from the content, the file name and the
+// declared content type of an upload aren't trusted. It returns the content
+// type the image should be stored as: GIFs are stored as PNG.
+func Decode(data []byte, maxBytes int) (image.Image, string, error) {
+	if len(data) > maxBytes {
+		return nil, "", errors.New("file too large")
+	}
+
+	contentType := http.DetectContentType(data)
+	if contentType != ContentTypeJPEG && contentType != ContentTypePNG && contentType != ContentTypeGIF {
+		return nil, "", errors.New("unsupported image type")
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", errors.New("invalid image")
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, "", errors.New("invalid image")
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, "", errors.New("image dimensions too large")
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", errors.New("invalid image")
+	}
+
+	if contentType == ContentTypeGIF {
+		contentType = ContentTypePNG
+	}
+
+	return img, contentType, nil
+}
+
+// Encode encodes img as a JPEG or a PNG.
+func Encode(img image.Image, contentType string) (Image, error) {
+	var buf bytes.Buffer
+	if contentType == ContentTypeJPEG {
+		err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+		if err != nil {
+			return Image{}, err
+		}
+
+		return Image{Data: buf.Bytes(), ContentType: ContentTypeJPEG, Extension: ".jpg"}, nil
+	}
+
+	err := png.Encode(&buf, img)
+	if err != nil {
+		return Image{}, err
+	}
+
+	return Image{Data: buf.Bytes(), ContentType: ContentTypePNG, Extension: ".png"}, nil
+}
+
+// Thumbnail crops the largest centered area of src with the aspect ratio of
+// width x height and scales it to that size. Each thumbnail pixel is the
+// average of the source pixels it covers.
+func Thumbnail(src image.Image, width int, height int) image.Image {
+	bounds := src.Bounds()
+	cropWidth, cropHeight := bounds.Dx(), bounds.Dy()
+	if cropWidth*height > cropHeight*width {
+		cropWidth = cropHeight * width / height
+	} else {
+		cropHeight = cropWidth * height / width
+	}
+	if cropWidth < 1 {
+		cropWidth = 1
+	}
+	if cropHeight < 1 {
+		cropHeight = 1
+	}
+	left := bounds.Min.X + (bounds.Dx()-cropWidth)/2
+	top := bounds.Min.Y + (bounds.Dy()-cropHeight)/2
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := top + y*cropHeight/height
+		y1 := top + (y+1)*cropHeight/height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+
+		for x := 0; x < width; x++ {
+			x0 := left + x*cropWidth/width
+			x1 := left + (x+1)*cropWidth/width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					sr, sg, sb, sa := src.At(sx, sy).RGBA()
+					r += uint64(sr)
+					g += uint64(sg)
+					b += uint64(sb)
+					a += uint64(sa)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+
+	return dst
+}
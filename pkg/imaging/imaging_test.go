@@ -0,0 +1,121 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func solid(width int, height int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+// withExif inserts an APP1 segment carrying EXIF data right after the start
+// of image marker, where cameras put it.
+func withExif(jpegData []byte) []byte {
+	payload := append([]byte("Exif\x00\x00"), []byte("MM\x00\x2a\x00\x00\x00\x08GPS 8.6500,115.2167")...)
+	length := len(payload) + 2
+	segment := append([]byte{0xFF, 0xE1, byte(length >> 8), byte(length)}, payload...)
+
+	data := append([]byte{}, jpegData[:2]...)
+	data = append(data, segment...)
+	return append(data, jpegData[2:]...)
+}
+
+func TestDecodeStripsExif(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, solid(32, 16, color.RGBA{R: 200, A: 255}), nil))
+	data := withExif(buf.Bytes())
+	assert.True(t, bytes.Contains(data, []byte("Exif")))
+
+	img, contentType, err := Decode(data, 1<<20)
+	assert.NoError(t, err)
+	assert.Equal(t, ContentTypeJPEG, contentType)
+	assert.Equal(t, image.Rect(0, 0, 32, 16), img.Bounds())
+
+	encoded, err := Encode(img, contentType)
+	assert.NoError(t, err)
+	assert.Equal(t, ".jpg", encoded.Extension)
+	assert.False(t, bytes.Contains(encoded.Data, []byte("Exif")))
+	assert.False(t, bytes.Contains(encoded.Data, []byte("GPS")))
+}
+
+func TestDecode(t *testing.T) {
+	var pngData bytes.Buffer
+	assert.NoError(t, png.Encode(&pngData, solid(4, 4, color.White)))
+	var gifData bytes.Buffer
+	assert.NoError(t, gif.Encode(&gifData, solid(4, 4, color.White), nil))
+
+	t.Run("png", func(t *testing.T) {
+		_, contentType, err := Decode(pngData.Bytes(), 1<<20)
+		assert.NoError(t, err)
+		assert.Equal(t, ContentTypePNG, contentType)
+	})
+
+	t.Run("gif-stored-as-png", func(t *testing.T) {
+		_, contentType, err := Decode(gifData.Bytes(), 1<<20)
+		assert.NoError(t, err)
+		assert.Equal(t, ContentTypePNG, contentType)
+	})
+
+	t.Run("too-large", func(t *testing.T) {
+		_, _, err := Decode(pngData.Bytes(), 10)
+		assert.EqualError(t, err, "file too large")
+	})
+
+	t.Run("unsupported-type", func(t *testing.T) {
+		_, _, err := Decode([]byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"), 1<<20)
+		assert.EqualError(t, err, "unsupported image type")
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, _, err := Decode(pngData.Bytes()[:40], 1<<20)
+		assert.EqualError(t, err, "invalid image")
+	})
+
+	t.Run("dimensions-too-large", func(t *testing.T) {
+		// a header claiming 10000 x 10000 pixels, the pixel data is never read
+		var huge bytes.Buffer
+		assert.NoError(t, png.Encode(&huge, image.NewGray(image.Rect(0, 0, 10000, 10000))))
+		_, _, err := Decode(huge.Bytes(), 1<<30)
+		assert.EqualError(t, err, "image dimensions too large")
+	})
+}
+
+func TestThumbnail(t *testing.T) {
+	// left half red, right half blue
+	src := solid(200, 100, color.RGBA{R: 255, A: 255})
+	for y := 0; y < 100; y++ {
+		for x := 100; x < 200; x++ {
+			src.Set(x, y, color.RGBA{B: 255, A: 255})
+		}
+	}
+
+	thumbnail := Thumbnail(src, 10, 10)
+	assert.Equal(t, image.Rect(0, 0, 10, 10), thumbnail.Bounds())
+	// the centered square crop keeps half of each color
+	r, _, b, _ := thumbnail.At(0, 5).RGBA()
+	assert.Equal(t, uint32(0xffff), r)
+	assert.Equal(t, uint32(0), b)
+	r, _, b, _ = thumbnail.At(9, 5).RGBA()
+	assert.Equal(t, uint32(0), r)
+	assert.Equal(t, uint32(0xffff), b)
+
+	wide := Thumbnail(src, 40, 10)
+	assert.Equal(t, image.Rect(0, 0, 40, 10), wide.Bounds())
+
+	upscaled := Thumbnail(solid(2, 2, color.White), 8, 8)
+	assert.Equal(t, image.Rect(0, 0, 8, 8), upscaled.Bounds())
+}
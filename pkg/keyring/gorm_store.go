@@ -0,0 +1,109 @@
+package keyring
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SigningKey is the row the database store keeps for every key.
+type SigningKey struct {
+	KeyID     string `gorm:"primaryKey;size:191"`
+	Algorithm string `gorm:"not null"`
+	// PrivateKey is the encrypted PKCS #8 encoding of the key
+	PrivateKey  string    `gorm:"type:text;not null"`
+	ActivatesAt time.Time `gorm:"not null"`
+	CreatedAt   time.Time
+}
+
+// GormStore keeps keys in the database so that every API replica signs with
+// and accepts the same keys. Private keys are sealed with encrypt before they
+// are written. Keys decrypt can't open, such as those sealed under a previous
+// encryption key, are left out, so the ring generates a new one in their
+// place instead of trusting a key others may have read.
+type GormStore struct {
+	db      *gorm.DB
+	encrypt func(plaintext string) (string, error)
+	decrypt func(ciphertext string) (string, error)
+}
+
+func CreateNewGormStore(db *gorm.DB, encrypt func(plaintext string) (string, error), decrypt func(ciphertext string) (string, error)) Store {
+	return &GormStore{db: db, encrypt: encrypt, decrypt: decrypt}
+}
+
+func (gs *GormStore) GetKeys() ([]Key, error) {
+	var signingKeys []SigningKey
+	result := gs.db.Order("activates_at").Find(&signingKeys)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	keys := make([]Key, 0, len(signingKeys))
+	for _, signingKey := range signingKeys {
+		encoded, err := gs.decrypt(signingKey.PrivateKey)
+		if err != nil {
+			continue
+		}
+
+		der, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		privateKey, err := x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			return nil, err
+		}
+
+		signer, ok := privateKey.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("malformed signing key")
+		}
+
+		keys = append(keys, Key{
+			ID:          signingKey.KeyID,
+			Algorithm:   signingKey.Algorithm,
+			PrivateKey:  signer,
+			ActivatesAt: signingKey.ActivatesAt,
+		})
+	}
+
+	return keys, nil
+}
+
+func (gs *GormStore) CreateKey(key Key) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key.PrivateKey)
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := gs.encrypt(base64.StdEncoding.EncodeToString(der))
+	if err != nil {
+		return err
+	}
+
+	result := gs.db.Create(&SigningKey{
+		KeyID:       key.ID,
+		Algorithm:   key.Algorithm,
+		PrivateKey:  encrypted,
+		ActivatesAt: key.ActivatesAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (gs *GormStore) DeleteKey(id string) error {
+	result := gs.db.Where("key_id = ?", id).Delete(&SigningKey{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package keyring
+
+import (
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// capturedArg matches any value and remembers it
+type capturedArg struct {
+	value driver.Value
+}
+
+func (a *capturedArg) Match(v driver.Value) bool {
+	a.value = v
+	return true
+}
+
+func TestGormStore(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	encrypt := func(plaintext string) (string, error) { return "sealed:" + plaintext, nil }
+	decrypt := func(ciphertext string) (string, error) {
+		if !strings.HasPrefix(ciphertext, "sealed:") {
+			return "", errors.New("cipher: message authentication failed")
+		}
+		return ciphertext[len("sealed:"):], nil
+	}
+	store := CreateNewGormStore(db, encrypt, decrypt)
+
+	defer mockedDB.Close()
+
+	activatesAt := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	key, err := GenerateKey(AlgorithmEdDSA, activatesAt)
+	assert.NoError(t, err)
+	privateKey := &capturedArg{}
+
+	t.Run("create", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `signing_keys` (`key_id`,`algorithm`,`private_key`,`activates_at`,`created_at`) VALUES (?,?,?,?,?)")).WithArgs(key.ID, AlgorithmEdDSA, privateKey, activatesAt, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		err := store.CreateKey(key)
+		assert.NoError(t, err)
+		assert.Regexp(t, "^sealed:", privateKey.value)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `signing_keys` ORDER BY activates_at")).WillReturnRows(sqlmock.NewRows([]string{"key_id", "algorithm", "private_key", "activates_at"}).AddRow(key.ID, AlgorithmEdDSA, privateKey.value, activatesAt))
+
+		keys, err := store.GetKeys()
+		assert.NoError(t, err)
+		assert.Equal(t, []Key{key}, keys)
+	})
+
+	t.Run("get-sealed-under-another-key", func(t *testing.T) {
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `signing_keys` ORDER BY activates_at")).WillReturnRows(sqlmock.NewRows([]string{"key_id", "algorithm", "private_key", "activates_at"}).AddRow("old", AlgorithmEdDSA, "other:key", activatesAt).AddRow(key.ID, AlgorithmEdDSA, privateKey.value, activatesAt))
+
+		keys, err := store.GetKeys()
+		assert.NoError(t, err)
+		assert.Equal(t, []Key{key}, keys)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `signing_keys` WHERE key_id = ?")).WithArgs(key.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		err := store.DeleteKey(key.ID)
+		assert.NoError(t, err)
+	})
+
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
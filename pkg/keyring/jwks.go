@@ -0,0 +1,81 @@
+package keyring
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// JSONWebKey is the public half of a key, as described by RFC 7517.
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// RSA keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519 keys
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+func toJSONWebKey(key Key) JSONWebKey {
+	jwk := JSONWebKey{
+		KeyID:     key.ID,
+		Use:       "sig",
+		Algorithm: key.Algorithm,
+	}
+
+	switch publicKey := key.PrivateKey.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+	}
+
+	return jwk
+}
+
+// JWKS lists every key a valid token may be signed with, including the next
+// key before it starts signing.
+func (r *RingImpl) JWKS() JSONWebKeySet {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keySet := JSONWebKeySet{Keys: []JSONWebKey{}}
+	now := r.now()
+	for i, key := range r.keys {
+		if !r.expired(r.keys, i, now) {
+			keySet.Keys = append(keySet.Keys, toJSONWebKey(key))
+		}
+	}
+
+	return keySet
+}
+
+// JWKSHandler godoc
+// @Summary      Get the public keys access tokens are signed with
+// @Description  Returns the JSON Web Key Set other services verify our access tokens against. A key is listed before it starts signing and for a while after it has been replaced
+// @Tags         Auth
+// @Produce      json
+// @Success      200  {object}  keyring.JSONWebKeySet
+// @Router       /.well-known/jwks.json [get]
+func JWKSHandler(r Ring) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Response().Header().Set("Cache-Control", "public, max-age=300")
+		return c.JSON(http.StatusOK, r.JWKS())
+	}
+}
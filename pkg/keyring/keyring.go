@@ -0,0 +1,242 @@
+package keyring
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+var (
+	ErrUnknownKey           = errors.New("unknown signing key")
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+)
+
+// Key is one key pair of the ring. It signs tokens from ActivatesAt until the
+// next key activates.
+type Key struct {
+	ID          string
+	Algorithm   string
+	PrivateKey  crypto.Signer
+	ActivatesAt time.Time
+}
+
+// Store keeps the keys shared by every API replica.
+type Store interface {
+	GetKeys() ([]Key, error)
+	CreateKey(key Key) error
+	DeleteKey(id string) error
+}
+
+// Options describe how keys are rotated. A key signs for RotationInterval. Its
+// successor is published PrepublishWindow before it starts signing, so that
+// every replica and verifier has picked it up by then, and the replaced key is
+// still accepted for OverlapWindow, which must not be shorter than the lifetime
+// of an access token.
+type Options struct {
+	Algorithm        string
+	RotationInterval time.Duration
+	PrepublishWindow time.Duration
+	OverlapWindow    time.Duration
+}
+
+var DefaultOptions = Options{
+	Algorithm:        AlgorithmRS256,
+	RotationInterval: 30 * 24 * time.Hour,
+	PrepublishWindow: 10 * time.Minute,
+	OverlapWindow:    time.Hour,
+}
+
+type Ring interface {
+	// Sign builds a token carrying the claims, signed with the active key with
+	// the algorithm of that key and named in the kid header.
+	Sign(claims jwt.Claims) (string, error)
+	// Keyfunc is the jwt.Keyfunc verifying tokens signed by any key of the ring.
+	Keyfunc(token *jwt.Token) (interface{}, error)
+	JWKS() JSONWebKeySet
+	// Rotate adds a new key when the active one is due to be replaced, drops
+	// the keys that are no longer accepted and reloads the keys other
+	// replicas have added.
+	Rotate() error
+}
+
+type RingImpl struct {
+	store   Store
+	options Options
+	now     func() time.Time
+
+	mu   sync.RWMutex
+	keys []Key
+}
+
+// CreateNewRing loads the keys of the store, creating the first one when there
+// is none yet.
+func CreateNewRing(store Store, options Options) (Ring, error) {
+	if options.Algorithm != AlgorithmRS256 && options.Algorithm != AlgorithmEdDSA {
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	r := &RingImpl{store: store, options: options, now: time.Now}
+	err := r.Rotate()
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// RunRotation calls Rotate every interval and never returns.
+func RunRotation(r Ring, interval time.Duration) {
+	for range time.Tick(interval) {
+		err := r.Rotate()
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+func GenerateKey(algorithm string, activatesAt time.Time) (Key, error) {
+	var privateKey crypto.Signer
+	var err error
+	switch algorithm {
+	case AlgorithmRS256:
+		privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	case AlgorithmEdDSA:
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return Key{}, ErrUnsupportedAlgorithm
+	}
+	if err != nil {
+		return Key{}, err
+	}
+
+	id := make([]byte, 12)
+	if _, err := rand.Read(id); err != nil {
+		return Key{}, err
+	}
+
+	return Key{
+		ID:          base64.RawURLEncoding.EncodeToString(id),
+		Algorithm:   algorithm,
+		PrivateKey:  privateKey,
+		ActivatesAt: activatesAt,
+	}, nil
+}
+
+// expired reports whether keys[i] has been replaced for longer than the
+// overlap window. keys must be sorted by activation.
+func (r *RingImpl) expired(keys []Key, i int, now time.Time) bool {
+	return i+1 < len(keys) && now.After(keys[i+1].ActivatesAt.Add(r.options.OverlapWindow))
+}
+
+func (r *RingImpl) Rotate() error {
+	keys, err := r.store.GetKeys()
+	if err != nil {
+		return err
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ActivatesAt.Before(keys[j].ActivatesAt)
+	})
+
+	now := r.now()
+	if len(keys) == 0 || !now.Before(keys[len(keys)-1].ActivatesAt.Add(r.options.RotationInterval-r.options.PrepublishWindow)) {
+		// the very first key has nobody to be published ahead of
+		activatesAt := now.Add(r.options.PrepublishWindow)
+		if len(keys) == 0 {
+			activatesAt = now
+		}
+
+		key, err := GenerateKey(r.options.Algorithm, activatesAt)
+		if err != nil {
+			return err
+		}
+
+		err = r.store.CreateKey(key)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+
+	var liveKeys []Key
+	for i, key := range keys {
+		if r.expired(keys, i, now) {
+			err = r.store.DeleteKey(key.ID)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		liveKeys = append(liveKeys, key)
+	}
+
+	r.mu.Lock()
+	r.keys = liveKeys
+	r.mu.Unlock()
+
+	return nil
+}
+
+func signingMethod(algorithm string) jwt.SigningMethod {
+	if algorithm == AlgorithmEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+
+	return jwt.SigningMethodRS256
+}
+
+func (r *RingImpl) Sign(claims jwt.Claims) (string, error) {
+	r.mu.RLock()
+	now := r.now()
+	var key *Key
+	for i := len(r.keys) - 1; i >= 0; i-- {
+		if !r.keys[i].ActivatesAt.After(now) {
+			key = &r.keys[i]
+			break
+		}
+	}
+	r.mu.RUnlock()
+
+	if key == nil {
+		return "", errors.New("no active signing key")
+	}
+
+	token := jwt.NewWithClaims(signingMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.PrivateKey)
+}
+
+func (r *RingImpl) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	now := r.now()
+	for i, key := range r.keys {
+		if key.ID != kid || r.expired(r.keys, i, now) {
+			continue
+		}
+
+		// never let the token pick another algorithm than the key's
+		if token.Method.Alg() != key.Algorithm {
+			return nil, errors.New("unexpected signing method")
+		}
+
+		return key.PrivateKey.Public(), nil
+	}
+
+	return nil, ErrUnknownKey
+}
@@ -0,0 +1,137 @@
+package keyring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestRing(t *testing.T, algorithm string, now *time.Time) *RingImpl {
+	r := &RingImpl{
+		store: CreateNewMemoryStore(),
+		options: Options{
+			Algorithm:        algorithm,
+			RotationInterval: 24 * time.Hour,
+			PrepublishWindow: 10 * time.Minute,
+			OverlapWindow:    time.Hour,
+		},
+		now: func() time.Time { return *now },
+	}
+	assert.NoError(t, r.Rotate())
+	return r
+}
+
+func signTestToken(t *testing.T, r Ring) string {
+	encodedToken, err := r.Sign(jwt.StandardClaims{Subject: "1"})
+	assert.NoError(t, err)
+	return encodedToken
+}
+
+func parseTestToken(r Ring, encodedToken string) (*jwt.Token, error) {
+	parser := jwt.Parser{SkipClaimsValidation: true}
+	return parser.ParseWithClaims(encodedToken, &jwt.StandardClaims{}, r.Keyfunc)
+}
+
+func TestSignAndVerify(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	for _, algorithm := range []string{AlgorithmRS256, AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			r := createTestRing(t, algorithm, &now)
+
+			encodedToken := signTestToken(t, r)
+			token, err := parseTestToken(r, encodedToken)
+			assert.NoError(t, err)
+			assert.True(t, token.Valid)
+			assert.Equal(t, algorithm, token.Header["alg"])
+			assert.Equal(t, r.keys[0].ID, token.Header["kid"])
+		})
+	}
+}
+
+func TestRotation(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	r := createTestRing(t, AlgorithmEdDSA, &now)
+	firstKeyID := r.keys[0].ID
+	oldToken := signTestToken(t, r)
+
+	// nothing is due yet
+	now = now.Add(time.Hour)
+	assert.NoError(t, r.Rotate())
+	assert.Len(t, r.JWKS().Keys, 1)
+
+	// the next key is published before it signs
+	now = now.Add(23*time.Hour - 10*time.Minute)
+	assert.NoError(t, r.Rotate())
+	assert.Len(t, r.JWKS().Keys, 2)
+	token, err := parseTestToken(r, signTestToken(t, r))
+	assert.NoError(t, err)
+	assert.Equal(t, firstKeyID, token.Header["kid"])
+
+	// then takes over, while tokens of the replaced key are still accepted
+	now = now.Add(10 * time.Minute)
+	token, err = parseTestToken(r, signTestToken(t, r))
+	assert.NoError(t, err)
+	assert.NotEqual(t, firstKeyID, token.Header["kid"])
+	_, err = parseTestToken(r, oldToken)
+	assert.NoError(t, err)
+
+	// until the overlap window is over
+	now = now.Add(time.Hour + time.Minute)
+	assert.NoError(t, r.Rotate())
+	assert.Len(t, r.JWKS().Keys, 1)
+	_, err = parseTestToken(r, oldToken)
+	assert.Error(t, err)
+	keys, err := r.store.GetKeys()
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
+
+func TestRotationPicksUpKeysOfOtherReplicas(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	r := createTestRing(t, AlgorithmEdDSA, &now)
+	other := &RingImpl{store: r.store, options: r.options, now: r.now}
+	assert.NoError(t, other.Rotate())
+	assert.Equal(t, r.keys[0].ID, other.keys[0].ID)
+
+	encodedToken := signTestToken(t, other)
+	_, err := parseTestToken(r, encodedToken)
+	assert.NoError(t, err)
+}
+
+func TestKeyfuncRejectsOtherAlgorithms(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	r := createTestRing(t, AlgorithmRS256, &now)
+
+	// an HMAC token keyed with the public key must not verify
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{Subject: "1"})
+	token.Header["kid"] = r.keys[0].ID
+	encodedToken, err := token.SignedString([]byte("public key"))
+	assert.NoError(t, err)
+
+	_, err = parseTestToken(r, encodedToken)
+	assert.Error(t, err)
+}
+
+func TestJWKS(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	keySet := createTestRing(t, AlgorithmRS256, &now).JWKS()
+	assert.Len(t, keySet.Keys, 1)
+	assert.Equal(t, "RSA", keySet.Keys[0].KeyType)
+	assert.Equal(t, "AQAB", keySet.Keys[0].E)
+	assert.NotEmpty(t, keySet.Keys[0].N)
+	assert.Equal(t, "sig", keySet.Keys[0].Use)
+
+	keySet = createTestRing(t, AlgorithmEdDSA, &now).JWKS()
+	assert.Len(t, keySet.Keys, 1)
+	assert.Equal(t, "OKP", keySet.Keys[0].KeyType)
+	assert.Equal(t, "Ed25519", keySet.Keys[0].Curve)
+	assert.Len(t, keySet.Keys[0].X, 43)
+}
+
+func TestCreateNewRingUnsupportedAlgorithm(t *testing.T) {
+	_, err := CreateNewRing(CreateNewMemoryStore(), Options{Algorithm: "HS256"})
+	assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+}
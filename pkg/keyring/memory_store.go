@@ -0,0 +1,42 @@
+package keyring
+
+import "sync"
+
+// MemoryStore keeps keys in the process, for a single API replica. Tokens
+// signed before a restart stop being accepted.
+type MemoryStore struct {
+	mu   sync.Mutex
+	keys map[string]Key
+}
+
+func CreateNewMemoryStore() Store {
+	return &MemoryStore{keys: map[string]Key{}}
+}
+
+func (ms *MemoryStore) GetKeys() ([]Key, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	keys := make([]Key, 0, len(ms.keys))
+	for _, key := range ms.keys {
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (ms *MemoryStore) CreateKey(key Key) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.keys[key.ID] = key
+	return nil
+}
+
+func (ms *MemoryStore) DeleteKey(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	delete(ms.keys, id)
+	return nil
+}
@@ -0,0 +1,137 @@
+package loginguard
+
+import (
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LoginAttempt is the row the database store keeps for every key.
+type LoginAttempt struct {
+	AttemptKey    string `gorm:"primaryKey;size:191"`
+	Failures      int    `gorm:"not null"`
+	LastFailureAt *time.Time
+	LockedUntil   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// GormStore keeps attempts in the database so that every API replica sees the
+// same counters.
+//
+// As in the memory store, rows whose lockout has ended and whose last failure
+// is older than the retention are deleted. The retention must be at least the
+// longest LockoutDuration of the guards using the store.
+type GormStore struct {
+	db        *gorm.DB
+	retention time.Duration
+	lastSweep time.Time
+	now       func() time.Time
+	mu        sync.Mutex
+}
+
+func CreateNewGormStore(db *gorm.DB, retention time.Duration) Store {
+	return &GormStore{db: db, retention: retention, now: time.Now}
+}
+
+func (gs *GormStore) Get(key string) (Attempt, error) {
+	var loginAttempts []LoginAttempt
+	result := gs.db.Where("attempt_key = ?", key).Limit(1).Find(&loginAttempts)
+	if result.Error != nil {
+		return Attempt{}, result.Error
+	}
+
+	if len(loginAttempts) == 0 {
+		return Attempt{}, nil
+	}
+
+	return loginAttempts[0].toAttempt(), nil
+}
+
+func (gs *GormStore) Update(key string, update func(attempt *Attempt)) (Attempt, error) {
+	err := gs.sweep()
+	if err != nil {
+		return Attempt{}, err
+	}
+
+	var attempt Attempt
+	err = gs.db.Transaction(func(tx *gorm.DB) error {
+		// make sure the row exists, so that it can be locked below
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&LoginAttempt{AttemptKey: key})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		var loginAttempt LoginAttempt
+		result = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("attempt_key = ?", key).First(&loginAttempt)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		attempt = loginAttempt.toAttempt()
+		update(&attempt)
+
+		result = tx.Model(&LoginAttempt{}).Where("attempt_key = ?", key).Updates(map[string]interface{}{
+			"failures":        attempt.Failures,
+			"last_failure_at": timeOrNil(attempt.LastFailureAt),
+			"locked_until":    timeOrNil(attempt.LockedUntil),
+		})
+		return result.Error
+	})
+	if err != nil {
+		return Attempt{}, err
+	}
+
+	return attempt, nil
+}
+
+func (gs *GormStore) Delete(key string) error {
+	result := gs.db.Where("attempt_key = ?", key).Delete(&LoginAttempt{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// sweep deletes the expired rows, at most once per retention period on each
+// replica so that recording a failure stays cheap.
+func (gs *GormStore) sweep() error {
+	gs.mu.Lock()
+	now := gs.now()
+	if now.Sub(gs.lastSweep) < gs.retention {
+		gs.mu.Unlock()
+		return nil
+	}
+	gs.lastSweep = now
+	gs.mu.Unlock()
+
+	result := gs.db.Where("(locked_until IS NULL OR locked_until <= ?) AND (last_failure_at IS NULL OR last_failure_at < ?)", now, now.Add(-gs.retention)).Delete(&LoginAttempt{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (la LoginAttempt) toAttempt() Attempt {
+	attempt := Attempt{Failures: la.Failures}
+	if la.LastFailureAt != nil {
+		attempt.LastFailureAt = *la.LastFailureAt
+	}
+	if la.LockedUntil != nil {
+		attempt.LockedUntil = *la.LockedUntil
+	}
+
+	return attempt
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
@@ -0,0 +1,102 @@
+package loginguard
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alimikegami/compnouron/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGormStore(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	store := CreateNewGormStore(db, time.Minute)
+
+	defer mockedDB.Close()
+
+	t.Run("get-missing", func(t *testing.T) {
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `login_attempts` WHERE attempt_key = ? LIMIT 1")).WithArgs("account:user@gmail.com").WillReturnRows(sqlmock.NewRows([]string{"attempt_key", "failures"}))
+
+		attempt, err := store.Get("account:user@gmail.com")
+		assert.NoError(t, err)
+		assert.Equal(t, Attempt{}, attempt)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		lastFailureAt := time.Now().Add(-time.Second)
+		// the first update on a replica sweeps the expired rows
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `login_attempts` WHERE (locked_until IS NULL OR locked_until <= ?) AND (last_failure_at IS NULL OR last_failure_at < ?)")).WithArgs(utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(0, 3))
+		mockObj.ExpectCommit()
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `login_attempts` (`attempt_key`,`failures`,`last_failure_at`,`locked_until`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `attempt_key`=`attempt_key`")).WithArgs("account:user@gmail.com", 0, nil, nil, utils.AnyTime{}, utils.AnyTime{}).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `login_attempts` WHERE attempt_key = ? ORDER BY `login_attempts`.`attempt_key` LIMIT 1 FOR UPDATE")).WithArgs("account:user@gmail.com").WillReturnRows(sqlmock.NewRows([]string{"attempt_key", "failures", "last_failure_at", "locked_until"}).AddRow("account:user@gmail.com", 1, lastFailureAt, nil))
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `login_attempts` SET `failures`=?,`last_failure_at`=?,`locked_until`=?,`updated_at`=? WHERE attempt_key = ?")).WithArgs(2, utils.AnyTime{}, nil, utils.AnyTime{}, "account:user@gmail.com").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		attempt, err := store.Update("account:user@gmail.com", func(attempt *Attempt) {
+			attempt.Failures++
+			attempt.LastFailureAt = time.Now()
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, attempt.Failures)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `login_attempts` WHERE attempt_key = ?")).WithArgs("account:user@gmail.com").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		err := store.Delete("account:user@gmail.com")
+		assert.NoError(t, err)
+	})
+}
+
+func TestGormStoreSweep(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	store := &GormStore{db: db, retention: time.Minute, now: func() time.Time { return now }}
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `login_attempts` WHERE (locked_until IS NULL OR locked_until <= ?) AND (last_failure_at IS NULL OR last_failure_at < ?)")).WithArgs(now, now.Add(-time.Minute)).WillReturnResult(sqlmock.NewResult(0, 2))
+	mockObj.ExpectCommit()
+	assert.NoError(t, store.sweep())
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+
+	// within the retention period the rows aren't swept again
+	now = now.Add(30 * time.Second)
+	assert.NoError(t, store.sweep())
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+
+	now = now.Add(31 * time.Second)
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `login_attempts` WHERE (locked_until IS NULL OR locked_until <= ?) AND (last_failure_at IS NULL OR last_failure_at < ?)")).WithArgs(now, now.Add(-time.Minute)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mockObj.ExpectCommit()
+	assert.NoError(t, store.sweep())
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
@@ -0,0 +1,180 @@
+package loginguard
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrTooManyAttempts = errors.New("too many login attempts, try again later")
+
+// Attempt is the failed-login state kept for a single key.
+type Attempt struct {
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   time.Time
+}
+
+// Store keeps the attempts shared by every guard. Update must apply the
+// function atomically, since several API replicas may record failures for the
+// same key at once.
+type Store interface {
+	Get(key string) (Attempt, error)
+	Update(key string, update func(attempt *Attempt)) (Attempt, error)
+	Delete(key string) error
+}
+
+// Limits describe how a key is throttled. After every failure the next attempt
+// has to wait BaseDelay, doubled per consecutive failure up to MaxDelay. After
+// MaxFailures failures the key is locked for LockoutDuration. Failures older
+// than LockoutDuration are forgotten.
+type Limits struct {
+	MaxFailures     int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockoutDuration time.Duration
+}
+
+var (
+	DefaultAccountLimits = Limits{
+		MaxFailures:     5,
+		BaseDelay:       time.Second,
+		MaxDelay:        30 * time.Second,
+		LockoutDuration: 15 * time.Minute,
+	}
+	// an IP address may be shared by a whole campus, so it only gets locked
+	// out, without the backoff between attempts
+	DefaultIPLimits = Limits{
+		MaxFailures:     50,
+		LockoutDuration: 15 * time.Minute,
+	}
+)
+
+// Lockout tells which keys a failure has just locked.
+type Lockout struct {
+	Account     bool
+	IP          bool
+	LockedUntil time.Time
+}
+
+type Guard interface {
+	Check(email string, ipAddress string) error
+	Fail(email string, ipAddress string) (Lockout, error)
+	Succeed(email string, ipAddress string) error
+	Unlock(email string) error
+}
+
+type GuardImpl struct {
+	store         Store
+	accountLimits Limits
+	ipLimits      Limits
+	now           func() time.Time
+}
+
+func CreateNewGuard(store Store, accountLimits Limits, ipLimits Limits) Guard {
+	return &GuardImpl{store: store, accountLimits: accountLimits, ipLimits: ipLimits, now: time.Now}
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ipAddress string) string {
+	return "ip:" + ipAddress
+}
+
+func (g *GuardImpl) Check(email string, ipAddress string) error {
+	err := g.check(accountKey(email), g.accountLimits)
+	if err != nil {
+		return err
+	}
+
+	return g.check(ipKey(ipAddress), g.ipLimits)
+}
+
+func (g *GuardImpl) check(key string, limits Limits) error {
+	attempt, err := g.store.Get(key)
+	if err != nil {
+		return err
+	}
+
+	now := g.now()
+	if now.Before(attempt.LockedUntil) {
+		return ErrTooManyAttempts
+	}
+
+	if attempt.Failures > 0 && now.Before(attempt.LastFailureAt.Add(limits.delay(attempt.Failures))) {
+		return ErrTooManyAttempts
+	}
+
+	return nil
+}
+
+func (g *GuardImpl) Fail(email string, ipAddress string) (Lockout, error) {
+	var lockout Lockout
+	attempt, err := g.fail(accountKey(email), g.accountLimits)
+	if err != nil {
+		return Lockout{}, err
+	}
+	if attempt.Failures == g.accountLimits.MaxFailures {
+		lockout.Account = true
+		lockout.LockedUntil = attempt.LockedUntil
+	}
+
+	attempt, err = g.fail(ipKey(ipAddress), g.ipLimits)
+	if err != nil {
+		return Lockout{}, err
+	}
+	if attempt.Failures == g.ipLimits.MaxFailures {
+		lockout.IP = true
+		if attempt.LockedUntil.After(lockout.LockedUntil) {
+			lockout.LockedUntil = attempt.LockedUntil
+		}
+	}
+
+	return lockout, nil
+}
+
+func (g *GuardImpl) fail(key string, limits Limits) (Attempt, error) {
+	now := g.now()
+	return g.store.Update(key, func(attempt *Attempt) {
+		expired := !attempt.LockedUntil.IsZero() && !now.Before(attempt.LockedUntil)
+		stale := !attempt.LastFailureAt.IsZero() && now.Sub(attempt.LastFailureAt) > limits.LockoutDuration
+		if expired || stale {
+			*attempt = Attempt{}
+		}
+
+		attempt.Failures++
+		attempt.LastFailureAt = now
+		if attempt.Failures == limits.MaxFailures {
+			attempt.LockedUntil = now.Add(limits.LockoutDuration)
+		}
+	})
+}
+
+// Succeed forgets the failures of the account. The failures of the IP address
+// are kept, otherwise an attacker could reset them by logging into an account
+// of their own every few guesses.
+func (g *GuardImpl) Succeed(email string, ipAddress string) error {
+	return g.store.Delete(accountKey(email))
+}
+
+func (g *GuardImpl) Unlock(email string) error {
+	return g.store.Delete(accountKey(email))
+}
+
+func (l Limits) delay(failures int) time.Duration {
+	if l.BaseDelay == 0 {
+		return 0
+	}
+
+	delay := l.BaseDelay
+	for i := 1; i < failures && delay < l.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > l.MaxDelay {
+		delay = l.MaxDelay
+	}
+
+	return delay
+}
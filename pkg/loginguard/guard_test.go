@@ -0,0 +1,121 @@
+package loginguard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestGuard(now *time.Time) *GuardImpl {
+	clock := func() time.Time { return *now }
+	return &GuardImpl{
+		store: &MemoryStore{attempts: map[string]Attempt{}, retention: time.Minute, now: clock},
+		accountLimits: Limits{
+			MaxFailures:     3,
+			BaseDelay:       time.Second,
+			MaxDelay:        3 * time.Second,
+			LockoutDuration: time.Minute,
+		},
+		ipLimits: Limits{
+			MaxFailures:     5,
+			LockoutDuration: time.Minute,
+		},
+		now: clock,
+	}
+}
+
+func TestGuardBackoff(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	g := createTestGuard(&now)
+
+	assert.NoError(t, g.Check("user@gmail.com", "10.0.0.1"))
+	_, err := g.Fail("user@gmail.com", "10.0.0.1")
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, g.Check("User@Gmail.com", "10.0.0.1"), ErrTooManyAttempts)
+	now = now.Add(time.Second)
+	assert.NoError(t, g.Check("user@gmail.com", "10.0.0.1"))
+
+	_, err = g.Fail("user@gmail.com", "10.0.0.1")
+	assert.NoError(t, err)
+	now = now.Add(time.Second)
+	assert.ErrorIs(t, g.Check("user@gmail.com", "10.0.0.1"), ErrTooManyAttempts)
+	now = now.Add(time.Second)
+	assert.NoError(t, g.Check("user@gmail.com", "10.0.0.1"))
+
+	// other accounts are not slowed down by the failures of this one
+	assert.NoError(t, g.Check("other@gmail.com", "10.0.0.1"))
+}
+
+func TestGuardAccountLockout(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	g := createTestGuard(&now)
+
+	var lockout Lockout
+	for i := 0; i < 3; i++ {
+		var err error
+		lockout, err = g.Fail("user@gmail.com", "10.0.0.1")
+		assert.NoError(t, err)
+		now = now.Add(5 * time.Second)
+	}
+	assert.True(t, lockout.Account)
+	assert.False(t, lockout.IP)
+	assert.Equal(t, now.Add(-5*time.Second).Add(time.Minute), lockout.LockedUntil)
+
+	assert.ErrorIs(t, g.Check("user@gmail.com", "10.0.0.2"), ErrTooManyAttempts)
+
+	now = now.Add(time.Minute)
+	assert.NoError(t, g.Check("user@gmail.com", "10.0.0.2"))
+
+	// the counter starts over once the lockout is over
+	lockout, err := g.Fail("user@gmail.com", "10.0.0.2")
+	assert.NoError(t, err)
+	assert.False(t, lockout.Account)
+}
+
+func TestGuardIPLockout(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	g := createTestGuard(&now)
+
+	var lockout Lockout
+	for i := 0; i < 5; i++ {
+		var err error
+		lockout, err = g.Fail("user"+string(rune('a'+i))+"@gmail.com", "10.0.0.1")
+		assert.NoError(t, err)
+	}
+	assert.True(t, lockout.IP)
+	assert.False(t, lockout.Account)
+
+	assert.ErrorIs(t, g.Check("new@gmail.com", "10.0.0.1"), ErrTooManyAttempts)
+	assert.NoError(t, g.Check("new@gmail.com", "10.0.0.2"))
+}
+
+func TestGuardSucceedAndUnlock(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	g := createTestGuard(&now)
+
+	for i := 0; i < 3; i++ {
+		_, err := g.Fail("user@gmail.com", "10.0.0.1")
+		assert.NoError(t, err)
+	}
+	assert.ErrorIs(t, g.Check("user@gmail.com", "10.0.0.2"), ErrTooManyAttempts)
+
+	assert.NoError(t, g.Unlock("user@gmail.com"))
+	assert.NoError(t, g.Check("user@gmail.com", "10.0.0.2"))
+
+	_, err := g.Fail("user@gmail.com", "10.0.0.2")
+	assert.NoError(t, err)
+	assert.NoError(t, g.Succeed("user@gmail.com", "10.0.0.2"))
+	assert.NoError(t, g.Check("user@gmail.com", "10.0.0.2"))
+}
+
+func TestLimitsDelay(t *testing.T) {
+	limits := Limits{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	assert.Equal(t, time.Second, limits.delay(1))
+	assert.Equal(t, 2*time.Second, limits.delay(2))
+	assert.Equal(t, 4*time.Second, limits.delay(3))
+	assert.Equal(t, 5*time.Second, limits.delay(4))
+	assert.Equal(t, 5*time.Second, limits.delay(40))
+	assert.Equal(t, time.Duration(0), Limits{}.delay(3))
+}
@@ -0,0 +1,80 @@
+package loginguard
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps attempts in the memory of a single process. Use the
+// database store when the API runs on more than one replica.
+//
+// Attempts whose lockout has ended and whose last failure is older than the
+// retention are dropped, so that the addresses of every client that ever
+// mistyped a password do not pile up. The retention must be at least the
+// longest LockoutDuration of the guards using the store.
+type MemoryStore struct {
+	attempts  map[string]Attempt
+	retention time.Duration
+	lastSweep time.Time
+	now       func() time.Time
+	mu        sync.Mutex
+}
+
+func CreateNewMemoryStore(retention time.Duration) Store {
+	return &MemoryStore{attempts: map[string]Attempt{}, retention: retention, now: time.Now}
+}
+
+func (ms *MemoryStore) Get(key string) (Attempt, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	attempt := ms.attempts[key]
+	if ms.expired(attempt, ms.now()) {
+		delete(ms.attempts, key)
+		return Attempt{}, nil
+	}
+
+	return attempt, nil
+}
+
+func (ms *MemoryStore) Update(key string, update func(attempt *Attempt)) (Attempt, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.sweep()
+
+	attempt := ms.attempts[key]
+	update(&attempt)
+	ms.attempts[key] = attempt
+
+	return attempt, nil
+}
+
+func (ms *MemoryStore) Delete(key string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	delete(ms.attempts, key)
+
+	return nil
+}
+
+// sweep drops the expired attempts, at most once per retention period so that
+// recording a failure stays cheap.
+func (ms *MemoryStore) sweep() {
+	now := ms.now()
+	if now.Sub(ms.lastSweep) < ms.retention {
+		return
+	}
+	ms.lastSweep = now
+
+	for key, attempt := range ms.attempts {
+		if ms.expired(attempt, now) {
+			delete(ms.attempts, key)
+		}
+	}
+}
+
+func (ms *MemoryStore) expired(attempt Attempt, now time.Time) bool {
+	return !now.Before(attempt.LockedUntil) && now.Sub(attempt.LastFailureAt) > ms.retention
+}
@@ -0,0 +1,47 @@
+package loginguard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreEviction(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	store := &MemoryStore{attempts: map[string]Attempt{}, retention: time.Minute, now: func() time.Time { return now }}
+	fail := func(attempt *Attempt) {
+		attempt.Failures++
+		attempt.LastFailureAt = now
+	}
+
+	_, err := store.Update("ip:10.0.0.1", fail)
+	assert.NoError(t, err)
+	_, err = store.Update("ip:10.0.0.2", func(attempt *Attempt) {
+		fail(attempt)
+		attempt.LockedUntil = now.Add(2 * time.Minute)
+	})
+	assert.NoError(t, err)
+
+	now = now.Add(30 * time.Second)
+	attempt, err := store.Get("ip:10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempt.Failures)
+
+	// a failure past the retention sweeps the stale key, the locked one stays
+	now = now.Add(time.Minute)
+	_, err = store.Update("ip:10.0.0.3", fail)
+	assert.NoError(t, err)
+	assert.Len(t, store.attempts, 2)
+	assert.NotContains(t, store.attempts, "ip:10.0.0.1")
+
+	attempt, err = store.Get("ip:10.0.0.2")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempt.Failures)
+
+	now = now.Add(2 * time.Minute)
+	attempt, err = store.Get("ip:10.0.0.2")
+	assert.NoError(t, err)
+	assert.Equal(t, Attempt{}, attempt)
+	assert.NotContains(t, store.attempts, "ip:10.0.0.2")
+}
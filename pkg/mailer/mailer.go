@@ -0,0 +1,44 @@
+package mailer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+type Mailer interface {
+	Send(to string, subject string, body string) error
+}
+
+// LogMailer is a stand-in for a real mail provider during local development.
+// Messages are appended to the file at path, or written to the standard logger
+// when no path is configured.
+type LogMailer struct {
+	path string
+	mu   sync.Mutex
+}
+
+func CreateNewLogMailer(path string) Mailer {
+	return &LogMailer{path: path}
+}
+
+func (lm *LogMailer) Send(to string, subject string, body string) error {
+	message := fmt.Sprintf("Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC1123Z), to, subject, body)
+	if lm.path == "" {
+		log.Print(message)
+		return nil
+	}
+
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	f, err := os.OpenFile(lm.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(message)
+	return err
+}
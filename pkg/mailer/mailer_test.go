@@ -0,0 +1,25 @@
+package mailer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogMailerSend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.log")
+	m := CreateNewLogMailer(path)
+
+	err := m.Send("alimikegami1@gmail.com", "Verify your email", "token: abc")
+	assert.NoError(t, err)
+	err = m.Send("alimikegami1@gmail.com", "Verify your email", "token: def")
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "To: alimikegami1@gmail.com")
+	assert.Contains(t, string(content), "token: abc")
+	assert.Contains(t, string(content), "token: def")
+}
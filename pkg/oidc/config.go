@@ -0,0 +1,22 @@
+package oidc
+
+import (
+	"os"
+	"strings"
+)
+
+// ConfigFromEnv reads the configuration of the named provider from
+// OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET and
+// OIDC_<NAME>_TRUST_EMAIL. The redirect URL is the provider's callback route
+// under APP_URL.
+func ConfigFromEnv(name string) ProviderConfig {
+	prefix := "OIDC_" + strings.ToUpper(name) + "_"
+	return ProviderConfig{
+		Name:         name,
+		IssuerURL:    os.Getenv(prefix + "ISSUER"),
+		ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+		ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+		RedirectURL:  os.Getenv("APP_URL") + "/users/oidc/" + name + "/callback",
+		TrustEmail:   os.Getenv(prefix+"TRUST_EMAIL") == "true",
+	}
+}
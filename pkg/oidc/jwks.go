@@ -0,0 +1,61 @@
+package oidc
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+)
+
+// JSONWebKey is the subset of RFC 7517 needed to verify RS256 signatures.
+type JSONWebKey struct {
+	KeyID     string `json:"kid"`
+	KeyType   string `json:"kty"`
+	Algorithm string `json:"alg,omitempty"`
+	Use       string `json:"use,omitempty"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// NewRSAJSONWebKey describes the public half of key.
+func NewRSAJSONWebKey(keyID string, key *rsa.PublicKey) JSONWebKey {
+	return JSONWebKey{
+		KeyID:     keyID,
+		KeyType:   "RSA",
+		Algorithm: "RS256",
+		Use:       "sig",
+		N:         base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+// RSAPublicKey decodes the key. Only RSA keys are supported.
+func (k JSONWebKey) RSAPublicKey() (*rsa.PublicKey, error) {
+	if k.KeyType != "RSA" {
+		return nil, errors.New("unsupported key type")
+	}
+
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid key exponent")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}, nil
+}
@@ -0,0 +1,217 @@
+// Package oidctest runs a local OpenID Connect provider for tests. It accepts
+// every authorization request, so a test can walk through the whole login
+// without a browser.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/alimikegami/compnouron/pkg/oidc"
+	"github.com/golang-jwt/jwt"
+)
+
+type authorization struct {
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	identity      oidc.Identity
+}
+
+type Server struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	mu             sync.Mutex
+	identity       oidc.Identity
+	keyID          string
+	key            *rsa.PrivateKey
+	previousKeys   []oidc.JSONWebKey
+	authorizations map[string]authorization
+}
+
+// NewServer starts a provider that issues ID tokens for identity to the given
+// client. Close it when the test is done.
+func NewServer(clientID string, clientSecret string, identity oidc.Identity) *Server {
+	s := &Server{
+		ClientID:       clientID,
+		ClientSecret:   clientSecret,
+		identity:       identity,
+		authorizations: map[string]authorization{},
+	}
+	s.RotateKey()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/jwks", s.jwks)
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// SetIdentity changes the identity issued by later authorizations.
+func (s *Server) SetIdentity(identity oidc.Identity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.identity = identity
+}
+
+// RotateKey signs later ID tokens with a new key. The old key stays published.
+func (s *Server) RotateKey() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.key != nil {
+		s.previousKeys = append(s.previousKeys, oidc.NewRSAJSONWebKey(s.keyID, &s.key.PublicKey))
+	}
+	s.key = key
+	s.keyID = fmt.Sprintf("key-%d", len(s.previousKeys)+1)
+}
+
+// Authorize follows the authorization URL the way a browser would after the
+// user signs in, and returns the code and state sent to the redirect URI.
+func (s *Server) Authorize(authCodeURL string) (string, string, error) {
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(authCodeURL)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusFound {
+		return "", "", fmt.Errorf("authorize returned %d", resp.StatusCode)
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return "", "", err
+	}
+
+	return location.Query().Get("code"), location.Query().Get("state"), nil
+}
+
+func (s *Server) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, oidc.Discovery{
+		Issuer:                s.URL,
+		AuthorizationEndpoint: s.URL + "/authorize",
+		TokenEndpoint:         s.URL + "/token",
+		JWKSURI:               s.URL + "/jwks",
+	})
+}
+
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("response_type") != "code" || query.Get("client_id") != s.ClientID || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+
+	code := randomString()
+	s.mu.Lock()
+	s.authorizations[code] = authorization{
+		clientID:      query.Get("client_id"),
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		identity:      s.identity,
+	}
+	s.mu.Unlock()
+
+	redirect := url.Values{}
+	redirect.Set("code", code)
+	redirect.Set("state", query.Get("state"))
+	http.Redirect(w, r, query.Get("redirect_uri")+"?"+redirect.Encode(), http.StatusFound)
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	s.mu.Lock()
+	code := r.PostForm.Get("code")
+	auth, ok := s.authorizations[code]
+	// codes can only be redeemed once
+	delete(s.authorizations, code)
+	s.mu.Unlock()
+
+	if !ok || auth.clientID != r.PostForm.Get("client_id") || auth.redirectURI != r.PostForm.Get("redirect_uri") || r.PostForm.Get("client_secret") != s.ClientSecret {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	if oidc.CodeChallenge(r.PostForm.Get("code_verifier")) != auth.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	claims := jwt.MapClaims{
+		"iss":            s.URL,
+		"aud":            []string{s.ClientID},
+		"sub":            auth.identity.Subject,
+		"email":          auth.identity.Email,
+		"email_verified": auth.identity.EmailVerified,
+		"name":           auth.identity.Name,
+		"nonce":          auth.nonce,
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+
+	s.mu.Lock()
+	token.Header["kid"] = s.keyID
+	idToken, err := token.SignedString(s.key)
+	s.mu.Unlock()
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func (s *Server) jwks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	keys := append([]oidc.JSONWebKey{oidc.NewRSAJSONWebKey(s.keyID, &s.key.PublicKey)}, s.previousKeys...)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, oidc.JSONWebKeySet{Keys: keys})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,271 @@
+// Package oidc implements the relying party side of an OpenID Connect login
+// using the authorization code flow with PKCE.
+package oidc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// ProviderConfig describes a client registered with an identity provider.
+type ProviderConfig struct {
+	Name         string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// TrustEmail treats the email of every identity as verified, for providers
+	// that don't send the email_verified claim but only hand out addresses they
+	// own, such as a single-tenant Microsoft directory.
+	TrustEmail bool
+}
+
+// Discovery is the part of the provider metadata the login flow relies on.
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Identity is the verified content of an ID token.
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type Provider interface {
+	Name() string
+	// AuthCodeURL returns the URL to send the user to. The code challenge is
+	// derived from verifier, which has to be passed back to Exchange.
+	AuthCodeURL(state string, nonce string, verifier string) (string, error)
+	// Exchange redeems the authorization code and verifies the ID token that
+	// comes with it.
+	Exchange(code string, verifier string, nonce string) (Identity, error)
+}
+
+type ProviderImpl struct {
+	config    ProviderConfig
+	client    *http.Client
+	mu        sync.Mutex
+	discovery *Discovery
+	keys      map[string]interface{}
+}
+
+func CreateNewProvider(config ProviderConfig, client *http.Client) Provider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+
+	return &ProviderImpl{config: config, client: client}
+}
+
+func (p *ProviderImpl) Name() string {
+	return p.config.Name
+}
+
+func (p *ProviderImpl) AuthCodeURL(state string, nonce string, verifier string) (string, error) {
+	discovery, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", CodeChallenge(verifier))
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return discovery.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+func (p *ProviderImpl) Exchange(code string, verifier string, nonce string) (Identity, error) {
+	discovery, err := p.discover()
+	if err != nil {
+		return Identity{}, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("code_verifier", verifier)
+	form.Set("client_id", p.config.ClientID)
+	if p.config.ClientSecret != "" {
+		form.Set("client_secret", p.config.ClientSecret)
+	}
+
+	resp, err := p.client.PostForm(discovery.TokenEndpoint, form)
+	if err != nil {
+		return Identity{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Identity{}, fmt.Errorf("token endpoint returned %d", resp.StatusCode)
+	}
+
+	var tokens struct {
+		IDToken string `json:"id_token"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&tokens)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	if tokens.IDToken == "" {
+		return Identity{}, errors.New("token response has no id_token")
+	}
+
+	return p.verifyIDToken(discovery, tokens.IDToken, nonce)
+}
+
+func (p *ProviderImpl) verifyIDToken(discovery *Discovery, idToken string, nonce string) (Identity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method != jwt.SigningMethodRS256 {
+			return nil, errors.New("unexpected signing method")
+		}
+		keyID, _ := t.Header["kid"].(string)
+		return p.key(discovery, keyID)
+	})
+	if err != nil {
+		return Identity{}, err
+	}
+
+	if !claims.VerifyIssuer(discovery.Issuer, true) {
+		return Identity{}, errors.New("unexpected issuer")
+	}
+
+	if !claims.VerifyAudience(p.config.ClientID, true) {
+		return Identity{}, errors.New("unexpected audience")
+	}
+
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return Identity{}, errors.New("id token expired")
+	}
+
+	if claimedNonce, _ := claims["nonce"].(string); claimedNonce != nonce {
+		return Identity{}, errors.New("nonce mismatch")
+	}
+
+	identity := Identity{}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims["email"].(string)
+	identity.Name, _ = claims["name"].(string)
+	// some providers send the flag as a string
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		identity.EmailVerified = verified == "true"
+	}
+	if p.config.TrustEmail && identity.Email != "" {
+		identity.EmailVerified = true
+	}
+
+	if identity.Subject == "" {
+		return Identity{}, errors.New("id token has no subject")
+	}
+
+	return identity, nil
+}
+
+// discover fetches the provider metadata once and keeps it for the lifetime of
+// the provider.
+func (p *ProviderImpl) discover() (*Discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	discovery := &Discovery{}
+	err := p.getJSON(strings.TrimSuffix(p.config.IssuerURL, "/")+"/.well-known/openid-configuration", discovery)
+	if err != nil {
+		return nil, err
+	}
+
+	if discovery.Issuer != strings.TrimSuffix(p.config.IssuerURL, "/") && discovery.Issuer != p.config.IssuerURL {
+		return nil, errors.New("discovery document is for another issuer")
+	}
+
+	p.discovery = discovery
+	return discovery, nil
+}
+
+// key returns the signing key with the given ID. The key set is fetched again
+// when the ID is unknown, which is how providers roll their keys over.
+func (p *ProviderImpl) key(discovery *Discovery, keyID string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[keyID]; ok {
+		return key, nil
+	}
+
+	keySet := JSONWebKeySet{}
+	err := p.getJSON(discovery.JWKSURI, &keySet)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]interface{}{}
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.RSAPublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+	p.keys = keys
+
+	key, ok := keys[keyID]
+	if !ok {
+		return nil, errors.New("unknown signing key")
+	}
+
+	return key, nil
+}
+
+func (p *ProviderImpl) getJSON(url string, v interface{}) error {
+	resp, err := p.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,132 @@
+package oidc_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/alimikegami/compnouron/pkg/oidc"
+	"github.com/alimikegami/compnouron/pkg/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+)
+
+var identity = oidc.Identity{
+	Subject:       "110169484474386276334",
+	Email:         "alim@student.unud.ac.id",
+	EmailVerified: true,
+	Name:          "Alim Ikegami",
+}
+
+func newProvider(server *oidctest.Server, trustEmail bool) oidc.Provider {
+	return oidc.CreateNewProvider(oidc.ProviderConfig{
+		Name:         "campus",
+		IssuerURL:    server.URL,
+		ClientID:     server.ClientID,
+		ClientSecret: server.ClientSecret,
+		RedirectURL:  "http://localhost:8080/users/oidc/campus/callback",
+		TrustEmail:   trustEmail,
+	}, server.Client())
+}
+
+func TestLogin(t *testing.T) {
+	server := oidctest.NewServer("compnouron", "secret", identity)
+	defer server.Close()
+	provider := newProvider(server, false)
+
+	verifier, err := oidc.GenerateCodeVerifier()
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		authCodeURL, err := provider.AuthCodeURL("state-1", "nonce-1", verifier)
+		assert.NoError(t, err)
+		parsed, err := url.Parse(authCodeURL)
+		assert.NoError(t, err)
+		assert.Equal(t, oidc.CodeChallenge(verifier), parsed.Query().Get("code_challenge"))
+		assert.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+
+		code, state, err := server.Authorize(authCodeURL)
+		assert.NoError(t, err)
+		assert.Equal(t, "state-1", state)
+
+		verified, err := provider.Exchange(code, verifier, "nonce-1")
+		assert.NoError(t, err)
+		assert.Equal(t, identity, verified)
+	})
+
+	t.Run("wrong-verifier", func(t *testing.T) {
+		authCodeURL, err := provider.AuthCodeURL("state-1", "nonce-1", verifier)
+		assert.NoError(t, err)
+		code, _, err := server.Authorize(authCodeURL)
+		assert.NoError(t, err)
+
+		otherVerifier, _ := oidc.GenerateCodeVerifier()
+		_, err = provider.Exchange(code, otherVerifier, "nonce-1")
+		assert.Error(t, err)
+	})
+
+	t.Run("nonce-mismatch", func(t *testing.T) {
+		authCodeURL, err := provider.AuthCodeURL("state-1", "nonce-1", verifier)
+		assert.NoError(t, err)
+		code, _, err := server.Authorize(authCodeURL)
+		assert.NoError(t, err)
+
+		_, err = provider.Exchange(code, verifier, "nonce-2")
+		assert.EqualError(t, err, "nonce mismatch")
+	})
+
+	t.Run("code-redeemed-twice", func(t *testing.T) {
+		authCodeURL, err := provider.AuthCodeURL("state-1", "nonce-1", verifier)
+		assert.NoError(t, err)
+		code, _, err := server.Authorize(authCodeURL)
+		assert.NoError(t, err)
+
+		_, err = provider.Exchange(code, verifier, "nonce-1")
+		assert.NoError(t, err)
+		_, err = provider.Exchange(code, verifier, "nonce-1")
+		assert.Error(t, err)
+	})
+
+	t.Run("key-rotation", func(t *testing.T) {
+		server.RotateKey()
+		authCodeURL, err := provider.AuthCodeURL("state-1", "nonce-1", verifier)
+		assert.NoError(t, err)
+		code, _, err := server.Authorize(authCodeURL)
+		assert.NoError(t, err)
+
+		verified, err := provider.Exchange(code, verifier, "nonce-1")
+		assert.NoError(t, err)
+		assert.Equal(t, identity.Subject, verified.Subject)
+	})
+}
+
+func TestTrustEmail(t *testing.T) {
+	unverified := identity
+	unverified.EmailVerified = false
+	server := oidctest.NewServer("compnouron", "", unverified)
+	defer server.Close()
+	verifier, _ := oidc.GenerateCodeVerifier()
+
+	for _, trustEmail := range []bool{false, true} {
+		provider := newProvider(server, trustEmail)
+		authCodeURL, err := provider.AuthCodeURL("state-1", "nonce-1", verifier)
+		assert.NoError(t, err)
+		code, _, err := server.Authorize(authCodeURL)
+		assert.NoError(t, err)
+
+		verified, err := provider.Exchange(code, verifier, "nonce-1")
+		assert.NoError(t, err)
+		assert.Equal(t, trustEmail, verified.EmailVerified)
+	}
+}
+
+func TestDiscoveryUnknownIssuer(t *testing.T) {
+	server := oidctest.NewServer("compnouron", "secret", identity)
+	defer server.Close()
+	provider := oidc.CreateNewProvider(oidc.ProviderConfig{
+		Name:      "campus",
+		IssuerURL: server.URL + "/tenant",
+		ClientID:  server.ClientID,
+	}, server.Client())
+
+	_, err := provider.AuthCodeURL("state-1", "nonce-1", "verifier")
+	assert.Error(t, err)
+}
@@ -0,0 +1,60 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BreachList tells whether a password is known to have leaked.
+type BreachList interface {
+	Contains(password string) (bool, error)
+}
+
+// RangeDirectory is a local copy of the Pwned Passwords range files. Like the
+// k-anonymity API, a password is looked up by the first 5 hex characters of
+// its SHA-1 hash: <dir>/<PREFIX>.txt lists the remaining 35 characters of
+// every breached hash with that prefix, one "SUFFIX:COUNT" per line. A
+// missing file means no breached hash has that prefix, so a partial copy
+// still works.
+type RangeDirectory struct {
+	dir string
+}
+
+func CreateNewRangeDirectory(dir string) BreachList {
+	return &RangeDirectory{dir: dir}
+}
+
+const hashPrefixLength = 5
+
+func (rd *RangeDirectory) Contains(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:hashPrefixLength], hash[hashPrefixLength:]
+
+	file, err := os.Open(filepath.Join(rd.dir, prefix+".txt"))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if colon := strings.Index(line, ":"); colon >= 0 {
+			line = line[:colon]
+		}
+
+		if strings.EqualFold(line, suffix) {
+			return true, nil
+		}
+	}
+
+	return false, scanner.Err()
+}
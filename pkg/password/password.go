@@ -0,0 +1,198 @@
+// Package password decides whether a password may be used: it has to satisfy
+// a configurable Policy and must not appear in a list of breached passwords.
+package password
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Policy describes what a password has to look like. MinLength counts
+// characters, MaxLength bytes, since bcrypt ignores everything past 72 bytes.
+type Policy struct {
+	MinLength     int
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// DisallowPersonalInfo rejects passwords containing the email address or
+	// a part of the name of the user
+	DisallowPersonalInfo bool
+}
+
+var DefaultPolicy = Policy{
+	MinLength:            8,
+	MaxLength:            72,
+	RequireUpper:         true,
+	RequireLower:         true,
+	RequireDigit:         true,
+	DisallowPersonalInfo: true,
+}
+
+// PolicyFromEnv returns DefaultPolicy with the settings given in the
+// PASSWORD_* variables applied, e.g. PASSWORD_MIN_LENGTH=12 or
+// PASSWORD_REQUIRE_SYMBOL=true. Invalid values are ignored.
+func PolicyFromEnv() Policy {
+	policy := DefaultPolicy
+	envInt("PASSWORD_MIN_LENGTH", &policy.MinLength)
+	envInt("PASSWORD_MAX_LENGTH", &policy.MaxLength)
+	envBool("PASSWORD_REQUIRE_UPPERCASE", &policy.RequireUpper)
+	envBool("PASSWORD_REQUIRE_LOWERCASE", &policy.RequireLower)
+	envBool("PASSWORD_REQUIRE_DIGIT", &policy.RequireDigit)
+	envBool("PASSWORD_REQUIRE_SYMBOL", &policy.RequireSymbol)
+	envBool("PASSWORD_DISALLOW_PERSONAL_INFO", &policy.DisallowPersonalInfo)
+	return policy
+}
+
+func envInt(name string, value *int) {
+	parsed, err := strconv.Atoi(os.Getenv(name))
+	if err == nil && parsed >= 0 {
+		*value = parsed
+	}
+}
+
+func envBool(name string, value *bool) {
+	parsed, err := strconv.ParseBool(os.Getenv(name))
+	if err == nil {
+		*value = parsed
+	}
+}
+
+// Violation is one rule a password breaks. Code is stable for clients, Message
+// is meant to be shown to the user.
+type Violation struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ViolationError is returned for a password that can't be used. Its message
+// is the same whatever the violations are, they are listed in Violations.
+type ViolationError struct {
+	Violations []Violation
+}
+
+func (e *ViolationError) Error() string {
+	return "password does not meet the policy"
+}
+
+type Checker interface {
+	// Check returns a *ViolationError when password may not be used by the
+	// user with the given email and name.
+	Check(password string, email string, name string) error
+}
+
+type CheckerImpl struct {
+	policy   Policy
+	breaches BreachList
+}
+
+// CreateNewChecker returns a checker enforcing policy. breaches may be nil to
+// skip the breached-password check.
+func CreateNewChecker(policy Policy, breaches BreachList) Checker {
+	return &CheckerImpl{policy: policy, breaches: breaches}
+}
+
+func (c *CheckerImpl) Check(password string, email string, name string) error {
+	violations := c.policy.Violations(password, email, name)
+
+	// a password that is already rejected isn't worth the lookup
+	if len(violations) == 0 && c.breaches != nil {
+		breached, err := c.breaches.Contains(password)
+		if err != nil {
+			return err
+		}
+
+		if breached {
+			violations = append(violations, Violation{
+				Code:    "breached",
+				Message: "appears in a list of leaked passwords, choose another one",
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return &ViolationError{Violations: violations}
+	}
+
+	return nil
+}
+
+// Violations lists the rules of the policy password breaks.
+func (p Policy) Violations(password string, email string, name string) []Violation {
+	violations := []Violation{}
+
+	if utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, Violation{
+			Code:    "too_short",
+			Message: "must be at least " + strconv.Itoa(p.MinLength) + " characters long",
+		})
+	}
+
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		violations = append(violations, Violation{
+			Code:    "too_long",
+			Message: "must be at most " + strconv.Itoa(p.MaxLength) + " bytes long",
+		})
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	if p.RequireUpper && !hasUpper {
+		violations = append(violations, Violation{Code: "missing_uppercase", Message: "must contain an uppercase letter"})
+	}
+
+	if p.RequireLower && !hasLower {
+		violations = append(violations, Violation{Code: "missing_lowercase", Message: "must contain a lowercase letter"})
+	}
+
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, Violation{Code: "missing_digit", Message: "must contain a digit"})
+	}
+
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, Violation{Code: "missing_symbol", Message: "must contain a symbol"})
+	}
+
+	if p.DisallowPersonalInfo && containsPersonalInfo(password, email, name) {
+		violations = append(violations, Violation{Code: "contains_personal_info", Message: "must not contain your email address or name"})
+	}
+
+	return violations
+}
+
+// personal info shorter than this matches too many passwords by accident
+const minPersonalInfoLength = 3
+
+func containsPersonalInfo(password string, email string, name string) bool {
+	password = strings.ToLower(password)
+
+	parts := strings.Fields(strings.ToLower(name))
+	email = strings.ToLower(strings.TrimSpace(email))
+	if at := strings.LastIndex(email, "@"); at > 0 {
+		parts = append(parts, email, email[:at])
+	}
+
+	for _, part := range parts {
+		if utf8.RuneCountInString(part) >= minPersonalInfoLength && strings.Contains(password, part) {
+			return true
+		}
+	}
+
+	return false
+}
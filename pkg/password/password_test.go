@@ -0,0 +1,99 @@
+package password
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func codes(err error) []string {
+	violationErr, ok := err.(*ViolationError)
+	if !ok {
+		return nil
+	}
+
+	var result []string
+	for _, violation := range violationErr.Violations {
+		result = append(result, violation.Code)
+	}
+	return result
+}
+
+func writeRangeFile(t *testing.T, dir string, passwords ...string) {
+	for _, password := range passwords {
+		sum := sha1.Sum([]byte(password))
+		hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+		file, err := os.OpenFile(filepath.Join(dir, hash[:5]+".txt"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		assert.NoError(t, err)
+		_, err = file.WriteString("0000000000000000000000000000000000A:1\r\n" + hash[5:] + ":42\r\n")
+		assert.NoError(t, err)
+		assert.NoError(t, file.Close())
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	writeRangeFile(t, dir, "Password123")
+	checker := CreateNewChecker(DefaultPolicy, CreateNewRangeDirectory(dir))
+
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, checker.Check("Tr4vel-Lamp-Quiet", "alim@gmail.com", "Alim Ikegami"))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, []string{"too_short", "missing_uppercase", "missing_lowercase", "missing_digit"}, codes(checker.Check("", "alim@gmail.com", "Alim Ikegami")))
+	})
+
+	t.Run("character-classes", func(t *testing.T) {
+		assert.Equal(t, []string{"missing_uppercase", "missing_digit"}, codes(checker.Check("asdfasfas", "alim@gmail.com", "Alim Ikegami")))
+	})
+
+	t.Run("too-long", func(t *testing.T) {
+		assert.Equal(t, []string{"too_long"}, codes(checker.Check("Aa1"+strings.Repeat("x", 70), "alim@gmail.com", "Alim Ikegami")))
+	})
+
+	t.Run("contains-name", func(t *testing.T) {
+		assert.Equal(t, []string{"contains_personal_info"}, codes(checker.Check("IKEGAMI2022x", "alim@gmail.com", "Alim Ikegami")))
+	})
+
+	t.Run("contains-email", func(t *testing.T) {
+		assert.Equal(t, []string{"contains_personal_info"}, codes(checker.Check("Xalim.dev99", "alim.dev@gmail.com", "Budi")))
+	})
+
+	t.Run("short-name-parts-ignored", func(t *testing.T) {
+		assert.NoError(t, checker.Check("Stoneware-Li-88", "li@gmail.com", "Li Na"))
+	})
+
+	t.Run("breached", func(t *testing.T) {
+		err := checker.Check("Password123", "alim@gmail.com", "Alim Ikegami")
+		assert.EqualError(t, err, "password does not meet the policy")
+		assert.Equal(t, []string{"breached"}, codes(err))
+	})
+
+	t.Run("no-range-file", func(t *testing.T) {
+		assert.NoError(t, checker.Check("Password1234", "alim@gmail.com", "Alim Ikegami"))
+	})
+}
+
+func TestCheckWithoutBreachList(t *testing.T) {
+	checker := CreateNewChecker(Policy{MinLength: 4, RequireSymbol: true}, nil)
+	assert.NoError(t, checker.Check("ab c", "alim@gmail.com", "Alim Ikegami"))
+	assert.Equal(t, []string{"missing_symbol"}, codes(checker.Check("abcd", "alim@gmail.com", "Alim Ikegami")))
+}
+
+func TestPolicyFromEnv(t *testing.T) {
+	t.Setenv("PASSWORD_MIN_LENGTH", "12")
+	t.Setenv("PASSWORD_REQUIRE_SYMBOL", "true")
+	t.Setenv("PASSWORD_REQUIRE_UPPERCASE", "nope")
+
+	policy := PolicyFromEnv()
+	assert.Equal(t, 12, policy.MinLength)
+	assert.True(t, policy.RequireSymbol)
+	assert.True(t, policy.RequireUpper)
+	assert.Equal(t, DefaultPolicy.MaxLength, policy.MaxLength)
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage writes objects below a directory that the application serves
+// itself under baseURL.
+type LocalStorage struct {
+	dir     string
+	baseURL string
+}
+
+func CreateNewLocalStorage(dir string, baseURL string) Storage {
+	return &LocalStorage{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+func (ls *LocalStorage) Put(key string, contentType string, data []byte) error {
+	err := ValidateKey(key)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(ls.dir, filepath.FromSlash(key))
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+func (ls *LocalStorage) Delete(key string) error {
+	err := ValidateKey(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filepath.Join(ls.dir, filepath.FromSlash(key)))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (ls *LocalStorage) URL(key string) string {
+	return ls.baseURL + "/" + key
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	dir := t.TempDir()
+	s := CreateNewLocalStorage(dir, "/uploads/")
+
+	t.Run("put", func(t *testing.T) {
+		err := s.Put("avatars/1/a.png", "image/png", []byte("png"))
+		assert.NoError(t, err)
+		data, err := os.ReadFile(filepath.Join(dir, "avatars", "1", "a.png"))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("png"), data)
+		assert.Equal(t, "/uploads/avatars/1/a.png", s.URL("avatars/1/a.png"))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := s.Delete("avatars/1/a.png")
+		assert.NoError(t, err)
+		_, err = os.Stat(filepath.Join(dir, "avatars", "1", "a.png"))
+		assert.True(t, os.IsNotExist(err))
+		assert.NoError(t, s.Delete("avatars/1/a.png"))
+	})
+
+	t.Run("invalid-key", func(t *testing.T) {
+		for _, key := range []string{"", "/etc/passwd", "../secret", "avatars/../../secret", "avatars//a.png", "avatars\\a.png"} {
+			assert.EqualError(t, s.Put(key, "image/png", []byte("png")), "invalid key", key)
+		}
+	})
+}
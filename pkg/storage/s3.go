@@ -0,0 +1,196 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Config describes a bucket on an S3-compatible object store such as AWS S3
+// or MinIO. Objects are addressed path-style, as endpoint/bucket/key, which
+// every S3-compatible store supports.
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PublicURL is where the bucket's objects can be downloaded from, e.g. a
+	// CDN in front of the bucket. It defaults to endpoint/bucket.
+	PublicURL string
+}
+
+// S3ConfigFromEnv reads the configuration from S3_ENDPOINT, S3_REGION,
+// S3_BUCKET, S3_ACCESS_KEY_ID, S3_SECRET_ACCESS_KEY and S3_PUBLIC_URL.
+func S3ConfigFromEnv() S3Config {
+	return S3Config{
+		Endpoint:        os.Getenv("S3_ENDPOINT"),
+		Region:          os.Getenv("S3_REGION"),
+		Bucket:          os.Getenv("S3_BUCKET"),
+		AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		PublicURL:       os.Getenv("S3_PUBLIC_URL"),
+	}
+}
+
+// S3Storage talks to the object store over its REST API, signing every
+// request with AWS Signature Version 4.
+type S3Storage struct {
+	config S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+func CreateNewS3Storage(config S3Config, client *http.Client) Storage {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
+	if config.PublicURL == "" {
+		config.PublicURL = config.Endpoint + "/" + config.Bucket
+	}
+	config.PublicURL = strings.TrimSuffix(config.PublicURL, "/")
+
+	return &S3Storage{config: config, client: client, now: time.Now}
+}
+
+func (s *S3Storage) Put(key string, contentType string, data []byte) error {
+	err := ValidateKey(key)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, s.objectURL(key), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	return s.do(req, data, http.StatusOK)
+}
+
+func (s *S3Storage) Delete(key string) error {
+	err := ValidateKey(key)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+
+	return s.do(req, nil, http.StatusNoContent, http.StatusOK)
+}
+
+func (s *S3Storage) URL(key string) string {
+	return s.config.PublicURL + "/" + key
+}
+
+func (s *S3Storage) objectURL(key string) string {
+	return s.config.Endpoint + "/" + s.config.Bucket + "/" + EscapePath(key)
+}
+
+func (s *S3Storage) do(req *http.Request, payload []byte, expectedStatus ...int) error {
+	SignV4(req, payload, s.config.Region, s.config.AccessKeyID, s.config.SecretAccessKey, s.now())
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	for _, status := range expectedStatus {
+		if res.StatusCode == status {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("object store returned %s for %s %s", res.Status, req.Method, req.URL.Path)
+}
+
+// SignV4 adds the headers of an AWS Signature Version 4 for the s3 service to
+// the request.
+func SignV4(req *http.Request, payload []byte, region string, accessKeyID string, secretAccessKey string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	payloadHash := sha256.Sum256(payload)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+
+	scope := amzDate[:8] + "/" + region + "/s3/aws4_request"
+	signedHeaders, canonicalRequest := CanonicalRequest(req, []string{"content-type", "host", "x-amz-content-sha256", "x-amz-date"})
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalRequestHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretAccessKey), amzDate[:8])
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+accessKeyID+"/"+scope+", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// CanonicalRequest builds the canonical form of a request over the given
+// headers, skipping the ones the request doesn't carry. It returns the
+// signed header list along with the canonical request.
+func CanonicalRequest(req *http.Request, headers []string) (string, string) {
+	var names []string
+	var canonicalHeaders strings.Builder
+	sort.Strings(headers)
+	for _, name := range headers {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.Host
+			if value == "" {
+				value = req.URL.Host
+			}
+		}
+		if value == "" {
+			continue
+		}
+
+		names = append(names, name)
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	return signedHeaders, strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+}
+
+// EscapePath escapes a key the way S3 expects in a canonical URI: everything
+// but unreserved characters and slashes is percent-encoded.
+func EscapePath(key string) string {
+	var escaped strings.Builder
+	for _, b := range []byte(key) {
+		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') || b == '-' || b == '_' || b == '.' || b == '~' || b == '/' {
+			escaped.WriteByte(b)
+		} else {
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+
+	return escaped.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
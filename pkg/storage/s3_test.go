@@ -0,0 +1,60 @@
+package storage_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/alimikegami/compnouron/pkg/storage"
+	"github.com/alimikegami/compnouron/pkg/storage/s3test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3Storage(t *testing.T) {
+	server := s3test.NewServer("compnouron")
+	defer server.Close()
+	s := storage.CreateNewS3Storage(server.Config(), server.Client())
+
+	t.Run("put", func(t *testing.T) {
+		err := s.Put("banners/1/a b.jpg", "image/jpeg", []byte("jpeg"))
+		assert.NoError(t, err)
+		data, contentType, ok := server.Object("banners/1/a b.jpg")
+		assert.True(t, ok)
+		assert.Equal(t, []byte("jpeg"), data)
+		assert.Equal(t, "image/jpeg", contentType)
+	})
+
+	t.Run("public-url", func(t *testing.T) {
+		assert.Equal(t, server.URL+"/compnouron/banners/1/a.jpg", s.URL("banners/1/a.jpg"))
+		err := s.Put("banners/1/a.jpg", "image/jpeg", []byte("jpeg"))
+		assert.NoError(t, err)
+		res, err := http.Get(s.URL("banners/1/a.jpg"))
+		assert.NoError(t, err)
+		defer res.Body.Close()
+		data, _ := io.ReadAll(res.Body)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, []byte("jpeg"), data)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := s.Delete("banners/1/a.jpg")
+		assert.NoError(t, err)
+		_, _, ok := server.Object("banners/1/a.jpg")
+		assert.False(t, ok)
+	})
+
+	t.Run("wrong-secret", func(t *testing.T) {
+		config := server.Config()
+		config.SecretAccessKey = "wrong"
+		err := storage.CreateNewS3Storage(config, server.Client()).Put("banners/1/b.jpg", "image/jpeg", []byte("jpeg"))
+		assert.Error(t, err)
+		_, _, ok := server.Object("banners/1/b.jpg")
+		assert.False(t, ok)
+	})
+
+	t.Run("public-url-override", func(t *testing.T) {
+		config := server.Config()
+		config.PublicURL = "https://cdn.example.com/"
+		assert.Equal(t, "https://cdn.example.com/banners/1/a.jpg", storage.CreateNewS3Storage(config, nil).URL("banners/1/a.jpg"))
+	})
+}
@@ -0,0 +1,163 @@
+// Package s3test runs a local stand-in for an S3-compatible object store, in
+// the spirit of a throwaway MinIO container. It keeps objects in memory,
+// checks the Signature Version 4 of every write and serves objects publicly,
+// so a test can upload through storage.S3Storage and download the result from
+// its URL.
+package s3test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/alimikegami/compnouron/pkg/storage"
+)
+
+type object struct {
+	contentType string
+	data        []byte
+}
+
+type Server struct {
+	*httptest.Server
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+
+	mu      sync.Mutex
+	objects map[string]object
+}
+
+// NewServer starts an object store with a single bucket. Close it when the
+// test is done.
+func NewServer(bucket string) *Server {
+	s := &Server{
+		Bucket:          bucket,
+		Region:          "us-east-1",
+		AccessKeyID:     "minioadmin",
+		SecretAccessKey: "minioadmin-secret",
+		objects:         map[string]object{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// Config returns the configuration storage.CreateNewS3Storage needs to talk to
+// the server.
+func (s *Server) Config() storage.S3Config {
+	return storage.S3Config{
+		Endpoint:        s.URL,
+		Region:          s.Region,
+		Bucket:          s.Bucket,
+		AccessKeyID:     s.AccessKeyID,
+		SecretAccessKey: s.SecretAccessKey,
+	}
+}
+
+// Object returns a stored object and its content type.
+func (s *Server) Object(key string) ([]byte, string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.objects[key]
+	return o.data, o.contentType, ok
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	prefix := "/" + s.Bucket + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, prefix)
+
+	if r.Method == http.MethodGet {
+		o, ok := s.load(key)
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", o.contentType)
+		w.Write(o.data)
+		return
+	}
+
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "IncompleteBody", http.StatusBadRequest)
+		return
+	}
+	if !s.verify(r, payload) {
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.Method == http.MethodPut {
+		s.objects[key] = object{contentType: r.Header.Get("Content-Type"), data: payload}
+		w.WriteHeader(http.StatusOK)
+	} else if r.Method == http.MethodDelete {
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	} else {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) load(key string) (object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.objects[key]
+	return o, ok
+}
+
+// verify recomputes the request's signature from what arrived over the wire.
+func (s *Server) verify(r *http.Request, payload []byte) bool {
+	payloadHash := sha256.Sum256(payload)
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(payloadHash[:]) {
+		return false
+	}
+
+	fields := map[string]string{}
+	authorization := strings.TrimPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ")
+	for _, field := range strings.Split(authorization, ", ") {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) == 2 {
+			fields[parts[0]] = parts[1]
+		}
+	}
+
+	credential := strings.Split(fields["Credential"], "/")
+	amzDate := r.Header.Get("X-Amz-Date")
+	if len(credential) != 5 || credential[0] != s.AccessKeyID || len(amzDate) != 16 || credential[1] != amzDate[:8] || credential[2] != s.Region {
+		return false
+	}
+
+	signedHeaders, canonicalRequest := storage.CanonicalRequest(r, strings.Split(fields["SignedHeaders"], ";"))
+	if signedHeaders != fields["SignedHeaders"] {
+		return false
+	}
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := strings.Join(credential[1:], "/")
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalRequestHash[:])
+
+	signingKey := []byte("AWS4" + s.SecretAccessKey)
+	for _, part := range credential[1:] {
+		signingKey = sign(signingKey, part)
+	}
+
+	return hmac.Equal([]byte(hex.EncodeToString(sign(signingKey, stringToSign))), []byte(fields["Signature"]))
+}
+
+func sign(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,35 @@
+// Package storage keeps uploaded files behind a small interface, so the same
+// code can write to the local filesystem during development and to an
+// S3-compatible object store in production.
+package storage
+
+import (
+	"errors"
+	"strings"
+)
+
+// Storage stores objects under slash separated keys such as
+// "avatars/1/3f2a.jpg".
+type Storage interface {
+	Put(key string, contentType string, data []byte) error
+	// Delete removes the object. Deleting a key that doesn't exist isn't an
+	// error.
+	Delete(key string) error
+	// URL returns the public URL of the object.
+	URL(key string) string
+}
+
+// ValidateKey rejects keys that could escape the storage root.
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return errors.New("invalid key")
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return errors.New("invalid key")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the SHA1 test vectors of RFC 6238, truncated to 6 digits
+func TestCodeRFC6238(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, expected := range vectors {
+		code, err := Code(secret, Step(time.Unix(unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code, "time %d", unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.NoError(t, err)
+	now := time.Unix(1654070400, 0)
+
+	code, err := Code(secret, Step(now))
+	assert.NoError(t, err)
+	step, ok := Validate(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, Step(now), step)
+
+	// a code from the previous step is still accepted
+	step, ok = Validate(secret, code, now.Add(30*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, Step(now), step)
+
+	_, ok = Validate(secret, code, now.Add(90*time.Second))
+	assert.False(t, ok)
+
+	_, ok = Validate(secret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("JBSWY3DPEHPK3PXP", "Compnouron", "alim@gmail.com")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Compnouron:alim@gmail.com?"))
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=Compnouron")
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/alimikegami/compnouron/pkg/response"
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+)
+
+// ImpersonationAuditor records the requests made with impersonation tokens.
+type ImpersonationAuditor interface {
+	RecordImpersonatedRequest(impersonationID uint, actorID uint, userID uint, method string, path string, status int, ipAddress string) error
+}
+
+// ImpersonationAudit records every request authenticated with an impersonation
+// token once it has been handled, whatever its outcome. It is meant to be
+// registered with Echo.Use, so it sees the claims the JWT middleware of the
+// route sets.
+func ImpersonationAudit(auditor ImpersonationAuditor) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if err := next(c); err != nil {
+				// handle the error here so the status written to the client
+				// is known
+				c.Error(err)
+			}
+
+			user, ok := c.Get("user").(*jwt.Token)
+			if !ok {
+				return nil
+			}
+
+			claims, ok := user.Claims.(*JwtCustomClaims)
+			if !ok || claims.Actor == nil {
+				return nil
+			}
+
+			err := auditor.RecordImpersonatedRequest(claims.ImpersonationID, claims.Actor.ID, claims.ID, c.Request().Method, c.Request().URL.Path, c.Response().Status, c.RealIP())
+			if err != nil {
+				fmt.Println(err)
+			}
+
+			return nil
+		}
+	}
+}
+
+// IsImpersonating reports whether the request is made with an impersonation
+// token.
+func IsImpersonating(c echo.Context) bool {
+	return GetActor(c) != nil
+}
+
+// GetImpersonationID returns the impersonation the token of the request was
+// issued for, or 0 for requests made by the user themselves.
+func GetImpersonationID(c echo.Context) uint {
+	return getClaims(c).ImpersonationID
+}
+
+// ForbidImpersonation rejects impersonation tokens on routes that change the
+// credentials or the account of the user, so an admin acting as a user can't
+// take the account over. It must run after the JWT middleware.
+func ForbidImpersonation(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if IsImpersonating(c) {
+			return c.JSON(http.StatusForbidden, response.Response{
+				Status:  "error",
+				Message: "not allowed while impersonating",
+				Data:    nil,
+			})
+		}
+
+		return next(c)
+	}
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alimikegami/compnouron/pkg/keyring"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedRequest struct {
+	impersonationID uint
+	actorID         uint
+	userID          uint
+	method          string
+	path            string
+	status          int
+}
+
+type fakeAuditor struct {
+	requests []recordedRequest
+}
+
+func (a *fakeAuditor) RecordImpersonatedRequest(impersonationID uint, actorID uint, userID uint, method string, path string, status int, ipAddress string) error {
+	a.requests = append(a.requests, recordedRequest{impersonationID, actorID, userID, method, path, status})
+	return nil
+}
+
+func TestImpersonation(t *testing.T) {
+	kr, err := keyring.CreateNewRing(keyring.CreateNewMemoryStore(), keyring.DefaultOptions)
+	assert.NoError(t, err)
+	config := CreateJWTConfig(kr, fakeTokenValidator{})
+	auditor := &fakeAuditor{}
+	e := echo.New()
+	e.Use(ImpersonationAudit(auditor))
+	e.GET("/me", func(c echo.Context) error {
+		userID, _ := GetUserDetails(c)
+		assert.Equal(t, uint(1), userID)
+		return c.NoContent(http.StatusOK)
+	}, middleware.JWTWithConfig(config))
+	e.PUT("/me/password", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, middleware.JWTWithConfig(config), ForbidImpersonation)
+	e.GET("/broken", func(c echo.Context) error {
+		return errors.New("database is down")
+	}, middleware.JWTWithConfig(config))
+
+	impersonationToken, err := CreateSignedImpersonationToken(kr, 1, "asdfa@gmail.com", RoleStudent, Actor{ID: 2, Email: "admin@gmail.com"}, 3, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	endedToken, err := CreateSignedImpersonationToken(kr, 1, "asdfa@gmail.com", RoleStudent, Actor{ID: 2, Email: "admin@gmail.com"}, 4, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	loginToken, err := CreateSignedJWTToken(kr, 1, "asdfa@gmail.com", RoleStudent, 7)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		status int
+	}{
+		{"impersonation-token", http.MethodGet, "/me", impersonationToken, http.StatusOK},
+		{"sensitive-route", http.MethodPut, "/me/password", impersonationToken, http.StatusForbidden},
+		{"handler-error", http.MethodGet, "/broken", impersonationToken, http.StatusInternalServerError},
+		{"ended-impersonation", http.MethodGet, "/me", endedToken, http.StatusUnauthorized},
+		{"login-token", http.MethodGet, "/me", loginToken, http.StatusOK},
+		{"login-token-on-sensitive-route", http.MethodPut, "/me/password", loginToken, http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+tc.token)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+
+	assert.Equal(t, []recordedRequest{
+		{3, 2, 1, http.MethodGet, "/me", http.StatusOK},
+		{3, 2, 1, http.MethodPut, "/me/password", http.StatusForbidden},
+		{3, 2, 1, http.MethodGet, "/broken", http.StatusInternalServerError},
+	}, auditor.requests)
+}
@@ -1,46 +1,258 @@
 package utils
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/alimikegami/compnouron/pkg/keyring"
 	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+const (
+	EmailVerificationPurpose = "email_verification"
+	TwoFactorLoginPurpose    = "two_factor_login"
 )
 
 type JwtCustomClaims struct {
 	ID    uint   `json:"id"`
 	Email string `json:"email"`
+	Role  string `json:"role"`
+	// SessionID ties the access token to the login it was issued for, so
+	// revoking the session also rejects its outstanding access tokens
+	SessionID uint `json:"sid"`
+	// Scopes and PersonalAccessToken are only set for requests authenticated
+	// with a personal access token, which is never encoded as a JWT
+	Scopes              []string `json:"-"`
+	PersonalAccessToken bool     `json:"-"`
+	// Actor is only set on impersonation tokens, which let an admin act as the
+	// user identified by ID. Such tokens are bound to ImpersonationID instead of
+	// a session of the user.
+	Actor           *Actor `json:"act,omitempty"`
+	ImpersonationID uint   `json:"imp,omitempty"`
+	jwt.StandardClaims
+}
+
+// Actor is the admin behind an impersonation token, after the "act" claim of
+// RFC 8693.
+type Actor struct {
+	ID    uint   `json:"id"`
+	Email string `json:"email"`
+}
+
+// PurposeClaims are carried by single-purpose tokens such as email
+// verification links. They are signed with a key derived from the purpose, so
+// they are never accepted where an access token is expected.
+type PurposeClaims struct {
+	ID      uint   `json:"id"`
+	Email   string `json:"email"`
+	Purpose string `json:"purpose"`
 	jwt.StandardClaims
 }
 
-func CreateJWTToken(id uint, email string) *jwt.Token {
+func createAccessClaims(id uint, email string, role string, sessionID uint) *JwtCustomClaims {
+	return &JwtCustomClaims{
+		ID:        id,
+		Email:     email,
+		Role:      role,
+		SessionID: sessionID,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Minute * 30).Unix(),
+			Issuer:    "Compnouron",
+		},
+	}
+}
+
+// CreateJWTToken returns an access token as the JWT middleware leaves it in the
+// context once verified, for handlers called without the middleware. It is
+// never signed, CreateSignedJWTToken issues the tokens given to clients.
+func CreateJWTToken(id uint, email string, role string, sessionID uint) *jwt.Token {
+	return &jwt.Token{Claims: createAccessClaims(id, email, role, sessionID), Valid: true}
+}
+
+// CreateSignedJWTToken signs an access token with the active key of the ring,
+// so that other services can verify it against the published JWKS.
+func CreateSignedJWTToken(kr keyring.Ring, id uint, email string, role string, sessionID uint) (string, error) {
+	return kr.Sign(createAccessClaims(id, email, role, sessionID))
+}
+
+// CreateSignedImpersonationToken signs an access token for the user that is
+// marked as being used by actor on behalf of the user.
+func CreateSignedImpersonationToken(kr keyring.Ring, id uint, email string, role string, actor Actor, impersonationID uint, expiresAt time.Time) (string, error) {
 	claims := &JwtCustomClaims{
+		ID:              id,
+		Email:           email,
+		Role:            role,
+		Actor:           &actor,
+		ImpersonationID: impersonationID,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: expiresAt.Unix(),
+			Issuer:    "Compnouron",
+		},
+	}
+	return kr.Sign(claims)
+}
+
+func ParseJWTToken(kr keyring.Ring, encodedToken string) (*jwt.Token, error) {
+	token, err := jwt.ParseWithClaims(encodedToken, &JwtCustomClaims{}, kr.Keyfunc)
+	if err != nil || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	return token, nil
+}
+
+// TokenValidator checks the tokens accepted by the JWT middleware against
+// their stored state.
+type TokenValidator interface {
+	ValidateSession(userID uint, sessionID uint) error
+	ValidateImpersonation(impersonationID uint, actorID uint, userID uint) error
+	AuthenticatePersonalAccessToken(token string) (JwtCustomClaims, error)
+}
+
+// CreateJWTConfig returns the access token middleware config. Besides checking
+// the signature against the key ring, it asks the validator whether the session
+// the token was issued for is still active, or for impersonation tokens
+// whether the impersonation is. Personal access tokens are only accepted on
+// routes guarded by JWTWithScope.
+func CreateJWTConfig(kr keyring.Ring, v TokenValidator) middleware.JWTConfig {
+	return middleware.JWTConfig{
+		ParseTokenFunc: func(auth string, c echo.Context) (interface{}, error) {
+			if strings.HasPrefix(auth, PersonalAccessTokenPrefix) {
+				if _, ok := c.Get(requiredScopeKey).(string); !ok {
+					return nil, errors.New("personal access tokens are not accepted here")
+				}
+
+				claims, err := v.AuthenticatePersonalAccessToken(auth)
+				if err != nil {
+					return nil, err
+				}
+
+				claims.PersonalAccessToken = true
+				return &jwt.Token{Claims: &claims, Valid: true}, nil
+			}
+
+			token, err := ParseJWTToken(kr, auth)
+			if err != nil {
+				return nil, err
+			}
+
+			claims := token.Claims.(*JwtCustomClaims)
+			if claims.Actor != nil {
+				if err := v.ValidateImpersonation(claims.ImpersonationID, claims.Actor.ID, claims.ID); err != nil {
+					return nil, err
+				}
+
+				return token, nil
+			}
+
+			if err := v.ValidateSession(claims.ID, claims.SessionID); err != nil {
+				return nil, err
+			}
+
+			return token, nil
+		},
+	}
+}
+
+// purpose tokens are only ever verified by this service, so unlike access
+// tokens they are signed with a key derived from SIGNING_KEY
+func purposeSigningKey(purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(os.Getenv("SIGNING_KEY")))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+func CreateSignedPurposeToken(purpose string, id uint, email string, lifetime time.Duration) (string, error) {
+	claims := &PurposeClaims{
 		id,
 		email,
+		purpose,
 		jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(time.Minute * 30).Unix(),
+			ExpiresAt: time.Now().Add(lifetime).Unix(),
 			Issuer:    "Compnouron",
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token
+	return token.SignedString(purposeSigningKey(purpose))
 }
 
-func CreateSignedJWTToken(id uint, email string) (string, error) {
-	token := CreateJWTToken(id, email)
-	encodedToken, err := token.SignedString([]byte(os.Getenv("SIGNING_KEY")))
-	if err != nil {
-		return "", err
+func ParsePurposeToken(purpose string, encodedToken string) (*PurposeClaims, error) {
+	claims := &PurposeClaims{}
+	token, err := jwt.ParseWithClaims(encodedToken, claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method != jwt.SigningMethodHS256 {
+			return nil, errors.New("unexpected signing method")
+		}
+		return purposeSigningKey(purpose), nil
+	})
+	if err != nil || !token.Valid || claims.Purpose != purpose {
+		return nil, errors.New("invalid token")
 	}
-	return encodedToken, nil
+
+	return claims, nil
 }
 
-func GetUserDetails(c echo.Context) (uint, string) {
+func getClaims(c echo.Context) *JwtCustomClaims {
 	user := c.Get("user").(*jwt.Token)
-	claims := user.Claims.(*JwtCustomClaims)
+	return user.Claims.(*JwtCustomClaims)
+}
+
+func GetUserDetails(c echo.Context) (uint, string) {
+	claims := getClaims(c)
 	userID := claims.ID
 	email := claims.Email
 
 	return userID, email
 }
+
+func GetSessionID(c echo.Context) uint {
+	return getClaims(c).SessionID
+}
+
+func GetUserRole(c echo.Context) string {
+	return getClaims(c).Role
+}
+
+// GetActor returns the admin impersonating the user, or nil for requests
+// made by the user themselves.
+func GetActor(c echo.Context) *Actor {
+	return getClaims(c).Actor
+}
+
+// OptionalJWT runs the JWT middleware only when the request carries an
+// Authorization header, for public routes whose response depends on who is
+// asking.
+func OptionalJWT(config middleware.JWTConfig) echo.MiddlewareFunc {
+	jwtMiddleware := middleware.JWTWithConfig(config)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withJWT := jwtMiddleware(next)
+		return func(c echo.Context) error {
+			if c.Request().Header.Get(echo.HeaderAuthorization) == "" {
+				return next(c)
+			}
+
+			return withJWT(c)
+		}
+	}
+}
+
+// GetOptionalUserID returns the ID of the logged in user, or 0 when the request
+// went through OptionalJWT without a token.
+func GetOptionalUserID(c echo.Context) uint {
+	user, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return 0
+	}
+
+	claims, ok := user.Claims.(*JwtCustomClaims)
+	if !ok {
+		return 0
+	}
+
+	return claims.ID
+}
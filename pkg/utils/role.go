@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"net/http"
+
+	"github.com/alimikegami/compnouron/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	RoleAdmin     = "admin"
+	RoleOrganizer = "organizer"
+	RoleStudent   = "student"
+)
+
+func IsValidRole(role string) bool {
+	return role == RoleAdmin || role == RoleOrganizer || role == RoleStudent
+}
+
+// RequireRole only lets the request through when the role carried by the JWT
+// token is one of the given roles. It must run after the JWT middleware.
+func RequireRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			role := GetUserRole(c)
+			for _, r := range roles {
+				if role == r {
+					return next(c)
+				}
+			}
+
+			return c.JSON(http.StatusForbidden, response.Response{
+				Status:  "error",
+				Message: "action forbidden",
+				Data:    nil,
+			})
+		}
+	}
+}
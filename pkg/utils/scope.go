@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"net/http"
+
+	"github.com/alimikegami/compnouron/pkg/response"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// PersonalAccessTokenPrefix starts every personal access token so the JWT
+// middleware can tell them apart from access tokens issued at login.
+const PersonalAccessTokenPrefix = "cpat_"
+
+const (
+	ScopeProfileRead        = "profile:read"
+	ScopeCompetitionsRead   = "competitions:read"
+	ScopeCompetitionsWrite  = "competitions:write"
+	ScopeRegistrationsRead  = "registrations:read"
+	ScopeRegistrationsWrite = "registrations:write"
+	ScopeRecruitmentsRead   = "recruitments:read"
+	ScopeRecruitmentsWrite  = "recruitments:write"
+	ScopeTeamsWrite         = "teams:write"
+)
+
+const requiredScopeKey = "requiredScope"
+
+func IsValidScope(scope string) bool {
+	switch scope {
+	case ScopeProfileRead, ScopeCompetitionsRead, ScopeCompetitionsWrite, ScopeRegistrationsRead, ScopeRegistrationsWrite, ScopeRecruitmentsRead, ScopeRecruitmentsWrite, ScopeTeamsWrite:
+		return true
+	}
+
+	return false
+}
+
+// JWTWithScope is middleware.JWTWithConfig for routes that personal access
+// tokens may call. Such tokens are only let through when they were granted the
+// given scope, while access tokens issued at login are accepted as usual.
+// Routes guarded by middleware.JWTWithConfig alone never accept personal
+// access tokens.
+func JWTWithScope(config middleware.JWTConfig, scope string) echo.MiddlewareFunc {
+	jwtMiddleware := middleware.JWTWithConfig(config)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withJWT := jwtMiddleware(func(c echo.Context) error {
+			if !HasScope(c, scope) {
+				return c.JSON(http.StatusForbidden, response.Response{
+					Status:  "error",
+					Message: "insufficient scope",
+					Data:    nil,
+				})
+			}
+
+			return next(c)
+		})
+		return func(c echo.Context) error {
+			c.Set(requiredScopeKey, scope)
+			return withJWT(c)
+		}
+	}
+}
+
+// OptionalJWTWithScope is OptionalJWT for public routes that personal access
+// tokens may call. Requests without a token go through as they are, the others
+// are checked like JWTWithScope.
+func OptionalJWTWithScope(config middleware.JWTConfig, scope string) echo.MiddlewareFunc {
+	scopeMiddleware := JWTWithScope(config, scope)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withScope := scopeMiddleware(next)
+		return func(c echo.Context) error {
+			if c.Request().Header.Get(echo.HeaderAuthorization) == "" {
+				return next(c)
+			}
+
+			return withScope(c)
+		}
+	}
+}
+
+// HasScope reports whether the token of the request may be used for the given
+// scope. Access tokens issued at login carry every permission of their user.
+func HasScope(c echo.Context, scope string) bool {
+	claims := getClaims(c)
+	if !claims.PersonalAccessToken {
+		return true
+	}
+
+	for _, s := range claims.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
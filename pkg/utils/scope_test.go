@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alimikegami/compnouron/pkg/keyring"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTokenValidator struct{}
+
+func (fakeTokenValidator) ValidateSession(userID uint, sessionID uint) error {
+	if sessionID != 7 {
+		return errors.New("session revoked")
+	}
+	return nil
+}
+
+func (fakeTokenValidator) ValidateImpersonation(impersonationID uint, actorID uint, userID uint) error {
+	if impersonationID != 3 || actorID != 2 {
+		return errors.New("impersonation ended")
+	}
+	return nil
+}
+
+func (fakeTokenValidator) AuthenticatePersonalAccessToken(token string) (JwtCustomClaims, error) {
+	if token != PersonalAccessTokenPrefix+"token" {
+		return JwtCustomClaims{}, errors.New("invalid access token")
+	}
+	return JwtCustomClaims{ID: 1, Role: RoleStudent, Scopes: []string{ScopeRegistrationsRead}}, nil
+}
+
+func TestJWTWithScope(t *testing.T) {
+	options := keyring.DefaultOptions
+	options.Algorithm = keyring.AlgorithmEdDSA
+	kr, err := keyring.CreateNewRing(keyring.CreateNewMemoryStore(), options)
+	assert.NoError(t, err)
+	config := CreateJWTConfig(kr, fakeTokenValidator{})
+	e := echo.New()
+	handler := func(c echo.Context) error {
+		userID, _ := GetUserDetails(c)
+		assert.Equal(t, uint(1), userID)
+		return c.NoContent(http.StatusOK)
+	}
+	e.GET("/registrations", handler, JWTWithScope(config, ScopeRegistrationsRead))
+	e.POST("/registrations", handler, JWTWithScope(config, ScopeRegistrationsWrite))
+	e.GET("/me", handler, middleware.JWTWithConfig(config))
+
+	loginToken, err := CreateSignedJWTToken(kr, 1, "asdfa@gmail.com", RoleStudent, 7)
+	assert.NoError(t, err)
+	revokedToken, err := CreateSignedJWTToken(kr, 1, "asdfa@gmail.com", RoleStudent, 8)
+	assert.NoError(t, err)
+	otherRing, err := keyring.CreateNewRing(keyring.CreateNewMemoryStore(), options)
+	assert.NoError(t, err)
+	foreignToken, err := CreateSignedJWTToken(otherRing, 1, "asdfa@gmail.com", RoleStudent, 7)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		status int
+	}{
+		{"pat-with-scope", http.MethodGet, "/registrations", PersonalAccessTokenPrefix + "token", http.StatusOK},
+		{"pat-without-scope", http.MethodPost, "/registrations", PersonalAccessTokenPrefix + "token", http.StatusForbidden},
+		{"pat-on-unscoped-route", http.MethodGet, "/me", PersonalAccessTokenPrefix + "token", http.StatusUnauthorized},
+		{"unknown-pat", http.MethodGet, "/registrations", PersonalAccessTokenPrefix + "other", http.StatusUnauthorized},
+		{"login-token-on-scoped-route", http.MethodPost, "/registrations", loginToken, http.StatusOK},
+		{"login-token", http.MethodGet, "/me", loginToken, http.StatusOK},
+		{"revoked-session", http.MethodGet, "/me", revokedToken, http.StatusUnauthorized},
+		{"unknown-signing-key", http.MethodGet, "/me", foreignToken, http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+tc.token)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
+
+func TestOptionalJWTWithScope(t *testing.T) {
+	kr, err := keyring.CreateNewRing(keyring.CreateNewMemoryStore(), keyring.DefaultOptions)
+	assert.NoError(t, err)
+	config := CreateJWTConfig(kr, fakeTokenValidator{})
+	e := echo.New()
+	handler := func(c echo.Context) error {
+		return c.String(http.StatusOK, fmt.Sprint(GetOptionalUserID(c)))
+	}
+	e.GET("/registrations", handler, OptionalJWTWithScope(config, ScopeRegistrationsRead))
+	e.GET("/competitions", handler, OptionalJWTWithScope(config, ScopeCompetitionsRead))
+
+	cases := []struct {
+		name   string
+		path   string
+		token  string
+		status int
+		userID string
+	}{
+		{"anonymous", "/competitions", "", http.StatusOK, "0"},
+		{"pat-with-scope", "/registrations", PersonalAccessTokenPrefix + "token", http.StatusOK, "1"},
+		{"pat-without-scope", "/competitions", PersonalAccessTokenPrefix + "token", http.StatusForbidden, ""},
+		{"unknown-pat", "/competitions", PersonalAccessTokenPrefix + "other", http.StatusUnauthorized, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.token != "" {
+				req.Header.Set(echo.HeaderAuthorization, "Bearer "+tc.token)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tc.status, rec.Code)
+			if tc.status == http.StatusOK {
+				assert.Equal(t, tc.userID, rec.Body.String())
+			}
+		})
+	}
+}
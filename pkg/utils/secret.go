@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// MinEncryptionKeyLength is the shortest ENCRYPTION_KEY accepted. The key is
+// hashed into the AES key, so a short one can be guessed offline by whoever
+// reads the sealed secrets.
+const MinEncryptionKeyLength = 32
+
+// CheckEncryptionKey rejects a key too short to protect the secrets sealed
+// with it, an unset one included.
+func CheckEncryptionKey(key string) error {
+	if len(key) < MinEncryptionKeyLength {
+		return fmt.Errorf("the encryption key must be at least %d bytes long", MinEncryptionKeyLength)
+	}
+
+	return nil
+}
+
+func secretKey(key string) ([]byte, error) {
+	err := CheckEncryptionKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return sum[:], nil
+}
+
+// EncryptSecret seals plaintext with AES-GCM under a key derived from the
+// ENCRYPTION_KEY environment variable, for secrets that have to be read back,
+// unlike passwords. It fails when the variable is missing or too short.
+func EncryptSecret(plaintext string) (string, error) {
+	return EncryptSecretWithKey(os.Getenv("ENCRYPTION_KEY"), plaintext)
+}
+
+func DecryptSecret(ciphertext string) (string, error) {
+	return DecryptSecretWithKey(os.Getenv("ENCRYPTION_KEY"), ciphertext)
+}
+
+// EncryptSecretWithKey is EncryptSecret under another key than ENCRYPTION_KEY.
+func EncryptSecretWithKey(encryptionKey string, plaintext string) (string, error) {
+	key, err := secretKey(encryptionKey)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func DecryptSecretWithKey(encryptionKey string, ciphertext string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := secretKey(encryptionKey)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("malformed secret")
+	}
+
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
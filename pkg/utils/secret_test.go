@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptSecret(t *testing.T) {
+	t.Run("round-trip", func(t *testing.T) {
+		t.Setenv("ENCRYPTION_KEY", "0123456789abcdef0123456789abcdef")
+		sealed, err := EncryptSecret("JBSWY3DPEHPK3PXP")
+		assert.NoError(t, err)
+		assert.NotContains(t, sealed, "JBSWY3DPEHPK3PXP")
+
+		plaintext, err := DecryptSecret(sealed)
+		assert.NoError(t, err)
+		assert.Equal(t, "JBSWY3DPEHPK3PXP", plaintext)
+	})
+
+	t.Run("empty-key", func(t *testing.T) {
+		t.Setenv("ENCRYPTION_KEY", "")
+		_, err := EncryptSecret("JBSWY3DPEHPK3PXP")
+		assert.EqualError(t, err, "the encryption key must be at least 32 bytes long")
+
+		_, err = DecryptSecret("c2VhbGVk")
+		assert.EqualError(t, err, "the encryption key must be at least 32 bytes long")
+	})
+
+	t.Run("short-key", func(t *testing.T) {
+		t.Setenv("ENCRYPTION_KEY", "kitten")
+		_, err := EncryptSecret("JBSWY3DPEHPK3PXP")
+		assert.EqualError(t, err, "the encryption key must be at least 32 bytes long")
+	})
+}
+
+func TestEncryptSecretWithKey(t *testing.T) {
+	t.Setenv("ENCRYPTION_KEY", "0123456789abcdef0123456789abcdef")
+	sealed, err := EncryptSecretWithKey("fedcba9876543210fedcba9876543210", "private key")
+	assert.NoError(t, err)
+
+	plaintext, err := DecryptSecretWithKey("fedcba9876543210fedcba9876543210", sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, "private key", plaintext)
+
+	// a secret sealed under another key can't be opened with ENCRYPTION_KEY
+	_, err = DecryptSecret(sealed)
+	assert.Error(t, err)
+
+	_, err = EncryptSecretWithKey("", "private key")
+	assert.EqualError(t, err, "the encryption key must be at least 32 bytes long")
+}
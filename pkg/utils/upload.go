@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// multipartOverhead is what the boundaries and part headers of a single file
+// upload may add on top of the file itself.
+const multipartOverhead = 64 << 10
+
+// ReadUploadedFile returns the content of the multipart file field. The request
+// body is cut off at maxBytes plus the multipart overhead before the form is
+// parsed, so an oversized upload is rejected once that much has been read
+// instead of being received in full.
+func ReadUploadedFile(c echo.Context, field string, maxBytes int64) ([]byte, error) {
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, maxBytes+multipartOverhead)
+	fileHeader, err := c.FormFile(field)
+	if err != nil {
+		// the multipart reader formats the error of the body with %v, so
+		// it can only be told apart by its message
+		if strings.Contains(err.Error(), "request body too large") {
+			return nil, errors.New("file too large")
+		}
+		return nil, errors.New("file required")
+	}
+
+	if fileHeader.Size > maxBytes {
+		return nil, errors.New("file too large")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > maxBytes {
+		return nil, errors.New("file too large")
+	}
+
+	return data, nil
+}
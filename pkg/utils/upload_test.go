@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingReader counts how much of the request body the handler reads.
+type countingReader struct {
+	r    io.Reader
+	read int
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.read += n
+	return n, err
+}
+
+func newUploadContext(t *testing.T, size int) (echo.Context, *countingReader, int) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("image", "image.png")
+	assert.NoError(t, err)
+	_, err = part.Write(bytes.Repeat([]byte("a"), size))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	total := body.Len()
+	counter := &countingReader{r: body}
+	req := httptest.NewRequest(http.MethodPost, "/users/me/avatar", counter)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	e := echo.New()
+	return e.NewContext(req, httptest.NewRecorder()), counter, total
+}
+
+func TestReadUploadedFile(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c, _, _ := newUploadContext(t, 1024)
+		data, err := ReadUploadedFile(c, "image", 2048)
+		assert.NoError(t, err)
+		assert.Len(t, data, 1024)
+	})
+
+	t.Run("file-too-large", func(t *testing.T) {
+		c, _, _ := newUploadContext(t, 4096)
+		_, err := ReadUploadedFile(c, "image", 2048)
+		assert.EqualError(t, err, "file too large")
+	})
+
+	t.Run("body-too-large", func(t *testing.T) {
+		c, counter, total := newUploadContext(t, 4<<20)
+		_, err := ReadUploadedFile(c, "image", 2048)
+		assert.EqualError(t, err, "file too large")
+		// the body is cut off shortly past the limit, not received in full
+		assert.LessOrEqual(t, counter.read, 2048+multipartOverhead+4096)
+		assert.Less(t, counter.read, total)
+	})
+
+	t.Run("file-required", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/users/me/avatar", nil)
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+		_, err := ReadUploadedFile(c, "image", 2048)
+		assert.EqualError(t, err, "file required")
+	})
+}